@@ -24,6 +24,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/intelsdi-x/snap/mgmt/rest/client"
 	"github.com/urfave/cli"
 )
 
@@ -55,9 +56,15 @@ var (
 				},
 				{
 					Name:   "list",
-					Usage:  "list",
+					Usage:  "list [--state <states>] [--name-prefix <prefix>] [--labels <selector>] [--sort <key>] [--limit <n>] [--cursor <cursor>]",
 					Action: listTask,
 					Flags: []cli.Flag{
+						flTaskState,
+						flNamePrefix,
+						flTaskLabels,
+						flListSort,
+						flListLimit,
+						flListCursor,
 						flVerbose,
 					},
 				},
@@ -94,6 +101,14 @@ var (
 					Usage:  "enable <task_id>",
 					Action: enableTask,
 				},
+				{
+					Name:   "config",
+					Usage:  "config <task_id> [--metric-namespace <namespace>]",
+					Action: taskConfig,
+					Flags: []cli.Flag{
+						flMetricNamespace,
+					},
+				},
 			},
 		},
 		{
@@ -131,10 +146,27 @@ var (
 				},
 				{
 					Name:   "list",
-					Usage:  "list",
+					Usage:  "list [--running] [--verbose] [--plugin-type <type>] [--plugin-name <name>] [--name-prefix <prefix>] [--sort <key>] [--limit <n>] [--cursor <cursor>]",
 					Action: listPlugins,
 					Flags: []cli.Flag{
 						flRunning,
+						flVerbose,
+						flPluginType,
+						flPluginName,
+						flNamePrefix,
+						flListSort,
+						flListLimit,
+						flListCursor,
+					},
+				},
+				{
+					Name:   "logs",
+					Usage:  "logs <plugin_type> <plugin_name> <plugin_version> [--lines=<lines> --id=<instance_id> --follow]",
+					Action: pluginLogs,
+					Flags: []cli.Flag{
+						flPluginLogLines,
+						flPluginInstanceID,
+						flPluginLogFollow,
 					},
 				},
 				{
@@ -164,6 +196,17 @@ var (
 					Flags: []cli.Flag{
 						flMetricVersion,
 						flMetricNamespace,
+						flMetricSearch,
+						flMetricGlob,
+						flMetricRegex,
+						flPluginName,
+						flMetricPluginVersion,
+						flMetricDynamic,
+						flMetricStatic,
+						flMetricLimit,
+						flMetricOffset,
+						flListCursor,
+						flListSort,
 						flVerbose,
 					},
 				},
@@ -178,6 +221,46 @@ var (
 				},
 			},
 		},
+		{
+			Name: "token",
+			Subcommands: []cli.Command{
+				{
+					Name:   "list",
+					Usage:  "list",
+					Action: listTokens,
+				},
+				{
+					Name:   "create",
+					Usage:  "create <token_name> [--role <role>]",
+					Action: createToken,
+					Flags: []cli.Flag{
+						flTokenRole,
+					},
+				},
+				{
+					Name:   "revoke",
+					Usage:  "revoke <token_name>",
+					Action: revokeToken,
+				},
+			},
+		},
+		{
+			Name:   "events",
+			Usage:  "events [--type <types>] [--last-event-id <id>]",
+			Action: watchEvents,
+			Flags: []cli.Flag{
+				flEventType,
+				flLastEventID,
+			},
+		},
+		{
+			Name:   "health",
+			Usage:  "health [--ready]",
+			Action: checkHealth,
+			Flags: []cli.Flag{
+				flHealthReady,
+			},
+		},
 	}
 	tribeWarning  = "Can only be used when tribe mode is enabled."
 	tribeCommands = []cli.Command{
@@ -232,6 +315,39 @@ var (
 					Usage:  "members <agreement_name>",
 					Action: agreementMembers,
 				},
+				{
+					Name: "plugin",
+					Subcommands: []cli.Command{
+						{
+							Name:   "add",
+							Usage:  "add <agreement_name> <plugin_type> <plugin_name> <plugin_version>",
+							Action: addAgreementPlugin,
+						},
+						{
+							Name:   "remove",
+							Usage:  "remove <agreement_name> <plugin_type> <plugin_name> <plugin_version>",
+							Action: removeAgreementPlugin,
+						},
+					},
+				},
+				{
+					Name: "task",
+					Subcommands: []cli.Command{
+						{
+							Name:   "add",
+							Usage:  "add <agreement_name> <task_id> or add <agreement_name> <task_id> --start",
+							Action: addAgreementTask,
+							Flags: []cli.Flag{
+								flAgreementTaskStart,
+							},
+						},
+						{
+							Name:   "remove",
+							Usage:  "remove <agreement_name> <task_id>",
+							Action: removeAgreementTask,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -255,6 +371,27 @@ func printFields(tw *tabwriter.Writer, indent bool, width int, fields ...interfa
 	fmt.Fprintln(tw, argArray...)
 }
 
+// listOptions returns the pagination and sorting options given by the list flags
+func listOptions(ctx *cli.Context) client.ListOptions {
+	return client.ListOptions{
+		Limit:  ctx.Int("limit"),
+		Cursor: ctx.String("cursor"),
+		Sort:   ctx.String("sort"),
+	}
+}
+
+// printPageFooter tells how many of the matching items were listed, and how
+// to list the next page
+func printPageFooter(count, total int, next, items string) {
+	if count == total {
+		return
+	}
+	fmt.Printf("\nShowing %d of %d matching %s\n", count, total, items)
+	if next != "" {
+		fmt.Printf("Next page: --cursor %s\n", next)
+	}
+}
+
 // ByCommand contains array of CLI commands.
 type ByCommand []cli.Command
 
@@ -26,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	log "github.com/Sirupsen/logrus"
@@ -38,6 +39,7 @@ type config struct {
 }
 type restAPIConfig struct {
 	Password *string `json:"rest-auth-pwd"`
+	Token    *string `json:"rest-auth-token"`
 }
 
 func (c *config) loadConfig(path string) error {
@@ -107,6 +109,12 @@ func getConfig(ctx *cli.Context) error {
 			printFields(w, false, 0, k, t.Value, t.Type())
 		case ctypes.ConfigValueStr:
 			printFields(w, false, 0, k, t.Value, t.Type())
+		case ctypes.ConfigValueDuration:
+			printFields(w, false, 0, k, t.Value, t.Type())
+		case ctypes.ConfigValueStrList:
+			printFields(w, false, 0, k, strings.Join(t.Value, ","), t.Type())
+		case ctypes.ConfigValueSecret:
+			printFields(w, false, 0, k, t.Ref, t.Type())
 		}
 	}
 
@@ -0,0 +1,86 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+	"github.com/urfave/cli"
+)
+
+func watchEvents(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+	if ctx.Int("last-event-id") < 0 {
+		return newUsageError("Last event ID can't be negative", ctx)
+	}
+	var types []string
+	if t := ctx.String("type"); t != "" {
+		types = strings.Split(t, ",")
+	}
+	lastID := uint64(ctx.Int("last-event-id"))
+	r := pClient.WatchEvents(types, lastID)
+	if r.Err != nil {
+		return fmt.Errorf("Error watching events:\n%v\n", r.Err)
+	}
+	fmt.Println("Watching events:")
+
+	// catch interrupt so we signal the server we are done before exiting
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, syscall.SIGTERM)
+	for {
+		select {
+		case <-c:
+			fmt.Println("Stopping event watch")
+			r.Close()
+			return nil
+		case e, ok := <-r.EventChan:
+			if !ok {
+				if r.Err == nil {
+					return nil
+				}
+				// the stream ended, resume it from the last event received
+				r = pClient.WatchEvents(types, lastID)
+				if r.Err != nil {
+					return fmt.Errorf("Error watching events:\n%v\n", r.Err)
+				}
+				continue
+			}
+			switch e.Type {
+			case v2.EventStreamOpen:
+				continue
+			case v2.EventStreamMissed:
+				fmt.Println(e.Message)
+				continue
+			}
+			data, _ := json.Marshal(e.Data)
+			fmt.Printf("%s  %d  %s  %s\n", e.Time.Format(timeFormat), e.ID, e.Type, data)
+			lastID = e.ID
+		}
+	}
+}
@@ -45,6 +45,16 @@ var (
 		Usage:  "Ignore certificate errors when Snap's API is running HTTPS",
 		EnvVar: "SNAP_INSECURE",
 	}
+	flClientCert = cli.StringFlag{
+		Name:   "client-cert",
+		Usage:  "A path to the client certificate presented to Snap's API when it verifies its clients",
+		EnvVar: "SNAP_CLIENT_CERT",
+	}
+	flClientKey = cli.StringFlag{
+		Name:   "client-key",
+		Usage:  "A path to the key of the client certificate",
+		EnvVar: "SNAP_CLIENT_KEY",
+	}
 	flRunning = cli.BoolFlag{
 		Name:  "running",
 		Usage: "Shows running plugins",
@@ -54,6 +64,11 @@ var (
 		Usage:  "Require password for REST API authentication",
 		EnvVar: "SNAP_REST_PASSWORD",
 	}
+	flToken = cli.StringFlag{
+		Name:   "token",
+		Usage:  "API token for REST API authentication",
+		EnvVar: "SNAP_REST_TOKEN",
+	}
 	flConfig = cli.StringFlag{
 		Name:   "config, c",
 		EnvVar: "SNAPTEL_CONFIG_PATH,SNAPCTL_CONFIG_PATH",
@@ -95,6 +110,20 @@ var (
 		Name:  "plugin-version, v",
 		Usage: "The plugin version",
 	}
+	flPluginLogLines = cli.IntFlag{
+		Name:  "lines, l",
+		Usage: "The number of the most recent lines to show. 0 shows every captured line.",
+		Value: 100,
+	}
+	flPluginLogFollow = cli.BoolFlag{
+		Name:  "follow, f",
+		Usage: "Keep streaming new lines written by the plugin",
+	}
+	flPluginInstanceID = cli.IntFlag{
+		Name:  "id",
+		Usage: "Only show lines from the running plugin instance with this ID",
+		Value: -1,
+	}
 
 	// Task flags
 	flTaskName = cli.StringFlag{
@@ -162,6 +191,93 @@ var (
 		Name:  "metric-namespace, m",
 		Usage: "A metric namespace",
 	}
+	flMetricSearch = cli.StringFlag{
+		Name:  "search, s",
+		Usage: "Terms which all have to be found in the description or the unit of the metrics",
+	}
+	flMetricGlob = cli.StringFlag{
+		Name:  "glob, g",
+		Usage: "A namespace pattern matched element by element, ex: /intel/*/load/*",
+	}
+	flMetricRegex = cli.StringFlag{
+		Name:  "regex, r",
+		Usage: "A regular expression matched against the metric namespaces",
+	}
+	flMetricPluginVersion = cli.IntFlag{
+		Name:  "plugin-version",
+		Usage: "The version of the plugin exposing the metrics",
+	}
+	flMetricDynamic = cli.BoolFlag{
+		Name:  "dynamic",
+		Usage: "Only list metrics with a dynamic namespace",
+	}
+	flMetricStatic = cli.BoolFlag{
+		Name:  "static",
+		Usage: "Only list metrics with a static namespace",
+	}
+	flMetricLimit = cli.IntFlag{
+		Name:  "limit",
+		Usage: "The maximum number of metrics listed",
+	}
+	flMetricOffset = cli.IntFlag{
+		Name:  "offset",
+		Usage: "The number of matching metrics skipped",
+	}
+
+	// list
+	flListLimit = cli.IntFlag{
+		Name:  "limit",
+		Usage: "The maximum number of items listed",
+	}
+	flListCursor = cli.StringFlag{
+		Name:  "cursor",
+		Usage: "The cursor of the page listed, printed after the previous page",
+	}
+	flListSort = cli.StringFlag{
+		Name:  "sort",
+		Usage: "The key the items are sorted by, prefixed with - to sort them in descending order",
+	}
+	flNamePrefix = cli.StringFlag{
+		Name:  "name-prefix",
+		Usage: "The prefix of the names of the items listed",
+	}
+	flTaskState = cli.StringFlag{
+		Name:  "state, s",
+		Usage: "The states of the tasks listed, comma separated (ex: Running,Disabled)",
+	}
+	flTaskLabels = cli.StringFlag{
+		Name:  "labels, l",
+		Usage: "A label selector matched against the tags of the tasks (ex: dc=us-east,env!=dev)",
+	}
+
+	// token
+	flTokenRole = cli.StringFlag{
+		Name:  "role, r",
+		Usage: "The role granted by the token: read-only, operator or admin",
+		Value: "read-only",
+	}
+
+	// events
+	flEventType = cli.StringFlag{
+		Name:  "type, t",
+		Usage: "Comma separated types of the events to watch, like Control.PluginLoaded or Scheduler.*",
+	}
+	flLastEventID = cli.IntFlag{
+		Name:  "last-event-id",
+		Usage: "Replay the buffered events following this event ID",
+	}
+
+	// health
+	flHealthReady = cli.BoolFlag{
+		Name:  "ready, r",
+		Usage: "Check the readiness of snapteld instead of its liveness",
+	}
+
+	// tribe
+	flAgreementTaskStart = cli.BoolFlag{
+		Name:  "start, s",
+		Usage: "Start the task on the other members of the agreement once it is created",
+	}
 
 	// general
 	flVerbose = cli.BoolFlag{
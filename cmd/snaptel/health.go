@@ -0,0 +1,62 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/intelsdi-x/snap/mgmt/health"
+	"github.com/urfave/cli"
+)
+
+func checkHealth(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+	check, resp := "liveness", pClient.Liveness
+	if ctx.Bool("ready") {
+		check, resp = "readiness", pClient.Readiness
+	}
+	r := resp()
+	if r.Err != nil {
+		return fmt.Errorf("Error checking %s:\n%v\n", check, r.Err)
+	}
+	fmt.Printf("Status: %s (%s checked %s)\n\n", r.Status, check, r.Time.Format(timeFormat))
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	printFields(w, false, 0,
+		"SUBSYSTEM",
+		"STATUS",
+		"MESSAGE",
+	)
+	for _, s := range r.Subsystems {
+		printFields(w, false, 0,
+			s.Name,
+			s.Status,
+			s.Message,
+		)
+	}
+	w.Flush()
+	if r.Status == health.StatusDown {
+		return fmt.Errorf("snapteld is down")
+	}
+	return nil
+}
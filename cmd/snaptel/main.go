@@ -61,7 +61,7 @@ func main() {
 	app.Name = "snaptel"
 	app.Version = gitversion
 	app.Usage = "The open telemetry framework"
-	app.Flags = []cli.Flag{flURL, flSecure, flAPIVer, flPassword, flConfig, flTimeout}
+	app.Flags = []cli.Flag{flURL, flSecure, flClientCert, flClientKey, flAPIVer, flPassword, flToken, flConfig, flTimeout}
 	app.Commands = append(commands, tribeCommands...)
 	sort.Sort(ByCommand(app.Commands))
 	app.Before = beforeAction
@@ -78,13 +78,16 @@ func main() {
 
 // Run before every command
 func beforeAction(ctx *cli.Context) error {
-	username, password := checkForAuth(ctx)
-	pClient, err = client.New(ctx.String("url"), ctx.String("api-version"), ctx.Bool("insecure"), client.Timeout(ctx.Duration("timeout")))
+	username, password, token := checkForAuth(ctx)
+	pClient, err = client.New(ctx.String("url"), ctx.String("api-version"), ctx.Bool("insecure"),
+		client.Timeout(ctx.Duration("timeout")),
+		client.ClientCert(ctx.String("client-cert"), ctx.String("client-key")))
 	if err != nil {
 		return fmt.Errorf("%v", err)
 	}
 	pClient.Password = password
 	pClient.Username = username
+	pClient.Token = token
 	if err = checkTribeCommand(ctx); err != nil {
 		return fmt.Errorf("%v", err)
 	}
@@ -120,8 +123,11 @@ func checkTribeCommand(ctx *cli.Context) error {
 }
 
 // Checks for authentication flags and returns a username/password
-// from the specified settings
-func checkForAuth(ctx *cli.Context) (username, password string) {
+// or an API token from the specified settings
+func checkForAuth(ctx *cli.Context) (username, password, token string) {
+	if token = ctx.String("token"); token != "" {
+		return
+	}
 	if ctx.Bool("password") {
 		username = "snap" // for now since username is unused but needs to exist for basicAuth
 		// Prompt for password
@@ -142,11 +148,14 @@ func checkForAuth(ctx *cli.Context) (username, password string) {
 		if err := cfg.loadConfig(ctx.String("config")); err != nil {
 			fmt.Println(err)
 		}
-		if cfg.RestAPI.Password != nil {
+		if cfg.RestAPI.Token != nil {
+			// use token declared in config file
+			token = *cfg.RestAPI.Token
+		} else if cfg.RestAPI.Password != nil {
 			// use password declared in config file
 			password = *cfg.RestAPI.Password
 		} else {
-			fmt.Println("Error config password field 'rest-auth-pwd' is empty")
+			fmt.Println("Error config password field 'rest-auth-pwd' and token field 'rest-auth-token' are empty")
 		}
 	}
 	return
@@ -28,6 +28,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/mgmt/rest/client"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1/rbody"
 	"github.com/urfave/cli"
@@ -36,6 +37,11 @@ import (
 )
 
 func listMetrics(ctx *cli.Context) error {
+	for _, fl := range []string{"search", "glob", "regex", "plugin-name", "plugin-version", "dynamic", "static", "limit", "offset", "cursor", "sort"} {
+		if ctx.IsSet(fl) {
+			return searchMetrics(ctx)
+		}
+	}
 	ns := ctx.String("metric-namespace")
 	ver := ctx.Int("metric-version")
 	verbose := ctx.Bool("verbose")
@@ -59,6 +65,77 @@ func listMetrics(ctx *cli.Context) error {
 		fmt.Println("No metrics found. Have you loaded any collectors yet?")
 		return nil
 	}
+	printMetrics(mts.Catalog, verbose)
+	return nil
+}
+
+// searchMetrics lists the page of the metric catalog matching the search flags
+func searchMetrics(ctx *cli.Context) error {
+	search := core.MetricSearch{
+		Version:       ctx.Int("metric-version"),
+		Regex:         ctx.String("regex"),
+		PluginName:    ctx.String("plugin-name"),
+		PluginVersion: ctx.Int("plugin-version"),
+		Text:          ctx.String("search"),
+		Offset:        ctx.Int("offset"),
+		Limit:         ctx.Int("limit"),
+	}
+	if ns := strings.TrimSuffix(ctx.String("metric-namespace"), "*"); ns != "" {
+		search.Namespace = core.NewNamespace(parseNamespaceFlag(ns)...)
+	}
+	if glob := ctx.String("glob"); glob != "" {
+		search.Glob = parseNamespaceFlag(glob)
+	}
+	if ctx.Bool("dynamic") && ctx.Bool("static") {
+		return fmt.Errorf("Only one of --dynamic and --static can be set")
+	}
+	if ctx.Bool("dynamic") || ctx.Bool("static") {
+		dyn := ctx.Bool("dynamic")
+		search.Dynamic = &dyn
+	}
+	res := pClient.ListMetrics(search, client.ListOptions{
+		Cursor: ctx.String("cursor"),
+		Sort:   ctx.String("sort"),
+	})
+	if res.Err != nil {
+		return fmt.Errorf("Error searching metrics: %v\n", res.Err)
+	}
+	if res.Total == 0 {
+		fmt.Println("No metrics match the search.")
+		return nil
+	}
+	if len(res.Metrics) == 0 {
+		fmt.Printf("No metrics found in this page, %d metrics match the search.\n", res.Total)
+		return nil
+	}
+	catalog := make([]*rbody.Metric, len(res.Metrics))
+	for i, mt := range res.Metrics {
+		catalog[i] = &rbody.Metric{
+			Namespace:   mt.Namespace,
+			Version:     mt.Version,
+			Dynamic:     mt.Dynamic,
+			Description: mt.Description,
+			Unit:        mt.Unit,
+		}
+		for _, e := range mt.DynamicElements {
+			catalog[i].DynamicElements = append(catalog[i].DynamicElements, rbody.DynamicElement{
+				Index:       e.Index,
+				Name:        e.Name,
+				Description: e.Description,
+			})
+		}
+	}
+	printMetrics(catalog, ctx.Bool("verbose"))
+	printPageFooter(len(res.Metrics), res.Total, res.NextCursor, "metrics")
+	return nil
+}
+
+func parseNamespaceFlag(ns string) []string {
+	fc := stringutils.GetFirstChar(ns)
+	return strings.Split(strings.Trim(ns, fc), fc)
+}
+
+func printMetrics(catalog []*rbody.Metric, verbose bool) {
 	/*
 		NAMESPACE               VERSION
 		/intel/mock/foo         1,2
@@ -74,15 +151,15 @@ func listMetrics(ctx *cli.Context) error {
 		//      /intel/mock/[host]/baz   2               mock unit     mock description
 
 		printFields(w, false, 0, "NAMESPACE", "VERSION", "UNIT", "DESCRIPTION")
-		for _, mt := range mts.Catalog {
+		for _, mt := range catalog {
 			namespace := getNamespace(mt)
 			printFields(w, false, 0, namespace, mt.Version, mt.Unit, mt.Description)
 		}
 		w.Flush()
-		return nil
+		return
 	}
 	metsByVer := make(map[string][]string)
-	for _, mt := range mts.Catalog {
+	for _, mt := range catalog {
 		metsByVer[mt.Namespace] = append(metsByVer[mt.Namespace], strconv.Itoa(mt.Version))
 	}
 	//make list in alphabetical order
@@ -97,7 +174,6 @@ func listMetrics(ctx *cli.Context) error {
 		printFields(w, false, 0, ns, strings.Join(metsByVer[ns], ","))
 	}
 	w.Flush()
-	return nil
 }
 
 func printMetric(metric *client.GetMetricResult, idx int) error {
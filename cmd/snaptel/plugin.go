@@ -23,13 +23,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
+	"github.com/intelsdi-x/snap/mgmt/rest/client"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
 	"github.com/urfave/cli"
 )
 
@@ -176,6 +181,14 @@ func swapPlugins(ctx *cli.Context) error {
 }
 
 func listPlugins(ctx *cli.Context) error {
+	if ctx.Bool("verbose") {
+		return listPluginStats()
+	}
+	for _, fl := range []string{"plugin-type", "plugin-name", "name-prefix", "sort", "limit", "cursor"} {
+		if ctx.IsSet(fl) {
+			return listPluginPage(ctx)
+		}
+	}
 	plugins := pClient.GetPlugins(ctx.Bool("running"))
 	if plugins.Err != nil {
 		return fmt.Errorf("Error: %v\n", plugins.Err)
@@ -205,6 +218,156 @@ func listPlugins(ctx *cli.Context) error {
 	return nil
 }
 
+// listPluginPage lists the page of the plugins matching the filter flags
+func listPluginPage(ctx *cli.Context) error {
+	filter := client.PluginFilter{
+		Type:       ctx.String("plugin-type"),
+		Name:       ctx.String("plugin-name"),
+		NamePrefix: ctx.String("name-prefix"),
+		Running:    ctx.Bool("running"),
+	}
+	res := pClient.ListPlugins(filter, listOptions(ctx))
+	if res.Err != nil {
+		return fmt.Errorf("Error: %v\n", res.Err)
+	}
+	if res.Total == 0 {
+		fmt.Println("No plugins match the filters.")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	if filter.Running {
+		printFields(w, false, 0, "NAME", "HIT COUNT", "LAST HIT", "TYPE", "PPROF PORT")
+		for _, rp := range res.Plugins {
+			printFields(w, false, 0, rp.Name, rp.HitCount, time.Unix(rp.LastHitTimestamp, 0).Format(timeFormat), rp.Type, rp.PprofPort)
+		}
+	} else {
+		printFields(w, false, 0, "NAME", "VERSION", "TYPE", "SIGNED", "STATUS", "LOADED TIME")
+		for _, lp := range res.Plugins {
+			printFields(w, false, 0, lp.Name, lp.Version, lp.Type, lp.Signed, lp.Status, time.Unix(lp.LoadedTimestamp, 0).Format(timeFormat))
+		}
+	}
+	w.Flush()
+	printPageFooter(len(res.Plugins), res.Total, res.NextCursor, "plugins")
+	return nil
+}
+
+// listPluginStats prints the RPC call statistics of every running plugin instance
+func listPluginStats() error {
+	plugins := pClient.GetPlugins(true)
+	if plugins.Err != nil {
+		return fmt.Errorf("Error: %v\n", plugins.Err)
+	}
+	if len(plugins.AvailablePlugins) == 0 {
+		fmt.Println("No running plugins found. Have you started a task?")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	printFields(w, false, 0, "NAME", "VERSION", "TYPE", "ID", "CALL", "CALLS", "ERRORS", "TIMEOUTS", "AVG LATENCY", "P99 LATENCY", "METRICS IN", "METRICS OUT")
+	seen := map[string]bool{}
+	scaled := []*client.GetPluginStatsResult{}
+	for _, ap := range plugins.AvailablePlugins {
+		key := fmt.Sprintf("%s:%s:%d", ap.Type, ap.Name, ap.Version)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		r := pClient.GetPluginStats(ap.Type, ap.Name, ap.Version)
+		if r.Err != nil {
+			return fmt.Errorf("Error getting plugin stats:\n%v\n", r.Err.Error())
+		}
+		if r.Scaling.ScaleUps+r.Scaling.ScaleDowns > 0 {
+			scaled = append(scaled, r)
+		}
+		for _, in := range r.Instances {
+			calls := make([]string, 0, len(in.Calls))
+			for call := range in.Calls {
+				calls = append(calls, call)
+			}
+			sort.Strings(calls)
+			for _, call := range calls {
+				c := in.Calls[call]
+				printFields(w, false, 0, in.Name, in.Version, in.Type, in.ID, call, c.Calls, c.Errors, c.Timeouts,
+					fmt.Sprintf("%.2fms", c.AvgLatency), fmt.Sprintf("%.2fms", c.P99Latency), in.MetricsIn, in.MetricsOut)
+			}
+		}
+	}
+	w.Flush()
+	if len(scaled) == 0 {
+		return nil
+	}
+	fmt.Println()
+	printFields(w, false, 0, "NAME", "VERSION", "TYPE", "SCALE UPS", "SCALE DOWNS", "LAST ACTION", "LAST REASON")
+	for _, r := range scaled {
+		printFields(w, false, 0, r.Total.Name, r.Total.Version, r.Total.Type, r.Scaling.ScaleUps, r.Scaling.ScaleDowns, r.Scaling.LastAction, r.Scaling.LastReason)
+	}
+	w.Flush()
+	return nil
+}
+
+func pluginLogs(ctx *cli.Context) error {
+	pType := ctx.Args().Get(0)
+	pName := ctx.Args().Get(1)
+	pVerStr := ctx.Args().Get(2)
+
+	if pType == "" {
+		return newUsageError("Must provide plugin type", ctx)
+	}
+	if pName == "" {
+		return newUsageError("Must provide plugin name", ctx)
+	}
+	if pVerStr == "" {
+		return newUsageError("Must provide plugin version", ctx)
+	}
+	pVer, err := strconv.Atoi(pVerStr)
+	if err != nil {
+		return newUsageError("Can't convert version string to integer", ctx)
+	}
+	if pVer < 1 {
+		return newUsageError("Plugin version must be greater than zero", ctx)
+	}
+	lines := ctx.Int("lines")
+	id := int64(ctx.Int("id"))
+
+	if !ctx.Bool("follow") {
+		r := pClient.GetPluginLogs(pType, pName, pVer, lines, id)
+		if r.Err != nil {
+			return fmt.Errorf("Error getting plugin logs:\n%v\n", r.Err.Error())
+		}
+		if len(r.Logs) == 0 {
+			fmt.Println("No output captured for this plugin. Is it running?")
+			return nil
+		}
+		for _, l := range r.Logs {
+			printPluginLog(l)
+		}
+		return nil
+	}
+
+	r := pClient.WatchPluginLogs(pType, pName, pVer, lines, id)
+	if r.Err != nil {
+		return fmt.Errorf("Error following plugin logs:\n%v\n", r.Err.Error())
+	}
+	// catch interrupt so we signal the server we are done before exiting
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-c
+		r.Close()
+		os.Exit(0)
+	}()
+	for l := range r.LogChan {
+		printPluginLog(l)
+	}
+	if r.Err != nil {
+		return fmt.Errorf("Error following plugin logs:\n%v\n", r.Err.Error())
+	}
+	return nil
+}
+
+func printPluginLog(l v2.PluginLog) {
+	fmt.Printf("%s [id %d] %s: %s\n", l.Timestamp.Format(timeFormat), l.ID, l.Stream, l.Line)
+}
+
 // storeTLSPaths extracts paths related to TLS (certificate, key, plugin CA certs)
 // from command line context into temporary files. Those files are appended to
 // list of paths returned from this function.
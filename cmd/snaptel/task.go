@@ -34,6 +34,8 @@ import (
 	"time"
 
 	"github.com/intelsdi-x/snap/mgmt/rest/client"
+	"github.com/intelsdi-x/snap/mgmt/rest/v1/rbody"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
 	"github.com/intelsdi-x/snap/scheduler/wmap"
 	"github.com/robfig/cron"
 	"github.com/urfave/cli"
@@ -492,18 +494,62 @@ func mergeDateTime(tm, dt string) *time.Time {
 }
 
 func listTask(ctx *cli.Context) error {
+	for _, fl := range []string{"state", "name-prefix", "labels", "sort", "limit", "cursor"} {
+		if ctx.IsSet(fl) {
+			return listTaskPage(ctx)
+		}
+	}
 	tasks := pClient.GetTasks()
-	termWidth, _, _ := terminal.GetSize(int(os.Stdout.Fd()))
-	verbose := ctx.Bool("verbose")
 	if tasks.Err != nil {
 		return fmt.Errorf("Error getting tasks:\n%v\n", tasks.Err)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
 	if tasks.Len() == 0 {
 		fmt.Println("No task found. Have you created a task?")
 		return nil
 	}
+	printTasks(tasks.ScheduledTasks, ctx.Bool("verbose"))
+	return nil
+}
+
+// listTaskPage lists the page of the tasks matching the filter flags
+func listTaskPage(ctx *cli.Context) error {
+	filter := client.TaskFilter{
+		NamePrefix: ctx.String("name-prefix"),
+		Labels:     ctx.String("labels"),
+	}
+	if states := ctx.String("state"); states != "" {
+		filter.States = strings.Split(states, ",")
+	}
+	res := pClient.ListTasks(filter, listOptions(ctx))
+	if res.Err != nil {
+		return fmt.Errorf("Error getting tasks:\n%v\n", res.Err)
+	}
+	if res.Total == 0 {
+		fmt.Println("No tasks match the filters.")
+		return nil
+	}
+	tasks := make([]rbody.ScheduledTask, len(res.Tasks))
+	for i, t := range res.Tasks {
+		tasks[i] = rbody.ScheduledTask{
+			ID:                 t.ID,
+			Name:               t.Name,
+			State:              t.TaskState,
+			HitCount:           t.HitCount,
+			MissCount:          t.MissCount,
+			FailedCount:        t.FailedCount,
+			CreationTimestamp:  t.CreationTimestamp,
+			LastFailureMessage: t.LastFailureMessage,
+		}
+	}
+	printTasks(tasks, ctx.Bool("verbose"))
+	printPageFooter(len(res.Tasks), res.Total, res.NextCursor, "tasks")
+	return nil
+}
+
+func printTasks(tasks []rbody.ScheduledTask, verbose bool) {
+	termWidth, _, _ := terminal.GetSize(int(os.Stdout.Fd()))
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
 	printFields(w, false, 0,
 		"ID",
 		"NAME",
@@ -514,7 +560,7 @@ func listTask(ctx *cli.Context) error {
 		"CREATED",
 		"LAST FAILURE",
 	)
-	for _, task := range tasks.ScheduledTasks {
+	for _, task := range tasks {
 		//165 is the width of the error message from ID - LAST FAILURE inclusive.
 		//If the header row wraps, then the error message will automatically wrap too
 		if termWidth < 165 {
@@ -533,8 +579,6 @@ func listTask(ctx *cli.Context) error {
 		)
 	}
 	w.Flush()
-
-	return nil
 }
 
 func fixSize(verbose bool, msg string, width int) string {
@@ -720,6 +764,54 @@ func exportTask(ctx *cli.Context) error {
 	return nil
 }
 
+// taskConfig prints the config every metric and every process and publish
+// node of a task receives, with the config layer each key is taken from
+func taskConfig(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+	r := pClient.GetTaskConfig(ctx.Args().First(), ctx.String("metric-namespace"))
+	if r.Err != nil {
+		return fmt.Errorf("Error getting task config:\n%v\n", r.Err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	printFields(w, false, 0, "NAMESPACE", "VERSION", "PLUGIN", "KEY", "VALUE", "SOURCE")
+	for _, m := range r.Metrics {
+		plugin := fmt.Sprintf("%s:%d", m.PluginName, m.PluginVersion)
+		if len(m.Config) == 0 {
+			printFields(w, false, 0, m.Namespace, m.Version, plugin)
+		}
+		for _, k := range sortedConfigKeys(m.Config) {
+			printFields(w, false, 0, m.Namespace, m.Version, plugin, k, m.Config[k].Value, m.Config[k].Source)
+		}
+	}
+	w.Flush()
+	if len(r.Nodes) == 0 {
+		return nil
+	}
+	fmt.Println()
+	printFields(w, false, 0, "TYPE", "NAME", "VERSION", "KEY", "VALUE", "SOURCE")
+	for _, n := range r.Nodes {
+		if len(n.Config) == 0 {
+			printFields(w, false, 0, n.PluginType, n.PluginName, n.PluginVersion)
+		}
+		for _, k := range sortedConfigKeys(n.Config) {
+			printFields(w, false, 0, n.PluginType, n.PluginName, n.PluginVersion, k, n.Config[k].Value, n.Config[k].Source)
+		}
+	}
+	w.Flush()
+	return nil
+}
+
+func sortedConfigKeys(config map[string]v2.ConfigSetting) []string {
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func enableTask(ctx *cli.Context) error {
 	if len(ctx.Args()) != 1 {
 		return newUsageError("Incorrect usage", ctx)
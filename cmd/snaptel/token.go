@@ -0,0 +1,82 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+)
+
+func listTokens(ctx *cli.Context) error {
+	resp := pClient.ListTokens()
+	if resp.Err != nil {
+		return fmt.Errorf("Error getting tokens:\n%v\n", resp.Err)
+	}
+	if len(resp.Tokens) == 0 {
+		fmt.Println("No tokens found. Have you created a token?")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	defer w.Flush()
+	printFields(w, false, 0,
+		"NAME",
+		"ROLE",
+		"CREATED",
+	)
+	for _, t := range resp.Tokens {
+		printFields(w, false, 0,
+			t.Name,
+			t.Role,
+			t.Created.Format(timeFormat),
+		)
+	}
+	return nil
+}
+
+func createToken(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+	resp := pClient.CreateToken(ctx.Args().First(), ctx.String("role"))
+	if resp.Err != nil {
+		return fmt.Errorf("Error creating token:\n%v\n", resp.Err)
+	}
+	fmt.Println("Token created")
+	fmt.Printf("Name: %s\n", resp.Name)
+	fmt.Printf("Role: %s\n", resp.Role)
+	fmt.Printf("Token: %s\n", resp.Token.Token)
+	fmt.Println("The token can't be shown again, store it now.")
+	return nil
+}
+
+func revokeToken(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+	resp := pClient.RevokeToken(ctx.Args().First())
+	if resp.Err != nil {
+		return fmt.Errorf("Error revoking token:\n%v\n", resp.Err)
+	}
+	fmt.Printf("Token revoked: %s\n", ctx.Args().First())
+	return nil
+}
@@ -25,8 +25,10 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"text/tabwriter"
 
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
 	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
 	"github.com/urfave/cli"
 )
@@ -175,6 +177,89 @@ func agreementMembers(ctx *cli.Context) error {
 	return nil
 }
 
+func addAgreementPlugin(ctx *cli.Context) error {
+	if len(ctx.Args()) != 4 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+	version, err := strconv.Atoi(ctx.Args().Get(3))
+	if err != nil {
+		return newUsageError(fmt.Sprintf("Can't convert version string (%v) to an integer", ctx.Args().Get(3)), ctx)
+	}
+
+	resp := pClient.AddAgreementPlugin(ctx.Args().First(), ctx.Args().Get(1), ctx.Args().Get(2), version)
+	if resp.Err != nil {
+		return fmt.Errorf("Error adding plugin to agreement: %v\n", resp.Err)
+	}
+	printAgreement(resp.Agreement)
+	return nil
+}
+
+func removeAgreementPlugin(ctx *cli.Context) error {
+	if len(ctx.Args()) != 4 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+	version, err := strconv.Atoi(ctx.Args().Get(3))
+	if err != nil {
+		return newUsageError(fmt.Sprintf("Can't convert version string (%v) to an integer", ctx.Args().Get(3)), ctx)
+	}
+
+	resp := pClient.RemoveAgreementPlugin(ctx.Args().First(), ctx.Args().Get(1), ctx.Args().Get(2), version)
+	if resp.Err != nil {
+		return fmt.Errorf("Error removing plugin from agreement: %v\n", resp.Err)
+	}
+	fmt.Printf("Plugin %v:%v:%v removed from agreement %v\n", ctx.Args().Get(1), ctx.Args().Get(2), version, ctx.Args().First())
+	return nil
+}
+
+func addAgreementTask(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+
+	resp := pClient.AddAgreementTask(ctx.Args().First(), ctx.Args().Get(1), ctx.Bool("start"))
+	if resp.Err != nil {
+		return fmt.Errorf("Error adding task to agreement: %v\n", resp.Err)
+	}
+	printAgreement(resp.Agreement)
+	return nil
+}
+
+func removeAgreementTask(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+
+	resp := pClient.RemoveAgreementTask(ctx.Args().First(), ctx.Args().Get(1))
+	if resp.Err != nil {
+		return fmt.Errorf("Error removing task from agreement: %v\n", resp.Err)
+	}
+	fmt.Printf("Task %v removed from agreement %v\n", ctx.Args().Get(1), ctx.Args().First())
+	return nil
+}
+
+// printAgreement prints the plugins and tasks of a single agreement as
+// returned by the v2 API
+func printAgreement(a v2.Agreement) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	defer w.Flush()
+	printFields(w, false, 0, "Name", "Number of Members", "plugins", "tasks")
+	printFields(w, false, 0, a.Name, len(a.Members), len(a.Plugins), len(a.Tasks))
+	if len(a.Plugins) > 0 {
+		printFields(w, false, 0, "")
+		printFields(w, false, 0, "Plugin Type", "Plugin Name", "Plugin Version")
+		for _, p := range a.Plugins {
+			printFields(w, false, 0, p.Type, p.Name, p.Version)
+		}
+	}
+	if len(a.Tasks) > 0 {
+		printFields(w, false, 0, "")
+		printFields(w, false, 0, "Task ID", "Start On Create")
+		for _, t := range a.Tasks {
+			printFields(w, false, 0, t.ID, t.StartOnCreate)
+		}
+	}
+}
+
 func printAgreements(agreements map[string]*agreement.Agreement) {
 	if len(agreements) > 0 {
 		w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
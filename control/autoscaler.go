@@ -0,0 +1,191 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/intelsdi-x/snap/control/strategy"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/control_event"
+)
+
+// DefaultAutoscaleInterval is how often the autoscaler evaluates plugin pools
+var DefaultAutoscaleInterval = 5 * time.Second
+
+var autoscalerLog = log.WithField("_module", "control-autoscaler")
+
+// autoscaler starts and reaps instances of running plugins following
+// the load of their pools and strategy.Autoscaling
+type autoscaler struct {
+	runner   *runner
+	interval time.Duration
+	// samples holds the number of calls and their total latency
+	// for each pool at the previous evaluation
+	samples map[string]latencySample
+	quit    chan struct{}
+}
+
+type latencySample struct {
+	calls uint64
+	total time.Duration
+}
+
+func newAutoscaler(r *runner) *autoscaler {
+	return &autoscaler{
+		runner:   r,
+		interval: DefaultAutoscaleInterval,
+		samples:  map[string]latencySample{},
+	}
+}
+
+// Start evaluates the plugin pools at every interval until Stop is called.
+// Nothing is done while the autoscaler is disabled.
+func (a *autoscaler) Start() {
+	ticker := time.NewTicker(a.interval)
+	quit := make(chan struct{})
+	a.quit = quit
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if strategy.Autoscaling.Enabled {
+					a.scale()
+				}
+			case <-quit:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the autoscaler
+func (a *autoscaler) Stop() {
+	if a.quit != nil {
+		close(a.quit)
+		a.quit = nil
+	}
+}
+
+// scale evaluates every plugin pool and applies the decisions taken
+func (a *autoscaler) scale() {
+	aps := a.runner.availablePlugins
+	pools := map[string]strategy.Pool{}
+	aps.RLock()
+	for key, pool := range aps.table {
+		pools[key] = pool
+	}
+	aps.RUnlock()
+
+	for key, pool := range pools {
+		if isRemotePool(pool) {
+			// remote plugins are not started by this snapteld
+			continue
+		}
+		d := pool.Autoscale(a.latency(key))
+		if d.Action == strategy.ScaleNone {
+			continue
+		}
+		if err := a.apply(key, pool, d); err != nil {
+			autoscalerLog.WithFields(log.Fields{
+				"_block": "scale",
+				"pool":   key,
+				"action": d.Action.String(),
+				"reason": d.Reason,
+				"error":  err,
+			}).Error("error scaling plugin pool")
+			continue
+		}
+		autoscalerLog.WithFields(log.Fields{
+			"_block": "scale",
+			"pool":   key,
+			"action": d.Action.String(),
+			"reason": d.Reason,
+		}).Info("plugin pool scaled")
+		aps.statsFor(key).recordScale(d.Action.String(), d.Reason)
+		tnv := strings.Split(key, core.Separator)
+		a.runner.emitter.Emit(&control_event.ScalePluginPoolEvent{
+			Name:    tnv[1],
+			Version: pool.Version(),
+			Type:    pluginTypeOf(pool),
+			Key:     key,
+			Action:  d.Action.String(),
+			Reason:  d.Reason,
+			Id:      d.ID,
+		})
+	}
+}
+
+// apply starts or reaps an instance of the plugin as decided
+func (a *autoscaler) apply(key string, pool strategy.Pool, d strategy.ScaleDecision) error {
+	switch d.Action {
+	case strategy.ScaleUp:
+		lp, err := a.runner.pluginManager.get(key)
+		if err != nil {
+			return err
+		}
+		return a.runner.runPlugin(lp.Name(), lp.Details)
+	case strategy.ScaleDown:
+		reason := "autoscaler: " + d.Reason
+		if ap, ok := pool.Plugins()[d.ID]; ok {
+			if err := ap.Stop(reason); err != nil {
+				autoscalerLog.WithFields(log.Fields{
+					"_block": "apply",
+					"pool":   key,
+					"id":     d.ID,
+				}).Warn(err)
+			}
+		}
+		pool.Kill(d.ID, reason)
+	}
+	return nil
+}
+
+// latency returns the mean latency of the calls made to the pool
+// since the previous evaluation
+func (a *autoscaler) latency(key string) time.Duration {
+	calls, total := a.runner.availablePlugins.statsFor(key).latency()
+	prev := a.samples[key]
+	a.samples[key] = latencySample{calls: calls, total: total}
+	if calls <= prev.calls {
+		return 0
+	}
+	return (total - prev.total) / time.Duration(calls-prev.calls)
+}
+
+func isRemotePool(pool strategy.Pool) bool {
+	for _, ap := range pool.Plugins() {
+		if ap.IsRemote() {
+			return true
+		}
+	}
+	return false
+}
+
+func pluginTypeOf(pool strategy.Pool) int {
+	for _, ap := range pool.Plugins() {
+		return int(ap.Type())
+	}
+	return 0
+}
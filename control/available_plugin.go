@@ -24,9 +24,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
@@ -48,6 +50,8 @@ const (
 	DefaultHealthCheckTimeout = time.Second * 10
 	// DefaultHealthCheckFailureLimit - how any consecutive health check timeouts must occur to trigger a failure
 	DefaultHealthCheckFailureLimit = 3
+	// DefaultDeadPluginLogLines - number of the last plugin output lines attached to a DeadAvailablePluginEvent
+	DefaultDeadPluginLogLines = 10
 )
 
 var (
@@ -77,6 +81,19 @@ type availablePlugin struct {
 	fromPackage        bool
 	pprofPort          string
 	isRemote           bool
+	logs               *plugin.LogBuffer
+	stats              *pluginStats
+	// poolStats is shared by every instance of the plugin
+	poolStats *pluginStats
+	// inFlight is the number of calls currently being served
+	inFlight int32
+	breaker  *circuitBreaker
+}
+
+// logCapturer is implemented by executable plugins which keep
+// the output written by the plugin process
+type logCapturer interface {
+	Logs() *plugin.LogBuffer
 }
 
 // newAvailablePlugin returns an availablePlugin with information from a
@@ -102,8 +119,13 @@ func newAvailablePlugin(resp plugin.Response, emitter gomit.Emitter, ep executab
 		ePlugin:     ep,
 		pprofPort:   resp.PprofAddress,
 		isRemote:    false,
+		stats:       newPluginStats(),
+		breaker:     newCircuitBreaker(),
 	}
 	ap.key = fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", ap.pluginType.String(), ap.name, ap.version)
+	if lc, ok := ep.(logCapturer); ok {
+		ap.logs = lc.Logs()
+	}
 
 	// Create RPC Client
 	switch resp.Type {
@@ -217,6 +239,16 @@ func (a *availablePlugin) RoutingStrategy() plugin.RoutingStrategyType {
 	return a.meta.RoutingStrategy
 }
 
+// RoutingKey returns the config key hashed by the consistent-hash strategy
+func (a *availablePlugin) RoutingKey() string {
+	return a.meta.RoutingKey
+}
+
+// InFlight returns the number of calls currently being served by the plugin
+func (a *availablePlugin) InFlight() int {
+	return int(atomic.LoadInt32(&a.inFlight))
+}
+
 func (a *availablePlugin) ConcurrencyCount() int {
 	return a.meta.ConcurrencyCount
 }
@@ -249,6 +281,130 @@ func (a *availablePlugin) LastHit() time.Time {
 	return a.lastHitTime
 }
 
+// Logs returns up to n of the most recent lines written by the plugin.
+// A value of n lower than 1 returns every captured line.
+func (a *availablePlugin) Logs(n int) []core.PluginLogEntry {
+	if a.logs == nil {
+		return []core.PluginLogEntry{}
+	}
+	lines := a.logs.Tail(n)
+	entries := make([]core.PluginLogEntry, len(lines))
+	for i, l := range lines {
+		entries[i] = a.logEntry(l)
+	}
+	return entries
+}
+
+// WatchLogs returns a channel receiving each line written by the plugin
+// from now on and a function which stops the watch.
+// The returned channel is nil when the plugin output is not captured.
+func (a *availablePlugin) WatchLogs() (<-chan core.PluginLogEntry, func()) {
+	if a.logs == nil {
+		return nil, func() {}
+	}
+	lines, cancel := a.logs.Subscribe()
+	entries := make(chan core.PluginLogEntry)
+	done := make(chan struct{})
+	go func() {
+		defer close(entries)
+		for l := range lines {
+			select {
+			case entries <- a.logEntry(l):
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return entries, func() {
+		once.Do(func() {
+			close(done)
+			cancel()
+		})
+	}
+}
+
+func (a *availablePlugin) logEntry(l plugin.LogLine) core.PluginLogEntry {
+	return core.PluginLogEntry{
+		PluginName:    a.name,
+		PluginVersion: a.version,
+		PluginType:    a.TypeName(),
+		InstanceID:    a.id,
+		Stream:        l.Stream,
+		Timestamp:     l.Timestamp,
+		Line:          l.Line,
+	}
+}
+
+// Stats returns the statistics of the RPC calls made to the plugin instance
+func (a *availablePlugin) Stats() core.PluginStats {
+	s := a.stats.snapshot()
+	s.Name = a.name
+	s.Version = a.version
+	s.Type = a.TypeName()
+	s.ID = a.id
+	return s
+}
+
+// recordCall updates the instance and pool statistics with a call
+// started at start, along with the number of metrics sent and received
+func (a *availablePlugin) recordCall(call string, start time.Time, err error, in, out int) {
+	d := time.Since(start)
+	a.stats.record(call, d, err, in, out)
+	a.poolStats.record(call, d, err, in, out)
+}
+
+// startCall marks a call as in flight and returns its start time
+func (a *availablePlugin) startCall() time.Time {
+	atomic.AddInt32(&a.inFlight, 1)
+	return time.Now()
+}
+
+// finishCall records a call returned by startCall which is no longer in flight
+func (a *availablePlugin) finishCall(call string, start time.Time, err error, in, out int) {
+	atomic.AddInt32(&a.inFlight, -1)
+	a.recordCall(call, start, err, in, out)
+	if from, to := a.breaker.record(err); from != to {
+		l := log.WithFields(log.Fields{
+			"_module":     "control-aplugin",
+			"block":       "circuit-breaker",
+			"plugin_name": a,
+			"id":          a.id,
+			"from":        from,
+			"to":          to,
+		})
+		if to == BreakerOpen {
+			l.WithField("error", err).Warning("circuit breaker opened")
+		} else {
+			l.Info("circuit breaker state changed")
+		}
+	}
+}
+
+// checkBreaker returns an error when the circuit breaker of the
+// plugin does not let calls through
+func (a *availablePlugin) checkBreaker() serror.SnapError {
+	ok, wait := a.breaker.allow()
+	if ok {
+		return nil
+	}
+	fields := map[string]interface{}{
+		"plugin-name":    a.name,
+		"plugin-version": a.version,
+		"plugin-type":    a.TypeName(),
+		"plugin-id":      a.id,
+	}
+	if wait > 0 {
+		fields["retry-in"] = wait.String()
+	}
+	return serror.New(ErrCircuitOpen, fields)
+}
+
+// CircuitBreaker returns the state of the circuit breaker of the plugin
+func (a *availablePlugin) CircuitBreaker() core.CircuitBreakerStatus {
+	return a.breaker.status()
+}
+
 func (a *availablePlugin) IsRemote() bool {
 	return a.isRemote
 }
@@ -302,11 +458,13 @@ func (a *availablePlugin) Kill(r string) error {
 // CheckHealth checks the health of a plugin and updates
 // a.failedHealthChecks
 func (a *availablePlugin) CheckHealth() {
+	start := time.Now()
 	go func() {
 		a.healthChan <- a.client.Ping()
 	}()
 	select {
 	case err := <-a.healthChan:
+		a.recordCall(core.PingCall, start, err, 0, 0)
 		if err == nil {
 			if a.failedHealthChecks > 0 {
 				// only log on first ok health check
@@ -321,6 +479,7 @@ func (a *availablePlugin) CheckHealth() {
 			a.healthCheckFailed()
 		}
 	case <-time.After(DefaultHealthCheckTimeout):
+		a.recordCall(core.PingCall, start, errHealthCheckTimeout, 0, 0)
 		a.healthCheckFailed()
 	}
 }
@@ -348,6 +507,9 @@ func (a *availablePlugin) healthCheckFailed() {
 			Id:      a.ID(),
 			String:  a.String(),
 		}
+		for _, l := range a.Logs(DefaultDeadPluginLogLines) {
+			pde.Logs = append(pde.Logs, l.Stream+": "+l.Line)
+		}
 		defer a.emitter.Emit(pde)
 	}
 	hcfe := &control_event.HealthCheckFailedEvent{
@@ -365,12 +527,17 @@ type availablePlugins struct {
 	// The Pools' primary keys are equal to
 	// {plugin_type}:{plugin_name}:{plugin_version}
 	table map[string]strategy.Pool
+	// stats holds the call statistics of each pool,
+	// using the same keys as table
+	stats      map[string]*pluginStats
+	statsMutex sync.Mutex
 }
 
 func newAvailablePlugins() *availablePlugins {
 	return &availablePlugins{
 		RWMutex: &sync.RWMutex{},
 		table:   make(map[string]strategy.Pool),
+		stats:   make(map[string]*pluginStats),
 	}
 }
 
@@ -383,6 +550,7 @@ func (ap *availablePlugins) insert(pl *availablePlugin) error {
 	defer ap.Unlock()
 
 	key := fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", pl.TypeName(), pl.name, pl.version)
+	pl.poolStats = ap.statsFor(key)
 	_, exists := ap.table[key]
 	if !exists {
 		p, err := strategy.NewPool(key, pl)
@@ -461,15 +629,25 @@ func (ap *availablePlugins) collectMetrics(pluginKey string, metricTypes []core.
 	if serr != nil {
 		return nil, serr
 	}
-
 	// cast client to PluginCollectorClient
 	cli, ok := p.(*availablePlugin).client.(client.PluginCollectorClient)
 	if !ok {
 		return nil, serror.New(errors.New("unable to cast client to PluginCollectorClient"))
 	}
 
+	// resolve secret references only now so they never leave control
+	resolvedMetrics, err := resolveMetricsConfig(metricsToCollect)
+	if err != nil {
+		return nil, serror.New(err)
+	}
+	if serr := p.(*availablePlugin).checkBreaker(); serr != nil {
+		return nil, serr
+	}
+
 	// collect metrics
-	metrics, err := cli.CollectMetrics(metricsToCollect)
+	start := p.(*availablePlugin).startCall()
+	metrics, err := cli.CollectMetrics(resolvedMetrics)
+	p.(*availablePlugin).finishCall(core.CollectMetricsCall, start, err, len(metricsToCollect), len(metrics))
 	if err != nil {
 		return nil, serror.New(err)
 	}
@@ -531,7 +709,12 @@ func (ap *availablePlugins) streamMetrics(
 		return nil, nil, serror.New(errors.New("Invalid streaming client"))
 	}
 
-	metricChan, errChan, err := cli.StreamMetrics(taskID, metricTypes)
+	resolvedMetrics, err := resolveMetricsConfig(metricTypes)
+	if err != nil {
+		return nil, nil, serror.New(err)
+	}
+
+	metricChan, errChan, err := cli.StreamMetrics(taskID, resolvedMetrics)
 	if err != nil {
 		return nil, nil, serror.New(err)
 	}
@@ -564,13 +747,22 @@ func (ap *availablePlugins) publishMetrics(metrics []core.Metric, pluginName str
 	if serr != nil {
 		return []error{serr}
 	}
-
 	cli, ok := p.(*availablePlugin).client.(client.PluginPublisherClient)
 	if !ok {
 		return []error{errors.New("unable to cast client to PluginPublisherClient")}
 	}
 
-	err := cli.Publish(metrics, config)
+	resolved, err := resolveConfig(config)
+	if err != nil {
+		return []error{err}
+	}
+	if serr := p.(*availablePlugin).checkBreaker(); serr != nil {
+		return []error{serr}
+	}
+
+	start := p.(*availablePlugin).startCall()
+	err = cli.Publish(metrics, resolved)
+	p.(*availablePlugin).finishCall(core.PublishCall, start, err, len(metrics), 0)
 	if err != nil {
 		return []error{err}
 	}
@@ -598,13 +790,22 @@ func (ap *availablePlugins) processMetrics(metrics []core.Metric, pluginName str
 		errs = append(errs, err)
 		return nil, errs
 	}
-
 	cli, ok := p.(*availablePlugin).client.(client.PluginProcessorClient)
 	if !ok {
 		return nil, []error{errors.New("unable to cast client to PluginProcessorClient")}
 	}
 
-	mts, errp := cli.Process(metrics, config)
+	resolved, rerr := resolveConfig(config)
+	if rerr != nil {
+		return nil, []error{rerr}
+	}
+	if err := p.(*availablePlugin).checkBreaker(); err != nil {
+		return nil, []error{err}
+	}
+
+	start := p.(*availablePlugin).startCall()
+	mts, errp := cli.Process(metrics, resolved)
+	p.(*availablePlugin).finishCall(core.ProcessCall, start, errp, len(metrics), len(mts))
 	if errp != nil {
 		return nil, []error{errp}
 	}
@@ -652,6 +853,18 @@ func (ap *availablePlugins) getOrCreatePool(key string) (strategy.Pool, error) {
 	return pool, nil
 }
 
+// statsFor returns the call statistics of the pool under the given key
+func (ap *availablePlugins) statsFor(key string) *pluginStats {
+	ap.statsMutex.Lock()
+	defer ap.statsMutex.Unlock()
+	st, ok := ap.stats[key]
+	if !ok {
+		st = newPluginStats()
+		ap.stats[key] = st
+	}
+	return st
+}
+
 func (ap *availablePlugins) pools() map[string]strategy.Pool {
 	ap.RLock()
 	defer ap.RUnlock()
@@ -669,3 +882,158 @@ func (ap *availablePlugins) all() []strategy.AvailablePlugin {
 	}
 	return aps
 }
+
+// poolStats returns the call statistics of the plugin pool under the given
+// key since it was first started, along with those of its running instances
+func (ap *availablePlugins) poolStats(key string) (core.PluginPoolStats, serror.SnapError) {
+	pool, serr := ap.getPool(key)
+	if serr != nil {
+		return core.PluginPoolStats{}, serr
+	}
+	if pool == nil {
+		return core.PluginPoolStats{}, serror.New(ErrPoolNotFound, map[string]interface{}{"key": key})
+	}
+	// the version in the key may be lower than 1 to select the latest pool
+	tnv := strings.Split(key, core.Separator)
+	st := ap.statsFor(fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", tnv[0], tnv[1], pool.Version()))
+	total := st.snapshot()
+	total.Type = tnv[0]
+	total.Name = tnv[1]
+	total.Version = pool.Version()
+	ps := core.PluginPoolStats{
+		Total:     total,
+		Instances: []core.PluginStats{},
+		Scaling:   st.scalingSnapshot(),
+	}
+	for _, p := range pool.Plugins() {
+		if a, ok := p.(*availablePlugin); ok {
+			ps.Instances = append(ps.Instances, a.Stats())
+		}
+	}
+	sort.Sort(pluginStatsByID(ps.Instances))
+	return ps, nil
+}
+
+// health returns the state of every plugin pool, sorted by key
+func (ap *availablePlugins) health() []core.PluginPoolHealth {
+	ap.RLock()
+	defer ap.RUnlock()
+	pools := make([]core.PluginPoolHealth, 0, len(ap.table))
+	for key, pool := range ap.table {
+		tnv := strings.Split(key, core.Separator)
+		if len(tnv) != 3 {
+			continue
+		}
+		pools = append(pools, core.PluginPoolHealth{
+			Type:          tnv[0],
+			Name:          tnv[1],
+			Version:       pool.Version(),
+			Instances:     pool.Count(),
+			Subscriptions: pool.SubscriptionCount(),
+			Restarts:      pool.RestartCount(),
+		})
+	}
+	sort.Sort(poolHealthByKey(pools))
+	return pools
+}
+
+// logs returns up to n of the most recent lines written by the instances
+// of the plugin pool under the given key, oldest first
+func (ap *availablePlugins) logs(key string, n int) ([]core.PluginLogEntry, serror.SnapError) {
+	pool, serr := ap.getPool(key)
+	if serr != nil {
+		return nil, serr
+	}
+	if pool == nil {
+		return nil, serror.New(ErrPoolNotFound, map[string]interface{}{"key": key})
+	}
+	entries := []core.PluginLogEntry{}
+	for _, p := range pool.Plugins() {
+		if a, ok := p.(*availablePlugin); ok {
+			entries = append(entries, a.Logs(n)...)
+		}
+	}
+	sort.Stable(logEntriesByTime(entries))
+	if n > 0 && len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries, nil
+}
+
+// watchLogs returns a channel receiving the lines written by the instances
+// of the plugin pool under the given key and a function which stops the watch.
+// Only instances running at the time of the call are followed.
+func (ap *availablePlugins) watchLogs(key string) (<-chan core.PluginLogEntry, func(), serror.SnapError) {
+	pool, serr := ap.getPool(key)
+	if serr != nil {
+		return nil, nil, serr
+	}
+	if pool == nil {
+		return nil, nil, serror.New(ErrPoolNotFound, map[string]interface{}{"key": key})
+	}
+	out := make(chan core.PluginLogEntry)
+	done := make(chan struct{})
+	cancels := []func(){}
+	wg := sync.WaitGroup{}
+	for _, p := range pool.Plugins() {
+		a, ok := p.(*availablePlugin)
+		if !ok {
+			continue
+		}
+		entries, cancel := a.WatchLogs()
+		if entries == nil {
+			continue
+		}
+		cancels = append(cancels, cancel)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range entries {
+				select {
+				case out <- e:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	var once sync.Once
+	return out, func() {
+		once.Do(func() {
+			close(done)
+			for _, cancel := range cancels {
+				cancel()
+			}
+		})
+	}, nil
+}
+
+type logEntriesByTime []core.PluginLogEntry
+
+func (l logEntriesByTime) Len() int           { return len(l) }
+func (l logEntriesByTime) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+func (l logEntriesByTime) Less(i, j int) bool { return l[i].Timestamp.Before(l[j].Timestamp) }
+
+type pluginStatsByID []core.PluginStats
+
+func (p pluginStatsByID) Len() int           { return len(p) }
+func (p pluginStatsByID) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p pluginStatsByID) Less(i, j int) bool { return p[i].ID < p[j].ID }
+
+type poolHealthByKey []core.PluginPoolHealth
+
+func (p poolHealthByKey) Len() int      { return len(p) }
+func (p poolHealthByKey) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p poolHealthByKey) Less(i, j int) bool {
+	if p[i].Type != p[j].Type {
+		return p[i].Type < p[j].Type
+	}
+	if p[i].Name != p[j].Name {
+		return p[i].Name < p[j].Name
+	}
+	return p[i].Version < p[j].Version
+}
@@ -0,0 +1,171 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/gomit"
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/control_event"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type recordingEmitter struct {
+	events []gomit.EventBody
+}
+
+func (r *recordingEmitter) Emit(e gomit.EventBody) (int, error) {
+	r.events = append(r.events, e)
+	return 1, nil
+}
+
+func newLoggingAvailablePlugin(id uint32) *availablePlugin {
+	ap := &availablePlugin{
+		name:       "mock",
+		version:    1,
+		pluginType: plugin.CollectorPluginType,
+		key:        "collector" + core.Separator + "mock" + core.Separator + "1",
+		emitter:    &recordingEmitter{},
+		logs:       plugin.NewLogBuffer(20),
+	}
+	ap.SetID(id)
+	return ap
+}
+
+func TestAvailablePluginLogs(t *testing.T) {
+	Convey("Given a running plugin with captured output", t, func() {
+		ap := newLoggingAvailablePlugin(3)
+		for i := 0; i < 15; i++ {
+			ap.logs.Add("stderr", "line")
+		}
+		ap.logs.Add("stdout", "last")
+
+		Convey("Logs() returns the lines tagged with the plugin instance", func() {
+			entries := ap.Logs(2)
+			So(entries, ShouldHaveLength, 2)
+			So(entries[1].PluginName, ShouldEqual, "mock")
+			So(entries[1].PluginVersion, ShouldEqual, 1)
+			So(entries[1].PluginType, ShouldEqual, "collector")
+			So(entries[1].InstanceID, ShouldEqual, 3)
+			So(entries[1].Stream, ShouldEqual, "stdout")
+			So(entries[1].Line, ShouldEqual, "last")
+		})
+		Convey("WatchLogs() streams new lines until cancelled", func() {
+			c, cancel := ap.WatchLogs()
+			ap.logs.Add("stdout", "new")
+			select {
+			case e := <-c:
+				So(e.Line, ShouldEqual, "new")
+				So(e.InstanceID, ShouldEqual, 3)
+			case <-time.After(time.Second):
+				So("timed out", ShouldBeEmpty)
+			}
+			cancel()
+			_, ok := <-c
+			So(ok, ShouldBeFalse)
+		})
+		Convey("a dead plugin event carries its last lines", func() {
+			ap.failedHealthChecks = DefaultHealthCheckFailureLimit
+			ap.healthCheckFailed()
+			var dead *control_event.DeadAvailablePluginEvent
+			for _, e := range ap.emitter.(*recordingEmitter).events {
+				if d, ok := e.(*control_event.DeadAvailablePluginEvent); ok {
+					dead = d
+				}
+			}
+			So(dead, ShouldNotBeNil)
+			So(dead.Logs, ShouldHaveLength, DefaultDeadPluginLogLines)
+			So(dead.Logs[DefaultDeadPluginLogLines-1], ShouldEqual, "stdout: last")
+		})
+	})
+
+	Convey("Given a plugin without captured output", t, func() {
+		ap := newLoggingAvailablePlugin(1)
+		ap.logs = nil
+		So(ap.Logs(0), ShouldBeEmpty)
+		c, _ := ap.WatchLogs()
+		So(c, ShouldBeNil)
+	})
+
+	Convey("Given a pool of running plugins", t, func() {
+		aps := newAvailablePlugins()
+		ap1 := newLoggingAvailablePlugin(1)
+		ap2 := newLoggingAvailablePlugin(2)
+		So(aps.insert(ap1), ShouldBeNil)
+		So(aps.insert(ap2), ShouldBeNil)
+		ap1.logs.Add("stderr", "first")
+		time.Sleep(time.Millisecond)
+		ap2.logs.Add("stderr", "second")
+		time.Sleep(time.Millisecond)
+		ap1.logs.Add("stderr", "third")
+
+		Convey("logs() merges the output of every instance in time order", func() {
+			entries, err := aps.logs(ap1.key, 0)
+			So(err, ShouldBeNil)
+			So(entries, ShouldHaveLength, 3)
+			So(entries[0].Line, ShouldEqual, "first")
+			So(entries[1].InstanceID, ShouldEqual, 2)
+			So(entries[2].Line, ShouldEqual, "third")
+			entries, err = aps.logs(ap1.key, 1)
+			So(err, ShouldBeNil)
+			So(entries, ShouldHaveLength, 1)
+			So(entries[0].Line, ShouldEqual, "third")
+		})
+		Convey("watchLogs() follows every instance", func() {
+			c, cancel, err := aps.watchLogs(ap1.key)
+			So(err, ShouldBeNil)
+			defer cancel()
+			ap2.logs.Add("stdout", "followed")
+			select {
+			case e := <-c:
+				So(e.Line, ShouldEqual, "followed")
+				So(e.InstanceID, ShouldEqual, 2)
+			case <-time.After(time.Second):
+				So("timed out", ShouldBeEmpty)
+			}
+		})
+		Convey("logs() returns an error for a plugin which is not running", func() {
+			_, err := aps.logs("collector"+core.Separator+"other"+core.Separator+"1", 0)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestAvailablePluginInFlight(t *testing.T) {
+	Convey("Given a running plugin", t, func() {
+		ap := newLoggingAvailablePlugin(1)
+		So(ap.InFlight(), ShouldEqual, 0)
+		Convey("calls are counted in flight until they are finished", func() {
+			s1 := ap.startCall()
+			s2 := ap.startCall()
+			So(ap.InFlight(), ShouldEqual, 2)
+			ap.finishCall(core.CollectMetricsCall, s1, nil, 1, 1)
+			So(ap.InFlight(), ShouldEqual, 1)
+			ap.finishCall(core.CollectMetricsCall, s2, nil, 1, 1)
+			So(ap.InFlight(), ShouldEqual, 0)
+		})
+	})
+}
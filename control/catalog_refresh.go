@@ -0,0 +1,317 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/vrischmann/jsonutil"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/client"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/control_event"
+	"github.com/intelsdi-x/snap/core/serror"
+)
+
+var (
+	// ErrCatalogRefreshUnsupported - error message when refreshing the metric catalog of a plugin which is not a collector
+	ErrCatalogRefreshUnsupported = errors.New("Only the metric types of collector plugins can be refreshed")
+
+	// ErrNoRunningInstance - error message when there is no instance of a remote plugin to call
+	ErrNoRunningInstance = errors.New("No running instance of the plugin")
+)
+
+// DefaultCatalogRefreshTick is how often the catalog refresher looks for
+// collectors due for a refresh
+var DefaultCatalogRefreshTick = time.Second
+
+var refresherLog = log.WithField("_module", "control-catalog-refresher")
+
+// catalogRefresher periodically refreshes the metric types of the collectors
+// configured with a catalog refresh interval
+type catalogRefresher struct {
+	sync.Mutex
+	control *pluginControl
+	tick    time.Duration
+	// intervals holds the refresh interval of collectors by plugin name
+	intervals map[string]time.Duration
+	// last holds the time of the last refresh of collectors by plugin key
+	last map[string]time.Time
+	quit chan struct{}
+}
+
+func newCatalogRefresher(c *pluginControl) *catalogRefresher {
+	return &catalogRefresher{
+		control:   c,
+		tick:      DefaultCatalogRefreshTick,
+		intervals: map[string]time.Duration{},
+		last:      map[string]time.Time{},
+	}
+}
+
+// setIntervals sets the refresh interval of collectors by plugin name.
+// Collectors without an interval are not refreshed periodically.
+func (r *catalogRefresher) setIntervals(intervals map[string]jsonutil.Duration) {
+	r.Lock()
+	defer r.Unlock()
+	r.intervals = map[string]time.Duration{}
+	for name, d := range intervals {
+		if d.Duration > 0 {
+			r.intervals[name] = d.Duration
+		}
+	}
+}
+
+// Start refreshes the collectors due for a refresh at every tick until
+// Stop is called
+func (r *catalogRefresher) Start() {
+	ticker := time.NewTicker(r.tick)
+	quit := make(chan struct{})
+	r.quit = quit
+	go func() {
+		for {
+			select {
+			case now := <-ticker.C:
+				r.refresh(now)
+			case <-quit:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the catalog refresher
+func (r *catalogRefresher) Stop() {
+	if r.quit != nil {
+		close(r.quit)
+		r.quit = nil
+	}
+}
+
+// refresh refreshes the metric types of each collector whose interval
+// elapsed since it was loaded or last refreshed
+func (r *catalogRefresher) refresh(now time.Time) {
+	for _, lp := range r.due(now) {
+		pluginType := core.PluginType(lp.Type)
+		if _, serr := r.control.RefreshPlugin(pluginType, lp.Name(), lp.Version()); serr != nil {
+			refresherLog.WithFields(log.Fields{
+				"_block":         "refresh",
+				"plugin-name":    lp.Name(),
+				"plugin-version": lp.Version(),
+				"plugin-type":    pluginType.String(),
+				"error":          serr,
+			}).Error("error refreshing metric types")
+		}
+	}
+}
+
+func (r *catalogRefresher) due(now time.Time) []*loadedPlugin {
+	r.Lock()
+	defer r.Unlock()
+	if len(r.intervals) == 0 {
+		return nil
+	}
+	due := []*loadedPlugin{}
+	loaded := map[string]bool{}
+	for key, lp := range r.control.pluginManager.all() {
+		loaded[key] = true
+		interval, ok := r.intervals[lp.Name()]
+		if !ok || !isCollector(lp.Type) {
+			continue
+		}
+		last, ok := r.last[key]
+		if !ok {
+			last = lp.LoadedTime
+		}
+		if now.Sub(last) >= interval {
+			r.last[key] = now
+			due = append(due, lp)
+		}
+	}
+	// forget the collectors which were unloaded
+	for key := range r.last {
+		if !loaded[key] {
+			delete(r.last, key)
+		}
+	}
+	return due
+}
+
+func isCollector(t plugin.PluginType) bool {
+	return t == plugin.CollectorPluginType || t == plugin.StreamCollectorPluginType
+}
+
+// RefreshPlugin calls GetMetricTypes on a collector again and applies the
+// difference to the metric catalog. Running subscription groups are processed
+// again when the catalog changed, so that their requested namespaces match
+// the metrics now available.
+func (p *pluginControl) RefreshPlugin(pluginType core.PluginType, name string, ver int) (core.CatalogRefresh, serror.SnapError) {
+	f := map[string]interface{}{
+		"plugin-name":    name,
+		"plugin-version": ver,
+		"plugin-type":    pluginType.String(),
+	}
+	if pluginType != core.CollectorPluginType && pluginType != core.StreamingCollectorPluginType {
+		return core.CatalogRefresh{}, serror.New(ErrCatalogRefreshUnsupported, f)
+	}
+	lp, err := p.pluginManager.get(fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", pluginType.String(), name, ver))
+	if err != nil {
+		return core.CatalogRefresh{}, serror.New(err, f)
+	}
+
+	mts, err := p.getMetricTypes(lp)
+	if err != nil {
+		return core.CatalogRefresh{}, serror.New(err, f)
+	}
+	added, removed, err := p.metricCatalog.Refresh(lp, mts)
+	if err != nil {
+		return core.CatalogRefresh{}, serror.New(err, f)
+	}
+
+	res := core.CatalogRefresh{
+		Added:   make([]core.CatalogedMetric, 0, len(added)),
+		Removed: make([]core.CatalogedMetric, 0, len(removed)),
+	}
+	for _, mt := range added {
+		res.Added = append(res.Added, mt)
+		p.eventManager.Emit(&control_event.MetricAddedEvent{
+			PluginName:    lp.Name(),
+			PluginVersion: lp.Version(),
+			PluginType:    int(lp.Type),
+			Metric:        mt.Namespace().String(),
+			MetricVersion: mt.Version(),
+		})
+	}
+	for _, mt := range removed {
+		res.Removed = append(res.Removed, mt)
+		p.eventManager.Emit(&control_event.MetricRemovedEvent{
+			PluginName:    lp.Name(),
+			PluginVersion: lp.Version(),
+			PluginType:    int(lp.Type),
+			Metric:        mt.Namespace().String(),
+			MetricVersion: mt.Version(),
+		})
+	}
+	controlLogger.WithFields(log.Fields{
+		"_block":         "refresh-plugin",
+		"plugin-name":    lp.Name(),
+		"plugin-version": lp.Version(),
+		"added":          len(added),
+		"removed":        len(removed),
+	}).Info("metric types refreshed")
+
+	if len(added) > 0 || len(removed) > 0 {
+		for _, serr := range p.subscriptionGroups.Process() {
+			controlLogger.WithFields(log.Fields{
+				"_block": "refresh-plugin",
+			}).Error(serr)
+		}
+	}
+	return res, nil
+}
+
+// getMetricTypes calls GetMetricTypes on a running instance of the collector.
+// When none is running, an instance is started for the call.
+func (p *pluginControl) getMetricTypes(lp *loadedPlugin) ([]core.Metric, error) {
+	ap := p.runningInstance(lp)
+	if ap == nil {
+		if lp.Details.Uri != nil {
+			return nil, ErrNoRunningInstance
+		}
+		if err := p.pluginRunner.runPlugin(lp.Name(), lp.Details); err != nil {
+			return nil, err
+		}
+		ap = p.runningInstance(lp)
+		if ap == nil {
+			return nil, ErrNoRunningInstance
+		}
+		defer p.stopInstance(ap, "metric types refreshed")
+	}
+	cli, ok := ap.client.(client.PluginCollectorClient)
+	if !ok {
+		return nil, errors.New("unable to cast client to PluginCollectorClient")
+	}
+
+	// the config is built as when the plugin was loaded
+	cfgNode := p.Config.Plugins.getPluginConfigDataNode(core.PluginType(lp.Type), lp.Name(), lp.Version())
+	if lp.ConfigPolicy != nil {
+		defaults := cdata.NewNode()
+		for _, node := range lp.ConfigPolicy.GetAll() {
+			_, errs := node.AddDefaults(defaults.Table())
+			if len(errs.Errors()) > 0 {
+				return nil, errs.Errors()[0]
+			}
+		}
+		cfgNode = cfgNode.ReverseMerge(defaults)
+	}
+	resolvedNode, err := resolveConfigNode(cfgNode)
+	if err != nil {
+		return nil, err
+	}
+	mts, err := cli.GetMetricTypes(plugin.ConfigType{ConfigDataNode: resolvedNode})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]core.Metric, 0, len(mts))
+	for _, mt := range mts {
+		nmt, err := advertisedMetricType(lp.Version(), mt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p.pluginManager.AddStandardAndWorkflowTags(nmt, nil))
+	}
+	return res, nil
+}
+
+// runningInstance returns an instance of the plugin from its pool
+// or nil if none is running
+func (p *pluginControl) runningInstance(lp *loadedPlugin) *availablePlugin {
+	pool, serr := p.pluginRunner.AvailablePlugins().getPool(lp.Key())
+	if serr != nil || pool == nil {
+		return nil
+	}
+	for _, ap := range pool.Plugins() {
+		if a, ok := ap.(*availablePlugin); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+func (p *pluginControl) stopInstance(ap *availablePlugin, reason string) {
+	if err := ap.Stop(reason); err != nil {
+		controlLogger.WithFields(log.Fields{
+			"_block": "stop-instance",
+			"id":     ap.ID(),
+		}).Warn(err)
+	}
+	pool, serr := p.pluginRunner.AvailablePlugins().getPool(ap.key)
+	if serr == nil && pool != nil {
+		pool.Kill(ap.ID(), reason)
+	}
+}
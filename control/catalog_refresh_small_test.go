@@ -0,0 +1,139 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vrischmann/jsonutil"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
+	"github.com/intelsdi-x/snap/core"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newRefreshTestPlugin(name string, ver int, loaded time.Time) *loadedPlugin {
+	return &loadedPlugin{
+		Meta:         plugin.PluginMeta{Name: name, Version: ver},
+		Type:         plugin.CollectorPluginType,
+		ConfigPolicy: cpolicy.New(),
+		Details:      &pluginDetails{},
+		LoadedTime:   loaded,
+	}
+}
+
+func advertised(nss ...string) []core.Metric {
+	mts := []core.Metric{}
+	for _, ns := range nss {
+		mts = append(mts, plugin.MetricType{
+			Namespace_: core.NewNamespace("intel", "mock", ns),
+			Version_:   1,
+		})
+	}
+	return mts
+}
+
+func TestMetricCatalogRefresh(t *testing.T) {
+	Convey("Refreshing the metric types of a plugin", t, func() {
+		mc := newMetricCatalog()
+		lp := newRefreshTestPlugin("mock", 1, time.Now())
+		other := newRefreshTestPlugin("other", 1, time.Now())
+		mc.Add(newLoadedMetricType(other, plugin.MetricType{
+			Namespace_: core.NewNamespace("intel", "other", "foo"),
+			Version_:   1,
+		}))
+
+		added, removed, err := mc.Refresh(lp, advertised("foo", "bar"))
+		So(err, ShouldBeNil)
+		So(added, ShouldHaveLength, 2)
+		So(removed, ShouldBeEmpty)
+		So(mc.Keys(), ShouldHaveLength, 3)
+
+		Convey("with the same metric types does not change the catalog", func() {
+			added, removed, err := mc.Refresh(lp, advertised("foo", "bar"))
+			So(err, ShouldBeNil)
+			So(added, ShouldBeEmpty)
+			So(removed, ShouldBeEmpty)
+			So(mc.Keys(), ShouldHaveLength, 3)
+		})
+		Convey("adds new and removes missing metric types", func() {
+			added, removed, err := mc.Refresh(lp, advertised("foo", "baz"))
+			So(err, ShouldBeNil)
+			So(added, ShouldHaveLength, 1)
+			So(added[0].Namespace().String(), ShouldEqual, "/intel/mock/baz")
+			So(removed, ShouldHaveLength, 1)
+			So(removed[0].Namespace().String(), ShouldEqual, "/intel/mock/bar")
+			So(mc.Keys(), ShouldContain, "/intel/mock/baz")
+			So(mc.Keys(), ShouldNotContain, "/intel/mock/bar")
+			_, err = mc.Fetch(core.NewNamespace("intel", "mock", "bar"))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("leaves the metric types of other plugins", func() {
+			_, removed, err := mc.Refresh(lp, advertised())
+			So(err, ShouldBeNil)
+			So(removed, ShouldHaveLength, 2)
+			So(mc.Keys(), ShouldResemble, []string{"/intel/other/foo"})
+		})
+		Convey("fails on an invalid namespace", func() {
+			_, _, err := mc.Refresh(lp, []core.Metric{plugin.MetricType{
+				Namespace_: core.NewNamespace("intel", "mock", "*"),
+				Version_:   1,
+			}})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCatalogRefresherDue(t *testing.T) {
+	Convey("Given a catalog refresher", t, func() {
+		loaded := time.Now()
+		pm := newPluginManager()
+		lp := newRefreshTestPlugin("mock", 1, loaded)
+		pm.loadedPlugins.add(lp)
+		c := &pluginControl{pluginManager: pm}
+		r := newCatalogRefresher(c)
+
+		Convey("no collector is due without intervals", func() {
+			So(r.due(loaded.Add(time.Hour)), ShouldBeEmpty)
+		})
+		Convey("a collector is due once its interval elapsed", func() {
+			r.setIntervals(map[string]jsonutil.Duration{
+				"mock":  {Duration: time.Minute},
+				"other": {Duration: time.Second},
+			})
+			So(r.due(loaded.Add(30*time.Second)), ShouldBeEmpty)
+			due := r.due(loaded.Add(time.Minute))
+			So(due, ShouldHaveLength, 1)
+			So(due[0].Name(), ShouldEqual, "mock")
+			So(r.due(loaded.Add(90*time.Second)), ShouldBeEmpty)
+			So(r.due(loaded.Add(2*time.Minute)), ShouldHaveLength, 1)
+
+			Convey("and forgotten once unloaded", func() {
+				pm.loadedPlugins.remove(lp.Key())
+				So(r.due(loaded.Add(time.Hour)), ShouldBeEmpty)
+				So(r.last, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,203 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/intelsdi-x/snap/core"
+)
+
+const (
+	// DefaultBreakerWindow - number of the most recent calls over which the error rate is computed
+	DefaultBreakerWindow = 20
+	// DefaultBreakerMinCalls - minimum number of calls in the window before the error rate can open the breaker
+	DefaultBreakerMinCalls = 5
+	// DefaultBreakerErrorRate - error rate over the window at which the breaker opens
+	DefaultBreakerErrorRate = 0.5
+	// DefaultBreakerTimeoutLimit - how many consecutive call timeouts open the breaker
+	DefaultBreakerTimeoutLimit = 3
+	// DefaultBreakerOpenTimeout - how long an open breaker fails fast before a probe call is let through
+	DefaultBreakerOpenTimeout = 30 * time.Second
+	// DefaultBreakerProbeTimeout - how long a probe call may hold a half-open breaker before another probe is let through
+	DefaultBreakerProbeTimeout = 30 * time.Second
+)
+
+const (
+	// BreakerClosed - calls go through to the plugin
+	BreakerClosed = "closed"
+	// BreakerOpen - calls fail fast without reaching the plugin
+	BreakerOpen = "open"
+	// BreakerHalfOpen - a single probe call goes through to find out whether the plugin recovered
+	BreakerHalfOpen = "half-open"
+)
+
+var (
+	ErrCircuitOpen = errors.New("plugin instance is failing, circuit breaker open")
+)
+
+// circuitBreaker stops the calls to a plugin instance when too many
+// of them fail or time out, and lets a probe call through once the
+// open timeout has passed to close again when the instance recovered
+type circuitBreaker struct {
+	sync.Mutex
+	state string
+	// outcomes is a ring of the most recent calls, true for a failure
+	outcomes []bool
+	next     int
+	count    int
+	timeouts int
+	openedAt time.Time
+	probing  bool
+	probedAt time.Time
+	trips    uint64
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{
+		state:    BreakerClosed,
+		outcomes: make([]bool, DefaultBreakerWindow),
+	}
+}
+
+// allow returns whether a call may go through, or how long it is
+// until the next probe call otherwise
+func (b *circuitBreaker) allow() (bool, time.Duration) {
+	if b == nil {
+		return true, 0
+	}
+	b.Lock()
+	defer b.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		wait := DefaultBreakerOpenTimeout - time.Since(b.openedAt)
+		if wait > 0 {
+			return false, wait
+		}
+		b.state = BreakerHalfOpen
+		b.probe()
+		return true, 0
+	case BreakerHalfOpen:
+		// a single probe at a time, unless the probe never recorded
+		// its outcome in time
+		if b.probing && time.Since(b.probedAt) < DefaultBreakerProbeTimeout {
+			return false, 0
+		}
+		b.probe()
+		return true, 0
+	}
+	return true, 0
+}
+
+// record adds the outcome of a call and returns the state of the breaker
+// before and after the call
+func (b *circuitBreaker) record(err error) (string, string) {
+	if b == nil {
+		return BreakerClosed, BreakerClosed
+	}
+	b.Lock()
+	defer b.Unlock()
+	from := b.state
+	switch b.state {
+	case BreakerHalfOpen:
+		b.probing = false
+		if err == nil {
+			b.reset()
+		} else {
+			b.open()
+		}
+	case BreakerClosed:
+		if b.count < len(b.outcomes) {
+			b.count++
+		}
+		b.outcomes[b.next] = err != nil
+		b.next = (b.next + 1) % len(b.outcomes)
+		if err != nil && isTimeout(err) {
+			b.timeouts++
+		} else {
+			b.timeouts = 0
+		}
+		if b.timeouts >= DefaultBreakerTimeoutLimit ||
+			(b.count >= DefaultBreakerMinCalls && float64(b.failures()) >= DefaultBreakerErrorRate*float64(b.count)) {
+			b.open()
+		}
+	}
+	return from, b.state
+}
+
+func (b *circuitBreaker) probe() {
+	b.probing = true
+	b.probedAt = time.Now()
+}
+
+func (b *circuitBreaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = time.Now()
+	b.trips++
+}
+
+func (b *circuitBreaker) reset() {
+	b.state = BreakerClosed
+	b.outcomes = make([]bool, len(b.outcomes))
+	b.next = 0
+	b.count = 0
+	b.timeouts = 0
+}
+
+func (b *circuitBreaker) failures() int {
+	n := 0
+	for i := 0; i < b.count; i++ {
+		if b.outcomes[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// status returns the current state of the breaker
+func (b *circuitBreaker) status() core.CircuitBreakerStatus {
+	if b == nil {
+		return core.CircuitBreakerStatus{State: BreakerClosed, Accepting: true}
+	}
+	b.Lock()
+	defer b.Unlock()
+	return core.CircuitBreakerStatus{
+		State:     b.state,
+		Calls:     b.count,
+		Failures:  b.failures(),
+		Trips:     b.trips,
+		OpenedAt:  b.openedAt,
+		Accepting: b.accepting(),
+	}
+}
+
+// accepting returns whether allow would let a call through, without
+// starting a probe
+func (b *circuitBreaker) accepting() bool {
+	switch b.state {
+	case BreakerOpen:
+		return time.Since(b.openedAt) >= DefaultBreakerOpenTimeout
+	case BreakerHalfOpen:
+		return !b.probing || time.Since(b.probedAt) >= DefaultBreakerProbeTimeout
+	}
+	return true
+}
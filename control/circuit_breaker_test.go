@@ -0,0 +1,176 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/client"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/ctypes"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errBoom := errors.New("boom")
+	errTimeout := errors.New("rpc error: context deadline exceeded")
+
+	Convey("Given a closed circuit breaker", t, func() {
+		b := newCircuitBreaker()
+		ok, _ := b.allow()
+		So(ok, ShouldBeTrue)
+		So(b.status().State, ShouldEqual, BreakerClosed)
+
+		Convey("it stays closed under the error rate", func() {
+			for i := 0; i < DefaultBreakerWindow; i++ {
+				if i%4 == 0 {
+					b.record(errBoom)
+				} else {
+					b.record(nil)
+				}
+			}
+			st := b.status()
+			So(st.State, ShouldEqual, BreakerClosed)
+			So(st.Calls, ShouldEqual, DefaultBreakerWindow)
+			So(st.Failures, ShouldEqual, DefaultBreakerWindow/4)
+		})
+		Convey("it does not open before the minimum number of calls", func() {
+			for i := 0; i < DefaultBreakerMinCalls-1; i++ {
+				b.record(errBoom)
+			}
+			So(b.status().State, ShouldEqual, BreakerClosed)
+		})
+		Convey("it opens when the error rate is reached", func() {
+			for i := 0; i < DefaultBreakerMinCalls-1; i++ {
+				b.record(errBoom)
+			}
+			from, to := b.record(errBoom)
+			So(from, ShouldEqual, BreakerClosed)
+			So(to, ShouldEqual, BreakerOpen)
+			So(b.status().Trips, ShouldEqual, 1)
+
+			Convey("and fails fast until the open timeout", func() {
+				ok, wait := b.allow()
+				So(ok, ShouldBeFalse)
+				So(wait, ShouldBeGreaterThan, 0)
+				So(wait, ShouldBeLessThanOrEqualTo, DefaultBreakerOpenTimeout)
+			})
+			Convey("and lets a single probe through after the open timeout", func() {
+				b.openedAt = time.Now().Add(-DefaultBreakerOpenTimeout)
+				ok, _ := b.allow()
+				So(ok, ShouldBeTrue)
+				So(b.status().State, ShouldEqual, BreakerHalfOpen)
+				ok, _ = b.allow()
+				So(ok, ShouldBeFalse)
+
+				Convey("closing when the probe succeeds", func() {
+					_, to := b.record(nil)
+					So(to, ShouldEqual, BreakerClosed)
+					st := b.status()
+					So(st.Calls, ShouldEqual, 0)
+					So(st.Failures, ShouldEqual, 0)
+					ok, _ := b.allow()
+					So(ok, ShouldBeTrue)
+				})
+				Convey("opening again when the probe fails", func() {
+					_, to := b.record(errBoom)
+					So(to, ShouldEqual, BreakerOpen)
+					So(b.status().Trips, ShouldEqual, 2)
+					ok, _ := b.allow()
+					So(ok, ShouldBeFalse)
+				})
+				Convey("letting another probe through when the probe never returns", func() {
+					b.probedAt = time.Now().Add(-DefaultBreakerProbeTimeout)
+					ok, _ := b.allow()
+					So(ok, ShouldBeTrue)
+					So(b.status().State, ShouldEqual, BreakerHalfOpen)
+					ok, _ = b.allow()
+					So(ok, ShouldBeFalse)
+				})
+			})
+		})
+		Convey("it opens after consecutive timeouts", func() {
+			for i := 0; i < DefaultBreakerWindow; i++ {
+				b.record(nil)
+			}
+			for i := 0; i < DefaultBreakerTimeoutLimit-1; i++ {
+				b.record(errTimeout)
+			}
+			So(b.status().State, ShouldEqual, BreakerClosed)
+			b.record(errTimeout)
+			So(b.status().State, ShouldEqual, BreakerOpen)
+		})
+	})
+
+	Convey("Given a running plugin with an open circuit breaker", t, func() {
+		ap := newLoggingAvailablePlugin(4)
+		ap.breaker = newCircuitBreaker()
+		So(ap.checkBreaker(), ShouldBeNil)
+		for i := 0; i < DefaultBreakerMinCalls; i++ {
+			ap.finishCall(core.CollectMetricsCall, ap.startCall(), errors.New("boom"), 1, 0)
+		}
+		So(ap.CircuitBreaker().State, ShouldEqual, BreakerOpen)
+		So(ap.CircuitBreaker().Accepting, ShouldBeFalse)
+
+		Convey("calls fail fast with a clear error", func() {
+			err := ap.checkBreaker()
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, ErrCircuitOpen.Error())
+			So(err.Fields()["plugin-id"], ShouldEqual, 4)
+			So(err.Fields()["retry-in"], ShouldNotBeEmpty)
+		})
+	})
+}
+
+// unusedPublisher fails the test when the plugin is called
+type unusedPublisher struct {
+	client.PluginPublisherClient
+}
+
+func TestCircuitBreakerUnresolvedSecret(t *testing.T) {
+	Convey("Given a publisher whose circuit breaker lets a probe through", t, func() {
+		aps := newAvailablePlugins()
+		ap := newLoggingAvailablePlugin(1)
+		ap.pluginType = plugin.PublisherPluginType
+		ap.key = "publisher" + core.Separator + "mock" + core.Separator + "1"
+		ap.client = unusedPublisher{}
+		ap.breaker = newCircuitBreaker()
+		ap.breaker.open()
+		ap.breaker.openedAt = time.Now().Add(-DefaultBreakerOpenTimeout)
+		So(aps.insert(ap), ShouldBeNil)
+
+		Convey("a secret which does not resolve does not hold the probe", func() {
+			defer allowSecrets(SecretsConfig{Env: []string{"SNAP_TEST_SECRET*"}})()
+			cfg := map[string]ctypes.ConfigValue{
+				"password": ctypes.ConfigValueSecret{Ref: "env:SNAP_TEST_SECRET_UNSET"},
+			}
+			errs := aps.publishMetrics(nil, "mock", 1, cfg, "task")
+			So(errs, ShouldHaveLength, 1)
+			So(errs[0].Error(), ShouldContainSubstring, ErrSecretNotFound.Error())
+			So(ap.checkBreaker(), ShouldBeNil)
+		})
+	})
+}
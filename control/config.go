@@ -26,6 +26,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
@@ -34,23 +35,29 @@ import (
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
 	"github.com/intelsdi-x/snap/core/ctypes"
+	"github.com/intelsdi-x/snap/core/serror"
 )
 
 // default configuration values
 var (
-	defaultListenAddr        = "127.0.0.1"
-	defaultListenPort        = 8082
-	defaultMaxRunningPlugins = 3
-	defaultPluginLoadTimeout = 3
-	defaultPluginTrust       = 1
-	defaultAutoDiscoverPath  = ""
-	defaultKeyringPaths      = ""
-	defaultCacheExpiration   = 500 * time.Millisecond
-	defaultPprof             = false
-	defaultTempDirPath       = os.TempDir()
-	defaultTLSCertPath       = ""
-	defaultTLSKeyPath        = ""
-	defaultCACertPaths       = ""
+	defaultListenAddr         = "127.0.0.1"
+	defaultListenPort         = 8082
+	defaultMaxRunningPlugins  = 3
+	defaultPluginLoadTimeout  = 3
+	defaultPluginTrust        = 1
+	defaultAutoDiscoverPath   = ""
+	defaultKeyringPaths       = ""
+	defaultCacheExpiration    = 500 * time.Millisecond
+	defaultPprof              = false
+	defaultTempDirPath        = os.TempDir()
+	defaultTLSCertPath        = ""
+	defaultTLSKeyPath         = ""
+	defaultCACertPaths        = ""
+	defaultAutoscaleEnabled   = false
+	defaultAutoscaleInFlight  = 4
+	defaultAutoscaleLatency   = time.Second
+	defaultAutoscaleCoolDown  = time.Minute
+	defaultMaxExpandedMetrics = 0
 )
 
 type pluginConfig struct {
@@ -59,6 +66,11 @@ type pluginConfig struct {
 	Publisher   *pluginTypeConfigItem `json:"publisher"`
 	Processor   *pluginTypeConfigItem `json:"processor"`
 	pluginCache map[string]*cdata.ConfigDataNode
+	// mutex guards the tables, which are swapped on reload, and the cache
+	mutex sync.RWMutex
+	// updates serializes the changes to the config, from being validated to
+	// being applied
+	updates sync.Mutex
 }
 
 type pluginTypeConfigItem struct {
@@ -76,22 +88,55 @@ type pluginConfigItem struct {
 //         UnmarshalJSON method in this same file needs to be modified to
 //         match the field mapping that is defined here
 type Config struct {
-	MaxRunningPlugins int                          `json:"max_running_plugins"yaml:"max_running_plugins"`
-	PluginLoadTimeout int                          `json:"plugin_load_timeout"yaml:"plugin_load_timeout"`
-	PluginTrust       int                          `json:"plugin_trust_level"yaml:"plugin_trust_level"`
-	AutoDiscoverPath  string                       `json:"auto_discover_path"yaml:"auto_discover_path"`
-	KeyringPaths      string                       `json:"keyring_paths"yaml:"keyring_paths"`
-	CacheExpiration   jsonutil.Duration            `json:"cache_expiration"yaml:"cache_expiration"`
-	Plugins           *pluginConfig                `json:"plugins"yaml:"plugins"`
-	Tags              map[string]map[string]string `json:"tags,omitempty"yaml:"tags"`
-	ListenAddr        string                       `json:"listen_addr,omitempty"yaml:"listen_addr"`
-	ListenPort        int                          `json:"listen_port,omitempty"yaml:"listen_port"`
-	Pprof             bool                         `json:"pprof"yaml:"pprof"`
-	MaxPluginRestarts int                          `json:"max_plugin_restarts"yaml:"max_plugin_restarts"`
-	TempDirPath       string                       `json:"temp_dir_path"yaml:"temp_dir_path"`
-	TLSCertPath       string                       `json:"tls_cert_path"yaml:"tls_cert_path"`
-	TLSKeyPath        string                       `json:"tls_key_path"yaml:"tls_key_path"`
-	CACertPaths       string                       `json:"ca_cert_paths"yaml:"ca_cert_paths"`
+	MaxRunningPlugins  int                          `json:"max_running_plugins"yaml:"max_running_plugins"`
+	PluginLoadTimeout  int                          `json:"plugin_load_timeout"yaml:"plugin_load_timeout"`
+	PluginTrust        int                          `json:"plugin_trust_level"yaml:"plugin_trust_level"`
+	AutoDiscoverPath   string                       `json:"auto_discover_path"yaml:"auto_discover_path"`
+	KeyringPaths       string                       `json:"keyring_paths"yaml:"keyring_paths"`
+	CacheExpiration    jsonutil.Duration            `json:"cache_expiration"yaml:"cache_expiration"`
+	Plugins            *pluginConfig                `json:"plugins"yaml:"plugins"`
+	Tags               map[string]map[string]string `json:"tags,omitempty"yaml:"tags"`
+	ListenAddr         string                       `json:"listen_addr,omitempty"yaml:"listen_addr"`
+	ListenPort         int                          `json:"listen_port,omitempty"yaml:"listen_port"`
+	Pprof              bool                         `json:"pprof"yaml:"pprof"`
+	MaxPluginRestarts  int                          `json:"max_plugin_restarts"yaml:"max_plugin_restarts"`
+	TempDirPath        string                       `json:"temp_dir_path"yaml:"temp_dir_path"`
+	TLSCertPath        string                       `json:"tls_cert_path"yaml:"tls_cert_path"`
+	TLSKeyPath         string                       `json:"tls_key_path"yaml:"tls_key_path"`
+	CACertPaths        string                       `json:"ca_cert_paths"yaml:"ca_cert_paths"`
+	Autoscale          *AutoscaleConfig             `json:"autoscale"yaml:"autoscale"`
+	CatalogRefresh     map[string]jsonutil.Duration `json:"catalog_refresh,omitempty"yaml:"catalog_refresh"`
+	MaxExpandedMetrics int                          `json:"max_expanded_metrics"yaml:"max_expanded_metrics"`
+	Secrets            *SecretsConfig               `json:"secrets"yaml:"secrets"`
+
+	// subscriptions validates changes to Plugins against the running tasks;
+	// it is set once the config is handed to control
+	subscriptions validatesPluginConfig
+}
+
+// validatesPluginConfig is implemented by the subscription groups, which
+// check a candidate global plugin config before it replaces the current one
+type validatesPluginConfig interface {
+	validatePluginConfig(*pluginConfig) []serror.SnapError
+	Process() []serror.SnapError
+}
+
+// AutoscaleConfig holds the settings of the plugin pool autoscaler
+type AutoscaleConfig struct {
+	Enabled     bool              `json:"enabled"yaml:"enabled"`
+	MaxInFlight int               `json:"max_in_flight"yaml:"max_in_flight"`
+	MaxLatency  jsonutil.Duration `json:"max_latency"yaml:"max_latency"`
+	CoolDown    jsonutil.Duration `json:"cool_down"yaml:"cool_down"`
+}
+
+// SecretsConfig limits what the secret references of a config may resolve to
+type SecretsConfig struct {
+	// Dir is the directory file references are resolved in; file references
+	// are refused when it is empty
+	Dir string `json:"dir"yaml:"dir"`
+	// Env lists the environment variables env references may read.  An entry
+	// ending in * allows every variable starting with the rest of the entry.
+	Env []string `json:"env"yaml:"env"`
 }
 
 const (
@@ -147,6 +192,10 @@ const (
 					"max_plugin_restarts": {
 						"type": "integer"
 					},
+					"max_expanded_metrics": {
+						"type": "integer",
+						"minimum": 0
+					},
 					"tls_cert_path": {
 						"type": "string"
 					},
@@ -155,6 +204,47 @@ const (
 					},
 					"ca_cert_paths": {
 						"type": "string"
+					},
+					"autoscale": {
+						"type": ["object", "null"],
+						"properties": {
+							"enabled": {
+								"type": "boolean"
+							},
+							"max_in_flight": {
+								"type": "integer",
+								"minimum": 0
+							},
+							"max_latency": {
+								"type": "string"
+							},
+							"cool_down": {
+								"type": "string"
+							}
+						},
+						"additionalProperties": false
+					},
+					"catalog_refresh": {
+						"type": ["object", "null"],
+						"properties": {},
+						"additionalProperties": {
+							"type": "string"
+						}
+					},
+					"secrets": {
+						"type": ["object", "null"],
+						"properties": {
+							"dir": {
+								"type": "string"
+							},
+							"env": {
+								"type": ["array", "null"],
+								"items": {
+									"type": "string"
+								}
+							}
+						},
+						"additionalProperties": false
 					}
 				},
 				"additionalProperties": false
@@ -165,22 +255,34 @@ const (
 // get the default snapteld configuration
 func GetDefaultConfig() *Config {
 	return &Config{
-		ListenAddr:        defaultListenAddr,
-		ListenPort:        defaultListenPort,
-		MaxRunningPlugins: defaultMaxRunningPlugins,
-		PluginLoadTimeout: defaultPluginLoadTimeout,
-		PluginTrust:       defaultPluginTrust,
-		AutoDiscoverPath:  defaultAutoDiscoverPath,
-		KeyringPaths:      defaultKeyringPaths,
-		CacheExpiration:   jsonutil.Duration{defaultCacheExpiration},
-		Plugins:           newPluginConfig(),
-		Tags:              newPluginTags(),
-		Pprof:             defaultPprof,
-		MaxPluginRestarts: MaxPluginRestartCount,
-		TempDirPath:       defaultTempDirPath,
-		TLSCertPath:       defaultTLSCertPath,
-		TLSKeyPath:        defaultTLSKeyPath,
-		CACertPaths:       defaultCACertPaths,
+		ListenAddr:         defaultListenAddr,
+		ListenPort:         defaultListenPort,
+		MaxRunningPlugins:  defaultMaxRunningPlugins,
+		PluginLoadTimeout:  defaultPluginLoadTimeout,
+		PluginTrust:        defaultPluginTrust,
+		AutoDiscoverPath:   defaultAutoDiscoverPath,
+		KeyringPaths:       defaultKeyringPaths,
+		CacheExpiration:    jsonutil.Duration{defaultCacheExpiration},
+		Plugins:            newPluginConfig(),
+		Tags:               newPluginTags(),
+		Pprof:              defaultPprof,
+		MaxPluginRestarts:  MaxPluginRestartCount,
+		TempDirPath:        defaultTempDirPath,
+		TLSCertPath:        defaultTLSCertPath,
+		TLSKeyPath:         defaultTLSKeyPath,
+		CACertPaths:        defaultCACertPaths,
+		Autoscale:          newAutoscaleConfig(),
+		MaxExpandedMetrics: defaultMaxExpandedMetrics,
+		Secrets:            &SecretsConfig{},
+	}
+}
+
+func newAutoscaleConfig() *AutoscaleConfig {
+	return &AutoscaleConfig{
+		Enabled:     defaultAutoscaleEnabled,
+		MaxInFlight: defaultAutoscaleInFlight,
+		MaxLatency:  jsonutil.Duration{defaultAutoscaleLatency},
+		CoolDown:    jsonutil.Duration{defaultAutoscaleCoolDown},
 	}
 }
 
@@ -222,32 +324,95 @@ func (p *Config) GetPluginConfigDataNode(pluginType core.PluginType, name string
 	return *p.Plugins.getPluginConfigDataNode(pluginType, name, ver)
 }
 
-func (p *Config) MergePluginConfigDataNode(pluginType core.PluginType, name string, ver int, cdn *cdata.ConfigDataNode) cdata.ConfigDataNode {
-	p.Plugins.mergePluginConfigDataNode(pluginType, name, ver, cdn)
-	return *p.Plugins.getPluginConfigDataNode(pluginType, name, ver)
+func (p *Config) MergePluginConfigDataNode(pluginType core.PluginType, name string, ver int, cdn *cdata.ConfigDataNode) (cdata.ConfigDataNode, []serror.SnapError) {
+	serrs := p.updatePluginConfig(func(cfg *pluginConfig) {
+		cfg.mergePluginConfigDataNode(pluginType, name, ver, cdn)
+	})
+	if serrs != nil {
+		return cdata.ConfigDataNode{}, serrs
+	}
+	return *p.Plugins.getPluginConfigDataNode(pluginType, name, ver), nil
 }
 
-func (p *Config) MergePluginConfigDataNodeAll(cdn *cdata.ConfigDataNode) cdata.ConfigDataNode {
-	p.Plugins.mergePluginConfigDataNodeAll(cdn)
-	return *p.Plugins.All
+func (p *Config) MergePluginConfigDataNodeAll(cdn *cdata.ConfigDataNode) (cdata.ConfigDataNode, []serror.SnapError) {
+	serrs := p.updatePluginConfig(func(cfg *pluginConfig) {
+		cfg.mergePluginConfigDataNodeAll(cdn)
+	})
+	if serrs != nil {
+		return cdata.ConfigDataNode{}, serrs
+	}
+	return *p.Plugins.all(), nil
 }
 
-func (p *Config) DeletePluginConfigDataNodeField(pluginType core.PluginType, name string, ver int, fields ...string) cdata.ConfigDataNode {
-	for _, field := range fields {
-		p.Plugins.deletePluginConfigDataNodeField(pluginType, name, ver, field)
+func (p *Config) DeletePluginConfigDataNodeField(pluginType core.PluginType, name string, ver int, fields ...string) (cdata.ConfigDataNode, []serror.SnapError) {
+	serrs := p.updatePluginConfig(func(cfg *pluginConfig) {
+		for _, field := range fields {
+			cfg.deletePluginConfigDataNodeField(pluginType, name, ver, field)
+		}
+	})
+	if serrs != nil {
+		return cdata.ConfigDataNode{}, serrs
 	}
-	return *p.Plugins.getPluginConfigDataNode(pluginType, name, ver)
+	return *p.Plugins.getPluginConfigDataNode(pluginType, name, ver), nil
 }
 
-func (p *Config) DeletePluginConfigDataNodeFieldAll(fields ...string) cdata.ConfigDataNode {
-	for _, field := range fields {
-		p.Plugins.deletePluginConfigDataNodeFieldAll(field)
+func (p *Config) DeletePluginConfigDataNodeFieldAll(fields ...string) (cdata.ConfigDataNode, []serror.SnapError) {
+	serrs := p.updatePluginConfig(func(cfg *pluginConfig) {
+		for _, field := range fields {
+			cfg.deletePluginConfigDataNodeFieldAll(field)
+		}
+	})
+	if serrs != nil {
+		return cdata.ConfigDataNode{}, serrs
 	}
-	return *p.Plugins.All
+	return *p.Plugins.all(), nil
+}
+
+// updatePluginConfig applies update to a copy of the global plugin config,
+// which then replaces it unless it is invalid for any of the running tasks.
+// Accepted changes are applied to the subscription groups so they take effect
+// on the next collection.
+func (p *Config) updatePluginConfig(update func(*pluginConfig)) []serror.SnapError {
+	serrs := func() []serror.SnapError {
+		p.Plugins.updates.Lock()
+		defer p.Plugins.updates.Unlock()
+		cfg := p.Plugins.clone()
+		update(cfg)
+		return p.setPluginConfig(cfg)
+	}()
+	if serrs != nil {
+		return serrs
+	}
+	if p.subscriptions == nil {
+		return nil
+	}
+	// the subscription groups are processed once the updates lock is released
+	// so other changes do not wait for every task to be reprocessed; Process
+	// reads the config current when it runs so the groups always end up with
+	// the last change applied
+	for _, serr := range p.subscriptions.Process() {
+		controlLogger.WithFields(log.Fields{
+			"_block": "update-plugin-config",
+		}).Error(serr)
+	}
+	return nil
+}
+
+// setPluginConfig replaces the global plugin config with cfg unless it is
+// invalid for any of the running tasks, whose IDs are then in the fields of
+// the first error. The caller holds the updates lock of the plugin config.
+func (p *Config) setPluginConfig(cfg *pluginConfig) []serror.SnapError {
+	if p.subscriptions != nil {
+		if serrs := p.subscriptions.validatePluginConfig(cfg); len(serrs) > 0 {
+			return serrs
+		}
+	}
+	p.Plugins.replace(cfg)
+	return nil
 }
 
 func (p *Config) GetPluginConfigDataNodeAll() cdata.ConfigDataNode {
-	return *p.Plugins.All
+	return *p.Plugins.all()
 }
 
 // IsTLSEnabled returns true if config values enable TLS in plugin communication
@@ -315,6 +480,8 @@ func optAddPluginConfigItem(key string, value ctypes.ConfigValue) pluginConfigOp
 }
 
 func (p *pluginConfig) mergePluginConfigDataNodeAll(cdn *cdata.ConfigDataNode) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	// clear cache
 	p.pluginCache = make(map[string]*cdata.ConfigDataNode)
 
@@ -323,6 +490,8 @@ func (p *pluginConfig) mergePluginConfigDataNodeAll(cdn *cdata.ConfigDataNode) {
 }
 
 func (p *pluginConfig) deletePluginConfigDataNodeFieldAll(key string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	// clear cache
 	p.pluginCache = make(map[string]*cdata.ConfigDataNode)
 
@@ -330,6 +499,59 @@ func (p *pluginConfig) deletePluginConfigDataNodeFieldAll(key string) {
 	return
 }
 
+// replace swaps the contents of the plugin config for those of cfg in place,
+// so every holder of p sees the new config.
+func (p *pluginConfig) replace(cfg *pluginConfig) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.All = cfg.All
+	p.Collector = cfg.Collector
+	p.Processor = cfg.Processor
+	p.Publisher = cfg.Publisher
+	p.pluginCache = make(map[string]*cdata.ConfigDataNode)
+}
+
+// clone returns a deep copy of the plugin config, so changes can be
+// validated before they are applied.
+func (p *pluginConfig) clone() *pluginConfig {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	cfg := newPluginConfig()
+	cfg.All = copyConfigDataNode(p.All)
+	cfg.Collector = p.Collector.clone()
+	cfg.Processor = p.Processor.clone()
+	cfg.Publisher = p.Publisher.clone()
+	return cfg
+}
+
+// all returns the config shared by every plugin
+func (p *pluginConfig) all() *cdata.ConfigDataNode {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.All
+}
+
+func (t *pluginTypeConfigItem) clone() *pluginTypeConfigItem {
+	item := newPluginTypeConfigItem()
+	item.All = copyConfigDataNode(t.All)
+	for name, plg := range t.Plugins {
+		c := newPluginConfigItem()
+		c.ConfigDataNode = copyConfigDataNode(plg.ConfigDataNode)
+		for ver, node := range plg.Versions {
+			c.Versions[ver] = copyConfigDataNode(node)
+		}
+		item.Plugins[name] = c
+	}
+	return item
+}
+
+func copyConfigDataNode(node *cdata.ConfigDataNode) *cdata.ConfigDataNode {
+	if node == nil {
+		return cdata.NewNode()
+	}
+	return node.ReverseMerge(cdata.NewNode())
+}
+
 func (p *pluginConfig) switchPluginConfigType(pluginType core.PluginType) *pluginTypeConfigItem {
 	switch {
 	case pluginType == core.CollectorPluginType || pluginType == core.StreamingCollectorPluginType:
@@ -343,6 +565,8 @@ func (p *pluginConfig) switchPluginConfigType(pluginType core.PluginType) *plugi
 }
 
 func (p *pluginConfig) mergePluginConfigDataNode(pluginType core.PluginType, name string, ver int, cdn *cdata.ConfigDataNode) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	// clear cache
 	p.pluginCache = make(map[string]*cdata.ConfigDataNode)
 	configItem := p.switchPluginConfigType(pluginType)
@@ -374,6 +598,8 @@ func (p *pluginConfig) mergePluginConfigDataNode(pluginType core.PluginType, nam
 }
 
 func (p *pluginConfig) deletePluginConfigDataNodeField(pluginType core.PluginType, name string, ver int, key string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	// clear cache
 	p.pluginCache = make(map[string]*cdata.ConfigDataNode)
 	configItem := p.switchPluginConfigType(pluginType)
@@ -394,6 +620,9 @@ func (p *pluginConfig) deletePluginConfigDataNodeField(pluginType core.PluginTyp
 }
 
 func (p *pluginConfig) getPluginConfigDataNode(pluginType core.PluginType, name string, ver int) *cdata.ConfigDataNode {
+	// the cache is filled on reads too
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	// check cache
 	key := fmt.Sprintf("%d"+core.Separator+"%s"+core.Separator+"%d", pluginType, name, ver)
 	if res, ok := p.pluginCache[key]; ok {
@@ -422,7 +651,7 @@ func (p *pluginConfig) getPluginConfigDataNode(pluginType core.PluginType, name
 		"_block_":            "getPluginConfigDataNode",
 		"_module":            "config",
 		"config-cache-key":   key,
-		"config-cache-value": p.pluginCache[key],
+		"config-cache-value": p.pluginCache[key].Redacted(),
 	}).Debug("Getting plugin config")
 
 	return p.pluginCache[key]
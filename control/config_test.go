@@ -26,9 +26,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/intelsdi-x/snap/control/strategy"
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
 	"github.com/intelsdi-x/snap/core/ctypes"
+	"github.com/intelsdi-x/snap/core/serror"
 	"github.com/intelsdi-x/snap/pkg/cfgfile"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -146,6 +148,134 @@ func TestPluginConfig(t *testing.T) {
 	})
 }
 
+func TestPluginConfigReplace(t *testing.T) {
+	Convey("Given a plugin config read while it is replaced", t, func() {
+		cfg := newPluginConfig()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 100; i++ {
+				next := newPluginConfig()
+				next.Collector.All.AddItem("count", ctypes.ConfigValueInt{Value: i})
+				cfg.replace(next)
+			}
+		}()
+		for i := 0; i < 100; i++ {
+			So(cfg.getPluginConfigDataNode(core.CollectorPluginType, "test", 1), ShouldNotBeNil)
+			So(cfg.clone(), ShouldNotBeNil)
+		}
+		<-done
+		So(cfg.getPluginConfigDataNode(core.CollectorPluginType, "test", 1).Table()["count"], ShouldResemble, ctypes.ConfigValueInt{Value: 99})
+	})
+}
+
+func TestControlApplyConfig(t *testing.T) {
+	Convey("Given a running control module", t, func() {
+		defer func(ttl time.Duration, restarts int) {
+			strategy.GlobalCacheExpiration = ttl
+			MaxPluginRestartCount = restarts
+		}(strategy.GlobalCacheExpiration, MaxPluginRestartCount)
+
+		c := New(GetDefaultConfig())
+		plugins := c.Config.Plugins
+		So(len(plugins.getPluginConfigDataNode(core.CollectorPluginType, "test", 1).Table()), ShouldEqual, 0)
+
+		Convey("applying a new config updates the reloadable settings in place", func() {
+			cfg := GetDefaultConfig()
+			cfg.Plugins.Collector.All.AddItem("user", ctypes.ConfigValueStr{Value: "jane"})
+			cfg.Tags = map[string]map[string]string{"/intel": {"dc": "east"}}
+			cfg.CacheExpiration.Duration = 2 * time.Second
+			cfg.MaxPluginRestarts = 7
+			cfg.MaxRunningPlugins = 9
+			So(c.ApplyConfig(cfg), ShouldBeNil)
+
+			So(c.Config.Plugins, ShouldEqual, plugins)
+			So(c.pluginManager.GetPluginConfig(), ShouldEqual, plugins)
+			So(plugins.getPluginConfigDataNode(core.CollectorPluginType, "test", 1).Table()["user"], ShouldResemble, ctypes.ConfigValueStr{Value: "jane"})
+			So(c.pluginManager.(*pluginManager).pluginTags, ShouldResemble, cfg.Tags)
+			So(c.Config.Tags, ShouldResemble, cfg.Tags)
+			So(strategy.GlobalCacheExpiration, ShouldEqual, 2*time.Second)
+			So(MaxPluginRestartCount, ShouldEqual, 7)
+			So(c.Config.MaxRunningPlugins, ShouldEqual, GetDefaultConfig().MaxRunningPlugins)
+		})
+
+		Convey("applying a plugin config invalid for a running task applies nothing", func() {
+			c.Config.subscriptions = rejectingSubscriptions{}
+			cfg := GetDefaultConfig()
+			cfg.Plugins.Collector.All.AddItem("user", ctypes.ConfigValueStr{Value: "jane"})
+			cfg.MaxPluginRestarts = 7
+			serrs := c.ApplyConfig(cfg)
+			So(serrs, ShouldHaveLength, 1)
+			So(serrs[0].Fields()["task-ids"], ShouldEqual, "1234")
+			So(plugins.getPluginConfigDataNode(core.CollectorPluginType, "test", 1).Table(), ShouldBeEmpty)
+			So(c.Config.MaxPluginRestarts, ShouldEqual, GetDefaultConfig().MaxPluginRestarts)
+		})
+	})
+}
+
+// rejectingSubscriptions refuses every plugin config as running task 1234
+// does not accept it
+type rejectingSubscriptions struct{}
+
+func (rejectingSubscriptions) validatePluginConfig(*pluginConfig) []serror.SnapError {
+	return []serror.SnapError{serror.New(ErrPluginConfigRejected, map[string]interface{}{"task-ids": "1234"})}
+}
+
+func (rejectingSubscriptions) Process() []serror.SnapError {
+	return nil
+}
+
+func TestPluginConfigUpdateProcess(t *testing.T) {
+	Convey("Given subscription groups whose processing is held", t, func() {
+		subs := &heldSubscriptions{processing: make(chan struct{}, 2), release: make(chan struct{})}
+		cfg := GetDefaultConfig()
+		cfg.subscriptions = subs
+
+		first := make(chan []serror.SnapError)
+		go func() {
+			cdn := cdata.NewNode()
+			cdn.AddItem("user", ctypes.ConfigValueStr{Value: "jane"})
+			_, serrs := cfg.MergePluginConfigDataNodeAll(cdn)
+			first <- serrs
+		}()
+		<-subs.processing
+
+		Convey("another change is applied while the groups are processed", func() {
+			cdn := cdata.NewNode()
+			cdn.AddItem("password", ctypes.ConfigValueStr{Value: "p@ss"})
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				cfg.MergePluginConfigDataNodeAll(cdn)
+			}()
+			<-subs.processing
+			So(cfg.Plugins.all().Table()["user"], ShouldResemble, ctypes.ConfigValueStr{Value: "jane"})
+			So(cfg.Plugins.all().Table()["password"], ShouldResemble, ctypes.ConfigValueStr{Value: "p@ss"})
+
+			close(subs.release)
+			<-done
+			So(<-first, ShouldBeNil)
+		})
+	})
+}
+
+// heldSubscriptions accepts every plugin config and blocks in Process until
+// release is closed
+type heldSubscriptions struct {
+	processing chan struct{}
+	release    chan struct{}
+}
+
+func (heldSubscriptions) validatePluginConfig(*pluginConfig) []serror.SnapError {
+	return nil
+}
+
+func (h *heldSubscriptions) Process() []serror.SnapError {
+	h.processing <- struct{}{}
+	<-h.release
+	return nil
+}
+
 func TestControlConfigJSON(t *testing.T) {
 	config := &mockConfig{
 		Control: GetDefaultConfig(),
@@ -315,5 +445,8 @@ func TestControlDefaultConfig(t *testing.T) {
 		Convey("max_plugin_restarts should be set to 3", func() {
 			So(cfg.MaxPluginRestarts, ShouldEqual, 3)
 		})
+		Convey("max_expanded_metrics should be disabled", func() {
+			So(cfg.MaxExpandedMetrics, ShouldEqual, 0)
+		})
 	})
 }
@@ -33,6 +33,7 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/vrischmann/jsonutil"
 	"google.golang.org/grpc"
 
 	"github.com/intelsdi-x/gomit"
@@ -92,6 +93,7 @@ type pluginControl struct {
 
 	subscriptionGroups ManagesSubscriptionGroups
 	grpcSecurity       client.GRPCSecurity
+	catalogRefresher   *catalogRefresher
 }
 
 type subscribedPlugin struct {
@@ -140,8 +142,10 @@ type catalogsMetrics interface {
 	Add(*metricType)
 	AddLoadedMetricType(*loadedPlugin, core.Metric) error
 	RmUnloadedPluginMetrics(lp *loadedPlugin)
+	Refresh(*loadedPlugin, []core.Metric) ([]*metricType, []*metricType, error)
 	GetVersions(core.Namespace) ([]*metricType, error)
 	Fetch(core.Namespace) ([]*metricType, error)
+	Search(core.MetricSearch) ([]*metricType, int, error)
 	Keys() []string
 	Subscribe([]string, int) error
 	Unsubscribe([]string, int) error
@@ -174,6 +178,7 @@ func CacheExpiration(t time.Duration) PluginControlOpt {
 func OptSetConfig(cfg *Config) PluginControlOpt {
 	return func(c *pluginControl) {
 		c.Config = cfg
+		c.Config.subscriptions = c.subscriptionGroups
 		c.pluginManager.SetPluginConfig(cfg.Plugins)
 		c.pluginManager.SetPluginLoadTimeout(c.Config.PluginLoadTimeout)
 		c.pluginRunner.SetPluginLoadTimeout(c.Config.PluginLoadTimeout)
@@ -194,6 +199,39 @@ func MaxPluginRestarts(cfg *Config) PluginControlOpt {
 	}
 }
 
+// Autoscale sets the policy of the plugin pool autoscaler
+func Autoscale(cfg *AutoscaleConfig) PluginControlOpt {
+	return func(*pluginControl) {
+		if cfg == nil {
+			return
+		}
+		strategy.Autoscaling = strategy.AutoscalePolicy{
+			Enabled:     cfg.Enabled,
+			MaxInFlight: cfg.MaxInFlight,
+			MaxLatency:  cfg.MaxLatency.Duration,
+			CoolDown:    cfg.CoolDown.Duration,
+		}
+	}
+}
+
+// CatalogRefresh sets the interval at which the metric types of collectors
+// are refreshed, by plugin name
+func CatalogRefresh(intervals map[string]jsonutil.Duration) PluginControlOpt {
+	return func(c *pluginControl) {
+		c.catalogRefresher.setIntervals(intervals)
+	}
+}
+
+// Secrets sets where the secret references of a config may resolve to
+func Secrets(cfg *SecretsConfig) PluginControlOpt {
+	return func(*pluginControl) {
+		if cfg == nil {
+			return
+		}
+		secrets = *cfg
+	}
+}
+
 // New returns a new pluginControl instance
 func New(cfg *Config) *pluginControl {
 	// construct a slice of options from the input configuration
@@ -203,6 +241,9 @@ func New(cfg *Config) *pluginControl {
 		OptSetConfig(cfg),
 		OptSetTags(cfg.Tags),
 		MaxPluginRestarts(cfg),
+		Autoscale(cfg.Autoscale),
+		CatalogRefresh(cfg.CatalogRefresh),
+		Secrets(cfg.Secrets),
 	}
 	c := &pluginControl{}
 	c.Config = cfg
@@ -265,6 +306,9 @@ func New(cfg *Config) *pluginControl {
 	// Create subscription group - used for managing a group of subscriptions
 	c.subscriptionGroups = newSubscriptionGroups(c)
 
+	// Catalog refresher - refreshes the metric types of collectors
+	c.catalogRefresher = newCatalogRefresher(c)
+
 	// Start stuff
 	err := c.pluginRunner.Start()
 	if err != nil {
@@ -282,6 +326,43 @@ func New(cfg *Config) *pluginControl {
 	return c
 }
 
+// ApplyConfig applies the settings of cfg that can change while control is
+// running: the plugin config, tags, metric cache expiration, the plugin
+// restart limit, the catalog refresh intervals and the limit of expanded
+// metrics.  The cache expiration only affects plugin pools started after it
+// is applied.  Nothing is applied when the plugin config is invalid for any of
+// the running tasks, the errors then name the impacted tasks as changes made
+// through the REST API do.
+func (p *pluginControl) ApplyConfig(cfg *Config) []serror.SnapError {
+	p.Config.Plugins.updates.Lock()
+	if serrs := p.Config.setPluginConfig(cfg.Plugins); serrs != nil {
+		p.Config.Plugins.updates.Unlock()
+		return serrs
+	}
+	for _, opt := range []PluginControlOpt{
+		CacheExpiration(cfg.CacheExpiration.Duration),
+		OptSetTags(cfg.Tags),
+		MaxPluginRestarts(cfg),
+		CatalogRefresh(cfg.CatalogRefresh),
+	} {
+		opt(p)
+	}
+	p.Config.CacheExpiration = cfg.CacheExpiration
+	p.Config.CatalogRefresh = cfg.CatalogRefresh
+	p.Config.Tags = cfg.Tags
+	p.Config.MaxPluginRestarts = cfg.MaxPluginRestarts
+	p.Config.MaxExpandedMetrics = cfg.MaxExpandedMetrics
+	p.Config.Plugins.updates.Unlock()
+	// as for changes made through the REST API, the subscription groups are
+	// processed outside the updates lock
+	for _, serr := range p.subscriptionGroups.Process() {
+		controlLogger.WithFields(log.Fields{
+			"_block": "apply-config",
+		}).Error(serr)
+	}
+	return nil
+}
+
 func (p *pluginControl) HandleGomitEvent(e gomit.Event) {
 	switch v := e.Body.(type) {
 	case *control_event.LoadPluginEvent:
@@ -455,6 +536,8 @@ func (p *pluginControl) Start() error {
 		}).Info("auto discover path is disabled")
 	}
 
+	p.catalogRefresher.Start()
+
 	lis, err := net.Listen("tcp", fmt.Sprintf("%v:%v", p.Config.ListenAddr, p.Config.ListenPort))
 	if err != nil {
 		controlLogger.WithField("error", err.Error()).Error("Failed to start control grpc listener")
@@ -495,6 +578,8 @@ func (p *pluginControl) Stop() {
 	p.grpcServer.Stop()
 	p.wg.Wait()
 
+	p.catalogRefresher.Stop()
+
 	// stop runner
 	err := p.pluginRunner.Stop()
 	if err != nil {
@@ -782,6 +867,9 @@ func (p *pluginControl) getMetricsAndCollectors(requested []core.RequestedMetric
 	newMetricsGroupedByPlugin := make(map[string]metricTypes)
 	newPlugins := []core.SubscribedPlugin{}
 	var serrs []serror.SnapError
+	// metrics matched by more than one requested namespace are collected once
+	expanded := map[string]bool{}
+	requested, exclusions := splitRequestedMetrics(requested)
 	for _, r := range requested {
 		// get all metric types available in metricCatalog which fulfill the requested namespace and version (if ver <=0 the latest version will be taken)
 		newMetrics, err := p.metricCatalog.GetMetrics(r.Namespace(), r.Version())
@@ -807,11 +895,22 @@ func (p *pluginControl) getMetricsAndCollectors(requested []core.RequestedMetric
 		}
 
 		for _, mt := range newMetrics {
+			if isExcluded(mt.Namespace(), exclusions) {
+				continue
+			}
+			if expanded[mt.Key()] {
+				continue
+			}
+			expanded[mt.Key()] = true
 			// in case config tree doesn't have any configuration for current namespace
 			// it's needed to initialize config, otherwise it will stay nil and panic later on
 			cfg := configTree.Get(mt.Namespace().Strings())
 			if cfg == nil {
 				cfg = cdata.NewNode()
+			} else {
+				// work on a copy so the defaults applied below don't end up
+				// in the config tree and shadow later global config changes
+				cfg = cfg.ReverseMerge(cdata.NewNode())
 			}
 			// set config to metric
 			mt.config = cfg
@@ -851,6 +950,9 @@ func (p *pluginControl) getMetricsAndCollectors(requested []core.RequestedMetric
 			}
 		}
 	}
+	if serr := p.checkExpandedMetrics(len(expanded)); serr != nil {
+		serrs = append(serrs, serr)
+	}
 	if controlLogger.Level >= log.DebugLevel {
 		for _, pmt := range newMetricsGroupedByPlugin {
 			for _, m := range pmt.Metrics() {
@@ -902,6 +1004,38 @@ func (p *pluginControl) AvailablePlugins() []core.AvailablePlugin {
 	return caps
 }
 
+// PluginLogs returns up to n of the most recent lines written by the running
+// instances of the given plugin, oldest first. A version lower than 1 selects
+// the latest running version.
+func (p *pluginControl) PluginLogs(pluginType core.PluginType, name string, ver int, n int) ([]core.PluginLogEntry, serror.SnapError) {
+	key := fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", pluginType.String(), name, ver)
+	return p.pluginRunner.AvailablePlugins().logs(key, n)
+}
+
+// WatchPluginLogs streams the lines written by the running instances of the
+// given plugin until the returned function is called.
+func (p *pluginControl) WatchPluginLogs(pluginType core.PluginType, name string, ver int) (<-chan core.PluginLogEntry, func(), serror.SnapError) {
+	key := fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", pluginType.String(), name, ver)
+	return p.pluginRunner.AvailablePlugins().watchLogs(key)
+}
+
+// PluginStats returns the statistics of the RPC calls made to the given plugin
+// since it was first started, along with those of its running instances.
+// A version lower than 1 selects the latest running version.
+func (p *pluginControl) PluginStats(pluginType core.PluginType, name string, ver int) (core.PluginPoolStats, serror.SnapError) {
+	key := fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", pluginType.String(), name, ver)
+	return p.pluginRunner.AvailablePlugins().poolStats(key)
+}
+
+// HealthCheck returns whether control is started along with the state of
+// the plugin pools
+func (p *pluginControl) HealthCheck() core.ControlHealth {
+	return core.ControlHealth{
+		Started: p.Started,
+		Pools:   p.pluginRunner.AvailablePlugins().health(),
+	}
+}
+
 // MetricCatalog returns the entire metric catalog
 // NOTE: The returned data from this function should be considered constant and read only
 func (p *pluginControl) MetricCatalog() ([]core.CatalogedMetric, error) {
@@ -828,6 +828,14 @@ func (m *mc) RmUnloadedPluginMetrics(lp *loadedPlugin) {
 
 }
 
+func (m *mc) Refresh(*loadedPlugin, []core.Metric) ([]*metricType, []*metricType, error) {
+	return nil, nil, nil
+}
+
+func (m *mc) Search(core.MetricSearch) ([]*metricType, int, error) {
+	return nil, 0, nil
+}
+
 type mockCDProc struct {
 }
 
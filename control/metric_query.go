@@ -0,0 +1,168 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/serror"
+)
+
+var (
+	// ErrTooManyExpandedMetrics - error message when the requested metrics of a task expand to more metrics than allowed
+	ErrTooManyExpandedMetrics = errors.New("Requested metrics expand to more metrics than allowed by max_expanded_metrics")
+)
+
+// isAlternation returns true when the namespace element is an alternation,
+// a string which starts with `core.AlternationPrefix` and ends with
+// `core.AlternationSuffix`, e.g. {psutil,procfs}
+func isAlternation(element string) bool {
+	return strings.HasPrefix(element, core.AlternationPrefix) && strings.HasSuffix(element, core.AlternationSuffix)
+}
+
+// alternatives returns the values matched by a requested namespace element,
+// which are the items of an alternation or of a tuple, or the element itself
+func alternatives(element string) []string {
+	var items []string
+	switch {
+	case isAlternation(element):
+		items = strings.Split(strings.TrimSuffix(strings.TrimPrefix(element, core.AlternationPrefix), core.AlternationSuffix), core.AlternationSeparator)
+	case isTuple(element):
+		items = strings.Split(strings.TrimSuffix(strings.TrimPrefix(element, core.TuplePrefix), core.TupleSuffix), core.TupleSeparator)
+	default:
+		return []string{element}
+	}
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
+
+// isExtendedQuery returns true when the requested namespace contains a
+// recursive wildcard or an alternation, which only MTTrie.Query resolves
+func isExtendedQuery(ns core.Namespace) bool {
+	for _, e := range ns {
+		if e.Value == core.RecursiveWildcard || isAlternation(e.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExclusion returns true when the requested namespace excludes metrics
+// instead of requesting them
+func isExclusion(ns core.Namespace) bool {
+	return len(ns) > 0 && ns[0].Value == core.ExclusionPrefix
+}
+
+// splitRequestedMetrics separates the requested metrics from the namespaces
+// whose matching metrics are excluded from them
+func splitRequestedMetrics(requested []core.RequestedMetric) ([]core.RequestedMetric, []core.Namespace) {
+	var included []core.RequestedMetric
+	var excluded []core.Namespace
+	for _, r := range requested {
+		if isExclusion(r.Namespace()) {
+			excluded = append(excluded, r.Namespace()[1:])
+			continue
+		}
+		included = append(included, r)
+	}
+	return included, excluded
+}
+
+// isExcluded returns true when the namespace matches one of the exclusions
+func isExcluded(ns core.Namespace, exclusions []core.Namespace) bool {
+	for _, ex := range exclusions {
+		if matchesQuery(ns.Strings(), ex.Strings()) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesQuery returns true when the namespace ns matches the requested
+// namespace query, following the rules of MTTrie.Query
+func matchesQuery(ns, query []string) bool {
+	if len(query) == 0 {
+		return len(ns) == 0
+	}
+	switch query[0] {
+	case core.RecursiveWildcard:
+		for i := 0; i <= len(ns); i++ {
+			if matchesQuery(ns[i:], query[1:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		if len(ns) == 0 {
+			return false
+		}
+		// a wildcard ending the query matches all descendants
+		return len(query) == 1 || matchesQuery(ns[1:], query[1:])
+	}
+	if len(ns) == 0 {
+		return false
+	}
+	for _, value := range alternatives(query[0]) {
+		if value == ns[0] && matchesQuery(ns[1:], query[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandedMetrics returns the metrics, sorted by namespace and version, which
+// the requested metrics of a running task currently expand to
+func (p *pluginControl) ExpandedMetrics(taskID string) ([]core.RequestedMetric, error) {
+	pmts, _, err := p.subscriptionGroups.Get(taskID)
+	if err != nil {
+		return nil, err
+	}
+	var mts []*metricType
+	for _, pmt := range pmts {
+		for _, m := range pmt.metricTypes {
+			if mt, ok := m.(*metricType); ok {
+				mts = append(mts, mt)
+			}
+		}
+	}
+	sort.Sort(metricTypesByNamespace(mts))
+	expanded := make([]core.RequestedMetric, len(mts))
+	for i, mt := range mts {
+		expanded[i] = mt
+	}
+	return expanded, nil
+}
+
+// checkExpandedMetrics returns an error when the number of expanded metrics
+// exceeds the limit set by max_expanded_metrics
+func (p *pluginControl) checkExpandedMetrics(count int) serror.SnapError {
+	if p.Config == nil || p.Config.MaxExpandedMetrics < 1 || count <= p.Config.MaxExpandedMetrics {
+		return nil
+	}
+	return serror.New(ErrTooManyExpandedMetrics, map[string]interface{}{
+		"expanded": count,
+		"max":      p.Config.MaxExpandedMetrics,
+	})
+}
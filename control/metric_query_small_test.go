@@ -0,0 +1,187 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/fixtures"
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func queryNamespace(ns string) core.Namespace {
+	return core.NewNamespace(strings.Split(strings.TrimPrefix(ns, "/"), "/")...)
+}
+
+func newQueryTestCatalog() *metricCatalog {
+	mc := newMetricCatalog()
+	psutil := newRefreshTestPlugin("psutil", 1, time.Now())
+	procfs := newRefreshTestPlugin("procfs", 1, time.Now())
+	for lp, nss := range map[*loadedPlugin][]core.Namespace{
+		psutil: {
+			queryNamespace("/intel/psutil/load/load1"),
+			queryNamespace("/intel/psutil/load/load5"),
+		},
+		procfs: {
+			queryNamespace("/intel/procfs/load/min1"),
+			queryNamespace("/intel/procfs/meminfo/free"),
+			core.NewNamespace("intel", "procfs", "iface").
+				AddDynamicElement("interface", "network interface").
+				AddStaticElement("bytes_recv"),
+		},
+	} {
+		for _, ns := range nss {
+			mc.Add(newLoadedMetricType(lp, plugin.MetricType{Namespace_: ns, Version_: 1}))
+		}
+	}
+	return mc
+}
+
+func metricNamespaces(mts []*metricType) []string {
+	nss := []string{}
+	for _, mt := range mts {
+		nss = append(nss, mt.Namespace().String())
+	}
+	return nss
+}
+
+func TestMatchesQuery(t *testing.T) {
+	Convey("Matching a namespace against a requested namespace", t, func() {
+		cases := []struct {
+			ns, query string
+			match     bool
+		}{
+			{"/intel/procfs/load/min1", "/intel/procfs/**", true},
+			{"/intel/procfs/load/min1", "/intel/**", true},
+			{"/intel/procfs/load/min1", "/intel/**/min1", true},
+			{"/intel/procfs/load/min1", "/intel/procfs/load/min1/**", true},
+			{"/intel/procfs/load/min1", "/intel/**/max1", false},
+			{"/intel/procfs/load/min1", "/intel/{psutil,procfs}/load/*", true},
+			{"/intel/procfs/load/min1", "/intel/{psutil,mock}/load/*", false},
+			{"/intel/procfs/load/min1", "/intel/(procfs;psutil)/load/min1", true},
+			{"/intel/procfs/load/min1", "/intel/*", true},
+			{"/intel/procfs/load/min1", "/intel/*/min1", false},
+			{"/intel/procfs/load/min1", "/intel/procfs/load", false},
+			{"/intel/procfs/iface/*/bytes_recv", "/intel/procfs/iface/eth0/**", false},
+		}
+		for _, c := range cases {
+			So(matchesQuery(queryNamespace(c.ns).Strings(), queryNamespace(c.query).Strings()), ShouldEqual, c.match)
+		}
+	})
+}
+
+func TestMetricCatalogGetMetricsQuery(t *testing.T) {
+	Convey("Given a metric catalog", t, func() {
+		mc := newQueryTestCatalog()
+
+		Convey("a recursive wildcard matches any depth", func() {
+			mts, err := mc.GetMetrics(queryNamespace("/intel/procfs/**"), 0)
+			So(err, ShouldBeNil)
+			So(metricNamespaces(mts), ShouldResemble, []string{
+				"/intel/procfs/iface/*/bytes_recv",
+				"/intel/procfs/load/min1",
+				"/intel/procfs/meminfo/free",
+			})
+			mts, err = mc.GetMetrics(queryNamespace("/intel/**/load/*"), 0)
+			So(err, ShouldBeNil)
+			So(mts, ShouldHaveLength, 3)
+		})
+		Convey("an alternation matches any of its alternatives", func() {
+			mts, err := mc.GetMetrics(queryNamespace("/intel/{psutil,procfs}/load/*"), 0)
+			So(err, ShouldBeNil)
+			So(metricNamespaces(mts), ShouldResemble, []string{
+				"/intel/procfs/load/min1",
+				"/intel/psutil/load/load1",
+				"/intel/psutil/load/load5",
+			})
+			mts, err = mc.GetMetrics(queryNamespace("/intel/{mock,procfs}/load/min1"), 0)
+			So(err, ShouldBeNil)
+			So(metricNamespaces(mts), ShouldResemble, []string{"/intel/procfs/load/min1"})
+		})
+		Convey("a requested instance of a dynamic metric is kept", func() {
+			mts, err := mc.GetMetrics(queryNamespace("/intel/**/iface/{eth0,eth1}/bytes_recv"), 0)
+			So(err, ShouldBeNil)
+			So(metricNamespaces(mts), ShouldResemble, []string{
+				"/intel/procfs/iface/eth0/bytes_recv",
+				"/intel/procfs/iface/eth1/bytes_recv",
+			})
+		})
+		Convey("metrics matched more than once are returned once", func() {
+			mts, err := mc.GetMetrics(queryNamespace("/intel/**/**/min1"), 0)
+			So(err, ShouldBeNil)
+			So(mts, ShouldHaveLength, 1)
+		})
+		Convey("a query matching no metric fails", func() {
+			_, err := mc.GetMetrics(queryNamespace("/intel/{mock,nope}/**"), 0)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestValidateMetricNamespaceQuery(t *testing.T) {
+	Convey("Plugins can't advertise namespaces with query elements", t, func() {
+		So(validateMetricNamespace(queryNamespace("/intel/mock/**/foo")), ShouldNotBeNil)
+		So(validateMetricNamespace(queryNamespace("/intel/{a,b}/foo")), ShouldNotBeNil)
+		So(validateMetricNamespace(queryNamespace("/intel/mock/foo")), ShouldBeNil)
+	})
+}
+
+func TestGetMetricsAndCollectorsExclusions(t *testing.T) {
+	Convey("Given requested metrics with exclusions", t, func() {
+		c := New(GetDefaultConfig())
+		c.metricCatalog = newQueryTestCatalog()
+		requested := []core.RequestedMetric{
+			fixtures.NewMockRequestedMetric(queryNamespace("/intel/{psutil,procfs}/**"), 0),
+			fixtures.NewMockRequestedMetric(queryNamespace("/intel/psutil/load/load1"), 0),
+			fixtures.NewMockRequestedMetric(queryNamespace("/!/intel/*/meminfo/**"), 0),
+			fixtures.NewMockRequestedMetric(queryNamespace("/!/intel/psutil/load/load5"), 0),
+		}
+
+		Convey("the excluded metrics are not collected and the others once", func() {
+			pmts, plugins, serrs := c.getMetricsAndCollectors(requested, cdata.NewTree())
+			So(serrs, ShouldBeEmpty)
+			So(plugins, ShouldHaveLength, 2)
+			nss := []string{}
+			for _, pmt := range pmts {
+				for _, mt := range pmt.Metrics() {
+					nss = append(nss, mt.Namespace().String())
+				}
+			}
+			So(nss, ShouldHaveLength, 3)
+			So(nss, ShouldContain, "/intel/psutil/load/load1")
+			So(nss, ShouldContain, "/intel/procfs/load/min1")
+			So(nss, ShouldContain, "/intel/procfs/iface/*/bytes_recv")
+		})
+		Convey("the expansion is capped by max_expanded_metrics", func() {
+			c.Config.MaxExpandedMetrics = 2
+			_, _, serrs := c.getMetricsAndCollectors(requested, cdata.NewTree())
+			So(serrs, ShouldHaveLength, 1)
+			So(serrs[0].Error(), ShouldEqual, ErrTooManyExpandedMetrics.Error())
+			So(serrs[0].Fields()["expanded"], ShouldEqual, 3)
+		})
+	})
+}
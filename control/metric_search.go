@@ -0,0 +1,172 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/intelsdi-x/snap/core"
+)
+
+// metricMatcher holds the compiled criteria of a metric catalog search
+type metricMatcher struct {
+	search core.MetricSearch
+	regex  *regexp.Regexp
+	terms  []string
+}
+
+func newMetricMatcher(search core.MetricSearch) (*metricMatcher, error) {
+	m := &metricMatcher{search: search}
+	for _, p := range search.Glob {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("Invalid namespace glob element `%s`: %v", p, err)
+		}
+	}
+	if search.Regex != "" {
+		re, err := regexp.Compile(search.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid namespace regular expression: %v", err)
+		}
+		m.regex = re
+	}
+	for _, term := range strings.Fields(search.Text) {
+		m.terms = append(m.terms, strings.ToLower(term))
+	}
+	if search.Offset < 0 || search.Limit < 0 {
+		return nil, fmt.Errorf("Offset and limit of a metric search can't be negative")
+	}
+	return m, nil
+}
+
+func (m *metricMatcher) match(mt *metricType) bool {
+	ns := mt.Namespace()
+	if len(m.search.Namespace) > len(ns) {
+		return false
+	}
+	for i, e := range m.search.Namespace {
+		if e.Value != ns[i].Value && ns[i].Value != "*" {
+			return false
+		}
+	}
+	if m.regex != nil && !m.regex.MatchString(ns.String()) {
+		return false
+	}
+	if m.search.Version > 0 && mt.Version() != m.search.Version {
+		return false
+	}
+	if m.search.PluginName != "" || m.search.PluginVersion > 0 {
+		if mt.Plugin == nil {
+			return false
+		}
+		if m.search.PluginName != "" && mt.Plugin.Name() != m.search.PluginName {
+			return false
+		}
+		if m.search.PluginVersion > 0 && mt.Plugin.Version() != m.search.PluginVersion {
+			return false
+		}
+	}
+	if m.search.Dynamic != nil {
+		if dyn, _ := ns.IsDynamic(); dyn != *m.search.Dynamic {
+			return false
+		}
+	}
+	text := strings.ToLower(mt.Description() + " " + mt.Unit())
+	for _, term := range m.terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+type metricTypesByNamespace []*metricType
+
+func (m metricTypesByNamespace) Len() int {
+	return len(m)
+}
+
+func (m metricTypesByNamespace) Less(i, j int) bool {
+	nsi, nsj := m[i].Namespace().String(), m[j].Namespace().String()
+	if nsi != nsj {
+		return nsi < nsj
+	}
+	return m[i].Version() < m[j].Version()
+}
+
+func (m metricTypesByNamespace) Swap(i, j int) {
+	m[i], m[j] = m[j], m[i]
+}
+
+// Search returns the page of the metric types matching the given search,
+// sorted by namespace and version, and the number of matching metric types.
+func (mc *metricCatalog) Search(search core.MetricSearch) ([]*metricType, int, error) {
+	matcher, err := newMetricMatcher(search)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mc.mutex.Lock()
+	var candidates []*metricType
+	if len(search.Glob) > 0 {
+		candidates = mc.tree.Glob(search.Glob)
+	} else {
+		// an unknown namespace does not match any metric
+		candidates, _ = mc.tree.Fetch(search.Namespace.Strings())
+	}
+	mts := []*metricType{}
+	for _, mt := range candidates {
+		if matcher.match(mt) {
+			mts = append(mts, mt)
+		}
+	}
+	mc.mutex.Unlock()
+
+	sort.Sort(metricTypesByNamespace(mts))
+	total := len(mts)
+	if search.Offset >= total {
+		return []*metricType{}, total, nil
+	}
+	mts = mts[search.Offset:]
+	if search.Limit > 0 && search.Limit < len(mts) {
+		mts = mts[:search.Limit]
+	}
+	return mts, total, nil
+}
+
+// SearchMetrics returns the page of the metric catalog matching the given
+// search and the number of matching metrics
+func (p *pluginControl) SearchMetrics(search core.MetricSearch) (core.MetricSearchResult, error) {
+	mts, total, err := p.metricCatalog.Search(search)
+	if err != nil {
+		return core.MetricSearchResult{}, err
+	}
+	res := core.MetricSearchResult{
+		Metrics: make([]core.CatalogedMetric, len(mts)),
+		Total:   total,
+	}
+	for i, mt := range mts {
+		res.Metrics[i] = mt
+	}
+	return res, nil
+}
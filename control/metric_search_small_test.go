@@ -0,0 +1,157 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func searchNamespaces(mts []*metricType) []string {
+	nss := []string{}
+	for _, mt := range mts {
+		nss = append(nss, mt.Key())
+	}
+	return nss
+}
+
+func TestMetricCatalogSearch(t *testing.T) {
+	Convey("Given a metric catalog", t, func() {
+		mc := newMetricCatalog()
+		psutil := newRefreshTestPlugin("psutil", 1, time.Now())
+		procfs := newRefreshTestPlugin("procfs", 2, time.Now())
+		add := func(lp *loadedPlugin, ver int, desc, unit string, ns core.Namespace) {
+			mc.Add(newLoadedMetricType(lp, plugin.MetricType{
+				Namespace_:   ns,
+				Version_:     ver,
+				Description_: desc,
+				Unit_:        unit,
+			}))
+		}
+		add(psutil, 1, "Load average over 1 minute", "", core.NewNamespace("intel", "psutil", "load", "load1"))
+		add(psutil, 1, "Load average over 5 minutes", "", core.NewNamespace("intel", "psutil", "load", "load5"))
+		add(psutil, 2, "Load average over 5 minutes", "", core.NewNamespace("intel", "psutil", "load", "load5"))
+		add(procfs, 2, "Load average over 1 minute", "", core.NewNamespace("intel", "procfs", "load", "min1"))
+		add(procfs, 2, "Bytes received by an interface", "B", core.NewNamespace("intel", "procfs", "iface").
+			AddDynamicElement("interface", "network interface").
+			AddStaticElement("bytes_recv"))
+
+		Convey("an empty search returns the whole catalog sorted", func() {
+			mts, total, err := mc.Search(core.MetricSearch{})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 5)
+			So(searchNamespaces(mts), ShouldResemble, []string{
+				"/intel/procfs/iface/*/bytes_recv/2",
+				"/intel/procfs/load/min1/2",
+				"/intel/psutil/load/load1/1",
+				"/intel/psutil/load/load5/1",
+				"/intel/psutil/load/load5/2",
+			})
+		})
+		Convey("a glob matches namespaces element by element", func() {
+			mts, _, err := mc.Search(core.MetricSearch{Glob: []string{"intel", "*", "load", "*1"}})
+			So(err, ShouldBeNil)
+			So(searchNamespaces(mts), ShouldResemble, []string{
+				"/intel/procfs/load/min1/2",
+				"/intel/psutil/load/load1/1",
+			})
+			mts, _, err = mc.Search(core.MetricSearch{Glob: []string{"intel", "procfs", "iface", "eth0", "bytes_*"}})
+			So(err, ShouldBeNil)
+			So(searchNamespaces(mts), ShouldResemble, []string{"/intel/procfs/iface/*/bytes_recv/2"})
+			mts, _, err = mc.Search(core.MetricSearch{Glob: []string{"intel", "*"}})
+			So(err, ShouldBeNil)
+			So(mts, ShouldBeEmpty)
+		})
+		Convey("a namespace restricts the search to the metrics below it", func() {
+			mts, total, err := mc.Search(core.MetricSearch{Namespace: core.NewNamespace("intel", "psutil")})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 3)
+			So(mts, ShouldHaveLength, 3)
+			mts, total, err = mc.Search(core.MetricSearch{Namespace: core.NewNamespace("intel", "nope")})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 0)
+			So(mts, ShouldBeEmpty)
+		})
+		Convey("a regex matches the namespace", func() {
+			mts, _, err := mc.Search(core.MetricSearch{Regex: "load[0-9]$"})
+			So(err, ShouldBeNil)
+			So(mts, ShouldHaveLength, 3)
+		})
+		Convey("the metric and plugin versions and plugin name filter metrics", func() {
+			_, total, err := mc.Search(core.MetricSearch{Version: 2})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 3)
+			_, total, err = mc.Search(core.MetricSearch{PluginName: "procfs"})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 2)
+			_, total, err = mc.Search(core.MetricSearch{PluginName: "psutil", PluginVersion: 2})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 0)
+		})
+		Convey("text terms are searched in the description and unit", func() {
+			mts, _, err := mc.Search(core.MetricSearch{Text: "LOAD 5"})
+			So(err, ShouldBeNil)
+			So(searchNamespaces(mts), ShouldResemble, []string{
+				"/intel/psutil/load/load5/1",
+				"/intel/psutil/load/load5/2",
+			})
+			_, total, err := mc.Search(core.MetricSearch{Text: "b"})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 1)
+		})
+		Convey("dynamic and static metrics can be selected", func() {
+			dyn := true
+			mts, _, err := mc.Search(core.MetricSearch{Dynamic: &dyn})
+			So(err, ShouldBeNil)
+			So(searchNamespaces(mts), ShouldResemble, []string{"/intel/procfs/iface/*/bytes_recv/2"})
+			dyn = false
+			_, total, err := mc.Search(core.MetricSearch{Dynamic: &dyn})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 4)
+		})
+		Convey("the results are paginated", func() {
+			mts, total, err := mc.Search(core.MetricSearch{Offset: 1, Limit: 2})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 5)
+			So(searchNamespaces(mts), ShouldResemble, []string{
+				"/intel/procfs/load/min1/2",
+				"/intel/psutil/load/load1/1",
+			})
+			mts, total, err = mc.Search(core.MetricSearch{Offset: 5})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 5)
+			So(mts, ShouldBeEmpty)
+		})
+		Convey("invalid criteria are refused", func() {
+			_, _, err := mc.Search(core.MetricSearch{Regex: "("})
+			So(err, ShouldNotBeNil)
+			_, _, err = mc.Search(core.MetricSearch{Glob: []string{"["}})
+			So(err, ShouldNotBeNil)
+			_, _, err = mc.Search(core.MetricSearch{Limit: -1})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
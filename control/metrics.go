@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -117,6 +118,10 @@ func errorMetricElementHasTuple(value, ns string) error {
 	return fmt.Errorf("A element %s should not define tuple for namespace %s.", value, ns)
 }
 
+func errorMetricElementHasQuery(value, ns string) error {
+	return fmt.Errorf("A element %s should not define a recursive wildcard or an alternation for namespace %s.", value, ns)
+}
+
 func errorEmptyNamespace() error {
 	return fmt.Errorf("Incorrect format of requested metric, empty list of namespace elements")
 }
@@ -147,6 +152,7 @@ type metricType struct {
 	timestamp          time.Time
 	description        string
 	unit               string
+	kind               core.MetricKind
 }
 
 type metric struct {
@@ -176,6 +182,9 @@ func (m *metric) Description() string {
 func (m *metric) Unit() string {
 	return ""
 }
+func (m *metric) Kind() core.MetricKind {
+	return core.MetricKindUnknown
+}
 func (m *metric) Tags() map[string]string {
 	return nil
 }
@@ -266,6 +275,10 @@ func (m *metricType) Unit() string {
 	return m.unit
 }
 
+func (m *metricType) Kind() core.MetricKind {
+	return m.kind
+}
+
 type catalogedPlugin struct {
 	name         string
 	version      int
@@ -381,7 +394,12 @@ func (mc *metricCatalog) AddLoadedMetricType(lp *loadedPlugin, mt core.Metric) e
 		return err
 	}
 
-	newMt := metricType{
+	mc.Add(newLoadedMetricType(lp, mt))
+	return nil
+}
+
+func newLoadedMetricType(lp *loadedPlugin, mt core.Metric) *metricType {
+	return &metricType{
 		Plugin:             newCatalogedPlugin(lp),
 		namespace:          mt.Namespace(),
 		version:            mt.Version(),
@@ -390,9 +408,57 @@ func (mc *metricCatalog) AddLoadedMetricType(lp *loadedPlugin, mt core.Metric) e
 		policy:             lp.ConfigPolicy.Get(mt.Namespace().Strings()),
 		description:        mt.Description(),
 		unit:               mt.Unit(),
+		kind:               core.KindOf(mt),
 	}
-	mc.Add(&newMt)
-	return nil
+}
+
+// Refresh replaces the metrics cataloged for a loaded plugin with mts
+// and returns the metrics which were added to and removed from the catalog
+func (mc *metricCatalog) Refresh(lp *loadedPlugin, mts []core.Metric) (added, removed []*metricType, err error) {
+	for _, mt := range mts {
+		if err := validateMetricNamespace(mt.Namespace()); err != nil {
+			return nil, nil, err
+		}
+	}
+	if lp.ConfigPolicy == nil {
+		return nil, nil, errors.New("Config policy is nil")
+	}
+
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	current := map[string]metricType{}
+	for _, mt := range mc.tree.gatherMetricTypes() {
+		if mt.Plugin.Key() == lp.Key() {
+			current[mt.Key()] = mt
+		}
+	}
+	advertised := map[string]bool{}
+	for _, mt := range mts {
+		key := fmt.Sprintf("%s/%d", mt.Namespace().String(), mt.Version())
+		advertised[key] = true
+		if _, ok := current[key]; ok {
+			continue
+		}
+		newMt := newLoadedMetricType(lp, mt)
+		mc.tree.Add(newMt)
+		added = append(added, newMt)
+	}
+	for key, mt := range current {
+		if advertised[key] {
+			continue
+		}
+		mc.tree.RemoveMetric(mt)
+		m := mt
+		removed = append(removed, &m)
+	}
+
+	// Update metric catalog keys
+	mc.keys = []string{}
+	for _, m := range mc.tree.gatherMetricTypes() {
+		mc.keys = appendIfMissing(mc.keys, m.Namespace().String())
+	}
+	return added, removed, nil
 }
 
 // RmUnloadedPluginMetrics removes plugin metrics which was unloaded,
@@ -461,6 +527,7 @@ func (mc *metricCatalog) GetMetric(requested core.Namespace, version int) (*metr
 		policy:             catalogedmt.Plugin.Policy().Get(catalogedmt.Namespace().Strings()),
 		config:             catalogedmt.Config(),
 		unit:               catalogedmt.Unit(),
+		kind:               catalogedmt.Kind(),
 		description:        catalogedmt.Description(),
 		subscriptions:      catalogedmt.SubscriptionCount(),
 	}
@@ -477,8 +544,15 @@ func (mc *metricCatalog) GetMetrics(requested core.Namespace, version int) ([]*m
 
 	// resolve queried tuples in metric namespace
 	requestedNss := findTuplesMatches(requested)
+	returned := map[string]bool{}
 	for _, rns := range requestedNss {
-		catalogedmts, err := mc.tree.GetMetrics(rns.Strings(), version)
+		var matches []metricMatch
+		var err error
+		if isExtendedQuery(rns) {
+			matches, err = mc.tree.Query(rns.Strings(), version)
+		} else {
+			matches, err = mc.getMetricMatches(rns, version)
+		}
 		if err != nil {
 			log.WithFields(log.Fields{
 				"_module": "control",
@@ -488,17 +562,14 @@ func (mc *metricCatalog) GetMetrics(requested core.Namespace, version int) ([]*m
 			}).Error("error getting metric")
 			return nil, err
 		}
-		for _, catalogedmt := range catalogedmts {
-			ns := catalogedmt.Namespace()
-
-			if isDynamic, _ := ns.IsDynamic(); isDynamic {
-				// when namespace is dynamic and the cataloged namespace (e.g. ns=/intel/mock/*/bar) is different than
-				// the requested (e.g. requested=/intel/mock/host0/bar), than specify an instance of dynamic element,
-				// so as a result the dynamic element will have set a value (e.g. ns[2].Value equals "host0")
-				if ns.String() != rns.String() {
-					ns = specifyInstanceOfDynamicMetric(ns, rns)
-				}
+		for _, match := range matches {
+			catalogedmt, ns := match.mt, match.ns
+			// overlapping queries may match the same metric more than once
+			key := fmt.Sprintf("%s/%d", ns.String(), catalogedmt.Version())
+			if returned[key] {
+				continue
 			}
+			returned[key] = true
 
 			returnedmt := &metricType{
 				Plugin:             catalogedmt.Plugin,
@@ -509,16 +580,43 @@ func (mc *metricCatalog) GetMetrics(requested core.Namespace, version int) ([]*m
 				policy:             catalogedmt.Plugin.Policy().Get(catalogedmt.Namespace().Strings()),
 				config:             catalogedmt.Config(),
 				unit:               catalogedmt.Unit(),
+				kind:               catalogedmt.Kind(),
 				description:        catalogedmt.Description(),
 				subscriptions:      catalogedmt.SubscriptionCount(),
 			}
 			returnedmts = append(returnedmts, returnedmt)
 		}
 	}
+	// return the metrics in the same order whatever the order of the trie
+	sort.Sort(metricTypesByNamespace(returnedmts))
 
 	return returnedmts, nil
 }
 
+// getMetricMatches retrieves the metrics which fulfill a requested namespace
+// without tuples, recursive wildcards or alternations
+func (mc *metricCatalog) getMetricMatches(rns core.Namespace, version int) ([]metricMatch, error) {
+	catalogedmts, err := mc.tree.GetMetrics(rns.Strings(), version)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]metricMatch, len(catalogedmts))
+	for i, catalogedmt := range catalogedmts {
+		ns := catalogedmt.Namespace()
+
+		if isDynamic, _ := ns.IsDynamic(); isDynamic {
+			// when namespace is dynamic and the cataloged namespace (e.g. ns=/intel/mock/*/bar) is different than
+			// the requested (e.g. requested=/intel/mock/host0/bar), than specify an instance of dynamic element,
+			// so as a result the dynamic element will have set a value (e.g. ns[2].Value equals "host0")
+			if ns.String() != rns.String() {
+				ns = specifyInstanceOfDynamicMetric(ns, rns)
+			}
+		}
+		matches[i] = metricMatch{mt: catalogedmt, ns: ns}
+	}
+	return matches, nil
+}
+
 // GetVersions retrieves all versions of a given metric namespace.
 func (mc *metricCatalog) GetVersions(ns core.Namespace) ([]*metricType, error) {
 	mc.mutex.Lock()
@@ -769,6 +867,9 @@ func validateMetricNamespace(ns core.Namespace) error {
 		if isTuple(i.Value) {
 			return errorMetricElementHasTuple(i.Value, ns.String())
 		}
+		if i.Value == core.RecursiveWildcard || isAlternation(i.Value) {
+			return errorMetricElementHasQuery(i.Value, ns.String())
+		}
 		value += i.Value
 	}
 	// plugin should NOT advertise metrics ending with a wildcard
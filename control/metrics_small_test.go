@@ -49,6 +49,36 @@ func TestAddTagsFromWorkflow(t *testing.T) {
 	})
 }
 
+func TestAdvertisedMetricType(t *testing.T) {
+	Convey("Checking the metric types advertised by a plugin", t, func() {
+		ns := core.NewNamespace("intel", "foo")
+		Convey("a metric without a kind keeps a free-form unit", func() {
+			mt, err := advertisedMetricType(2, plugin.MetricType{Namespace_: ns, Unit_: "mock unit"})
+			So(err, ShouldBeNil)
+			So(mt.Version(), ShouldEqual, 2)
+			So(core.KindOf(mt), ShouldEqual, core.MetricKindUnknown)
+			So(mt.Unit(), ShouldEqual, "mock unit")
+		})
+		Convey("the kind of a metric is normalized", func() {
+			mt, err := advertisedMetricType(2, plugin.MetricType{Namespace_: ns, Version_: 1, Kind_: "Histogram", Unit_: "ms"})
+			So(err, ShouldBeNil)
+			So(mt.Version(), ShouldEqual, 1)
+			So(core.KindOf(mt), ShouldEqual, core.MetricKindDistribution)
+			So(mt.Unit(), ShouldEqual, "ms")
+		})
+		Convey("a metric declaring a kind needs a UCUM unit", func() {
+			_, err := advertisedMetricType(2, plugin.MetricType{Namespace_: ns, Kind_: core.MetricKindCounter, Unit_: "bytes"})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, core.ErrInvalidUnit.Error())
+		})
+		Convey("an unknown kind is an error", func() {
+			_, err := advertisedMetricType(2, plugin.MetricType{Namespace_: ns, Kind_: "summary"})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, core.ErrUnknownMetricKind.Error())
+		})
+	})
+}
+
 func TestContainsTuplePositive(t *testing.T) {
 	Convey("when tuple contains two items", t, func() {
 		dut := "(host0;host1)"
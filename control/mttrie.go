@@ -21,6 +21,7 @@ package control
 
 import (
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 
@@ -159,6 +160,127 @@ func (mtt *mttNode) Fetch(ns []string) ([]*metricType, error) {
 	return mts, nil
 }
 
+// metricMatch is a metric type matching a requested namespace, along with
+// its namespace where dynamic elements are set to the requested values
+type metricMatch struct {
+	mt *metricType
+	ns core.Namespace
+}
+
+// Query returns the metric types in the queried version (or in the latest if ver < 1)
+// matching a requested namespace which may contain, besides the elements supported
+// by GetMetrics, the recursive wildcard "**" matching any number of elements and
+// alternations like {psutil,procfs} matching any of their alternatives.
+func (mtt *mttNode) Query(ns []string, ver int) ([]metricMatch, error) {
+	if len(ns) == 0 {
+		return nil, errorEmptyNamespace()
+	}
+	var matches []metricMatch
+	for _, qm := range mtt.query(nil, ns, nil, false) {
+		mt, err := getVersion(qm.node.mts, ver)
+		if err != nil {
+			continue
+		}
+		matches = append(matches, metricMatch{
+			mt: mt,
+			ns: specifyInstanceOfDynamicMetric(mt.Namespace(), core.NewNamespace(qm.values...)),
+		})
+	}
+	if len(matches) == 0 {
+		return nil, errorMetricNotFound("/"+strings.Join(ns, "/"), ver)
+	}
+	return matches, nil
+}
+
+// queryMatch is a node matching a requested namespace and the value requested
+// for each element of the path leading to it ("*" when any value matches)
+type queryMatch struct {
+	node   *mttNode
+	values []string
+}
+
+// query returns the nodes below mtt matching the requested namespace ns,
+// values holding the requested values of the path from the root to mtt.
+// The element following a recursive wildcard only matches static elements,
+// otherwise any value would match the dynamic elements at any depth.
+func (mtt *mttNode) query(matches []queryMatch, ns []string, values []string, staticOnly bool) []queryMatch {
+	if len(ns) == 0 {
+		if mtt.mts != nil {
+			matches = append(matches, queryMatch{node: mtt, values: values})
+		}
+		return matches
+	}
+	switch ns[0] {
+	case core.RecursiveWildcard:
+		// match no element, then one or more elements
+		matches = mtt.query(matches, ns[1:], values, true)
+		for _, child := range mtt.children {
+			matches = child.query(matches, ns, appendValue(values, "*"), false)
+		}
+	case "*":
+		rest := ns[1:]
+		if len(rest) == 0 {
+			// as in GetMetrics, a wildcard ending the namespace matches all descendants
+			rest = []string{core.RecursiveWildcard}
+		}
+		for _, child := range mtt.children {
+			matches = child.query(matches, rest, appendValue(values, "*"), false)
+		}
+	default:
+		for _, value := range alternatives(ns[0]) {
+			child := mtt.children[value]
+			if child == nil && !staticOnly {
+				// it might be a specific instance of a dynamic metric
+				child = mtt.children["*"]
+			}
+			if child != nil {
+				matches = child.query(matches, ns[1:], appendValue(values, value), false)
+			}
+		}
+	}
+	return matches
+}
+
+// appendValue returns a copy of values with value appended, so that the
+// branches of a query don't share their values
+func appendValue(values []string, value string) []string {
+	out := make([]string, len(values), len(values)+1)
+	copy(out, values)
+	return append(out, value)
+}
+
+// Glob returns the metric types whose namespace matches, element by element,
+// the given patterns (see path.Match). A dynamic element of a cataloged
+// namespace matches any pattern, as it stands for any value.
+func (mtt *mttNode) Glob(patterns []string) []*metricType {
+	var mts []*metricType
+	for _, node := range mtt.glob(nil, patterns) {
+		for _, mt := range node.mts {
+			mts = append(mts, mt)
+		}
+	}
+	return mts
+}
+
+// glob returns the nodes in the trie matching the given patterns
+func (mtt *mttNode) glob(nodes []*mttNode, patterns []string) []*mttNode {
+	if len(patterns) == 0 {
+		if mtt.mts != nil {
+			nodes = append(nodes, mtt)
+		}
+		return nodes
+	}
+	for name, child := range mtt.children {
+		if name != "*" {
+			if ok, _ := path.Match(patterns[0], name); !ok {
+				continue
+			}
+		}
+		nodes = child.glob(nodes, patterns[1:])
+	}
+	return nodes
+}
+
 // Remove removes all descendants nodes below a given namespace
 func (mtt *mttNode) Remove(ns []string) error {
 	_, err := mtt.find(ns)
@@ -623,6 +623,7 @@ type metric struct {
 	tags               map[string]string
 	description        string
 	unit               string
+	kind               core.MetricKind
 }
 
 func (m *metric) Namespace() core.Namespace     { return m.namespace }
@@ -634,6 +635,7 @@ func (m *metric) LastAdvertisedTime() time.Time { return m.lastAdvertisedTime }
 func (m *metric) Timestamp() time.Time          { return m.timeStamp }
 func (m *metric) Description() string           { return m.description }
 func (m *metric) Unit() string                  { return m.unit }
+func (m *metric) Kind() core.MetricKind         { return m.kind }
 
 func ToCoreMetrics(mts []*rpc.Metric) []core.Metric {
 	metrics := make([]core.Metric, len(mts))
@@ -661,6 +663,7 @@ func ToCoreMetric(mt *rpc.Metric) core.Metric {
 		config:             ConfigMapToConfig(mt.Config),
 		description:        mt.Description,
 		unit:               mt.Unit,
+		kind:               core.MetricKind(mt.Kind),
 	}
 
 	switch mt.Data.(type) {
@@ -682,6 +685,8 @@ func ToCoreMetric(mt *rpc.Metric) core.Metric {
 		ret.data = mt.GetUint32Data()
 	case *rpc.Metric_Uint64Data:
 		ret.data = mt.GetUint64Data()
+	case *rpc.Metric_DistributionData:
+		ret.data = ToCoreDistribution(mt.GetDistributionData())
 	}
 	return ret
 }
@@ -708,6 +713,7 @@ func ToMetric(co core.Metric) *rpc.Metric {
 			Nsec: int64(co.Timestamp().Nanosecond()),
 		},
 		Unit: co.Unit(),
+		Kind: string(core.KindOf(co)),
 	}
 	if co.Config() != nil {
 		cm.Config = ConfigToConfigMap(co.Config())
@@ -729,6 +735,10 @@ func ToMetric(co core.Metric) *rpc.Metric {
 		cm.Data = &rpc.Metric_Uint32Data{t}
 	case uint64:
 		cm.Data = &rpc.Metric_Uint64Data{t}
+	case core.Distribution:
+		cm.Data = &rpc.Metric_DistributionData{ToDistribution(t)}
+	case *core.Distribution:
+		cm.Data = &rpc.Metric_DistributionData{ToDistribution(*t)}
 	case []byte:
 		cm.Data = &rpc.Metric_BytesData{t}
 	case bool:
@@ -764,10 +774,12 @@ func ConfigMapToConfig(cfg *rpc.ConfigMap) *cdata.ConfigDataNode {
 
 func ToConfigMap(cv map[string]ctypes.ConfigValue) *rpc.ConfigMap {
 	newConfig := &rpc.ConfigMap{
-		IntMap:    make(map[string]int64),
-		FloatMap:  make(map[string]float64),
-		StringMap: make(map[string]string),
-		BoolMap:   make(map[string]bool),
+		IntMap:        make(map[string]int64),
+		FloatMap:      make(map[string]float64),
+		StringMap:     make(map[string]string),
+		BoolMap:       make(map[string]bool),
+		DurationMap:   make(map[string]int64),
+		StringListMap: make(map[string]*rpc.StringList),
 	}
 	for k, v := range cv {
 		switch v.Type() {
@@ -779,6 +791,10 @@ func ToConfigMap(cv map[string]ctypes.ConfigValue) *rpc.ConfigMap {
 			newConfig.StringMap[k] = v.(ctypes.ConfigValueStr).Value
 		case "bool":
 			newConfig.BoolMap[k] = v.(ctypes.ConfigValueBool).Value
+		case "duration":
+			newConfig.DurationMap[k] = int64(v.(ctypes.ConfigValueDuration).Value)
+		case "string_list":
+			newConfig.StringListMap[k] = &rpc.StringList{Value: v.(ctypes.ConfigValueStrList).Value}
 		}
 	}
 	return newConfig
@@ -822,9 +838,37 @@ func ParseConfig(config *rpc.ConfigMap) map[string]ctypes.ConfigValue {
 		bval := ctypes.ConfigValueBool{Value: v}
 		c[k] = bval
 	}
+	for k, v := range config.DurationMap {
+		dval := ctypes.ConfigValueDuration{Value: time.Duration(v)}
+		c[k] = dval
+	}
+	for k, v := range config.StringListMap {
+		lval := ctypes.ConfigValueStrList{Value: v.GetValue()}
+		c[k] = lval
+	}
 	return c
 }
 
+// Convert core.Distribution to rpc.Distribution protobuf message
+func ToDistribution(d core.Distribution) *rpc.Distribution {
+	return &rpc.Distribution{
+		Bounds: d.Bounds,
+		Counts: d.Counts,
+		Sum:    d.Sum,
+		Count:  d.Count,
+	}
+}
+
+// Convert rpc.Distribution protobuf message to core.Distribution
+func ToCoreDistribution(d *rpc.Distribution) core.Distribution {
+	return core.Distribution{
+		Bounds: d.GetBounds(),
+		Counts: d.GetCounts(),
+		Sum:    d.GetSum(),
+		Count:  d.GetCount(),
+	}
+}
+
 func ToTime(t time.Time) *rpc.Time {
 	return &rpc.Time{
 		Nsec: t.Unix(),
@@ -25,7 +25,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
+	"github.com/intelsdi-x/snap/control/plugin/rpc"
 	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/ctypes"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -47,6 +52,101 @@ func TestToCoreMetric(t *testing.T) {
 	})
 }
 
+func TestDistributionData(t *testing.T) {
+	Convey("Distributions survive the Metric message", t, func() {
+		d := core.Distribution{Bounds: []float64{0.1, 1}, Counts: []uint64{3, 0, 1}, Sum: 2.5, Count: 4}
+		m := &metric{
+			namespace:          core.NewNamespace("intel", "latency"),
+			timeStamp:          time.Now(),
+			lastAdvertisedTime: time.Now(),
+			data:               d,
+			kind:               core.MetricKindDistribution,
+			unit:               "s",
+		}
+		b, err := proto.Marshal(ToMetric(m))
+		So(err, ShouldBeNil)
+		mt := &rpc.Metric{}
+		So(proto.Unmarshal(b, mt), ShouldBeNil)
+		cmt := ToCoreMetric(mt)
+		So(cmt.Data(), ShouldResemble, d)
+		So(core.KindOf(cmt), ShouldEqual, core.MetricKindDistribution)
+	})
+}
+
+func TestConfigMap(t *testing.T) {
+	Convey("Durations and string lists survive the ConfigMap", t, func() {
+		cfg := map[string]ctypes.ConfigValue{
+			"user":    ctypes.ConfigValueStr{Value: "root"},
+			"timeout": ctypes.ConfigValueDuration{Value: 90 * time.Second},
+			"hosts":   ctypes.ConfigValueStrList{Value: []string{"a", "b"}},
+		}
+		b, err := proto.Marshal(ToConfigMap(cfg))
+		So(err, ShouldBeNil)
+		cm := &rpc.ConfigMap{}
+		So(proto.Unmarshal(b, cm), ShouldBeNil)
+		So(ParseConfig(cm), ShouldResemble, cfg)
+	})
+}
+
+func TestConfigPolicyReply(t *testing.T) {
+	Convey("Enum, regex, duration and list rules survive GetConfigPolicyReply", t, func() {
+		r1, _ := cpolicy.NewEnumRule("proto", true, []string{"tcp", "udp"}, "udp")
+		r2, _ := cpolicy.NewRegexRule("iface", false, "^eth[0-9]+$")
+		r3, _ := cpolicy.NewDurationRule("timeout", false, time.Second)
+		r3.SetMinimum(time.Millisecond)
+		r4, _ := cpolicy.NewListRule("hosts", true)
+		r4.SetMaximum(3)
+		r4.SetAllowed([]string{"a", "b"})
+		node := cpolicy.NewPolicyNode()
+		node.Add(r1, r2, r3, r4)
+		policy := cpolicy.New()
+		policy.Add([]string{"intel", "mock"}, node)
+
+		reply, err := rpc.NewGetConfigPolicyReply(policy)
+		So(err, ShouldBeNil)
+		b, err := proto.Marshal(reply)
+		So(err, ShouldBeNil)
+		decoded := &rpc.GetConfigPolicyReply{}
+		So(proto.Unmarshal(b, decoded), ShouldBeNil)
+
+		got := rpc.ToConfigPolicy(decoded).Get([]string{"intel", "mock"})
+		So(got, ShouldNotBeNil)
+		rules := map[string]cpolicy.RuleTable{}
+		for _, rt := range got.RulesAsTable() {
+			rules[rt.Name] = rt
+		}
+		So(rules, ShouldHaveLength, 4)
+		So(rules["proto"].Type, ShouldEqual, cpolicy.EnumType)
+		So(rules["proto"].Allowed, ShouldResemble, []string{"tcp", "udp"})
+		So(rules["proto"].Default, ShouldResemble, ctypes.ConfigValueStr{Value: "udp"})
+		So(rules["iface"].Pattern, ShouldEqual, "^eth[0-9]+$")
+		So(rules["timeout"].Default, ShouldResemble, ctypes.ConfigValueDuration{Value: time.Second})
+		So(rules["timeout"].Minimum, ShouldResemble, ctypes.ConfigValueDuration{Value: time.Millisecond})
+		So(rules["hosts"].Required, ShouldBeTrue)
+		So(rules["hosts"].Maximum, ShouldResemble, ctypes.ConfigValueInt{Value: 3})
+		So(rules["hosts"].Allowed, ShouldResemble, []string{"a", "b"})
+	})
+	Convey("Sensitive string rules survive GetConfigPolicyReply", t, func() {
+		r, _ := cpolicy.NewStringRule("password", true)
+		r.SetSensitive(true)
+		node := cpolicy.NewPolicyNode()
+		node.Add(r)
+		policy := cpolicy.New()
+		policy.Add([]string{"intel", "mock"}, node)
+
+		reply, err := rpc.NewGetConfigPolicyReply(policy)
+		So(err, ShouldBeNil)
+		b, err := proto.Marshal(reply)
+		So(err, ShouldBeNil)
+		decoded := &rpc.GetConfigPolicyReply{}
+		So(proto.Unmarshal(b, decoded), ShouldBeNil)
+
+		got := rpc.ToConfigPolicy(decoded).Get([]string{"intel", "mock"})
+		So(got, ShouldNotBeNil)
+		So(got.SensitiveKeys(), ShouldResemble, []string{"password"})
+	})
+}
+
 func testCases() []*metric {
 	now := time.Now()
 	tc := []*metric{
@@ -121,6 +121,7 @@ func encodeMetrics(metrics []core.Metric) []byte {
 			Config_:             m.Config(),
 			LastAdvertisedTime_: checkTime(m.LastAdvertisedTime()),
 			Unit_:               m.Unit(),
+			Kind_:               core.KindOf(m),
 			Description_:        m.Description(),
 			Data_:               m.Data(),
 		}
@@ -227,6 +228,7 @@ func (p *PluginNativeClient) CollectMetrics(mts []core.Metric) ([]core.Metric, e
 			Tags_:               mt.Tags(),
 			Config_:             mt.Config(),
 			Unit_:               mt.Unit(),
+			Kind_:               core.KindOf(mt),
 		}
 	}
 
@@ -349,6 +351,8 @@ func init() {
 	gob.RegisterName("conf_value_int", *(&ctypes.ConfigValueInt{}))
 	gob.RegisterName("conf_value_float", *(&ctypes.ConfigValueFloat{}))
 	gob.RegisterName("conf_value_bool", *(&ctypes.ConfigValueBool{}))
+	gob.RegisterName("conf_value_string_list", *(&ctypes.ConfigValueStrList{}))
+	gob.RegisterName("conf_value_duration", *(&ctypes.ConfigValueDuration{}))
 
 	gob.RegisterName("conf_policy_node", cpolicy.NewPolicyNode())
 	gob.RegisterName("conf_data_node", &cdata.ConfigDataNode{})
@@ -356,6 +360,12 @@ func init() {
 	gob.RegisterName("conf_policy_int", &cpolicy.IntRule{})
 	gob.RegisterName("conf_policy_float", &cpolicy.FloatRule{})
 	gob.RegisterName("conf_policy_bool", &cpolicy.BoolRule{})
+	gob.RegisterName("conf_policy_enum", &cpolicy.EnumRule{})
+	gob.RegisterName("conf_policy_regex", &cpolicy.RegexRule{})
+	gob.RegisterName("conf_policy_duration", &cpolicy.DurationRule{})
+	gob.RegisterName("conf_policy_list", &cpolicy.ListRule{})
+
+	gob.RegisterName("metric_distribution", core.Distribution{})
 }
 
 func upcaseInitial(str string) string {
@@ -0,0 +1,105 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigPolicyRuleDuration(t *testing.T) {
+	Convey("NewDurationRule", t, func() {
+
+		Convey("empty key", func() {
+			r, e := NewDurationRule("", true)
+			So(r, ShouldBeNil)
+			So(e, ShouldResemble, EmptyKeyError)
+		})
+
+		Convey("default is set", func() {
+			r, e := NewDurationRule("thekey", false, 30*time.Second)
+			So(e, ShouldBeNil)
+			So(r.Type(), ShouldEqual, DurationType)
+			So(r.Default(), ShouldResemble, ctypes.ConfigValueDuration{Value: 30 * time.Second})
+		})
+
+		Convey("processing", func() {
+			r, _ := NewDurationRule("thekey", true)
+			r.SetMinimum(time.Second)
+			r.SetMaximum(time.Minute)
+
+			Convey("passes with a duration value", func() {
+				So(r.Validate(ctypes.ConfigValueDuration{Value: 10 * time.Second}), ShouldBeNil)
+			})
+
+			Convey("passes with a string which parses as a duration", func() {
+				So(r.Validate(ctypes.ConfigValueStr{Value: "1m"}), ShouldBeNil)
+			})
+
+			Convey("errors with a string which does not parse", func() {
+				So(r.Validate(ctypes.ConfigValueStr{Value: "soon"}), ShouldNotBeNil)
+			})
+
+			Convey("errors under the minimum", func() {
+				e := r.Validate(ctypes.ConfigValueDuration{Value: time.Millisecond})
+				So(e, ShouldNotBeNil)
+				So(e.Error(), ShouldEqual, "value is under minimum (thekey value 1ms < 1s)")
+			})
+
+			Convey("errors over the maximum", func() {
+				e := r.Validate(ctypes.ConfigValueStr{Value: "2m"})
+				So(e, ShouldNotBeNil)
+				So(e.Error(), ShouldEqual, "value is over maximum (thekey value 2m0s > 1m0s)")
+			})
+
+			Convey("errors with a non-duration value", func() {
+				So(r.Validate(ctypes.ConfigValueInt{Value: 1}), ShouldNotBeNil)
+			})
+
+			Convey("strings are converted to durations by the policy node", func() {
+				n := NewPolicyNode()
+				n.Add(r)
+				m, errs := n.Process(map[string]ctypes.ConfigValue{"thekey": ctypes.ConfigValueStr{Value: "15s"}})
+				So(errs.HasErrors(), ShouldBeFalse)
+				So((*m)["thekey"], ShouldResemble, ctypes.ConfigValueDuration{Value: 15 * time.Second})
+			})
+		})
+
+		Convey("gob encoding", func() {
+			r, _ := NewDurationRule("thekey", true, time.Second)
+			r.SetMaximum(time.Hour)
+			buf := new(bytes.Buffer)
+			So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+			r2 := &DurationRule{}
+			So(gob.NewDecoder(buf).Decode(r2), ShouldBeNil)
+			So(r2.Key(), ShouldEqual, "thekey")
+			So(r2.Default(), ShouldResemble, ctypes.ConfigValueDuration{Value: time.Second})
+			So(r2.Minimum(), ShouldBeNil)
+			So(r2.Maximum(), ShouldResemble, ctypes.ConfigValueDuration{Value: time.Hour})
+		})
+	})
+}
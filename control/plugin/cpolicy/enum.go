@@ -0,0 +1,184 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+const (
+	EnumType = "enum"
+)
+
+var (
+	ErrEmptyEnum = errors.New("enum rule must allow at least one value")
+)
+
+// EnumRule A rule validating that a string config value is one of a fixed
+// set of allowed values
+type EnumRule struct {
+	rule
+
+	key      string
+	required bool
+	allowed  []string
+	default_ *string
+}
+
+// NewEnumRule returns a new enum rule. Arguments are key(string), required(bool),
+// allowed([]string), default(string).
+func NewEnumRule(key string, req bool, allowed []string, opts ...string) (*EnumRule, error) {
+	// Return error if key is empty
+	if key == "" {
+		return nil, EmptyKeyError
+	}
+	if len(allowed) == 0 {
+		return nil, ErrEmptyEnum
+	}
+
+	var def *string
+	if len(opts) > 0 {
+		if !contains(allowed, opts[0]) {
+			return nil, notAllowed(key, opts[0], allowed)
+		}
+		def = &opts[0]
+	}
+
+	return &EnumRule{
+		key:      key,
+		required: req,
+		allowed:  append([]string{}, allowed...),
+		default_: def,
+	}, nil
+}
+
+func (e *EnumRule) Type() string {
+	return EnumType
+}
+
+// MarshalJSON marshals a EnumRule into JSON
+func (e *EnumRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Key      string             `json:"key"`
+		Required bool               `json:"required"`
+		Default  ctypes.ConfigValue `json:"default,omitempty"`
+		Allowed  []string           `json:"allowed"`
+		Type     string             `json:"type"`
+	}{
+		Key:      e.key,
+		Required: e.required,
+		Default:  e.Default(),
+		Allowed:  e.allowed,
+		Type:     EnumType,
+	})
+}
+
+// GobEncode encodes a EnumRule into a GOB
+func (e *EnumRule) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(e.key); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(e.required); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(e.allowed); err != nil {
+		return nil, err
+	}
+	if e.default_ == nil {
+		encoder.Encode(false)
+	} else {
+		encoder.Encode(true)
+		if err := encoder.Encode(e.default_); err != nil {
+			return nil, err
+		}
+	}
+	return w.Bytes(), nil
+}
+
+// GobDecode decodes a GOB into a EnumRule
+func (e *EnumRule) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	if err := decoder.Decode(&e.key); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&e.required); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&e.allowed); err != nil {
+		return err
+	}
+	var is_default_set bool
+	decoder.Decode(&is_default_set)
+	if is_default_set {
+		return decoder.Decode(&e.default_)
+	}
+	return nil
+}
+
+// Key returns the key
+func (e *EnumRule) Key() string {
+	return e.key
+}
+
+// Validate validates a config value against this rule.
+func (e *EnumRule) Validate(cv ctypes.ConfigValue) error {
+	// Check that type is correct
+	if cv.Type() != StringType {
+		return wrongType(e.key, cv.Type(), StringType)
+	}
+	if v := cv.(ctypes.ConfigValueStr).Value; !contains(e.allowed, v) {
+		return notAllowed(e.key, v, e.allowed)
+	}
+	return nil
+}
+
+// Default returns a default value is it exists.
+func (e *EnumRule) Default() ctypes.ConfigValue {
+	if e.default_ != nil {
+		return ctypes.ConfigValueStr{Value: *e.default_}
+	}
+	return nil
+}
+
+// Required returns a boolean indicating if this rule is required
+func (e *EnumRule) Required() bool {
+	return e.required
+}
+
+// Allowed returns the values accepted by this rule
+func (e *EnumRule) Allowed() []string {
+	return append([]string{}, e.allowed...)
+}
+
+func (e *EnumRule) Minimum() ctypes.ConfigValue {
+	return nil
+}
+
+func (e *EnumRule) Maximum() ctypes.ConfigValue {
+	return nil
+}
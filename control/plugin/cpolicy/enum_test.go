@@ -0,0 +1,94 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigPolicyRuleEnum(t *testing.T) {
+	Convey("NewEnumRule", t, func() {
+
+		Convey("empty key", func() {
+			r, e := NewEnumRule("", true, []string{"a"})
+			So(r, ShouldBeNil)
+			So(e, ShouldResemble, EmptyKeyError)
+		})
+
+		Convey("no allowed values", func() {
+			r, e := NewEnumRule("thekey", true, nil)
+			So(r, ShouldBeNil)
+			So(e, ShouldEqual, ErrEmptyEnum)
+		})
+
+		Convey("default must be allowed", func() {
+			r, e := NewEnumRule("thekey", false, []string{"tcp", "udp"}, "icmp")
+			So(r, ShouldBeNil)
+			So(e, ShouldNotBeNil)
+		})
+
+		Convey("default is set", func() {
+			r, e := NewEnumRule("thekey", false, []string{"tcp", "udp"}, "udp")
+			So(e, ShouldBeNil)
+			So(r.Type(), ShouldEqual, EnumType)
+			So(r.Allowed(), ShouldResemble, []string{"tcp", "udp"})
+			So(r.Default(), ShouldResemble, ctypes.ConfigValueStr{Value: "udp"})
+		})
+
+		Convey("processing", func() {
+			r, _ := NewEnumRule("thekey", true, []string{"tcp", "udp"})
+
+			Convey("passes with an allowed value", func() {
+				So(r.Validate(ctypes.ConfigValueStr{Value: "tcp"}), ShouldBeNil)
+			})
+
+			Convey("errors with a value which is not allowed", func() {
+				e := r.Validate(ctypes.ConfigValueStr{Value: "icmp"})
+				So(e, ShouldNotBeNil)
+				So(e.Error(), ShouldEqual, "value is not allowed (thekey value 'icmp' not in [tcp, udp])")
+			})
+
+			Convey("errors with a non-string value", func() {
+				e := r.Validate(ctypes.ConfigValueInt{Value: 1})
+				So(e, ShouldNotBeNil)
+				So(e.Error(), ShouldEqual, "type mismatch (thekey wanted type 'string' but provided type 'integer')")
+			})
+		})
+
+		Convey("gob encoding", func() {
+			r, _ := NewEnumRule("thekey", true, []string{"tcp", "udp"}, "tcp")
+			buf := new(bytes.Buffer)
+			So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+			r2 := &EnumRule{}
+			So(gob.NewDecoder(buf).Decode(r2), ShouldBeNil)
+			So(r2.Key(), ShouldEqual, "thekey")
+			So(r2.Required(), ShouldBeTrue)
+			So(r2.Allowed(), ShouldResemble, []string{"tcp", "udp"})
+			So(r2.Default(), ShouldResemble, ctypes.ConfigValueStr{Value: "tcp"})
+		})
+	})
+}
@@ -0,0 +1,277 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+const (
+	ListType = "list"
+)
+
+// ListRule A rule validating against string list config.  The length of the
+// list can be bounded and each element can be restricted to a set of allowed
+// values and/or a regular expression.
+type ListRule struct {
+	rule
+
+	key       string
+	required  bool
+	default_  []string
+	minLength *int
+	maxLength *int
+	allowed   []string
+	pattern   *regexp.Regexp
+}
+
+// NewListRule returns a new string list rule. Arguments are key(string),
+// required(bool), default([]string).
+func NewListRule(key string, req bool, opts ...[]string) (*ListRule, error) {
+	// Return error if key is empty
+	if key == "" {
+		return nil, EmptyKeyError
+	}
+
+	var def []string
+	if len(opts) > 0 {
+		def = append([]string{}, opts[0]...)
+	}
+
+	return &ListRule{
+		key:      key,
+		required: req,
+		default_: def,
+	}, nil
+}
+
+func (l *ListRule) Type() string {
+	return ListType
+}
+
+// MarshalJSON marshals a ListRule into JSON
+func (l *ListRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Key      string             `json:"key"`
+		Required bool               `json:"required"`
+		Default  ctypes.ConfigValue `json:"default,omitempty"`
+		Minimum  ctypes.ConfigValue `json:"minimum,omitempty"`
+		Maximum  ctypes.ConfigValue `json:"maximum,omitempty"`
+		Allowed  []string           `json:"allowed,omitempty"`
+		Pattern  string             `json:"pattern,omitempty"`
+		Type     string             `json:"type"`
+	}{
+		Key:      l.key,
+		Required: l.required,
+		Default:  l.Default(),
+		Minimum:  l.Minimum(),
+		Maximum:  l.Maximum(),
+		Allowed:  l.allowed,
+		Pattern:  l.Pattern(),
+		Type:     ListType,
+	})
+}
+
+// GobEncode encodes a ListRule into a GOB
+func (l *ListRule) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(l.key); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(l.required); err != nil {
+		return nil, err
+	}
+	if l.default_ == nil {
+		encoder.Encode(false)
+	} else {
+		encoder.Encode(true)
+		if err := encoder.Encode(l.default_); err != nil {
+			return nil, err
+		}
+	}
+	for _, v := range []*int{l.minLength, l.maxLength} {
+		if v == nil {
+			encoder.Encode(false)
+			continue
+		}
+		encoder.Encode(true)
+		if err := encoder.Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	if err := encoder.Encode(append([]string{}, l.allowed...)); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(l.Pattern()); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// GobDecode decodes a GOB into a ListRule
+func (l *ListRule) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	if err := decoder.Decode(&l.key); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&l.required); err != nil {
+		return err
+	}
+	var is_default_set bool
+	decoder.Decode(&is_default_set)
+	if is_default_set {
+		if err := decoder.Decode(&l.default_); err != nil {
+			return err
+		}
+		// gob decodes an empty slice as nil
+		if l.default_ == nil {
+			l.default_ = []string{}
+		}
+	}
+	for _, v := range []**int{&l.minLength, &l.maxLength} {
+		var is_set bool
+		decoder.Decode(&is_set)
+		if is_set {
+			if err := decoder.Decode(v); err != nil {
+				return err
+			}
+		}
+	}
+	if err := decoder.Decode(&l.allowed); err != nil {
+		return err
+	}
+	var pattern string
+	if err := decoder.Decode(&pattern); err != nil {
+		return err
+	}
+	return l.SetPattern(pattern)
+}
+
+// Key returns the key
+func (l *ListRule) Key() string {
+	return l.key
+}
+
+// Validate validates a config value against this rule.
+func (l *ListRule) Validate(cv ctypes.ConfigValue) error {
+	// Check that type is correct
+	if cv.Type() != (ctypes.ConfigValueStrList{}).Type() {
+		return wrongType(l.key, cv.Type(), (ctypes.ConfigValueStrList{}).Type())
+	}
+	v := cv.(ctypes.ConfigValueStrList).Value
+	if l.minLength != nil && len(v) < *l.minLength {
+		return fmt.Errorf("list is too short (%s length %d < %d)", l.key, len(v), *l.minLength)
+	}
+	if l.maxLength != nil && len(v) > *l.maxLength {
+		return fmt.Errorf("list is too long (%s length %d > %d)", l.key, len(v), *l.maxLength)
+	}
+	for _, e := range v {
+		if len(l.allowed) > 0 && !contains(l.allowed, e) {
+			return notAllowed(l.key, e, l.allowed)
+		}
+		if l.pattern != nil && !l.pattern.MatchString(e) {
+			return notMatched(l.key, e, l.Pattern())
+		}
+	}
+	return nil
+}
+
+// Default returns a default value is it exists.
+func (l *ListRule) Default() ctypes.ConfigValue {
+	if l.default_ != nil {
+		return ctypes.ConfigValueStrList{Value: append([]string{}, l.default_...)}
+	}
+	return nil
+}
+
+// Required returns a boolean indicating if this rule is required
+func (l *ListRule) Required() bool {
+	return l.required
+}
+
+// SetMinimum sets the minimum allowed list length
+func (l *ListRule) SetMinimum(m int) {
+	l.minLength = &m
+}
+
+// SetMaximum sets the maximum allowed list length
+func (l *ListRule) SetMaximum(m int) {
+	l.maxLength = &m
+}
+
+// SetAllowed restricts each element of the list to one of allowed
+func (l *ListRule) SetAllowed(allowed []string) {
+	l.allowed = append([]string{}, allowed...)
+}
+
+// SetPattern requires each element of the list to match pattern.  An empty
+// pattern removes the restriction.
+func (l *ListRule) SetPattern(pattern string) error {
+	if pattern == "" {
+		l.pattern = nil
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	l.pattern = re
+	return nil
+}
+
+// Allowed returns the values elements are restricted to, if any
+func (l *ListRule) Allowed() []string {
+	if len(l.allowed) == 0 {
+		return nil
+	}
+	return append([]string{}, l.allowed...)
+}
+
+// Pattern returns the regular expression elements must match, if any
+func (l *ListRule) Pattern() string {
+	if l.pattern == nil {
+		return ""
+	}
+	return l.pattern.String()
+}
+
+// Minimum returns the minimum list length
+func (l *ListRule) Minimum() ctypes.ConfigValue {
+	if l.minLength != nil {
+		return ctypes.ConfigValueInt{Value: *l.minLength}
+	}
+	return nil
+}
+
+// Maximum returns the maximum list length
+func (l *ListRule) Maximum() ctypes.ConfigValue {
+	if l.maxLength != nil {
+		return ctypes.ConfigValueInt{Value: *l.maxLength}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigPolicyRuleList(t *testing.T) {
+	Convey("NewListRule", t, func() {
+
+		Convey("empty key", func() {
+			r, e := NewListRule("", true)
+			So(r, ShouldBeNil)
+			So(e, ShouldResemble, EmptyKeyError)
+		})
+
+		Convey("default is set", func() {
+			r, e := NewListRule("thekey", false, []string{"a", "b"})
+			So(e, ShouldBeNil)
+			So(r.Type(), ShouldEqual, ListType)
+			So(r.Default(), ShouldResemble, ctypes.ConfigValueStrList{Value: []string{"a", "b"}})
+		})
+
+		Convey("default is unset", func() {
+			r, _ := NewListRule("thekey", false)
+			So(r.Default(), ShouldBeNil)
+		})
+
+		Convey("invalid pattern", func() {
+			r, _ := NewListRule("thekey", false)
+			So(r.SetPattern("["), ShouldNotBeNil)
+		})
+
+		Convey("processing", func() {
+			r, _ := NewListRule("thekey", true)
+			r.SetMinimum(1)
+			r.SetMaximum(3)
+
+			Convey("passes within the length bounds", func() {
+				So(r.Validate(ctypes.ConfigValueStrList{Value: []string{"a", "b"}}), ShouldBeNil)
+			})
+
+			Convey("errors when too short", func() {
+				e := r.Validate(ctypes.ConfigValueStrList{Value: []string{}})
+				So(e, ShouldNotBeNil)
+				So(e.Error(), ShouldEqual, "list is too short (thekey length 0 < 1)")
+			})
+
+			Convey("errors when too long", func() {
+				e := r.Validate(ctypes.ConfigValueStrList{Value: []string{"a", "b", "c", "d"}})
+				So(e, ShouldNotBeNil)
+				So(e.Error(), ShouldEqual, "list is too long (thekey length 4 > 3)")
+			})
+
+			Convey("errors with an element which is not allowed", func() {
+				r.SetAllowed([]string{"a", "b"})
+				So(r.Validate(ctypes.ConfigValueStrList{Value: []string{"a", "c"}}), ShouldNotBeNil)
+			})
+
+			Convey("errors with an element which does not match", func() {
+				So(r.SetPattern("^[a-z]$"), ShouldBeNil)
+				So(r.Validate(ctypes.ConfigValueStrList{Value: []string{"a"}}), ShouldBeNil)
+				So(r.Validate(ctypes.ConfigValueStrList{Value: []string{"a", "B"}}), ShouldNotBeNil)
+			})
+
+			Convey("errors with a non-list value", func() {
+				e := r.Validate(ctypes.ConfigValueStr{Value: "a"})
+				So(e, ShouldNotBeNil)
+				So(e.Error(), ShouldEqual, "type mismatch (thekey wanted type 'string_list' but provided type 'string')")
+			})
+		})
+
+		Convey("gob encoding", func() {
+			r, _ := NewListRule("thekey", true, []string{"a"})
+			r.SetMaximum(2)
+			r.SetAllowed([]string{"a", "b"})
+			r.SetPattern("^[a-z]$")
+			buf := new(bytes.Buffer)
+			So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+			r2 := &ListRule{}
+			So(gob.NewDecoder(buf).Decode(r2), ShouldBeNil)
+			So(r2.Key(), ShouldEqual, "thekey")
+			So(r2.Default(), ShouldResemble, ctypes.ConfigValueStrList{Value: []string{"a"}})
+			So(r2.Minimum(), ShouldBeNil)
+			So(r2.Maximum(), ShouldResemble, ctypes.ConfigValueInt{Value: 2})
+			So(r2.Allowed(), ShouldResemble, []string{"a", "b"})
+			So(r2.Pattern(), ShouldEqual, "^[a-z]$")
+		})
+	})
+}
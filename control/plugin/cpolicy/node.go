@@ -26,6 +26,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/intelsdi-x/snap/core/ctypes"
 	"github.com/intelsdi-x/snap/pkg/ctree"
@@ -75,7 +76,7 @@ func (c *ConfigPolicyNode) CopyRules() ([]Rule, error) {
 	rules := []Rule{}
 	for _, rule := range c.rules {
 		var err error
-		switch rule.(type) {
+		switch r := rule.(type) {
 		case *BoolRule:
 			var newBoolRule *BoolRule
 			if rule.Default() != nil {
@@ -91,6 +92,9 @@ func (c *ConfigPolicyNode) CopyRules() ([]Rule, error) {
 			} else {
 				newStringRule, err = NewStringRule(rule.Key(), rule.Required())
 			}
+			if err == nil {
+				newStringRule.SetSensitive(r.Sensitive())
+			}
 			rules = append(rules, newStringRule)
 		case *FloatRule:
 			var newFloatRule *FloatRule
@@ -108,6 +112,56 @@ func (c *ConfigPolicyNode) CopyRules() ([]Rule, error) {
 				newIntRule, err = NewIntegerRule(rule.Key(), rule.Required())
 			}
 			rules = append(rules, newIntRule)
+		case *EnumRule:
+			var newEnumRule *EnumRule
+			if rule.Default() != nil {
+				newEnumRule, err = NewEnumRule(rule.Key(), rule.Required(), r.Allowed(), rule.Default().(ctypes.ConfigValueStr).Value)
+			} else {
+				newEnumRule, err = NewEnumRule(rule.Key(), rule.Required(), r.Allowed())
+			}
+			rules = append(rules, newEnumRule)
+		case *RegexRule:
+			var newRegexRule *RegexRule
+			if rule.Default() != nil {
+				newRegexRule, err = NewRegexRule(rule.Key(), rule.Required(), r.Pattern(), rule.Default().(ctypes.ConfigValueStr).Value)
+			} else {
+				newRegexRule, err = NewRegexRule(rule.Key(), rule.Required(), r.Pattern())
+			}
+			rules = append(rules, newRegexRule)
+		case *DurationRule:
+			var newDurationRule *DurationRule
+			if rule.Default() != nil {
+				newDurationRule, err = NewDurationRule(rule.Key(), rule.Required(), rule.Default().(ctypes.ConfigValueDuration).Value)
+			} else {
+				newDurationRule, err = NewDurationRule(rule.Key(), rule.Required())
+			}
+			if err == nil {
+				if r.minimum != nil {
+					newDurationRule.SetMinimum(*r.minimum)
+				}
+				if r.maximum != nil {
+					newDurationRule.SetMaximum(*r.maximum)
+				}
+			}
+			rules = append(rules, newDurationRule)
+		case *ListRule:
+			var newListRule *ListRule
+			if rule.Default() != nil {
+				newListRule, err = NewListRule(rule.Key(), rule.Required(), rule.Default().(ctypes.ConfigValueStrList).Value)
+			} else {
+				newListRule, err = NewListRule(rule.Key(), rule.Required())
+			}
+			if err == nil {
+				if r.minLength != nil {
+					newListRule.SetMinimum(*r.minLength)
+				}
+				if r.maxLength != nil {
+					newListRule.SetMaximum(*r.maxLength)
+				}
+				newListRule.SetAllowed(r.allowed)
+				newListRule.pattern = r.pattern
+			}
+			rules = append(rules, newListRule)
 		default:
 			return []Rule{}, errors.New(fmt.Sprint("Unknown rule type"))
 		}
@@ -170,12 +224,15 @@ func (p *ConfigPolicyNode) Add(rules ...Rule) {
 type RuleTableSlice []RuleTable
 
 type RuleTable struct {
-	Name     string      `json:"name"`
-	Type     string      `json:"type"`
-	Default  interface{} `json:"default,omitempty"`
-	Required bool        `json:"required"`
-	Minimum  interface{} `json:"minimum,omitempty"`
-	Maximum  interface{} `json:"maximum,omitempty"`
+	Name      string      `json:"name"`
+	Type      string      `json:"type"`
+	Default   interface{} `json:"default,omitempty"`
+	Required  bool        `json:"required"`
+	Minimum   interface{} `json:"minimum,omitempty"`
+	Maximum   interface{} `json:"maximum,omitempty"`
+	Allowed   []string    `json:"allowed,omitempty"`
+	Pattern   string      `json:"pattern,omitempty"`
+	Sensitive bool        `json:"sensitive,omitempty"`
 }
 
 func (p *ConfigPolicyNode) RulesAsTable() RuleTableSlice {
@@ -184,14 +241,39 @@ func (p *ConfigPolicyNode) RulesAsTable() RuleTableSlice {
 
 	rt := make([]RuleTable, 0, len(p.rules))
 	for _, r := range p.rules {
-		rt = append(rt, RuleTable{
+		t := RuleTable{
 			Name:     r.Key(),
 			Type:     r.Type(),
 			Default:  r.Default(),
 			Required: r.Required(),
 			Minimum:  r.Minimum(),
 			Maximum:  r.Maximum(),
-		})
+		}
+		switch r := r.(type) {
+		case *StringRule:
+			t.Sensitive = r.Sensitive()
+		case *EnumRule:
+			t.Allowed = r.Allowed()
+		case *RegexRule:
+			t.Pattern = r.Pattern()
+		case *ListRule:
+			t.Allowed = r.Allowed()
+			t.Pattern = r.Pattern()
+		}
+		rt = append(rt, t)
+	}
+	return rt
+}
+
+// Redacted returns a copy of the rule tables where the defaults of the
+// sensitive rules are replaced by ctypes.Redacted.
+func (r RuleTableSlice) Redacted() RuleTableSlice {
+	rt := make(RuleTableSlice, len(r))
+	for i, t := range r {
+		if t.Sensitive && t.Default != nil {
+			t.Default = ctypes.ConfigValueStr{Value: ctypes.Redacted}
+		}
+		rt[i] = t
 	}
 	return rt
 }
@@ -203,6 +285,19 @@ func (c *ConfigPolicyNode) HasRules() bool {
 	return false
 }
 
+// SensitiveKeys returns the keys of the rules which are marked sensitive.
+func (c *ConfigPolicyNode) SensitiveKeys() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	keys := []string{}
+	for key, rule := range c.rules {
+		if r, ok := rule.(*StringRule); ok && r.Sensitive() {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // Defaults returns a map[string]ctypes.ConfigValue for all of the rules that
 // have defaults.
 func (c *ConfigPolicyNode) Defaults() map[string]ctypes.ConfigValue {
@@ -228,6 +323,8 @@ func (c *ConfigPolicyNode) Process(m map[string]ctypes.ConfigValue) (*map[string
 			e := rule.Validate(cv)
 			if e != nil {
 				pErrors.AddError(e)
+			} else if c, ok := rule.(coercer); ok {
+				m[key] = c.coerce(cv)
 			}
 		} else {
 			// If it was required add error
@@ -335,6 +432,7 @@ func addRulesToConfigPolicyNode(rules map[string]interface{}, cpn *ConfigPolicyN
 						r.default_ = &def
 					}
 				}
+				r.sensitive, _ = rule["sensitive"].(bool)
 
 				cpn.Add(r)
 			case "bool":
@@ -360,6 +458,64 @@ func addRulesToConfigPolicyNode(rules map[string]interface{}, cpn *ConfigPolicyN
 					r.maximum = &max
 				}
 				cpn.Add(r)
+			case EnumType:
+				allowed := stringsFromJSON(rule["allowed"])
+				r, err := NewEnumRule(k, req, allowed)
+				if err != nil {
+					return err
+				}
+				if d, ok := rule["default"].(string); ok {
+					r.default_ = &d
+				}
+				cpn.Add(r)
+			case RegexType:
+				pattern, _ := rule["pattern"].(string)
+				r, err := NewRegexRule(k, req, pattern)
+				if err != nil {
+					return err
+				}
+				if d, ok := rule["default"].(string); ok {
+					r.default_ = &d
+				}
+				cpn.Add(r)
+			case DurationType:
+				r, _ := NewDurationRule(k, req)
+				// durations are marshalled in their string form
+				if d, ok := rule["default"].(string); ok {
+					if def, err := time.ParseDuration(d); err == nil {
+						r.default_ = &def
+					}
+				}
+				if m, ok := rule["minimum"].(string); ok {
+					if min, err := time.ParseDuration(m); err == nil {
+						r.minimum = &min
+					}
+				}
+				if m, ok := rule["maximum"].(string); ok {
+					if max, err := time.ParseDuration(m); err == nil {
+						r.maximum = &max
+					}
+				}
+				cpn.Add(r)
+			case ListType:
+				r, _ := NewListRule(k, req)
+				if d, ok := rule["default"]; ok {
+					r.default_ = stringsFromJSON(d)
+				}
+				if m, ok := rule["minimum"]; ok {
+					min_, _ := m.(float64)
+					r.SetMinimum(int(min_))
+				}
+				if m, ok := rule["maximum"]; ok {
+					max_, _ := m.(float64)
+					r.SetMaximum(int(max_))
+				}
+				r.SetAllowed(stringsFromJSON(rule["allowed"]))
+				pattern, _ := rule["pattern"].(string)
+				if err := r.SetPattern(pattern); err != nil {
+					return err
+				}
+				cpn.Add(r)
 			default:
 				return errors.New("unknown type")
 			}
@@ -367,3 +523,19 @@ func addRulesToConfigPolicyNode(rules map[string]interface{}, cpn *ConfigPolicyN
 	}
 	return nil
 }
+
+// stringsFromJSON converts a decoded JSON array into a []string, dropping
+// any elements which are not strings.
+func stringsFromJSON(i interface{}) []string {
+	a, ok := i.([]interface{})
+	if !ok {
+		return nil
+	}
+	s := make([]string, 0, len(a))
+	for _, e := range a {
+		if v, ok := e.(string); ok {
+			s = append(s, v)
+		}
+	}
+	return s
+}
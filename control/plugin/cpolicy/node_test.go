@@ -22,7 +22,9 @@ limitations under the License.
 package cpolicy
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/intelsdi-x/snap/core/ctypes"
 	. "github.com/smartystreets/goconvey/convey"
@@ -244,4 +246,47 @@ func TestConfigPolicyNode(t *testing.T) {
 		So(len(pe.Errors()), ShouldEqual, 1)
 	})
 
+	Convey("enum, regex, duration and list rules survive JSON encoding", t, func() {
+		n := NewPolicyNode()
+		r1, _ := NewEnumRule("proto", true, []string{"tcp", "udp"}, "tcp")
+		r2, _ := NewRegexRule("iface", false, "^eth[0-9]+$")
+		r3, _ := NewDurationRule("timeout", false, 5*time.Second)
+		r3.SetMaximum(time.Minute)
+		r4, _ := NewListRule("hosts", false, []string{"a"})
+		r4.SetMinimum(1)
+		r4.SetPattern("^[a-z]+$")
+		n.Add(r1, r2, r3, r4)
+
+		b, err := json.Marshal(n)
+		So(err, ShouldBeNil)
+		n2 := NewPolicyNode()
+		So(json.Unmarshal(b, n2), ShouldBeNil)
+		So(n2.RulesAsTable(), ShouldHaveLength, 4)
+
+		rules := map[string]Rule{}
+		copies, err := n2.CopyRules()
+		So(err, ShouldBeNil)
+		for _, r := range copies {
+			rules[r.Key()] = r
+		}
+		So(rules["proto"].(*EnumRule).Allowed(), ShouldResemble, []string{"tcp", "udp"})
+		So(rules["proto"].Default(), ShouldResemble, ctypes.ConfigValueStr{Value: "tcp"})
+		So(rules["iface"].(*RegexRule).Pattern(), ShouldEqual, "^eth[0-9]+$")
+		So(rules["timeout"].Default(), ShouldResemble, ctypes.ConfigValueDuration{Value: 5 * time.Second})
+		So(rules["timeout"].Maximum(), ShouldResemble, ctypes.ConfigValueDuration{Value: time.Minute})
+		So(rules["hosts"].Default(), ShouldResemble, ctypes.ConfigValueStrList{Value: []string{"a"}})
+		So(rules["hosts"].Minimum(), ShouldResemble, ctypes.ConfigValueInt{Value: 1})
+		So(rules["hosts"].(*ListRule).Pattern(), ShouldEqual, "^[a-z]+$")
+
+		for _, rt := range n2.RulesAsTable() {
+			switch rt.Name {
+			case "proto":
+				So(rt.Type, ShouldEqual, EnumType)
+				So(rt.Allowed, ShouldResemble, []string{"tcp", "udp"})
+			case "iface":
+				So(rt.Pattern, ShouldEqual, "^eth[0-9]+$")
+			}
+		}
+	})
+
 }
@@ -0,0 +1,191 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"regexp"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+const (
+	RegexType = "regex"
+)
+
+// RegexRule A rule validating that a string config value matches a regular
+// expression
+type RegexRule struct {
+	rule
+
+	key      string
+	required bool
+	pattern  *regexp.Regexp
+	default_ *string
+}
+
+// NewRegexRule returns a new regex rule. Arguments are key(string), required(bool),
+// pattern(string), default(string).  An error is returned if the pattern
+// does not compile.
+func NewRegexRule(key string, req bool, pattern string, opts ...string) (*RegexRule, error) {
+	// Return error if key is empty
+	if key == "" {
+		return nil, EmptyKeyError
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var def *string
+	if len(opts) > 0 {
+		if !re.MatchString(opts[0]) {
+			return nil, notMatched(key, opts[0], pattern)
+		}
+		def = &opts[0]
+	}
+
+	return &RegexRule{
+		key:      key,
+		required: req,
+		pattern:  re,
+		default_: def,
+	}, nil
+}
+
+func (x *RegexRule) Type() string {
+	return RegexType
+}
+
+// MarshalJSON marshals a RegexRule into JSON
+func (x *RegexRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Key      string             `json:"key"`
+		Required bool               `json:"required"`
+		Default  ctypes.ConfigValue `json:"default,omitempty"`
+		Pattern  string             `json:"pattern"`
+		Type     string             `json:"type"`
+	}{
+		Key:      x.key,
+		Required: x.required,
+		Default:  x.Default(),
+		Pattern:  x.Pattern(),
+		Type:     RegexType,
+	})
+}
+
+// GobEncode encodes a RegexRule into a GOB
+func (x *RegexRule) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(x.key); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(x.required); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(x.Pattern()); err != nil {
+		return nil, err
+	}
+	if x.default_ == nil {
+		encoder.Encode(false)
+	} else {
+		encoder.Encode(true)
+		if err := encoder.Encode(x.default_); err != nil {
+			return nil, err
+		}
+	}
+	return w.Bytes(), nil
+}
+
+// GobDecode decodes a GOB into a RegexRule
+func (x *RegexRule) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	if err := decoder.Decode(&x.key); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&x.required); err != nil {
+		return err
+	}
+	var pattern string
+	if err := decoder.Decode(&pattern); err != nil {
+		return err
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	x.pattern = re
+	var is_default_set bool
+	decoder.Decode(&is_default_set)
+	if is_default_set {
+		return decoder.Decode(&x.default_)
+	}
+	return nil
+}
+
+// Key returns the key
+func (x *RegexRule) Key() string {
+	return x.key
+}
+
+// Validate validates a config value against this rule.
+func (x *RegexRule) Validate(cv ctypes.ConfigValue) error {
+	// Check that type is correct
+	if cv.Type() != StringType {
+		return wrongType(x.key, cv.Type(), StringType)
+	}
+	if v := cv.(ctypes.ConfigValueStr).Value; !x.pattern.MatchString(v) {
+		return notMatched(x.key, v, x.Pattern())
+	}
+	return nil
+}
+
+// Default returns a default value is it exists.
+func (x *RegexRule) Default() ctypes.ConfigValue {
+	if x.default_ != nil {
+		return ctypes.ConfigValueStr{Value: *x.default_}
+	}
+	return nil
+}
+
+// Required returns a boolean indicating if this rule is required
+func (x *RegexRule) Required() bool {
+	return x.required
+}
+
+// Pattern returns the regular expression values are matched against
+func (x *RegexRule) Pattern() string {
+	if x.pattern == nil {
+		return ""
+	}
+	return x.pattern.String()
+}
+
+func (x *RegexRule) Minimum() ctypes.ConfigValue {
+	return nil
+}
+
+func (x *RegexRule) Maximum() ctypes.ConfigValue {
+	return nil
+}
@@ -0,0 +1,92 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigPolicyRuleRegex(t *testing.T) {
+	Convey("NewRegexRule", t, func() {
+
+		Convey("empty key", func() {
+			r, e := NewRegexRule("", true, ".*")
+			So(r, ShouldBeNil)
+			So(e, ShouldResemble, EmptyKeyError)
+		})
+
+		Convey("invalid pattern", func() {
+			r, e := NewRegexRule("thekey", true, "(")
+			So(r, ShouldBeNil)
+			So(e, ShouldNotBeNil)
+		})
+
+		Convey("default must match", func() {
+			r, e := NewRegexRule("thekey", false, "^eth[0-9]+$", "lo")
+			So(r, ShouldBeNil)
+			So(e, ShouldNotBeNil)
+		})
+
+		Convey("default is set", func() {
+			r, e := NewRegexRule("thekey", false, "^eth[0-9]+$", "eth0")
+			So(e, ShouldBeNil)
+			So(r.Type(), ShouldEqual, RegexType)
+			So(r.Pattern(), ShouldEqual, "^eth[0-9]+$")
+			So(r.Default(), ShouldResemble, ctypes.ConfigValueStr{Value: "eth0"})
+		})
+
+		Convey("processing", func() {
+			r, _ := NewRegexRule("thekey", true, "^eth[0-9]+$")
+
+			Convey("passes with a matching value", func() {
+				So(r.Validate(ctypes.ConfigValueStr{Value: "eth1"}), ShouldBeNil)
+			})
+
+			Convey("errors with a value which does not match", func() {
+				e := r.Validate(ctypes.ConfigValueStr{Value: "wlan0"})
+				So(e, ShouldNotBeNil)
+				So(e.Error(), ShouldEqual, "value does not match pattern (thekey value 'wlan0' !~ /^eth[0-9]+$/)")
+			})
+
+			Convey("errors with a non-string value", func() {
+				So(r.Validate(ctypes.ConfigValueBool{Value: true}), ShouldNotBeNil)
+			})
+		})
+
+		Convey("gob encoding", func() {
+			r, _ := NewRegexRule("thekey", false, "^eth[0-9]+$")
+			buf := new(bytes.Buffer)
+			So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+			r2 := &RegexRule{}
+			So(gob.NewDecoder(buf).Decode(r2), ShouldBeNil)
+			So(r2.Key(), ShouldEqual, "thekey")
+			So(r2.Pattern(), ShouldEqual, "^eth[0-9]+$")
+			So(r2.Default(), ShouldBeNil)
+			So(r2.Validate(ctypes.ConfigValueStr{Value: "eth2"}), ShouldBeNil)
+		})
+	})
+}
@@ -24,6 +24,7 @@ package cpolicy
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/intelsdi-x/snap/core/ctypes"
 )
@@ -50,3 +51,28 @@ type rule struct {
 func wrongType(key, inType, reqType string) error {
 	return errors.New(fmt.Sprintf("type mismatch (%s wanted type '%s' but provided type '%s')", key, reqType, inType))
 }
+
+// coercer is implemented by rules which accept a config value in a looser
+// form than the one they hand to plugins (e.g. a duration written as a
+// string in a task manifest).  Once a value validates it is replaced with
+// the result of coerce.
+type coercer interface {
+	coerce(ctypes.ConfigValue) ctypes.ConfigValue
+}
+
+func notAllowed(key, value string, allowed []string) error {
+	return fmt.Errorf("value is not allowed (%s value '%s' not in [%s])", key, value, strings.Join(allowed, ", "))
+}
+
+func notMatched(key, value, pattern string) error {
+	return fmt.Errorf("value does not match pattern (%s value '%s' !~ /%s/)", key, value, pattern)
+}
+
+func contains(allowed []string, v string) bool {
+	for _, a := range allowed {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
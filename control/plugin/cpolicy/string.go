@@ -35,9 +35,10 @@ const (
 type StringRule struct {
 	rule
 
-	key      string
-	required bool
-	default_ *string
+	key       string
+	required  bool
+	default_  *string
+	sensitive bool
 }
 
 // Returns a new string-typed rule. Arguments are key(string), required(bool), default(string).
@@ -66,15 +67,17 @@ func (s *StringRule) Type() string {
 // MarshalJSON marshals a StringRule into JSON
 func (s *StringRule) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Key      string             `json:"key"`
-		Required bool               `json:"required"`
-		Default  ctypes.ConfigValue `json:"default"`
-		Type     string             `json:"type"`
+		Key       string             `json:"key"`
+		Required  bool               `json:"required"`
+		Default   ctypes.ConfigValue `json:"default"`
+		Sensitive bool               `json:"sensitive,omitempty"`
+		Type      string             `json:"type"`
 	}{
-		Key:      s.key,
-		Required: s.required,
-		Default:  s.Default(),
-		Type:     StringType,
+		Key:       s.key,
+		Required:  s.required,
+		Default:   s.Default(),
+		Sensitive: s.sensitive,
+		Type:      StringType,
 	})
 }
 
@@ -96,6 +99,9 @@ func (s *StringRule) GobEncode() ([]byte, error) {
 			return nil, err
 		}
 	}
+	if err := encoder.Encode(s.sensitive); err != nil {
+		return nil, err
+	}
 	return w.Bytes(), nil
 }
 
@@ -112,8 +118,12 @@ func (s *StringRule) GobDecode(buf []byte) error {
 	var is_default_set bool
 	decoder.Decode(&is_default_set)
 	if is_default_set {
-		return decoder.Decode(&s.default_)
+		if err := decoder.Decode(&s.default_); err != nil {
+			return err
+		}
 	}
+	// rules encoded before sensitivity was added end here
+	decoder.Decode(&s.sensitive)
 	return nil
 }
 
@@ -124,8 +134,9 @@ func (s *StringRule) Key() string {
 
 // Validates a config value against this rule.
 func (s *StringRule) Validate(cv ctypes.ConfigValue) error {
-	// Check that type is correct
-	if cv.Type() != StringType {
+	// Check that type is correct.  Secret references are resolved into
+	// strings before they reach the plugin.
+	if cv.Type() != StringType && cv.Type() != (ctypes.ConfigValueSecret{}).Type() {
 		return wrongType(s.key, cv.Type(), StringType)
 	}
 	return nil
@@ -151,3 +162,14 @@ func (s *StringRule) Minimum() ctypes.ConfigValue {
 func (s *StringRule) Maximum() ctypes.ConfigValue {
 	return nil
 }
+
+// SetSensitive marks the value of this rule's key as sensitive (e.g. a
+// password) so that it is redacted wherever config is shown.
+func (s *StringRule) SetSensitive(sensitive bool) {
+	s.sensitive = sensitive
+}
+
+// Sensitive returns true if the value of this rule's key must be redacted
+func (s *StringRule) Sensitive() bool {
+	return s.sensitive
+}
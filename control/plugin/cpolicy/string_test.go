@@ -29,6 +29,53 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+func TestConfigPolicyRuleStringSensitive(t *testing.T) {
+	Convey("Sensitive string rules", t, func() {
+		r, e := NewStringRule("password", true, "changeme")
+		So(e, ShouldBeNil)
+		So(r.Sensitive(), ShouldBeFalse)
+		r.SetSensitive(true)
+		So(r.Sensitive(), ShouldBeTrue)
+
+		Convey("survive a gob round trip", func() {
+			buf, err := r.GobEncode()
+			So(err, ShouldBeNil)
+			r2 := &StringRule{}
+			So(r2.GobDecode(buf), ShouldBeNil)
+			So(r2.Sensitive(), ShouldBeTrue)
+			So(r2.Default(), ShouldResemble, ctypes.ConfigValueStr{Value: "changeme"})
+		})
+
+		Convey("survive a JSON round trip", func() {
+			node := NewPolicyNode()
+			node.Add(r)
+			buf, err := node.MarshalJSON()
+			So(err, ShouldBeNil)
+			node2 := NewPolicyNode()
+			So(node2.UnmarshalJSON(buf), ShouldBeNil)
+			So(node2.SensitiveKeys(), ShouldResemble, []string{"password"})
+			So(node2.RulesAsTable()[0].Sensitive, ShouldBeTrue)
+		})
+
+		Convey("have their default redacted from the rule tables", func() {
+			node := NewPolicyNode()
+			node.Add(r)
+			r2, _ := NewStringRule("user", false, "admin")
+			node.Add(r2)
+			for _, rt := range node.RulesAsTable().Redacted() {
+				if rt.Name == "password" {
+					So(rt.Default, ShouldResemble, ctypes.ConfigValueStr{Value: ctypes.Redacted})
+				} else {
+					So(rt.Default, ShouldResemble, ctypes.ConfigValueStr{Value: "admin"})
+				}
+			}
+			for _, rt := range node.RulesAsTable() {
+				So(rt.Default, ShouldNotResemble, ctypes.ConfigValueStr{Value: ctypes.Redacted})
+			}
+		})
+	})
+}
+
 func TestConfigPolicyRuleString(t *testing.T) {
 	Convey("NewStringRule", t, func() {
 
@@ -90,6 +137,12 @@ func TestConfigPolicyRuleString(t *testing.T) {
 				So(e, ShouldResemble, errors.New("type mismatch (thekey wanted type 'string' but provided type 'integer')"))
 			})
 
+			Convey("passes with a secret reference", func() {
+				r, e := NewStringRule("thekey", true)
+				So(e, ShouldBeNil)
+				So(r.Validate(ctypes.ConfigValueSecret{Ref: "env:THEKEY"}), ShouldBeNil)
+			})
+
 		})
 
 	})
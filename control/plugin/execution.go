@@ -39,6 +39,7 @@ type ExecutablePlugin struct {
 	cmd    command
 	stdout io.Reader
 	stderr io.Reader
+	logs   *LogBuffer
 }
 
 // An interface for the interactions ExecutablePlugin has with an exec.Cmd
@@ -98,6 +99,7 @@ func NewExecutablePlugin(a Arg, commands ...string) (*ExecutablePlugin, error) {
 		cmd:    &commandWrapper{cmd},
 		stdout: stdout,
 		stderr: stderr,
+		logs:   NewLogBuffer(LogBufferSize),
 	}, nil
 }
 
@@ -131,6 +133,7 @@ func (e *ExecutablePlugin) Run(timeout time.Duration) (Response, error) {
 					respReceived = true
 					close(doneChan)
 				} else {
+					e.logs.Add("stdout", stdOutScanner.Text())
 					execLogger.WithFields(log.Fields{
 						"plugin": e.name,
 						"io":     "stdout",
@@ -197,11 +200,17 @@ func (e *ExecutablePlugin) Kill() error {
 	return e.cmd.Kill()
 }
 
+// Logs returns the buffer holding the most recent output of the plugin.
+func (e *ExecutablePlugin) Logs() *LogBuffer {
+	return e.logs
+}
+
 func (e *ExecutablePlugin) captureStderr() {
 	stdErrScanner := bufio.NewScanner(e.stderr)
 	go func() {
 		for {
 			for stdErrScanner.Scan() {
+				e.logs.Add("stderr", stdErrScanner.Text())
 				execLogger.
 					WithField("plugin", e.name).
 					WithField("io", "stderr").
@@ -53,6 +53,7 @@ func setupMockExec(resp []byte, timeout bool) *ExecutablePlugin {
 		cmd:    &mockCmd{},
 		stdout: stdout,
 		stderr: stderr,
+		logs:   NewLogBuffer(LogBufferSize),
 	}
 }
 
@@ -69,6 +70,23 @@ func TestExecutablePlugin(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(resp.Token, ShouldEqual, "a token")
 		})
+		Convey("captures the plugin output after the handshake", func() {
+			e := setupMockExec([]byte(`{"Token": "a token"}`), false)
+			_, err := e.Run(time.Millisecond * 100)
+			So(err, ShouldBeNil)
+			So(func() bool {
+				for i := 0; i < 100 && e.Logs().Len() < 2; i++ {
+					time.Sleep(time.Millisecond * 10)
+				}
+				return e.Logs().Len() == 2
+			}(), ShouldBeTrue)
+			streams := map[string]string{}
+			for _, l := range e.Logs().Tail(0) {
+				streams[l.Stream] = l.Line
+			}
+			So(streams["stdout"], ShouldEqual, "some log message on stdout")
+			So(streams["stderr"], ShouldEqual, "some log message on stderr")
+		})
 		Convey("returns an error if an invalid response is given", func() {
 			e := setupMockExec([]byte(`this is bad`), false)
 			_, err := e.Run(time.Millisecond * 100)
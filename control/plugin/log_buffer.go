@@ -0,0 +1,139 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// LogBufferSize is the number of output lines kept for each running plugin.
+	LogBufferSize = 1000
+
+	// logSubscriberBuffer is the channel size of a log follower; lines
+	// are dropped for a follower which does not keep up.
+	logSubscriberBuffer = 100
+)
+
+// LogLine is a single line of output captured from a plugin.
+type LogLine struct {
+	Timestamp time.Time
+	// Stream is either "stdout" or "stderr"
+	Stream string
+	Line   string
+}
+
+// LogBuffer is a bounded ring buffer of the lines a plugin
+// wrote to its stdout and stderr.
+type LogBuffer struct {
+	sync.RWMutex
+	lines  []LogLine
+	next   int
+	full   bool
+	subs   map[int]chan LogLine
+	subSeq int
+}
+
+// NewLogBuffer returns a LogBuffer holding at most size lines.
+func NewLogBuffer(size int) *LogBuffer {
+	if size < 1 {
+		size = 1
+	}
+	return &LogBuffer{
+		lines: make([]LogLine, size),
+		subs:  map[int]chan LogLine{},
+	}
+}
+
+// Add appends a line to the buffer, overwriting the oldest line
+// once the buffer is full, and forwards it to any followers.
+func (b *LogBuffer) Add(stream, line string) {
+	l := LogLine{
+		Timestamp: time.Now(),
+		Stream:    stream,
+		Line:      line,
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.lines[b.next] = l
+	b.next = (b.next + 1) % len(b.lines)
+	if b.next == 0 {
+		b.full = true
+	}
+	for _, c := range b.subs {
+		select {
+		case c <- l:
+		default:
+		}
+	}
+}
+
+// Len returns the number of lines held in the buffer.
+func (b *LogBuffer) Len() int {
+	b.RLock()
+	defer b.RUnlock()
+	if b.full {
+		return len(b.lines)
+	}
+	return b.next
+}
+
+// Tail returns up to n of the most recent lines, oldest first.
+// A value of n lower than 1 returns every line in the buffer.
+func (b *LogBuffer) Tail(n int) []LogLine {
+	b.RLock()
+	defer b.RUnlock()
+	count := b.next
+	start := 0
+	if b.full {
+		count = len(b.lines)
+		start = b.next
+	}
+	if n > 0 && n < count {
+		start = (start + count - n) % len(b.lines)
+		count = n
+	}
+	out := make([]LogLine, count)
+	for i := 0; i < count; i++ {
+		out[i] = b.lines[(start+i)%len(b.lines)]
+	}
+	return out
+}
+
+// Subscribe returns a channel receiving every line added after the call
+// and a function which must be called to stop following the buffer.
+func (b *LogBuffer) Subscribe() (<-chan LogLine, func()) {
+	b.Lock()
+	defer b.Unlock()
+	id := b.subSeq
+	b.subSeq++
+	c := make(chan LogLine, logSubscriberBuffer)
+	b.subs[id] = c
+	var once sync.Once
+	return c, func() {
+		once.Do(func() {
+			b.Lock()
+			defer b.Unlock()
+			delete(b.subs, id)
+			close(c)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLogBuffer(t *testing.T) {
+	Convey("Given a log buffer of size 3", t, func() {
+		b := NewLogBuffer(3)
+		So(b.Len(), ShouldEqual, 0)
+		So(b.Tail(0), ShouldBeEmpty)
+
+		Convey("When fewer lines than its size are added", func() {
+			b.Add("stdout", "a")
+			b.Add("stderr", "b")
+			Convey("Then all of them are returned in order", func() {
+				lines := b.Tail(0)
+				So(len(lines), ShouldEqual, 2)
+				So(lines[0].Line, ShouldEqual, "a")
+				So(lines[0].Stream, ShouldEqual, "stdout")
+				So(lines[1].Line, ShouldEqual, "b")
+				So(lines[1].Stream, ShouldEqual, "stderr")
+			})
+		})
+
+		Convey("When more lines than its size are added", func() {
+			for i := 0; i < 5; i++ {
+				b.Add("stderr", fmt.Sprint(i))
+			}
+			Convey("Then only the most recent lines are kept", func() {
+				So(b.Len(), ShouldEqual, 3)
+				lines := b.Tail(0)
+				So(len(lines), ShouldEqual, 3)
+				So(lines[0].Line, ShouldEqual, "2")
+				So(lines[2].Line, ShouldEqual, "4")
+			})
+			Convey("Then the tail is limited to the requested count", func() {
+				lines := b.Tail(2)
+				So(len(lines), ShouldEqual, 2)
+				So(lines[0].Line, ShouldEqual, "3")
+				So(lines[1].Line, ShouldEqual, "4")
+			})
+		})
+
+		Convey("When a follower subscribes", func() {
+			b.Add("stdout", "before")
+			c, cancel := b.Subscribe()
+			b.Add("stdout", "after")
+			Convey("Then it receives only new lines", func() {
+				l := <-c
+				So(l.Line, ShouldEqual, "after")
+				cancel()
+				_, ok := <-c
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
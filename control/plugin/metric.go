@@ -27,6 +27,7 @@ import (
 
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/encoding"
 )
 
 const (
@@ -102,6 +103,10 @@ type MetricType struct {
 	// field.
 	Unit_ string
 
+	// Kind tells whether the metric is a gauge, a counter, etc.  Metrics
+	// declaring a kind must have a UCUM unit (see core.ValidateUnit).
+	Kind_ core.MetricKind `json:"kind,omitempty"`
+
 	// A (long) description for the metric.  The description is stored on the
 	// metric catalog and not sent through  collect -> process -> publish.
 	Description_ string `json:"description"`
@@ -155,6 +160,23 @@ func (p MetricType) Unit() string {
 	return p.Unit_
 }
 
+// returns the metrics kind
+func (p MetricType) Kind() core.MetricKind {
+	return p.Kind_
+}
+
+// UnmarshalJSON decodes a metric type, turning distributions found in its
+// data back into core.Distribution values.
+func (p *MetricType) UnmarshalJSON(data []byte) error {
+	type metricType MetricType
+	d, err := encoding.UnmarshalMetric(data, (*metricType)(p))
+	if err != nil {
+		return err
+	}
+	p.Data_ = d
+	return nil
+}
+
 func (p *MetricType) AddData(data interface{}) {
 	p.Data_ = data
 }
@@ -139,6 +139,33 @@ func TestMetric(t *testing.T) {
 		})
 	})
 
+	Convey("distributions survive snap.json and snap.gob", t, func() {
+		d, err := core.NewDistribution([]float64{1, 10})
+		So(err, ShouldBeNil)
+		So(d.Observe(0.5), ShouldBeNil)
+		So(d.Observe(20), ShouldBeNil)
+		m := []MetricType{
+			*NewMetricType(core.NewNamespace("foo", "bar"), time.Now(), nil, "ms", d),
+		}
+		for _, ct := range []string{"snap.json", "snap.gob"} {
+			a, _, e := MarshalMetricTypes(ct, m)
+			So(e, ShouldBeNil)
+			mts, e := UnmarshallMetricTypes(ct, a)
+			So(e, ShouldBeNil)
+			So(mts[0].Data(), ShouldResemble, d)
+		}
+
+		Convey("objects without the distribution kind are left alone by snap.json", func() {
+			data := map[string]interface{}{"bounds": []interface{}{1.0}, "counts": 1.0, "other": "x"}
+			m[0].Data_ = data
+			a, _, e := MarshalMetricTypes("snap.json", m)
+			So(e, ShouldBeNil)
+			mts, e := UnmarshallMetricTypes("snap.json", a)
+			So(e, ShouldBeNil)
+			So(mts[0].Data(), ShouldResemble, data)
+		})
+	})
+
 	Convey("error on unmarshall using bad content type", t, func() {
 		m := []MetricType{
 			*NewMetricType(core.NewNamespace("foo", "bar"), time.Now(), nil, "", 1),
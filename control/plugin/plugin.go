@@ -61,6 +61,14 @@ const (
 	// Using this strategy enables a running database plugin that has the same connection info between
 	// two tasks to be shared.
 	ConfigRouting
+	// LeastOutstandingRouting is routing to the running instance of a plugin
+	// with the fewest calls in flight.
+	LeastOutstandingRouting
+	// ConsistentHashRouting is routing to plugins based on a hash of the task ID,
+	// or of the value of the config key given by PluginMeta.RoutingKey.
+	// Using this strategy the same requests keep going to the same running instance
+	// while only a fraction of them move when instances are added or removed.
+	ConsistentHashRouting
 )
 
 // Plugin response states
@@ -98,6 +106,8 @@ var (
 		"least-recently-used",
 		"sticky",
 		"config",
+		"least-outstanding-requests",
+		"consistent-hash",
 	}
 )
 
@@ -132,6 +142,9 @@ type PluginMeta struct {
 	// RoutingStrategy will override the routing strategy this plugin requires.
 	// The default routing strategy round-robin.
 	RoutingStrategy RoutingStrategyType
+	// RoutingKey is the config key whose value is hashed by the consistent-hash
+	// routing strategy. The task ID is hashed when it is empty.
+	RoutingKey string
 	// TLSEnabled identifies status of plugin security
 	TLSEnabled bool
 }
@@ -70,6 +70,13 @@ func RoutingStrategy(r RoutingStrategyType) metaOp {
 	}
 }
 
+// RoutingKey is an option that can be be provided to the func NewPluginMeta.
+func RoutingKey(k string) metaOp {
+	return func(m *PluginMeta) {
+		m.RoutingKey = k
+	}
+}
+
 // CacheTTL is an option that can be be provided to the func NewPluginMeta.
 func CacheTTL(t time.Duration) metaOp {
 	return func(m *PluginMeta) {
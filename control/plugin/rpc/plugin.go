@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 
@@ -18,10 +19,14 @@ var (
 // NewGetConfigPolicyReply given a config *cpolicy.ConfigPolicy returns a GetConfigPolicyReply.
 func NewGetConfigPolicyReply(policy *cpolicy.ConfigPolicy) (*GetConfigPolicyReply, error) {
 	ret := &GetConfigPolicyReply{
-		BoolPolicy:    map[string]*BoolPolicy{},
-		FloatPolicy:   map[string]*FloatPolicy{},
-		IntegerPolicy: map[string]*IntegerPolicy{},
-		StringPolicy:  map[string]*StringPolicy{},
+		BoolPolicy:     map[string]*BoolPolicy{},
+		FloatPolicy:    map[string]*FloatPolicy{},
+		IntegerPolicy:  map[string]*IntegerPolicy{},
+		StringPolicy:   map[string]*StringPolicy{},
+		EnumPolicy:     map[string]*EnumPolicy{},
+		RegexPolicy:    map[string]*RegexPolicy{},
+		DurationPolicy: map[string]*DurationPolicy{},
+		ListPolicy:     map[string]*ListPolicy{},
 	}
 
 	for _, node := range policy.GetAll() {
@@ -45,7 +50,8 @@ func NewGetConfigPolicyReply(policy *cpolicy.ConfigPolicy) (*GetConfigPolicyRepl
 				ret.BoolPolicy[key].Rules[rule.Name] = r
 			case cpolicy.StringType:
 				r := &StringRule{
-					Required: rule.Required,
+					Required:  rule.Required,
+					Sensitive: rule.Sensitive,
 				}
 				if rule.Default != nil {
 					r.Default = rule.Default.(ctypes.ConfigValueStr).Value
@@ -97,6 +103,86 @@ func NewGetConfigPolicyReply(policy *cpolicy.ConfigPolicy) (*GetConfigPolicyRepl
 					}
 				}
 				ret.FloatPolicy[key].Rules[rule.Name] = r
+			case cpolicy.EnumType:
+				r := &EnumRule{
+					Required: rule.Required,
+					Allowed:  rule.Allowed,
+				}
+				if rule.Default != nil {
+					r.Default = rule.Default.(ctypes.ConfigValueStr).Value
+					r.HasDefault = true
+				}
+				if ret.EnumPolicy[key] == nil {
+					ret.EnumPolicy[key] = &EnumPolicy{
+						Rules: map[string]*EnumRule{},
+						Key:   node.Key,
+					}
+				}
+				ret.EnumPolicy[key].Rules[rule.Name] = r
+			case cpolicy.RegexType:
+				r := &RegexRule{
+					Required: rule.Required,
+					Pattern:  rule.Pattern,
+				}
+				if rule.Default != nil {
+					r.Default = rule.Default.(ctypes.ConfigValueStr).Value
+					r.HasDefault = true
+				}
+				if ret.RegexPolicy[key] == nil {
+					ret.RegexPolicy[key] = &RegexPolicy{
+						Rules: map[string]*RegexRule{},
+						Key:   node.Key,
+					}
+				}
+				ret.RegexPolicy[key].Rules[rule.Name] = r
+			case cpolicy.DurationType:
+				r := &DurationRule{
+					Required: rule.Required,
+				}
+				if rule.Default != nil {
+					r.Default = int64(rule.Default.(ctypes.ConfigValueDuration).Value)
+					r.HasDefault = true
+				}
+				if rule.Maximum != nil {
+					r.Maximum = int64(rule.Maximum.(ctypes.ConfigValueDuration).Value)
+					r.HasMax = true
+				}
+				if rule.Minimum != nil {
+					r.Minimum = int64(rule.Minimum.(ctypes.ConfigValueDuration).Value)
+					r.HasMin = true
+				}
+				if ret.DurationPolicy[key] == nil {
+					ret.DurationPolicy[key] = &DurationPolicy{
+						Rules: map[string]*DurationRule{},
+						Key:   node.Key,
+					}
+				}
+				ret.DurationPolicy[key].Rules[rule.Name] = r
+			case cpolicy.ListType:
+				r := &ListRule{
+					Required: rule.Required,
+					Allowed:  rule.Allowed,
+					Pattern:  rule.Pattern,
+				}
+				if rule.Default != nil {
+					r.Default = rule.Default.(ctypes.ConfigValueStrList).Value
+					r.HasDefault = true
+				}
+				if rule.Maximum != nil {
+					r.Maximum = int64(rule.Maximum.(ctypes.ConfigValueInt).Value)
+					r.HasMax = true
+				}
+				if rule.Minimum != nil {
+					r.Minimum = int64(rule.Minimum.(ctypes.ConfigValueInt).Value)
+					r.HasMin = true
+				}
+				if ret.ListPolicy[key] == nil {
+					ret.ListPolicy[key] = &ListPolicy{
+						Rules: map[string]*ListRule{},
+						Key:   node.Key,
+					}
+				}
+				ret.ListPolicy[key].Rules[rule.Name] = r
 			}
 
 		}
@@ -143,6 +229,7 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 				rpcLogger.Warn("Empty key found with value %v", val)
 				continue
 			}
+			sr.SetSensitive(val.Sensitive)
 
 			nodes[k].Add(sr)
 		}
@@ -204,6 +291,105 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 		}
 	}
 
+	for k, v := range reply.EnumPolicy {
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = cpolicy.NewPolicyNode()
+		}
+		for key, val := range v.Rules {
+			var er *cpolicy.EnumRule
+			var err error
+			if val.HasDefault {
+				er, err = cpolicy.NewEnumRule(key, val.Required, val.Allowed, val.Default)
+			} else {
+				er, err = cpolicy.NewEnumRule(key, val.Required, val.Allowed)
+			}
+			if err != nil {
+				rpcLogger.Warnf("Invalid enum rule %s: %v", key, err)
+				continue
+			}
+			nodes[k].Add(er)
+		}
+	}
+
+	for k, v := range reply.RegexPolicy {
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = cpolicy.NewPolicyNode()
+		}
+		for key, val := range v.Rules {
+			var xr *cpolicy.RegexRule
+			var err error
+			if val.HasDefault {
+				xr, err = cpolicy.NewRegexRule(key, val.Required, val.Pattern, val.Default)
+			} else {
+				xr, err = cpolicy.NewRegexRule(key, val.Required, val.Pattern)
+			}
+			if err != nil {
+				rpcLogger.Warnf("Invalid regex rule %s: %v", key, err)
+				continue
+			}
+			nodes[k].Add(xr)
+		}
+	}
+
+	for k, v := range reply.DurationPolicy {
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = cpolicy.NewPolicyNode()
+		}
+		for key, val := range v.Rules {
+			var dr *cpolicy.DurationRule
+			var err error
+			if val.HasDefault {
+				dr, err = cpolicy.NewDurationRule(key, val.Required, time.Duration(val.Default))
+			} else {
+				dr, err = cpolicy.NewDurationRule(key, val.Required)
+			}
+			if err != nil {
+				rpcLogger.Warn("Empty key found with value %v", val)
+				continue
+			}
+			if val.HasMin {
+				dr.SetMinimum(time.Duration(val.Minimum))
+			}
+			if val.HasMax {
+				dr.SetMaximum(time.Duration(val.Maximum))
+			}
+
+			nodes[k].Add(dr)
+		}
+	}
+
+	for k, v := range reply.ListPolicy {
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = cpolicy.NewPolicyNode()
+		}
+		for key, val := range v.Rules {
+			var lr *cpolicy.ListRule
+			var err error
+			if val.HasDefault {
+				lr, err = cpolicy.NewListRule(key, val.Required, val.Default)
+			} else {
+				lr, err = cpolicy.NewListRule(key, val.Required)
+			}
+			if err != nil {
+				rpcLogger.Warn("Empty key found with value %v", val)
+				continue
+			}
+			if val.HasMin {
+				lr.SetMinimum(int(val.Minimum))
+			}
+			if val.HasMax {
+				lr.SetMaximum(int(val.Maximum))
+			}
+			lr.SetAllowed(val.Allowed)
+			if err := lr.SetPattern(val.Pattern); err != nil {
+				rpcLogger.Warnf("Invalid list rule %s: %v", key, err)
+				continue
+			}
+
+			nodes[k].Add(lr)
+		}
+	}
+
 	for key, node := range nodes {
 		var keys []string
 		// if the []string is present, use it.
@@ -216,6 +402,14 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 			keys = val.Key
 		} else if val, ok := reply.IntegerPolicy[key]; ok && val != nil && val.Key != nil {
 			keys = val.Key
+		} else if val, ok := reply.EnumPolicy[key]; ok && val != nil && val.Key != nil {
+			keys = val.Key
+		} else if val, ok := reply.RegexPolicy[key]; ok && val != nil && val.Key != nil {
+			keys = val.Key
+		} else if val, ok := reply.DurationPolicy[key]; ok && val != nil && val.Key != nil {
+			keys = val.Key
+		} else if val, ok := reply.ListPolicy[key]; ok && val != nil && val.Key != nil {
+			keys = val.Key
 		} else {
 			keys = strings.Split(key, ".")
 		}
@@ -49,6 +49,16 @@ It has these top-level messages:
 	MetricsArg
 	MetricsReply
 	GetMetricTypesArg
+	StringList
+	EnumRule
+	EnumPolicy
+	RegexRule
+	RegexPolicy
+	DurationRule
+	DurationPolicy
+	ListRule
+	ListPolicy
+	Distribution
 */
 package rpc
 
@@ -273,7 +283,11 @@ type Metric struct {
 	//	*Metric_BoolData
 	//	*Metric_Uint32Data
 	//	*Metric_Uint64Data
+	//	*Metric_DistributionData
 	Data isMetric_Data `protobuf_oneof:"data"`
+	// Kind is the metric kind (gauge, counter, delta, distribution or state),
+	// empty for plugins which don't declare one.
+	Kind string `protobuf:"bytes,18,opt,name=Kind" json:"Kind,omitempty"`
 }
 
 func (m *Metric) Reset()                    { *m = Metric{} }
@@ -312,16 +326,20 @@ type Metric_Uint32Data struct {
 type Metric_Uint64Data struct {
 	Uint64Data uint64 `protobuf:"varint,17,opt,name=uint64_data,json=uint64Data,oneof"`
 }
+type Metric_DistributionData struct {
+	DistributionData *Distribution `protobuf:"bytes,19,opt,name=distribution_data,json=distributionData,oneof"`
+}
 
-func (*Metric_StringData) isMetric_Data()  {}
-func (*Metric_Float32Data) isMetric_Data() {}
-func (*Metric_Float64Data) isMetric_Data() {}
-func (*Metric_Int32Data) isMetric_Data()   {}
-func (*Metric_Int64Data) isMetric_Data()   {}
-func (*Metric_BytesData) isMetric_Data()   {}
-func (*Metric_BoolData) isMetric_Data()    {}
-func (*Metric_Uint32Data) isMetric_Data()  {}
-func (*Metric_Uint64Data) isMetric_Data()  {}
+func (*Metric_StringData) isMetric_Data()       {}
+func (*Metric_Float32Data) isMetric_Data()      {}
+func (*Metric_Float64Data) isMetric_Data()      {}
+func (*Metric_Int32Data) isMetric_Data()        {}
+func (*Metric_Int64Data) isMetric_Data()        {}
+func (*Metric_BytesData) isMetric_Data()        {}
+func (*Metric_BoolData) isMetric_Data()         {}
+func (*Metric_Uint32Data) isMetric_Data()       {}
+func (*Metric_Uint64Data) isMetric_Data()       {}
+func (*Metric_DistributionData) isMetric_Data() {}
 
 func (m *Metric) GetData() isMetric_Data {
 	if m != nil {
@@ -449,6 +467,20 @@ func (m *Metric) GetUint64Data() uint64 {
 	return 0
 }
 
+func (m *Metric) GetDistributionData() *Distribution {
+	if x, ok := m.GetData().(*Metric_DistributionData); ok {
+		return x.DistributionData
+	}
+	return nil
+}
+
+func (m *Metric) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Metric) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Metric_OneofMarshaler, _Metric_OneofUnmarshaler, _Metric_OneofSizer, []interface{}{
@@ -461,6 +493,7 @@ func (*Metric) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error,
 		(*Metric_BoolData)(nil),
 		(*Metric_Uint32Data)(nil),
 		(*Metric_Uint64Data)(nil),
+		(*Metric_DistributionData)(nil),
 	}
 }
 
@@ -499,6 +532,11 @@ func _Metric_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 	case *Metric_Uint64Data:
 		b.EncodeVarint(17<<3 | proto.WireVarint)
 		b.EncodeVarint(uint64(x.Uint64Data))
+	case *Metric_DistributionData:
+		b.EncodeVarint(19<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DistributionData); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Metric.Data has unexpected type %T", x)
@@ -572,6 +610,14 @@ func _Metric_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer)
 		x, err := b.DecodeVarint()
 		m.Data = &Metric_Uint64Data{x}
 		return true, err
+	case 19: // data.distribution_data
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Distribution)
+		err := b.DecodeMessage(msg)
+		m.Data = &Metric_DistributionData{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -610,6 +656,11 @@ func _Metric_OneofSizer(msg proto.Message) (n int) {
 	case *Metric_Uint64Data:
 		n += proto.SizeVarint(17<<3 | proto.WireVarint)
 		n += proto.SizeVarint(uint64(x.Uint64Data))
+	case *Metric_DistributionData:
+		s := proto.Size(x.DistributionData)
+		n += proto.SizeVarint(19<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -623,6 +674,9 @@ type ConfigMap struct {
 	// double is float64
 	FloatMap map[string]float64 `protobuf:"bytes,3,rep,name=FloatMap" json:"FloatMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	BoolMap  map[string]bool    `protobuf:"bytes,4,rep,name=BoolMap" json:"BoolMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// durations are carried as nanoseconds
+	DurationMap   map[string]int64       `protobuf:"bytes,5,rep,name=DurationMap" json:"DurationMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	StringListMap map[string]*StringList `protobuf:"bytes,6,rep,name=StringListMap" json:"StringListMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *ConfigMap) Reset()                    { *m = ConfigMap{} }
@@ -658,6 +712,20 @@ func (m *ConfigMap) GetBoolMap() map[string]bool {
 	return nil
 }
 
+func (m *ConfigMap) GetDurationMap() map[string]int64 {
+	if m != nil {
+		return m.DurationMap
+	}
+	return nil
+}
+
+func (m *ConfigMap) GetStringListMap() map[string]*StringList {
+	if m != nil {
+		return m.StringListMap
+	}
+	return nil
+}
+
 type KillArg struct {
 	Reason string `protobuf:"bytes,1,opt,name=Reason" json:"Reason,omitempty"`
 }
@@ -675,11 +743,15 @@ func (m *KillArg) GetReason() string {
 }
 
 type GetConfigPolicyReply struct {
-	Error         string                    `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
-	BoolPolicy    map[string]*BoolPolicy    `protobuf:"bytes,2,rep,name=bool_policy,json=boolPolicy" json:"bool_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	FloatPolicy   map[string]*FloatPolicy   `protobuf:"bytes,3,rep,name=float_policy,json=floatPolicy" json:"float_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	IntegerPolicy map[string]*IntegerPolicy `protobuf:"bytes,4,rep,name=integer_policy,json=integerPolicy" json:"integer_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	StringPolicy  map[string]*StringPolicy  `protobuf:"bytes,5,rep,name=string_policy,json=stringPolicy" json:"string_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Error          string                     `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	BoolPolicy     map[string]*BoolPolicy     `protobuf:"bytes,2,rep,name=bool_policy,json=boolPolicy" json:"bool_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	FloatPolicy    map[string]*FloatPolicy    `protobuf:"bytes,3,rep,name=float_policy,json=floatPolicy" json:"float_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	IntegerPolicy  map[string]*IntegerPolicy  `protobuf:"bytes,4,rep,name=integer_policy,json=integerPolicy" json:"integer_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StringPolicy   map[string]*StringPolicy   `protobuf:"bytes,5,rep,name=string_policy,json=stringPolicy" json:"string_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	EnumPolicy     map[string]*EnumPolicy     `protobuf:"bytes,6,rep,name=enum_policy,json=enumPolicy" json:"enum_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RegexPolicy    map[string]*RegexPolicy    `protobuf:"bytes,7,rep,name=regex_policy,json=regexPolicy" json:"regex_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DurationPolicy map[string]*DurationPolicy `protobuf:"bytes,8,rep,name=duration_policy,json=durationPolicy" json:"duration_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ListPolicy     map[string]*ListPolicy     `protobuf:"bytes,9,rep,name=list_policy,json=listPolicy" json:"list_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *GetConfigPolicyReply) Reset()                    { *m = GetConfigPolicyReply{} }
@@ -722,6 +794,34 @@ func (m *GetConfigPolicyReply) GetStringPolicy() map[string]*StringPolicy {
 	return nil
 }
 
+func (m *GetConfigPolicyReply) GetEnumPolicy() map[string]*EnumPolicy {
+	if m != nil {
+		return m.EnumPolicy
+	}
+	return nil
+}
+
+func (m *GetConfigPolicyReply) GetRegexPolicy() map[string]*RegexPolicy {
+	if m != nil {
+		return m.RegexPolicy
+	}
+	return nil
+}
+
+func (m *GetConfigPolicyReply) GetDurationPolicy() map[string]*DurationPolicy {
+	if m != nil {
+		return m.DurationPolicy
+	}
+	return nil
+}
+
+func (m *GetConfigPolicyReply) GetListPolicy() map[string]*ListPolicy {
+	if m != nil {
+		return m.ListPolicy
+	}
+	return nil
+}
+
 type BoolRule struct {
 	Required   bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
 	Default    bool `protobuf:"varint,2,opt,name=default" json:"default,omitempty"`
@@ -958,6 +1058,7 @@ type StringRule struct {
 	Required   bool   `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
 	Default    string `protobuf:"bytes,2,opt,name=default" json:"default,omitempty"`
 	HasDefault bool   `protobuf:"varint,3,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+	Sensitive  bool   `protobuf:"varint,4,opt,name=sensitive" json:"sensitive,omitempty"`
 }
 
 func (m *StringRule) Reset()                    { *m = StringRule{} }
@@ -986,6 +1087,13 @@ func (m *StringRule) GetHasDefault() bool {
 	return false
 }
 
+func (m *StringRule) GetSensitive() bool {
+	if m != nil {
+		return m.Sensitive
+	}
+	return false
+}
+
 type StringPolicy struct {
 	Rules map[string]*StringRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Key   []string               `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
@@ -1066,6 +1174,389 @@ func (m *GetMetricTypesArg) GetConfig() *ConfigMap {
 	return nil
 }
 
+type StringList struct {
+	Value []string `protobuf:"bytes,1,rep,name=value" json:"value,omitempty"`
+}
+
+func (m *StringList) Reset()                    { *m = StringList{} }
+func (m *StringList) String() string            { return proto.CompactTextString(m) }
+func (*StringList) ProtoMessage()               {}
+func (*StringList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *StringList) GetValue() []string {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type EnumRule struct {
+	Required   bool     `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Allowed    []string `protobuf:"bytes,2,rep,name=allowed" json:"allowed,omitempty"`
+	Default    string   `protobuf:"bytes,3,opt,name=default" json:"default,omitempty"`
+	HasDefault bool     `protobuf:"varint,4,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+}
+
+func (m *EnumRule) Reset()                    { *m = EnumRule{} }
+func (m *EnumRule) String() string            { return proto.CompactTextString(m) }
+func (*EnumRule) ProtoMessage()               {}
+func (*EnumRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *EnumRule) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *EnumRule) GetAllowed() []string {
+	if m != nil {
+		return m.Allowed
+	}
+	return nil
+}
+
+func (m *EnumRule) GetDefault() string {
+	if m != nil {
+		return m.Default
+	}
+	return ""
+}
+
+func (m *EnumRule) GetHasDefault() bool {
+	if m != nil {
+		return m.HasDefault
+	}
+	return false
+}
+
+type EnumPolicy struct {
+	Rules map[string]*EnumRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Key   []string             `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
+}
+
+func (m *EnumPolicy) Reset()                    { *m = EnumPolicy{} }
+func (m *EnumPolicy) String() string            { return proto.CompactTextString(m) }
+func (*EnumPolicy) ProtoMessage()               {}
+func (*EnumPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *EnumPolicy) GetRules() map[string]*EnumRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *EnumPolicy) GetKey() []string {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type RegexRule struct {
+	Required   bool   `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Pattern    string `protobuf:"bytes,2,opt,name=pattern" json:"pattern,omitempty"`
+	Default    string `protobuf:"bytes,3,opt,name=default" json:"default,omitempty"`
+	HasDefault bool   `protobuf:"varint,4,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+}
+
+func (m *RegexRule) Reset()                    { *m = RegexRule{} }
+func (m *RegexRule) String() string            { return proto.CompactTextString(m) }
+func (*RegexRule) ProtoMessage()               {}
+func (*RegexRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *RegexRule) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *RegexRule) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *RegexRule) GetDefault() string {
+	if m != nil {
+		return m.Default
+	}
+	return ""
+}
+
+func (m *RegexRule) GetHasDefault() bool {
+	if m != nil {
+		return m.HasDefault
+	}
+	return false
+}
+
+type RegexPolicy struct {
+	Rules map[string]*RegexRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Key   []string              `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
+}
+
+func (m *RegexPolicy) Reset()                    { *m = RegexPolicy{} }
+func (m *RegexPolicy) String() string            { return proto.CompactTextString(m) }
+func (*RegexPolicy) ProtoMessage()               {}
+func (*RegexPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *RegexPolicy) GetRules() map[string]*RegexRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *RegexPolicy) GetKey() []string {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// durations are carried as nanoseconds
+type DurationRule struct {
+	Required   bool  `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Minimum    int64 `protobuf:"varint,2,opt,name=minimum" json:"minimum,omitempty"`
+	Maximum    int64 `protobuf:"varint,3,opt,name=maximum" json:"maximum,omitempty"`
+	Default    int64 `protobuf:"varint,4,opt,name=default" json:"default,omitempty"`
+	HasDefault bool  `protobuf:"varint,5,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+	HasMin     bool  `protobuf:"varint,6,opt,name=has_min,json=hasMin" json:"has_min,omitempty"`
+	HasMax     bool  `protobuf:"varint,7,opt,name=has_max,json=hasMax" json:"has_max,omitempty"`
+}
+
+func (m *DurationRule) Reset()                    { *m = DurationRule{} }
+func (m *DurationRule) String() string            { return proto.CompactTextString(m) }
+func (*DurationRule) ProtoMessage()               {}
+func (*DurationRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *DurationRule) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *DurationRule) GetMinimum() int64 {
+	if m != nil {
+		return m.Minimum
+	}
+	return 0
+}
+
+func (m *DurationRule) GetMaximum() int64 {
+	if m != nil {
+		return m.Maximum
+	}
+	return 0
+}
+
+func (m *DurationRule) GetDefault() int64 {
+	if m != nil {
+		return m.Default
+	}
+	return 0
+}
+
+func (m *DurationRule) GetHasDefault() bool {
+	if m != nil {
+		return m.HasDefault
+	}
+	return false
+}
+
+func (m *DurationRule) GetHasMin() bool {
+	if m != nil {
+		return m.HasMin
+	}
+	return false
+}
+
+func (m *DurationRule) GetHasMax() bool {
+	if m != nil {
+		return m.HasMax
+	}
+	return false
+}
+
+type DurationPolicy struct {
+	Rules map[string]*DurationRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Key   []string                 `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
+}
+
+func (m *DurationPolicy) Reset()                    { *m = DurationPolicy{} }
+func (m *DurationPolicy) String() string            { return proto.CompactTextString(m) }
+func (*DurationPolicy) ProtoMessage()               {}
+func (*DurationPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *DurationPolicy) GetRules() map[string]*DurationRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *DurationPolicy) GetKey() []string {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// minimum and maximum bound the length of the list, allowed and pattern
+// restrict each element
+type ListRule struct {
+	Required   bool     `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Minimum    int64    `protobuf:"varint,2,opt,name=minimum" json:"minimum,omitempty"`
+	Maximum    int64    `protobuf:"varint,3,opt,name=maximum" json:"maximum,omitempty"`
+	Default    []string `protobuf:"bytes,4,rep,name=default" json:"default,omitempty"`
+	HasDefault bool     `protobuf:"varint,5,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+	HasMin     bool     `protobuf:"varint,6,opt,name=has_min,json=hasMin" json:"has_min,omitempty"`
+	HasMax     bool     `protobuf:"varint,7,opt,name=has_max,json=hasMax" json:"has_max,omitempty"`
+	Allowed    []string `protobuf:"bytes,8,rep,name=allowed" json:"allowed,omitempty"`
+	Pattern    string   `protobuf:"bytes,9,opt,name=pattern" json:"pattern,omitempty"`
+}
+
+func (m *ListRule) Reset()                    { *m = ListRule{} }
+func (m *ListRule) String() string            { return proto.CompactTextString(m) }
+func (*ListRule) ProtoMessage()               {}
+func (*ListRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *ListRule) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *ListRule) GetMinimum() int64 {
+	if m != nil {
+		return m.Minimum
+	}
+	return 0
+}
+
+func (m *ListRule) GetMaximum() int64 {
+	if m != nil {
+		return m.Maximum
+	}
+	return 0
+}
+
+func (m *ListRule) GetDefault() []string {
+	if m != nil {
+		return m.Default
+	}
+	return nil
+}
+
+func (m *ListRule) GetHasDefault() bool {
+	if m != nil {
+		return m.HasDefault
+	}
+	return false
+}
+
+func (m *ListRule) GetHasMin() bool {
+	if m != nil {
+		return m.HasMin
+	}
+	return false
+}
+
+func (m *ListRule) GetHasMax() bool {
+	if m != nil {
+		return m.HasMax
+	}
+	return false
+}
+
+func (m *ListRule) GetAllowed() []string {
+	if m != nil {
+		return m.Allowed
+	}
+	return nil
+}
+
+func (m *ListRule) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+type ListPolicy struct {
+	Rules map[string]*ListRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Key   []string             `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
+}
+
+func (m *ListPolicy) Reset()                    { *m = ListPolicy{} }
+func (m *ListPolicy) String() string            { return proto.CompactTextString(m) }
+func (*ListPolicy) ProtoMessage()               {}
+func (*ListPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *ListPolicy) GetRules() map[string]*ListRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *ListPolicy) GetKey() []string {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// core.Distribution
+type Distribution struct {
+	// Bounds are the ascending upper bounds of the buckets.
+	Bounds []float64 `protobuf:"fixed64,1,rep,packed,name=Bounds" json:"Bounds,omitempty"`
+	// Counts holds one count per bound plus the count of the values above
+	// the last bound.
+	Counts []uint64 `protobuf:"varint,2,rep,packed,name=Counts" json:"Counts,omitempty"`
+	Sum    float64  `protobuf:"fixed64,3,opt,name=Sum" json:"Sum,omitempty"`
+	Count  uint64   `protobuf:"varint,4,opt,name=Count" json:"Count,omitempty"`
+}
+
+func (m *Distribution) Reset()                    { *m = Distribution{} }
+func (m *Distribution) String() string            { return proto.CompactTextString(m) }
+func (*Distribution) ProtoMessage()               {}
+func (*Distribution) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *Distribution) GetBounds() []float64 {
+	if m != nil {
+		return m.Bounds
+	}
+	return nil
+}
+
+func (m *Distribution) GetCounts() []uint64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+func (m *Distribution) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *Distribution) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*CollectArg)(nil), "rpc.CollectArg")
 	proto.RegisterType((*CollectReply)(nil), "rpc.CollectReply")
@@ -1089,6 +1580,16 @@ func init() {
 	proto.RegisterType((*MetricsArg)(nil), "rpc.MetricsArg")
 	proto.RegisterType((*MetricsReply)(nil), "rpc.MetricsReply")
 	proto.RegisterType((*GetMetricTypesArg)(nil), "rpc.GetMetricTypesArg")
+	proto.RegisterType((*StringList)(nil), "rpc.StringList")
+	proto.RegisterType((*EnumRule)(nil), "rpc.EnumRule")
+	proto.RegisterType((*EnumPolicy)(nil), "rpc.EnumPolicy")
+	proto.RegisterType((*RegexRule)(nil), "rpc.RegexRule")
+	proto.RegisterType((*RegexPolicy)(nil), "rpc.RegexPolicy")
+	proto.RegisterType((*DurationRule)(nil), "rpc.DurationRule")
+	proto.RegisterType((*DurationPolicy)(nil), "rpc.DurationPolicy")
+	proto.RegisterType((*ListRule)(nil), "rpc.ListRule")
+	proto.RegisterType((*ListPolicy)(nil), "rpc.ListPolicy")
+	proto.RegisterType((*Distribution)(nil), "rpc.Distribution")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1855,100 +2356,128 @@ func init() {
 }
 
 var fileDescriptor0 = []byte{
-	// 1505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xdc, 0x58, 0xcb, 0x6e, 0xdb, 0x46,
-	0x17, 0x16, 0x4d, 0xdd, 0x78, 0x28, 0xf9, 0x32, 0xc8, 0x9f, 0x5f, 0x55, 0x12, 0x44, 0xa1, 0xeb,
-	0x44, 0xb9, 0x54, 0x4e, 0xe5, 0xd4, 0x4d, 0x9c, 0x76, 0xe1, 0xc4, 0x6e, 0x9c, 0xa4, 0x4a, 0x0d,
-	0xc6, 0xcd, 0xa6, 0x40, 0x83, 0x11, 0x3d, 0x96, 0x89, 0x50, 0x24, 0x3b, 0x24, 0x03, 0xeb, 0x15,
-	0xba, 0xed, 0xaa, 0x40, 0x81, 0x02, 0x7d, 0x82, 0xae, 0xbb, 0xea, 0xa2, 0x8b, 0xa2, 0x2f, 0xd1,
-	0x57, 0x29, 0xe6, 0x42, 0x71, 0xa8, 0x4b, 0x6c, 0x2f, 0x0a, 0x04, 0xdd, 0x71, 0xce, 0xf9, 0xce,
-	0xa7, 0x73, 0xbe, 0x73, 0x66, 0xc8, 0x11, 0x6c, 0x0d, 0xdc, 0xf8, 0x38, 0xe9, 0x77, 0x9c, 0x60,
-	0xb8, 0xee, 0xfa, 0x31, 0xf1, 0xa2, 0x43, 0xf7, 0xa3, 0x93, 0xf5, 0xc8, 0xc7, 0xe1, 0xba, 0x13,
-	0xf8, 0x31, 0x0d, 0xbc, 0xf5, 0xd0, 0x4b, 0x06, 0xae, 0xbf, 0x4e, 0x43, 0x47, 0x3e, 0x76, 0x42,
-	0x1a, 0xc4, 0x01, 0xd2, 0x69, 0xe8, 0x58, 0xbf, 0x6a, 0x00, 0x8f, 0x03, 0xcf, 0x23, 0x4e, 0xbc,
-	0x4d, 0x07, 0xe8, 0x2e, 0x98, 0x3d, 0x12, 0x53, 0xd7, 0x89, 0x5e, 0x6f, 0xd3, 0x41, 0x43, 0x6b,
-	0x69, 0x6d, 0xb3, 0xbb, 0xd4, 0xa1, 0xa1, 0xd3, 0x91, 0xf6, 0x6d, 0x3a, 0xb0, 0x21, 0x7b, 0x46,
-	0x1d, 0x40, 0x3d, 0x7c, 0x22, 0x29, 0x76, 0x12, 0x8a, 0x63, 0x37, 0xf0, 0x1b, 0x0b, 0x2d, 0xad,
-	0xad, 0xdb, 0x33, 0x3c, 0xe8, 0x16, 0x2c, 0xf7, 0xf0, 0x89, 0x24, 0x78, 0x94, 0x1c, 0x1d, 0x11,
-	0xda, 0xd0, 0x39, 0x7a, 0xca, 0x8e, 0x2e, 0x40, 0xe9, 0xab, 0xf8, 0x98, 0xd0, 0x46, 0xb1, 0xa5,
-	0xb5, 0x6b, 0xb6, 0x58, 0x58, 0x6f, 0xa0, 0x26, 0x49, 0x6d, 0x12, 0x7a, 0x23, 0xb4, 0x09, 0xf5,
-	0x34, 0x67, 0x6e, 0x90, 0x59, 0xaf, 0xa8, 0x59, 0x73, 0x87, 0x5d, 0x53, 0x57, 0x68, 0x15, 0x4a,
-	0xbb, 0x94, 0x06, 0x94, 0x27, 0x6b, 0x76, 0xeb, 0x1c, 0xbf, 0x4b, 0xa9, 0xc0, 0x0a, 0x9f, 0x55,
-	0x81, 0xd2, 0xee, 0x30, 0x8c, 0x47, 0x56, 0x0b, 0xaa, 0xa9, 0x8f, 0xe5, 0x45, 0x78, 0x24, 0xfb,
-	0x25, 0xc3, 0x16, 0x0b, 0xeb, 0x0e, 0x14, 0x0f, 0xdc, 0x21, 0x41, 0xcb, 0xa0, 0x47, 0xc4, 0xe1,
-	0x3e, 0xdd, 0x66, 0x8f, 0x08, 0x41, 0xd1, 0x67, 0x26, 0xa1, 0x0a, 0x7f, 0xb6, 0xbe, 0x85, 0xe5,
-	0x17, 0x78, 0x48, 0xa2, 0x10, 0x3b, 0x64, 0xd7, 0x23, 0x43, 0xe2, 0xc7, 0x8c, 0xf7, 0x15, 0xf6,
-	0x12, 0x92, 0xf2, 0xf2, 0x05, 0x6a, 0x81, 0xb9, 0x43, 0x22, 0x87, 0xba, 0xe1, 0x58, 0x5a, 0xc3,
-	0x56, 0x4d, 0x8c, 0x9f, 0x71, 0x71, 0x1d, 0x0d, 0x9b, 0x3f, 0x5b, 0xdf, 0x00, 0xec, 0x27, 0xfd,
-	0x7d, 0x1a, 0x38, 0xac, 0x4b, 0x6b, 0x50, 0x91, 0xb5, 0x37, 0xb4, 0x96, 0xde, 0x36, 0xbb, 0xa6,
-	0xa2, 0x8e, 0x9d, 0xfa, 0xd0, 0x75, 0x28, 0x3f, 0x0e, 0xfc, 0x23, 0x77, 0x20, 0x35, 0x59, 0xe4,
-	0x28, 0x61, 0xea, 0xe1, 0xd0, 0x96, 0x5e, 0xeb, 0xb7, 0x12, 0x94, 0x45, 0x0c, 0xda, 0x00, 0x63,
-	0x5c, 0x87, 0xe4, 0xfe, 0x1f, 0x8f, 0x9a, 0xac, 0xce, 0xce, 0x70, 0xa8, 0x01, 0x95, 0x57, 0x84,
-	0x46, 0xd9, 0xa4, 0xa4, 0x4b, 0x25, 0x03, 0xfd, 0x5d, 0x19, 0xa0, 0x07, 0x80, 0xbe, 0xc4, 0x51,
-	0xbc, 0x7d, 0xf8, 0x96, 0xd0, 0xd8, 0x8d, 0xc8, 0x21, 0x93, 0x9e, 0xcf, 0x89, 0xd9, 0x35, 0x78,
-	0x0c, 0x33, 0xd8, 0x33, 0x40, 0xe8, 0x26, 0x14, 0x0f, 0xf0, 0x20, 0x6a, 0x94, 0x94, 0x64, 0x45,
-	0x31, 0x1d, 0x66, 0xdf, 0xf5, 0x63, 0x3a, 0xb2, 0x39, 0x04, 0xdd, 0x00, 0x83, 0x85, 0x44, 0x31,
-	0x1e, 0x86, 0x8d, 0xf2, 0x24, 0x79, 0xe6, 0x63, 0x1d, 0xf8, 0xda, 0x77, 0xe3, 0x46, 0x45, 0x74,
-	0x80, 0x3d, 0x4f, 0xf6, 0xad, 0x3a, 0xdd, 0xb7, 0x6b, 0x60, 0x46, 0x31, 0x75, 0xfd, 0xc1, 0xeb,
-	0x43, 0x1c, 0xe3, 0x86, 0xc1, 0x10, 0x7b, 0x05, 0x1b, 0x84, 0x71, 0x07, 0xc7, 0x18, 0xad, 0x42,
-	0xed, 0xc8, 0x0b, 0x70, 0xbc, 0xd1, 0x15, 0x18, 0x68, 0x69, 0xed, 0x85, 0xbd, 0x82, 0x6d, 0x4a,
-	0x6b, 0x0e, 0xb4, 0x79, 0x4f, 0x80, 0xcc, 0x96, 0xd6, 0xd6, 0xc6, 0xa0, 0xcd, 0x7b, 0x1c, 0x74,
-	0x15, 0xc0, 0xf5, 0xc7, 0x3c, 0xb5, 0x96, 0xd6, 0x2e, 0xed, 0x15, 0x6c, 0x83, 0xdb, 0x14, 0x40,
-	0xca, 0x51, 0x67, 0x7d, 0x91, 0x80, 0x8c, 0xa1, 0x3f, 0x8a, 0x49, 0x24, 0x00, 0x8b, 0x6c, 0x4f,
-	0x32, 0x00, 0xb7, 0x71, 0xc0, 0x15, 0x30, 0xfa, 0x41, 0xe0, 0x09, 0xff, 0x52, 0x4b, 0x6b, 0x57,
-	0xf7, 0x0a, 0x76, 0x95, 0x99, 0xb8, 0xfb, 0x1a, 0x98, 0x89, 0x92, 0xc2, 0x72, 0x4b, 0x6b, 0xd7,
-	0x59, 0xb9, 0x49, 0x96, 0x83, 0x84, 0xa4, 0x49, 0xac, 0xb4, 0xb4, 0x76, 0x31, 0x85, 0x88, 0x2c,
-	0x9a, 0x9f, 0x82, 0x31, 0x6e, 0x13, 0xdb, 0x6b, 0x6f, 0xc8, 0x48, 0xee, 0x17, 0xf6, 0xc8, 0xf6,
-	0xd0, 0x5b, 0xbe, 0x87, 0xc4, 0x3e, 0x11, 0x8b, 0xad, 0x85, 0xfb, 0xda, 0xa3, 0x32, 0x14, 0x19,
-	0xa9, 0xf5, 0xb7, 0x0e, 0xc6, 0x78, 0xa0, 0x50, 0x17, 0xca, 0x4f, 0xfd, 0xb8, 0x87, 0x43, 0x39,
-	0xbc, 0xcd, 0xfc, 0xc0, 0x75, 0x84, 0x53, 0x0c, 0x85, 0x44, 0xa2, 0x87, 0x60, 0xbc, 0xe4, 0x2d,
-	0x62, 0x61, 0x0b, 0x3c, 0xec, 0xca, 0x44, 0xd8, 0xd8, 0x2f, 0x22, 0x33, 0x3c, 0xba, 0x0f, 0xd5,
-	0x2f, 0x58, 0x5b, 0x58, 0xac, 0xce, 0x63, 0x2f, 0x4f, 0xc4, 0xa6, 0x6e, 0x11, 0x3a, 0x46, 0xa3,
-	0x4f, 0xa0, 0xf2, 0x28, 0x08, 0x3c, 0x16, 0x58, 0xe4, 0x81, 0x97, 0x26, 0x02, 0xa5, 0x57, 0xc4,
-	0xa5, 0xd8, 0xe6, 0x03, 0x30, 0x95, 0x22, 0x4e, 0x93, 0x4c, 0x57, 0x24, 0x6b, 0x7e, 0x06, 0x8b,
-	0xf9, 0x42, 0xce, 0x23, 0x78, 0xf3, 0x21, 0xd4, 0x73, 0xa5, 0x9c, 0x16, 0xac, 0xa9, 0xc1, 0x5b,
-	0x50, 0x53, 0xcb, 0x39, 0x2d, 0xb6, 0xaa, 0xc4, 0x5a, 0xd7, 0xa0, 0xf2, 0xdc, 0xf5, 0x3c, 0x76,
-	0xf0, 0x5d, 0x84, 0xb2, 0x4d, 0x70, 0x14, 0xf8, 0x32, 0x52, 0xae, 0xd8, 0x09, 0x76, 0xe1, 0x09,
-	0x89, 0x85, 0x76, 0xfb, 0x81, 0xe7, 0x3a, 0xa3, 0x77, 0x9c, 0xed, 0xe8, 0x19, 0x98, 0x7c, 0xb2,
-	0x43, 0x8e, 0x94, 0x3d, 0xbf, 0xc9, 0xe5, 0x9f, 0xc5, 0xc2, 0x3b, 0x21, 0xd6, 0xa2, 0x19, 0xd0,
-	0x1f, 0x1b, 0x50, 0x4f, 0xee, 0xd6, 0x94, 0x4c, 0x0c, 0xc1, 0xad, 0xf9, 0x64, 0x5c, 0x44, 0x95,
-	0x4d, 0xec, 0x6b, 0x49, 0xf7, 0x12, 0x16, 0xd9, 0x9b, 0x7f, 0x40, 0x68, 0x4a, 0x28, 0x86, 0xe3,
-	0xce, 0x7c, 0xc2, 0xa7, 0x02, 0xaf, 0x52, 0xd6, 0x5d, 0xd5, 0x86, 0xf6, 0xa1, 0x2e, 0x4f, 0x26,
-	0xc9, 0x29, 0x0e, 0xcb, 0xdb, 0xf3, 0x39, 0xc5, 0x9c, 0xa8, 0x94, 0xb5, 0x48, 0x31, 0x35, 0x5f,
-	0xc0, 0xd2, 0x84, 0x28, 0x33, 0x5a, 0xba, 0xa6, 0xb6, 0x34, 0xfd, 0xf0, 0xc8, 0xc2, 0xd4, 0xf9,
-	0xd8, 0x87, 0xe5, 0x49, 0x5d, 0x66, 0x10, 0x5e, 0xcf, 0x13, 0x2e, 0x73, 0x42, 0x25, 0x4e, 0x65,
-	0x3c, 0x00, 0x34, 0x2d, 0xcc, 0x0c, 0xce, 0x76, 0x9e, 0x13, 0x71, 0xce, 0x5c, 0xa4, 0xca, 0x6a,
-	0xc3, 0xca, 0x94, 0x34, 0x33, 0x48, 0x6f, 0xe4, 0x49, 0xc5, 0xc7, 0x8b, 0x1a, 0xa8, 0xce, 0x37,
-	0x86, 0x2a, 0x13, 0xc5, 0x4e, 0x3c, 0x82, 0x9a, 0x50, 0xa5, 0xe4, 0xbb, 0xc4, 0xa5, 0xe4, 0x90,
-	0xf3, 0x55, 0xed, 0xf1, 0x9a, 0xbd, 0x66, 0x0f, 0xc9, 0x11, 0x4e, 0xbc, 0x58, 0xee, 0x91, 0x74,
-	0x89, 0xae, 0x82, 0x79, 0x8c, 0xa3, 0xd7, 0xa9, 0x57, 0xe7, 0x5e, 0x38, 0xc6, 0xd1, 0x8e, 0xb0,
-	0x58, 0x3f, 0x6a, 0x00, 0x99, 0xf0, 0xe8, 0x2e, 0x94, 0x68, 0xe2, 0x91, 0x28, 0x77, 0x48, 0x66,
-	0xfe, 0x0e, 0x4b, 0x45, 0xbe, 0x39, 0x05, 0x30, 0x2d, 0x91, 0xed, 0x14, 0x51, 0x62, 0xf3, 0x09,
-	0x40, 0x06, 0x9b, 0x21, 0xc1, 0x6a, 0x5e, 0x82, 0xfa, 0xf8, 0x37, 0x58, 0x94, 0x5a, 0xfe, 0x9f,
-	0x1a, 0x18, 0xbc, 0x87, 0x67, 0x11, 0x60, 0xe8, 0xfa, 0xee, 0x30, 0x19, 0xca, 0x03, 0x26, 0x5d,
-	0x72, 0x0f, 0x3e, 0xe1, 0x1e, 0x5d, 0x7a, 0xc4, 0x52, 0x15, 0xad, 0x28, 0x3c, 0x73, 0x44, 0x2b,
-	0x4d, 0x8a, 0x86, 0xfe, 0x0f, 0x15, 0x06, 0x18, 0xba, 0x3e, 0xff, 0x58, 0xa8, 0xda, 0xe5, 0x63,
-	0x1c, 0xf5, 0x5c, 0x7f, 0xec, 0xc0, 0x27, 0xfc, 0x0b, 0x41, 0x3a, 0xf0, 0x89, 0xf5, 0x93, 0x06,
-	0xa6, 0x32, 0x8e, 0xe8, 0xe3, 0xbc, 0xce, 0x97, 0x26, 0xe7, 0xf5, 0x4c, 0x42, 0xef, 0x9d, 0x22,
-	0xf4, 0x87, 0x79, 0xa1, 0x17, 0xb3, 0x1f, 0x99, 0x54, 0xfa, 0x2f, 0x8d, 0xbf, 0x3b, 0xd8, 0x64,
-	0x9f, 0x57, 0x6b, 0x7d, 0xae, 0xd6, 0xfa, 0x5c, 0xad, 0xf5, 0x7f, 0x55, 0xeb, 0x5f, 0x34, 0xa8,
-	0xe7, 0xb6, 0x29, 0xda, 0xc8, 0xab, 0x7d, 0x65, 0x7a, 0x27, 0x9f, 0x49, 0xef, 0x67, 0xa7, 0xe8,
-	0x3d, 0xf3, 0x10, 0x52, 0x64, 0x55, 0x15, 0x77, 0x00, 0xc4, 0xae, 0x3f, 0xef, 0xe6, 0x36, 0xce,
-	0xb1, 0xb9, 0x7f, 0xd6, 0xa0, 0xa6, 0x9e, 0x2d, 0xa8, 0x9b, 0x17, 0xe2, 0xf2, 0xd4, 0xe9, 0x73,
-	0x26, 0x1d, 0x9e, 0x9e, 0xa2, 0xc3, 0xcc, 0xd3, 0x3d, 0xab, 0x56, 0x95, 0x61, 0x03, 0xd4, 0x3b,
-	0xe6, 0x1a, 0x54, 0x86, 0xef, 0xb8, 0xbd, 0x48, 0x9f, 0xf5, 0x1c, 0xf2, 0x17, 0xbc, 0xb3, 0x85,
-	0x65, 0x6f, 0xfc, 0x05, 0xf5, 0x36, 0xf7, 0x10, 0x56, 0x9e, 0x90, 0x58, 0x60, 0x0f, 0x46, 0x21,
-	0xe1, 0x89, 0x5c, 0x87, 0xb2, 0x23, 0x6e, 0x27, 0xda, 0xec, 0xdb, 0x89, 0xf0, 0x76, 0xbf, 0x5f,
-	0x60, 0x9f, 0x98, 0xfc, 0x8e, 0x1a, 0x50, 0xb4, 0x09, 0x8b, 0x72, 0x91, 0xde, 0xb3, 0x26, 0x6f,
-	0xd4, 0xcd, 0xe9, 0xcb, 0xaa, 0x55, 0x40, 0x9f, 0xc3, 0x62, 0x3e, 0x05, 0x74, 0x31, 0x7d, 0xff,
-	0xe6, 0xf3, 0x9a, 0x1d, 0xbe, 0x0a, 0xc5, 0x7d, 0xd7, 0x1f, 0x20, 0x10, 0x17, 0x5b, 0x76, 0x8b,
-	0x6d, 0xe6, 0x2f, 0xb9, 0x56, 0x01, 0xad, 0x41, 0x91, 0x7d, 0x2a, 0xa1, 0x1a, 0x77, 0xc8, 0xaf,
-	0xa6, 0x69, 0xd8, 0x16, 0x2c, 0x4d, 0xbc, 0xf5, 0x73, 0xb4, 0x1f, 0xcc, 0xfd, 0x2e, 0xb0, 0x0a,
-	0xdd, 0x3f, 0x34, 0x30, 0xd8, 0x3d, 0x94, 0x44, 0x51, 0x40, 0xd1, 0x3a, 0x54, 0xe4, 0x42, 0xaa,
-	0x90, 0xdd, 0x52, 0xdf, 0xef, 0x32, 0x7e, 0x67, 0x65, 0x24, 0x7d, 0xcf, 0x8d, 0x8e, 0x09, 0x45,
-	0xb7, 0xa1, 0x22, 0x17, 0xd3, 0x65, 0x4c, 0xfd, 0xec, 0xfb, 0x52, 0xc2, 0x0f, 0x0b, 0xb0, 0xf4,
-	0x32, 0xa6, 0x04, 0x0f, 0xb3, 0xe1, 0x7c, 0x00, 0x75, 0x61, 0xca, 0xcf, 0x66, 0xf6, 0x9f, 0x90,
-	0xec, 0x8a, 0xfa, 0x97, 0x8b, 0x55, 0x68, 0x6b, 0x77, 0xb5, 0xff, 0xc8, 0x7c, 0xf6, 0xcb, 0xfc,
-	0xef, 0xb0, 0x8d, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x65, 0x21, 0x51, 0x00, 0x4c, 0x13, 0x00,
-	0x00,
+	// 1959 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xdc, 0x59, 0xdb, 0x8f, 0xdb, 0x4a,
+	0x19, 0x5f, 0xaf, 0xb3, 0x49, 0xfc, 0x39, 0xc9, 0xee, 0x4e, 0x0f, 0x87, 0x90, 0xd3, 0xd2, 0xd4,
+	0xa5, 0x3d, 0xe9, 0xb9, 0x64, 0x4b, 0xb6, 0x94, 0xd3, 0x16, 0x10, 0xdb, 0xee, 0xd2, 0xed, 0xe9,
+	0xd9, 0xb2, 0xb8, 0xa5, 0x2f, 0x48, 0x54, 0x4e, 0x32, 0x9b, 0xb5, 0x8e, 0x63, 0x9b, 0xb1, 0x5d,
+	0xb2, 0x82, 0x17, 0x5e, 0x79, 0x42, 0xe2, 0x09, 0x09, 0x09, 0x09, 0x89, 0x77, 0xfe, 0x04, 0x24,
+	0x78, 0x40, 0xf0, 0x27, 0x21, 0x21, 0x34, 0x37, 0x7b, 0xec, 0x38, 0x9b, 0xac, 0xd0, 0x4a, 0x85,
+	0x37, 0xcf, 0x77, 0xf9, 0x79, 0xe6, 0xf7, 0x5d, 0x66, 0x3c, 0x86, 0x87, 0x13, 0x37, 0x3e, 0x4d,
+	0x86, 0xfd, 0x51, 0x30, 0xdd, 0x71, 0xfd, 0x18, 0x7b, 0xd1, 0xd8, 0xfd, 0x74, 0xb6, 0x13, 0xf9,
+	0x4e, 0xb8, 0x33, 0x0a, 0xfc, 0x98, 0x04, 0xde, 0x4e, 0xe8, 0x25, 0x13, 0xd7, 0xdf, 0x21, 0xe1,
+	0x48, 0x3c, 0xf6, 0x43, 0x12, 0xc4, 0x01, 0xd2, 0x49, 0x38, 0xb2, 0xfe, 0xac, 0x01, 0x3c, 0x09,
+	0x3c, 0x0f, 0x8f, 0xe2, 0x3d, 0x32, 0x41, 0x77, 0xc1, 0x3c, 0xc2, 0x31, 0x71, 0x47, 0xd1, 0x9b,
+	0x3d, 0x32, 0x69, 0x6b, 0x5d, 0xad, 0x67, 0x0e, 0x36, 0xfb, 0x24, 0x1c, 0xf5, 0x85, 0x7c, 0x8f,
+	0x4c, 0x6c, 0xc8, 0x9e, 0x51, 0x1f, 0xd0, 0x91, 0x33, 0x13, 0x10, 0xfb, 0x09, 0x71, 0x62, 0x37,
+	0xf0, 0xdb, 0xeb, 0x5d, 0xad, 0xa7, 0xdb, 0x25, 0x1a, 0xf4, 0x11, 0x6c, 0x1d, 0x39, 0x33, 0x01,
+	0xf0, 0x38, 0x39, 0x39, 0xc1, 0xa4, 0xad, 0x33, 0xeb, 0x39, 0x39, 0x7a, 0x0f, 0x36, 0x7e, 0x18,
+	0x9f, 0x62, 0xd2, 0xae, 0x74, 0xb5, 0x5e, 0xc3, 0xe6, 0x03, 0xeb, 0x4b, 0x68, 0x08, 0x50, 0x1b,
+	0x87, 0xde, 0x19, 0xba, 0x0f, 0x4d, 0x39, 0x67, 0x26, 0x10, 0xb3, 0xde, 0x56, 0x67, 0xcd, 0x14,
+	0x76, 0x43, 0x1d, 0xa1, 0x9b, 0xb0, 0x71, 0x40, 0x48, 0x40, 0xd8, 0x64, 0xcd, 0x41, 0x93, 0xd9,
+	0x1f, 0x10, 0xc2, 0x6d, 0xb9, 0xce, 0xaa, 0xc1, 0xc6, 0xc1, 0x34, 0x8c, 0xcf, 0xac, 0x2e, 0xd4,
+	0xa5, 0x8e, 0xce, 0x0b, 0x33, 0x4f, 0xfa, 0x26, 0xc3, 0xe6, 0x03, 0xeb, 0x13, 0xa8, 0xbc, 0x72,
+	0xa7, 0x18, 0x6d, 0x81, 0x1e, 0xe1, 0x11, 0xd3, 0xe9, 0x36, 0x7d, 0x44, 0x08, 0x2a, 0x3e, 0x15,
+	0x71, 0x56, 0xd8, 0xb3, 0xf5, 0x53, 0xd8, 0x7a, 0xe1, 0x4c, 0x71, 0x14, 0x3a, 0x23, 0x7c, 0xe0,
+	0xe1, 0x29, 0xf6, 0x63, 0x8a, 0xfb, 0xda, 0xf1, 0x12, 0x2c, 0x71, 0xd9, 0x00, 0x75, 0xc1, 0xdc,
+	0xc7, 0xd1, 0x88, 0xb8, 0x61, 0x4a, 0xad, 0x61, 0xab, 0x22, 0x8a, 0x4f, 0xb1, 0x18, 0x8f, 0x86,
+	0xcd, 0x9e, 0xad, 0x9f, 0x00, 0x1c, 0x27, 0xc3, 0x63, 0x12, 0x8c, 0x68, 0x94, 0x6e, 0x41, 0x4d,
+	0xac, 0xbd, 0xad, 0x75, 0xf5, 0x9e, 0x39, 0x30, 0x15, 0x76, 0x6c, 0xa9, 0x43, 0xb7, 0xa1, 0xfa,
+	0x24, 0xf0, 0x4f, 0xdc, 0x89, 0xe0, 0xa4, 0xc5, 0xac, 0xb8, 0xe8, 0xc8, 0x09, 0x6d, 0xa1, 0xb5,
+	0x7e, 0x53, 0x85, 0x2a, 0xf7, 0x41, 0xbb, 0x60, 0xa4, 0xeb, 0x10, 0xd8, 0x5f, 0x61, 0x5e, 0xc5,
+	0xd5, 0xd9, 0x99, 0x1d, 0x6a, 0x43, 0xed, 0x35, 0x26, 0x51, 0x96, 0x29, 0x72, 0xa8, 0xcc, 0x40,
+	0x3f, 0x6f, 0x06, 0xe8, 0x01, 0xa0, 0x2f, 0x9c, 0x28, 0xde, 0x1b, 0xbf, 0xc5, 0x24, 0x76, 0x23,
+	0x3c, 0xa6, 0xd4, 0xb3, 0x3c, 0x31, 0x07, 0x06, 0xf3, 0xa1, 0x02, 0xbb, 0xc4, 0x08, 0xdd, 0x81,
+	0xca, 0x2b, 0x67, 0x12, 0xb5, 0x37, 0x94, 0xc9, 0xf2, 0xc5, 0xf4, 0xa9, 0xfc, 0xc0, 0x8f, 0xc9,
+	0x99, 0xcd, 0x4c, 0xd0, 0x87, 0x60, 0x50, 0x97, 0x28, 0x76, 0xa6, 0x61, 0xbb, 0x5a, 0x04, 0xcf,
+	0x74, 0x34, 0x02, 0x3f, 0xf6, 0xdd, 0xb8, 0x5d, 0xe3, 0x11, 0xa0, 0xcf, 0xc5, 0xb8, 0xd5, 0xe7,
+	0xe3, 0x76, 0x03, 0xcc, 0x28, 0x26, 0xae, 0x3f, 0x79, 0x33, 0x76, 0x62, 0xa7, 0x6d, 0x50, 0x8b,
+	0xc3, 0x35, 0x1b, 0xb8, 0x70, 0xdf, 0x89, 0x1d, 0x74, 0x13, 0x1a, 0x27, 0x5e, 0xe0, 0xc4, 0xbb,
+	0x03, 0x6e, 0x03, 0x5d, 0xad, 0xb7, 0x7e, 0xb8, 0x66, 0x9b, 0x42, 0x9a, 0x33, 0xba, 0x7f, 0x8f,
+	0x1b, 0x99, 0x5d, 0xad, 0xa7, 0xa5, 0x46, 0xf7, 0xef, 0x31, 0xa3, 0xeb, 0x00, 0xae, 0x9f, 0xe2,
+	0x34, 0xba, 0x5a, 0x6f, 0xe3, 0x70, 0xcd, 0x36, 0x98, 0x4c, 0x31, 0x90, 0x18, 0x4d, 0x1a, 0x17,
+	0x61, 0x90, 0x21, 0x0c, 0xcf, 0x62, 0x1c, 0x71, 0x83, 0x16, 0xad, 0x49, 0x6a, 0xc0, 0x64, 0xcc,
+	0xe0, 0x1a, 0x18, 0xc3, 0x20, 0xf0, 0xb8, 0x7e, 0xb3, 0xab, 0xf5, 0xea, 0x87, 0x6b, 0x76, 0x9d,
+	0x8a, 0x98, 0xfa, 0x06, 0x98, 0x89, 0x32, 0x85, 0xad, 0xae, 0xd6, 0x6b, 0xd2, 0xe5, 0x26, 0xd9,
+	0x1c, 0x84, 0x89, 0x9c, 0xc4, 0x76, 0x57, 0xeb, 0x55, 0xa4, 0x89, 0x98, 0xc5, 0xf7, 0x61, 0x7b,
+	0xec, 0x52, 0x86, 0x86, 0x09, 0x25, 0x91, 0x1b, 0x5e, 0x51, 0x4a, 0x7e, 0x5f, 0xd1, 0x1e, 0xae,
+	0xd9, 0x5b, 0xaa, 0x35, 0x43, 0x40, 0x50, 0x79, 0xee, 0xfa, 0xe3, 0x36, 0xe2, 0xc1, 0xa2, 0xcf,
+	0x9d, 0x6f, 0x83, 0x91, 0x06, 0x9f, 0x56, 0xf0, 0x97, 0xf8, 0x4c, 0x54, 0x21, 0x7d, 0xa4, 0x95,
+	0xf9, 0x96, 0x55, 0x26, 0xaf, 0x3e, 0x3e, 0x78, 0xb8, 0xfe, 0x99, 0xf6, 0xb8, 0x0a, 0x15, 0x3a,
+	0x03, 0xeb, 0xdf, 0x1b, 0x60, 0xa4, 0x69, 0x8a, 0x06, 0x50, 0x7d, 0xe6, 0xc7, 0x47, 0x4e, 0x28,
+	0x4a, 0xa2, 0x93, 0x4f, 0xe3, 0x3e, 0x57, 0xf2, 0x54, 0x13, 0x96, 0xe8, 0x11, 0x18, 0x2f, 0x59,
+	0xe0, 0xa9, 0xdb, 0x3a, 0x73, 0xbb, 0x56, 0x70, 0x4b, 0xf5, 0xdc, 0x33, 0xb3, 0x47, 0x9f, 0x41,
+	0xfd, 0x07, 0x34, 0xd8, 0xd4, 0x57, 0x67, 0xbe, 0x57, 0x0b, 0xbe, 0x52, 0xcd, 0x5d, 0x53, 0x6b,
+	0xf4, 0x2d, 0xa8, 0x3d, 0x0e, 0x02, 0x8f, 0x3a, 0x56, 0x98, 0xe3, 0x07, 0x05, 0x47, 0xa1, 0xe5,
+	0x7e, 0xd2, 0x16, 0xed, 0x81, 0x29, 0x7b, 0x3a, 0x75, 0xe5, 0xc5, 0x74, 0xbd, 0xe0, 0xaa, 0x58,
+	0x70, 0x77, 0xd5, 0x07, 0x3d, 0x85, 0x26, 0x5f, 0xc0, 0x17, 0x6e, 0xc4, 0x26, 0x5e, 0x65, 0x20,
+	0x37, 0x4a, 0x17, 0x2d, 0x6c, 0x38, 0x4c, 0xde, 0xaf, 0xf3, 0x00, 0x4c, 0x85, 0xd0, 0x65, 0xe1,
+	0xd3, 0x95, 0xf0, 0x75, 0xbe, 0x03, 0xad, 0x3c, 0xa9, 0x17, 0x09, 0x7e, 0xe7, 0x11, 0x34, 0x73,
+	0xb4, 0x2e, 0x73, 0xd6, 0x54, 0xe7, 0x87, 0xd0, 0x50, 0xa9, 0x5d, 0xe6, 0x5b, 0x57, 0x7d, 0xbf,
+	0x07, 0x5b, 0x45, 0x6e, 0x2f, 0xb4, 0xec, 0x1f, 0x01, 0x9a, 0xa7, 0xb5, 0x04, 0xe1, 0x96, 0x8a,
+	0x20, 0x4f, 0x02, 0x99, 0xa7, 0x02, 0x69, 0xdd, 0x80, 0xda, 0x73, 0xd7, 0xf3, 0xe8, 0x6e, 0xf3,
+	0x3e, 0x54, 0x6d, 0xec, 0x44, 0x81, 0x2f, 0xa0, 0xc4, 0xc8, 0xfa, 0x2b, 0xc0, 0x7b, 0x4f, 0x71,
+	0xcc, 0x43, 0x7b, 0x1c, 0x78, 0xee, 0xe8, 0xec, 0x9c, 0x0d, 0x15, 0x7d, 0x0e, 0x26, 0x6b, 0x27,
+	0x21, 0xb3, 0x14, 0x25, 0x71, 0x87, 0x4d, 0xa1, 0x0c, 0x85, 0x25, 0x2a, 0x1f, 0xf3, 0x2c, 0x81,
+	0x61, 0x2a, 0x40, 0x47, 0xa2, 0x45, 0x4a, 0x30, 0x5e, 0x23, 0x1f, 0x2d, 0x06, 0x63, 0x71, 0x55,
+	0xd1, 0xcc, 0x93, 0x4c, 0x82, 0x5e, 0x42, 0x8b, 0x1e, 0xb7, 0x26, 0x98, 0x48, 0x40, 0x5e, 0x3b,
+	0x9f, 0x2c, 0x06, 0x7c, 0xc6, 0xed, 0x55, 0xc8, 0xa6, 0xab, 0xca, 0xd0, 0x31, 0x34, 0xc5, 0x76,
+	0x20, 0x30, 0x79, 0x51, 0x7d, 0xbc, 0x18, 0x93, 0x47, 0x42, 0x85, 0x6c, 0x44, 0x8a, 0x88, 0x32,
+	0x88, 0xfd, 0x64, 0x2a, 0xf1, 0xaa, 0xcb, 0x18, 0x3c, 0xf0, 0x93, 0x69, 0x8e, 0x41, 0x9c, 0x0a,
+	0x28, 0x83, 0x04, 0x4f, 0xf0, 0x4c, 0x82, 0xd5, 0x96, 0x31, 0x68, 0x53, 0xeb, 0x1c, 0x83, 0x24,
+	0x93, 0xa0, 0xd7, 0xb0, 0x39, 0x16, 0x19, 0x2c, 0x11, 0xeb, 0x0c, 0xf1, 0xd3, 0xc5, 0x88, 0x32,
+	0xe5, 0x55, 0xd0, 0xd6, 0x38, 0x27, 0xa4, 0x4b, 0xf6, 0xdc, 0x28, 0x8d, 0xb3, 0xb1, 0x6c, 0xc9,
+	0x34, 0x8d, 0x73, 0x4b, 0xf6, 0x52, 0x41, 0xe7, 0x05, 0x6c, 0x16, 0x72, 0x6a, 0xd5, 0x12, 0xc9,
+	0xdc, 0xd4, 0xaa, 0x3b, 0x86, 0xad, 0x62, 0x5a, 0x95, 0x00, 0xde, 0xce, 0x03, 0x6e, 0x31, 0x40,
+	0xc5, 0x4f, 0x45, 0x7c, 0x05, 0x68, 0x3e, 0xaf, 0x4a, 0x30, 0x7b, 0x79, 0x4c, 0xc4, 0x30, 0x73,
+	0x9e, 0x2a, 0xaa, 0x0d, 0xdb, 0x73, 0x99, 0x55, 0x02, 0xfa, 0x61, 0x1e, 0x74, 0x5b, 0x69, 0x0e,
+	0xf3, 0x98, 0x2f, 0x60, 0xb3, 0x90, 0x5d, 0xab, 0x72, 0x99, 0xb9, 0x15, 0xb8, 0x2c, 0x26, 0xd8,
+	0xaa, 0x5c, 0x2a, 0x7e, 0x2a, 0xe2, 0x6b, 0xb8, 0x52, 0x92, 0x60, 0x25, 0xa0, 0x77, 0xf2, 0xa0,
+	0x57, 0xf8, 0xa9, 0x23, 0xe7, 0x5a, 0x58, 0x79, 0x21, 0xc9, 0x56, 0x5d, 0x79, 0xe6, 0xa6, 0x36,
+	0x5a, 0x07, 0xea, 0x34, 0xbd, 0xec, 0xc4, 0xc3, 0xa8, 0x03, 0x75, 0x82, 0x7f, 0x96, 0xb8, 0x04,
+	0x8f, 0x19, 0x5a, 0xdd, 0x4e, 0xc7, 0xf4, 0x90, 0x3d, 0xc6, 0x27, 0x4e, 0xe2, 0xc5, 0x62, 0xff,
+	0x90, 0x43, 0x74, 0x1d, 0xcc, 0x53, 0x27, 0x7a, 0x23, 0xb5, 0x3a, 0xd3, 0xc2, 0xa9, 0x13, 0xed,
+	0x73, 0x89, 0xf5, 0x3b, 0x0d, 0x20, 0x4b, 0x61, 0x74, 0x17, 0x36, 0x48, 0xe2, 0xe1, 0x28, 0x77,
+	0x98, 0xc9, 0xf4, 0x7d, 0x3a, 0x15, 0x71, 0x6e, 0xe6, 0x86, 0x72, 0x81, 0xb4, 0x65, 0xf3, 0x05,
+	0x76, 0x9e, 0x02, 0x64, 0x66, 0x25, 0x04, 0xdc, 0xcc, 0x13, 0xd0, 0x4c, 0xdf, 0x41, 0xbd, 0xd4,
+	0xe5, 0xff, 0x5d, 0x03, 0x83, 0x55, 0xc3, 0x2a, 0x04, 0x4c, 0x5d, 0xdf, 0x9d, 0x26, 0x53, 0xb1,
+	0xf9, 0xca, 0x21, 0xd3, 0x38, 0x33, 0xa6, 0xd1, 0x85, 0xc6, 0x99, 0x49, 0x8d, 0xa4, 0xa5, 0xc2,
+	0x35, 0x0b, 0x48, 0xdb, 0x28, 0x92, 0x86, 0xbe, 0x0a, 0x35, 0x6a, 0x30, 0x75, 0x7d, 0xf6, 0xa9,
+	0x50, 0xb7, 0xab, 0xa7, 0x4e, 0x74, 0xe4, 0xfa, 0xa9, 0xc2, 0x99, 0xb5, 0x6b, 0x99, 0xc2, 0x99,
+	0x59, 0xbf, 0xd7, 0xc0, 0x54, 0x0a, 0x1b, 0x7d, 0x33, 0xcf, 0xf3, 0x07, 0xc5, 0xca, 0x5f, 0x89,
+	0xe8, 0xc3, 0x25, 0x44, 0x7f, 0x23, 0x4f, 0x74, 0x2b, 0x7b, 0x49, 0x91, 0xe9, 0x7f, 0x68, 0x60,
+	0x8a, 0x1e, 0x71, 0x51, 0xae, 0xf5, 0x85, 0x5c, 0xeb, 0x0b, 0xb9, 0xd6, 0x2f, 0x95, 0xeb, 0x3f,
+	0x6a, 0xd0, 0xcc, 0x35, 0x3c, 0xb4, 0x9b, 0x67, 0xfb, 0xda, 0x7c, 0x4f, 0x5c, 0x89, 0xef, 0xcf,
+	0x97, 0xf0, 0x5d, 0xda, 0x82, 0x14, 0x5a, 0x55, 0xc6, 0x7f, 0xa5, 0x01, 0xf0, 0x06, 0x7a, 0xd1,
+	0xea, 0x36, 0x56, 0xaf, 0x6e, 0x74, 0x15, 0x8c, 0x08, 0xfb, 0x91, 0x1b, 0xbb, 0x6f, 0xf9, 0x27,
+	0x73, 0xdd, 0xce, 0x04, 0xd6, 0x1f, 0x34, 0x68, 0xa8, 0x4d, 0x1c, 0x0d, 0xf2, 0x3c, 0x5d, 0x9d,
+	0x6b, 0xf3, 0x2b, 0xd1, 0xf4, 0x6c, 0x09, 0x4d, 0xe7, 0x9c, 0x34, 0x8b, 0x2c, 0xed, 0x82, 0x7a,
+	0x01, 0x75, 0x0b, 0x6a, 0xd3, 0x73, 0xae, 0x36, 0x84, 0xce, 0x7a, 0x0e, 0xf9, 0xdb, 0x9f, 0xd5,
+	0xdc, 0xb2, 0x93, 0xe9, 0xba, 0x7a, 0xd5, 0xf3, 0x08, 0xb6, 0x9f, 0xe2, 0x98, 0xdb, 0xbe, 0x3a,
+	0x0b, 0x31, 0x9b, 0xc8, 0x6d, 0xa8, 0x8e, 0xf8, 0xd5, 0x85, 0x56, 0x7e, 0x75, 0xc1, 0xb5, 0x96,
+	0x25, 0x63, 0x4c, 0xdb, 0x7b, 0x76, 0x46, 0xd7, 0x18, 0x57, 0x7c, 0x60, 0xfd, 0x02, 0xea, 0x74,
+	0xdb, 0x5b, 0x25, 0x0b, 0x1c, 0xcf, 0x0b, 0x7e, 0x8e, 0xc7, 0x82, 0x6b, 0x39, 0x54, 0xf3, 0x43,
+	0x3f, 0x37, 0x3f, 0x2a, 0xa5, 0xdd, 0x3f, 0xdb, 0x74, 0xcb, 0xbb, 0x7f, 0xa6, 0xbf, 0xbc, 0xee,
+	0x2f, 0x19, 0x50, 0x63, 0xff, 0x4b, 0x30, 0xd8, 0xf6, 0xbd, 0x0a, 0x33, 0xa1, 0x13, 0xc7, 0x98,
+	0xc8, 0x1b, 0x33, 0x39, 0xfc, 0x6f, 0x98, 0xa1, 0x0d, 0x5b, 0x39, 0x3d, 0x94, 0x37, 0x6c, 0xc5,
+	0xe0, 0xf2, 0x1a, 0x76, 0x4a, 0x82, 0x4a, 0xce, 0x3f, 0x35, 0x68, 0xc8, 0x73, 0xc8, 0xff, 0x7e,
+	0xc7, 0xfe, 0x93, 0x06, 0xad, 0xfc, 0xa9, 0x0a, 0xdd, 0xcb, 0xf3, 0xfd, 0xf5, 0x92, 0x93, 0xd7,
+	0x4a, 0x94, 0x3f, 0x5f, 0x42, 0x79, 0xe9, 0xc9, 0x56, 0x65, 0x56, 0x65, 0xfd, 0x5f, 0x1a, 0xd4,
+	0xd9, 0xc7, 0xf0, 0xa5, 0x33, 0xae, 0xf7, 0x8c, 0xcb, 0x64, 0x5c, 0xed, 0x26, 0xf5, 0xb9, 0x6e,
+	0x22, 0xab, 0xc9, 0xc8, 0x55, 0x13, 0x6b, 0x16, 0xd9, 0x39, 0xb5, 0xbc, 0x59, 0x64, 0xfa, 0xcb,
+	0x6b, 0x16, 0x32, 0x02, 0x6a, 0x64, 0x4e, 0xa0, 0xa1, 0x5e, 0x06, 0xd2, 0x7b, 0x89, 0xc7, 0x41,
+	0xe2, 0x8f, 0xf9, 0xec, 0x34, 0x5b, 0x8c, 0xa8, 0xfc, 0x49, 0x90, 0xf8, 0x71, 0xc4, 0x66, 0x51,
+	0xb1, 0xc5, 0x88, 0xbe, 0xfa, 0x65, 0x7a, 0x44, 0xa4, 0x8f, 0xb4, 0x5b, 0x33, 0x1d, 0x4b, 0xff,
+	0x8a, 0xcd, 0x07, 0x83, 0x5f, 0xaf, 0x83, 0x21, 0x7e, 0x49, 0x04, 0x04, 0xdd, 0x87, 0x96, 0x18,
+	0xc8, 0x6b, 0xf5, 0xe2, 0x0f, 0x94, 0xce, 0xfc, 0xbf, 0x09, 0x6b, 0x0d, 0x7d, 0x17, 0x5a, 0xf9,
+	0x4d, 0x05, 0xbd, 0x2f, 0x3f, 0x5b, 0xf3, 0x3b, 0x4d, 0xb9, 0xfb, 0x4d, 0xa8, 0x1c, 0xbb, 0xfe,
+	0x04, 0x01, 0xef, 0x9d, 0xf4, 0xa7, 0x45, 0x27, 0xff, 0x4f, 0xc3, 0x5a, 0x43, 0xb7, 0xe8, 0xd5,
+	0xa7, 0xe7, 0xa1, 0x06, 0x53, 0x88, 0xfb, 0x9a, 0x79, 0xb3, 0x87, 0xb0, 0x59, 0xf8, 0x58, 0xce,
+	0xc1, 0x7e, 0x6d, 0xe1, 0xe7, 0xb4, 0xb5, 0x36, 0xf8, 0x9b, 0x06, 0x06, 0xfd, 0xed, 0x80, 0xa3,
+	0x28, 0x20, 0x68, 0x07, 0x6a, 0x62, 0x20, 0x58, 0xc8, 0x7e, 0x4a, 0xbc, 0xdb, 0xcb, 0xf8, 0x0b,
+	0x5d, 0x46, 0x32, 0xf4, 0xdc, 0xe8, 0x14, 0x13, 0xf4, 0x31, 0xd4, 0xc4, 0x60, 0x7e, 0x19, 0x73,
+	0xaf, 0x7d, 0x57, 0x96, 0xf0, 0xdb, 0x75, 0xd8, 0x7c, 0x19, 0x13, 0xec, 0x4c, 0xb3, 0xe4, 0x7c,
+	0xc0, 0xee, 0x5c, 0xb1, 0x33, 0xcd, 0xe7, 0x66, 0xf6, 0x0b, 0xb0, 0xb3, 0xad, 0x0a, 0x04, 0x54,
+	0x4f, 0xbb, 0xab, 0xfd, 0x9f, 0xe4, 0xe7, 0xb0, 0xca, 0xfe, 0x7e, 0xee, 0xfe, 0x67, 0x00, 0xeb,
+	0xc9, 0xf5, 0x26, 0x3b, 0x1d, 0x00, 0x00,
 }
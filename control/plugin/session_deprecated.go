@@ -41,6 +41,7 @@ import (
 	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
 	"github.com/intelsdi-x/snap/control/plugin/encoding"
 	"github.com/intelsdi-x/snap/control/plugin/encrypter"
+	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
 	"github.com/intelsdi-x/snap/core/ctypes"
 )
@@ -293,6 +294,8 @@ func init() {
 	gob.RegisterName("conf_value_int", *(&ctypes.ConfigValueInt{}))
 	gob.RegisterName("conf_value_float", *(&ctypes.ConfigValueFloat{}))
 	gob.RegisterName("conf_value_bool", *(&ctypes.ConfigValueBool{}))
+	gob.RegisterName("conf_value_string_list", *(&ctypes.ConfigValueStrList{}))
+	gob.RegisterName("conf_value_duration", *(&ctypes.ConfigValueDuration{}))
 
 	gob.RegisterName("conf_policy_node", cpolicy.NewPolicyNode())
 	gob.RegisterName("conf_data_node", &cdata.ConfigDataNode{})
@@ -300,6 +303,12 @@ func init() {
 	gob.RegisterName("conf_policy_int", &cpolicy.IntRule{})
 	gob.RegisterName("conf_policy_float", &cpolicy.FloatRule{})
 	gob.RegisterName("conf_policy_bool", &cpolicy.BoolRule{})
+	gob.RegisterName("conf_policy_enum", &cpolicy.EnumRule{})
+	gob.RegisterName("conf_policy_regex", &cpolicy.RegexRule{})
+	gob.RegisterName("conf_policy_duration", &cpolicy.DurationRule{})
+	gob.RegisterName("conf_policy_list", &cpolicy.ListRule{})
+
+	gob.RegisterName("metric_distribution", core.Distribution{})
 }
 
 // simpleFormatter is a logrus formatter that includes only the message.
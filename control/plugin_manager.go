@@ -496,6 +496,7 @@ func (p *pluginManager) LoadPlugin(details *pluginDetails, emitter gomit.Emitter
 		}
 
 		lPlugin.ConfigPolicy = cp
+		markSensitiveKeys(cp)
 		lPlugin.Meta = resp.Meta
 		lPlugin.Type = resp.Type
 		lPlugin.Token = resp.Token
@@ -542,6 +543,7 @@ func (p *pluginManager) LoadPlugin(details *pluginDetails, emitter gomit.Emitter
 					return
 				}
 				lPlugin.ConfigPolicy = cp
+				markSensitiveKeys(cp)
 			}
 
 			colClient := ap.client.(client.PluginCollectorClient)
@@ -549,8 +551,20 @@ func (p *pluginManager) LoadPlugin(details *pluginDetails, emitter gomit.Emitter
 				defer ap.client.(client.PluginCollectorClient).Close()
 			}
 
+			resolvedNode, err := resolveConfigNode(cfgNode)
+			if err != nil {
+				pmLogger.WithFields(log.Fields{
+					"_block":         "load-plugin",
+					"plugin-type":    resp.Type.String(),
+					"error":          err.Error(),
+					"plugin-name":    ap.Name(),
+					"plugin-version": ap.Version(),
+				}).Error("error in resolving config secrets")
+				resultChan <- result{nil, serror.New(err)}
+				return
+			}
 			cfg := plugin.ConfigType{
-				ConfigDataNode: cfgNode,
+				ConfigDataNode: resolvedNode,
 			}
 
 			metricTypes, err := colClient.GetMetricTypes(cfg)
@@ -567,37 +581,19 @@ func (p *pluginManager) LoadPlugin(details *pluginDetails, emitter gomit.Emitter
 			}
 
 			// Add metric types to metric catalog
-			for _, nmt := range metricTypes {
-				// If the version is 0 default it to the plugin version
-				// This honors the plugins explicit version but falls back
-				// to the plugin version as default
-				if nmt.Version() < 1 {
-					// Since we have to override version we convert to a internal struct
-					nmt = &metricType{
-						namespace:          nmt.Namespace(),
-						version:            resp.Meta.Version,
-						lastAdvertisedTime: nmt.LastAdvertisedTime(),
-						config:             nmt.Config(),
-						data:               nmt.Data(),
-						tags:               nmt.Tags(),
-						description:        nmt.Description(),
-						unit:               nmt.Unit(),
-					}
-				}
-				// We quit and throw an error on bad metric versions (<1)
-				// the is a safety catch otherwise the catalog will be corrupted
-				if nmt.Version() < 1 {
-					err := errors.New("Bad metric version from plugin")
+			for _, mt := range metricTypes {
+				nmt, err := advertisedMetricType(resp.Meta.Version, mt)
+				if err != nil {
 					pmLogger.WithFields(log.Fields{
 						"_block":           "load-plugin",
 						"plugin-name":      resp.Meta.Name,
 						"plugin-version":   resp.Meta.Version,
 						"plugin-type":      resp.Meta.Type.String(),
 						"plugin-path":      filepath.Base(lPlugin.Details.ExecPath),
-						"metric-namespace": nmt.Namespace(),
-						"metric-version":   nmt.Version(),
+						"metric-namespace": mt.Namespace(),
+						"metric-version":   mt.Version(),
 						"error":            err.Error(),
-					}).Error("received metric with bad version")
+					}).Error("received metric with bad version or kind")
 					resultChan <- result{nil, serror.New(err)}
 					return
 				}
@@ -689,6 +685,49 @@ func (p *pluginManager) LoadPlugin(details *pluginDetails, emitter gomit.Emitter
 	}
 }
 
+// advertisedMetricType checks the version of a metric type advertised by
+// a plugin, defaulting it to the version of the plugin, and its kind.  The unit
+// of a metric declaring a kind has to be a UCUM unit; metrics without a kind,
+// advertised by plugins which predate metric kinds, keep a free-form unit.
+func advertisedMetricType(pluginVersion int, nmt core.Metric) (core.Metric, error) {
+	kind, err := core.ParseMetricKind(string(core.KindOf(nmt)))
+	if err != nil {
+		return nil, err
+	}
+	if kind != core.MetricKindUnknown {
+		if err := core.ValidateUnit(nmt.Unit()); err != nil {
+			return nil, err
+		}
+	}
+	// If the version is 0 default it to the plugin version
+	// This honors the plugins explicit version but falls back
+	// to the plugin version as default
+	if nmt.Version() < 1 || kind != core.KindOf(nmt) {
+		// Since we have to override version we convert to a internal struct
+		version := nmt.Version()
+		if version < 1 {
+			version = pluginVersion
+		}
+		nmt = &metricType{
+			namespace:          nmt.Namespace(),
+			version:            version,
+			lastAdvertisedTime: nmt.LastAdvertisedTime(),
+			config:             nmt.Config(),
+			data:               nmt.Data(),
+			tags:               nmt.Tags(),
+			description:        nmt.Description(),
+			unit:               nmt.Unit(),
+			kind:               kind,
+		}
+	}
+	// We quit and throw an error on bad metric versions (<1)
+	// the is a safety catch otherwise the catalog will be corrupted
+	if nmt.Version() < 1 {
+		return nil, errors.New("Bad metric version from plugin")
+	}
+	return nmt, nil
+}
+
 // UnloadPlugin unloads a plugin from the LoadedPlugins table
 func (p *pluginManager) UnloadPlugin(pl core.Plugin) (*loadedPlugin, serror.SnapError) {
 	plugin, err := p.loadedPlugins.get(fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", pl.TypeName(), pl.Name(), pl.Version()))
@@ -846,6 +885,7 @@ func (p *pluginManager) AddStandardAndWorkflowTags(m core.Metric, allTags map[st
 		Tags_:               tags,
 		Description_:        m.Description(),
 		Unit_:               m.Unit(),
+		Kind_:               core.KindOf(m),
 		Timestamp_:          m.Timestamp(),
 	}
 	return metric
@@ -0,0 +1,158 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/strategy"
+	"github.com/intelsdi-x/snap/core"
+)
+
+var errHealthCheckTimeout = errors.New("health check timeout")
+
+// pluginStats records the RPC calls made to a plugin
+type pluginStats struct {
+	sync.Mutex
+	calls      map[string]*core.PluginCallStats
+	metricsIn  uint64
+	metricsOut uint64
+	scaling    core.PluginScalingStats
+}
+
+func newPluginStats() *pluginStats {
+	return &pluginStats{
+		calls: map[string]*core.PluginCallStats{},
+	}
+}
+
+// record adds a call which took d to complete, along with the number
+// of metrics sent to and received from the plugin
+func (s *pluginStats) record(call string, d time.Duration, err error, in, out int) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	c, ok := s.calls[call]
+	if !ok {
+		c = &core.PluginCallStats{
+			Buckets: make([]uint64, len(core.LatencyBuckets)+1),
+		}
+		s.calls[call] = c
+	}
+	c.Calls++
+	if err != nil {
+		c.Errors++
+		if isTimeout(err) {
+			c.Timeouts++
+		}
+	}
+	c.TotalLatency += d
+	if d > c.MaxLatency {
+		c.MaxLatency = d
+	}
+	i := 0
+	for i < len(core.LatencyBuckets) && d > core.LatencyBuckets[i] {
+		i++
+	}
+	c.Buckets[i]++
+	s.metricsIn += uint64(in)
+	s.metricsOut += uint64(out)
+}
+
+// snapshot returns a copy of the recorded statistics
+func (s *pluginStats) snapshot() core.PluginStats {
+	stats := core.PluginStats{Calls: map[string]core.PluginCallStats{}}
+	if s == nil {
+		return stats
+	}
+	s.Lock()
+	defer s.Unlock()
+	for k, c := range s.calls {
+		cs := *c
+		cs.Buckets = make([]uint64, len(c.Buckets))
+		copy(cs.Buckets, c.Buckets)
+		stats.Calls[k] = cs
+	}
+	stats.MetricsIn = s.metricsIn
+	stats.MetricsOut = s.metricsOut
+	return stats
+}
+
+// recordScale adds a scaling decision taken by the autoscaler
+func (s *pluginStats) recordScale(action, reason string) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	switch action {
+	case strategy.ScaleUp.String():
+		s.scaling.ScaleUps++
+	case strategy.ScaleDown.String():
+		s.scaling.ScaleDowns++
+	}
+	s.scaling.LastAction = action
+	s.scaling.LastReason = reason
+	s.scaling.LastScaled = time.Now()
+}
+
+// scalingSnapshot returns a copy of the recorded scaling decisions
+func (s *pluginStats) scalingSnapshot() core.PluginScalingStats {
+	if s == nil {
+		return core.PluginScalingStats{}
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.scaling
+}
+
+// latency returns the number of task calls recorded and their total latency.
+// Health checks are left out.
+func (s *pluginStats) latency() (uint64, time.Duration) {
+	if s == nil {
+		return 0, 0
+	}
+	s.Lock()
+	defer s.Unlock()
+	var calls uint64
+	var total time.Duration
+	for call, c := range s.calls {
+		if call == core.PingCall {
+			continue
+		}
+		calls += c.Calls
+		total += c.TotalLatency
+	}
+	return calls, total
+}
+
+func isTimeout(err error) bool {
+	if ne, ok := err.(net.Error); ok && ne.Timeout() {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline exceeded")
+}
@@ -0,0 +1,123 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/core"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPluginStats(t *testing.T) {
+	Convey("Given plugin statistics", t, func() {
+		s := newPluginStats()
+		s.record(core.CollectMetricsCall, 3*time.Millisecond, nil, 4, 4)
+		s.record(core.CollectMetricsCall, 30*time.Millisecond, errors.New("rpc error: context deadline exceeded"), 4, 0)
+		s.record(core.CollectMetricsCall, time.Minute, errors.New("boom"), 4, 0)
+
+		Convey("snapshot() returns the recorded calls", func() {
+			st := s.snapshot()
+			c := st.Calls[core.CollectMetricsCall]
+			So(c.Calls, ShouldEqual, 3)
+			So(c.Errors, ShouldEqual, 2)
+			So(c.Timeouts, ShouldEqual, 1)
+			So(c.MaxLatency, ShouldEqual, time.Minute)
+			So(c.Buckets[1], ShouldEqual, 1)
+			So(c.Buckets[4], ShouldEqual, 1)
+			So(c.Buckets[len(core.LatencyBuckets)], ShouldEqual, 1)
+			So(st.MetricsIn, ShouldEqual, 12)
+			So(st.MetricsOut, ShouldEqual, 4)
+		})
+		Convey("snapshot() is not changed by later calls", func() {
+			st := s.snapshot()
+			s.record(core.CollectMetricsCall, time.Millisecond, nil, 1, 1)
+			So(st.Calls[core.CollectMetricsCall].Calls, ShouldEqual, 3)
+			So(st.Calls[core.CollectMetricsCall].Buckets[0], ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given a pool of running plugins", t, func() {
+		aps := newAvailablePlugins()
+		ap1 := newLoggingAvailablePlugin(1)
+		ap2 := newLoggingAvailablePlugin(2)
+		ap1.stats = newPluginStats()
+		ap2.stats = newPluginStats()
+		So(aps.insert(ap1), ShouldBeNil)
+		So(aps.insert(ap2), ShouldBeNil)
+		ap1.recordCall(core.PingCall, time.Now(), nil, 0, 0)
+		ap2.recordCall(core.PingCall, time.Now(), errHealthCheckTimeout, 0, 0)
+
+		Convey("poolStats() returns the pool and the instance statistics", func() {
+			ps, err := aps.poolStats(ap1.key)
+			So(err, ShouldBeNil)
+			So(ps.Total.Name, ShouldEqual, "mock")
+			So(ps.Total.Version, ShouldEqual, 1)
+			So(ps.Total.Type, ShouldEqual, "collector")
+			So(ps.Total.Calls[core.PingCall].Calls, ShouldEqual, 2)
+			So(ps.Total.Calls[core.PingCall].Timeouts, ShouldEqual, 1)
+			So(ps.Instances, ShouldHaveLength, 2)
+			So(ps.Instances[0].ID, ShouldEqual, 1)
+			So(ps.Instances[0].Calls[core.PingCall].Errors, ShouldEqual, 0)
+			So(ps.Instances[1].Calls[core.PingCall].Errors, ShouldEqual, 1)
+		})
+		Convey("the pool statistics outlive the instances", func() {
+			aps.table[ap1.key].Kill(ap1.id, "test")
+			ps, err := aps.poolStats(ap1.key)
+			So(err, ShouldBeNil)
+			So(ps.Total.Calls[core.PingCall].Calls, ShouldEqual, 2)
+			So(ps.Instances, ShouldHaveLength, 1)
+		})
+		Convey("poolStats() returns the scaling decisions", func() {
+			aps.statsFor(ap1.key).recordScale("up", "busy")
+			aps.statsFor(ap1.key).recordScale("down", "idle")
+			ps, err := aps.poolStats(ap1.key)
+			So(err, ShouldBeNil)
+			So(ps.Scaling.ScaleUps, ShouldEqual, 1)
+			So(ps.Scaling.ScaleDowns, ShouldEqual, 1)
+			So(ps.Scaling.LastAction, ShouldEqual, "down")
+			So(ps.Scaling.LastReason, ShouldEqual, "idle")
+			So(ps.Scaling.LastScaled.IsZero(), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given an autoscaler", t, func() {
+		r := newRunner()
+		a := r.autoscaler
+		key := "collector" + core.Separator + "mock" + core.Separator + "1"
+		st := r.availablePlugins.statsFor(key)
+
+		Convey("latency() returns the mean latency since the previous call", func() {
+			So(a.latency(key), ShouldEqual, 0)
+			st.record(core.CollectMetricsCall, 10*time.Millisecond, nil, 1, 1)
+			st.record(core.CollectMetricsCall, 30*time.Millisecond, nil, 1, 1)
+			st.record(core.PingCall, time.Second, nil, 0, 0)
+			So(a.latency(key), ShouldEqual, 20*time.Millisecond)
+			st.record(core.ProcessCall, 50*time.Millisecond, nil, 1, 1)
+			So(a.latency(key), ShouldEqual, 50*time.Millisecond)
+			So(a.latency(key), ShouldEqual, 0)
+		})
+	})
+}
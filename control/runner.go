@@ -72,6 +72,7 @@ type runner struct {
 	delegates         []gomit.Delegator
 	emitter           gomit.Emitter
 	monitor           *monitor
+	autoscaler        *autoscaler
 	availablePlugins  *availablePlugins
 	metricCatalog     catalogsMetrics
 	pluginManager     managesPlugins
@@ -85,6 +86,7 @@ func newRunner(opts ...pluginRunnerOpt) *runner {
 		monitor:           newMonitor(),
 		availablePlugins:  newAvailablePlugins(),
 	}
+	r.autoscaler = newAutoscaler(r)
 	mergedOpts := append([]pluginRunnerOpt{}, defaultRunnerOpts...)
 	mergedOpts = append(mergedOpts, opts...)
 	for _, opt := range append(mergedOpts) {
@@ -158,6 +160,7 @@ func (r *runner) Start() error {
 
 	// Start the monitor
 	r.monitor.Start(r.availablePlugins)
+	r.autoscaler.Start()
 	runnerLog.WithFields(log.Fields{
 		"_block": "start",
 	}).Debug("started")
@@ -170,6 +173,7 @@ func (r *runner) Stop() []error {
 
 	// Stop the monitor
 	r.monitor.Stop()
+	r.autoscaler.Stop()
 
 	// TODO: Actually stop the plugins
 
@@ -278,6 +282,7 @@ func (r *runner) HandleGomitEvent(e gomit.Event) {
 			"_block":  "handle-events",
 			"event":   v.Namespace(),
 			"aplugin": v.String,
+			"logs":    v.Logs,
 		}).Warning("handling dead available plugin event")
 
 		pool, err := r.availablePlugins.getPool(v.Key)
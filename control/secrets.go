@@ -0,0 +1,247 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+const (
+	secretFileScheme = "file:"
+	secretEnvScheme  = "env:"
+)
+
+var (
+	ErrUnknownSecretScheme = errors.New("unknown secret reference scheme")
+	ErrSecretNotFound      = errors.New("secret not found")
+	ErrSecretNotAllowed    = errors.New("secret reference not allowed by control.secrets")
+)
+
+// secrets limits what secret references may resolve to; it is set from the
+// config of control when it starts
+var secrets SecretsConfig
+
+// resolveSecret returns the value a secret reference points at.  References
+// take the form "file:/path/to/secret" or "env:NAME".  A file must be inside
+// the configured secrets directory and a variable must be allowed by name.
+func resolveSecret(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, secretFileScheme):
+		path, err := secretFilePath(strings.TrimPrefix(ref, secretFileScheme))
+		if err != nil {
+			return "", fmt.Errorf("%v: %s", err, ref)
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	case strings.HasPrefix(ref, secretEnvScheme):
+		name := strings.TrimPrefix(ref, secretEnvScheme)
+		if !secretEnvAllowed(name) {
+			return "", fmt.Errorf("%v: %s", ErrSecretNotAllowed, ref)
+		}
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("%v: %s", ErrSecretNotFound, ref)
+		}
+		return v, nil
+	}
+	return "", fmt.Errorf("%v: %s", ErrUnknownSecretScheme, ref)
+}
+
+// secretFilePath returns the path of a secret file once it is known to be
+// inside the secrets directory.  A relative path is taken relative to that
+// directory.  Paths holding ".." and symlinks leading out of the directory are
+// refused.
+func secretFilePath(path string) (string, error) {
+	if secrets.Dir == "" {
+		return "", ErrSecretNotAllowed
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(path), "/") {
+		if elem == ".." {
+			return "", ErrSecretNotAllowed
+		}
+	}
+	dir, err := filepath.Abs(secrets.Dir)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	if !inDir(dir, path) {
+		return "", ErrSecretNotAllowed
+	}
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrSecretNotFound
+		}
+		return "", err
+	}
+	if !inDir(realDir, realPath) {
+		return "", ErrSecretNotAllowed
+	}
+	return realPath, nil
+}
+
+// inDir returns true if path is below dir.  Both are clean absolute paths.
+func inDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// secretEnvAllowed returns true if an env reference may read the variable
+// name.
+func secretEnvAllowed(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, allowed := range secrets.Env {
+		if strings.HasSuffix(allowed, "*") {
+			if strings.HasPrefix(name, strings.TrimSuffix(allowed, "*")) {
+				return true
+			}
+			continue
+		}
+		if name == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSecrets returns true if the config table holds a secret reference.
+func hasSecrets(cfg map[string]ctypes.ConfigValue) bool {
+	for _, v := range cfg {
+		if _, ok := v.(ctypes.ConfigValueSecret); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveConfig replaces secret references in a config table with the values
+// they point at.  The table passed in is left untouched; it is only copied
+// when it holds at least one reference.
+func resolveConfig(cfg map[string]ctypes.ConfigValue) (map[string]ctypes.ConfigValue, error) {
+	if !hasSecrets(cfg) {
+		return cfg, nil
+	}
+	resolved := make(map[string]ctypes.ConfigValue, len(cfg))
+	for k, v := range cfg {
+		s, ok := v.(ctypes.ConfigValueSecret)
+		if !ok {
+			resolved[k] = v
+			continue
+		}
+		val, err := resolveSecret(s.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("config item %s: %v", k, err)
+		}
+		resolved[k] = ctypes.ConfigValueStr{Value: val}
+	}
+	return resolved, nil
+}
+
+// resolveConfigNode is resolveConfig for a ConfigDataNode.
+func resolveConfigNode(node *cdata.ConfigDataNode) (*cdata.ConfigDataNode, error) {
+	if node == nil {
+		return nil, nil
+	}
+	table := node.Table()
+	if !hasSecrets(table) {
+		return node, nil
+	}
+	resolved, err := resolveConfig(table)
+	if err != nil {
+		return nil, err
+	}
+	return cdata.FromTable(resolved), nil
+}
+
+// resolvedMetric overrides the config of a metric with its resolved copy so
+// the metric held by the task is never modified.
+type resolvedMetric struct {
+	core.Metric
+	config *cdata.ConfigDataNode
+}
+
+func (r resolvedMetric) Config() *cdata.ConfigDataNode {
+	return r.config
+}
+
+// resolveMetricsConfig resolves the secret references in the config of each
+// metric right before the metrics are handed to a plugin.
+func resolveMetricsConfig(mts []core.Metric) ([]core.Metric, error) {
+	var out []core.Metric
+	for i, m := range mts {
+		cfg := m.Config()
+		resolved, err := resolveConfigNode(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if resolved == cfg {
+			if out != nil {
+				out[i] = m
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]core.Metric, len(mts))
+			copy(out, mts[:i])
+		}
+		out[i] = resolvedMetric{Metric: m, config: resolved}
+	}
+	if out == nil {
+		return mts, nil
+	}
+	return out, nil
+}
+
+// markSensitiveKeys records the keys a plugin's config policy marks as
+// sensitive so their values are redacted wherever config is shown.
+func markSensitiveKeys(cp *cpolicy.ConfigPolicy) {
+	if cp == nil {
+		return
+	}
+	for _, node := range cp.GetAll() {
+		ctypes.MarkSensitive(node.SensitiveKeys()...)
+	}
+}
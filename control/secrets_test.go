@@ -0,0 +1,222 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/ctypes"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// allowSecrets sets the secrets policy for the length of a test and returns
+// a func restoring the previous one
+func allowSecrets(cfg SecretsConfig) func() {
+	prev := secrets
+	secrets = cfg
+	return func() { secrets = prev }
+}
+
+func TestResolveSecret(t *testing.T) {
+	Convey("Given a secrets directory", t, func() {
+		dir, err := ioutil.TempDir("", "snap-secrets")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		secretsDir := filepath.Join(dir, "secrets")
+		So(os.Mkdir(secretsDir, 0700), ShouldBeNil)
+		path := filepath.Join(secretsDir, "influx")
+		So(ioutil.WriteFile(path, []byte("s3cr3t\n"), 0600), ShouldBeNil)
+		outside := filepath.Join(dir, "outside")
+		So(ioutil.WriteFile(outside, []byte("private"), 0600), ShouldBeNil)
+		defer allowSecrets(SecretsConfig{Dir: secretsDir})()
+
+		Convey("a file reference resolves to its contents without the trailing newline", func() {
+			v, err := resolveSecret("file:" + path)
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "s3cr3t")
+		})
+		Convey("a relative file reference resolves inside the directory", func() {
+			v, err := resolveSecret("file:influx")
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "s3cr3t")
+		})
+		Convey("a missing file is an error", func() {
+			_, err := resolveSecret("file:" + filepath.Join(secretsDir, "missing"))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, ErrSecretNotFound.Error())
+		})
+		Convey("a file outside the directory is refused", func() {
+			_, err := resolveSecret("file:" + outside)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, ErrSecretNotAllowed.Error())
+		})
+		Convey("a path leaving the directory through .. is refused", func() {
+			for _, ref := range []string{
+				"file:../outside",
+				"file:" + secretsDir + "/../outside",
+				"file:" + secretsDir + "/sub/../influx",
+			} {
+				_, err := resolveSecret(ref)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, ErrSecretNotAllowed.Error())
+			}
+		})
+		Convey("the directory itself is refused", func() {
+			_, err := resolveSecret("file:" + secretsDir)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, ErrSecretNotAllowed.Error())
+		})
+		Convey("a symlink pointing outside the directory is refused", func() {
+			So(os.Symlink(outside, filepath.Join(secretsDir, "link")), ShouldBeNil)
+			_, err := resolveSecret("file:link")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, ErrSecretNotAllowed.Error())
+		})
+		Convey("a symlink pointing inside the directory resolves", func() {
+			So(os.Symlink(path, filepath.Join(secretsDir, "alias")), ShouldBeNil)
+			v, err := resolveSecret("file:alias")
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "s3cr3t")
+		})
+		Convey("file references are refused when no directory is configured", func() {
+			defer allowSecrets(SecretsConfig{})()
+			_, err := resolveSecret("file:" + path)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, ErrSecretNotAllowed.Error())
+		})
+	})
+	Convey("Given a secret environment variable", t, func() {
+		os.Setenv("SNAP_TEST_SECRET", "hunter2")
+		defer os.Unsetenv("SNAP_TEST_SECRET")
+		os.Setenv("SNAP_TEST_PRIVATE", "private")
+		defer os.Unsetenv("SNAP_TEST_PRIVATE")
+
+		Convey("an allowed variable resolves to its value", func() {
+			defer allowSecrets(SecretsConfig{Env: []string{"SNAP_TEST_SECRET"}})()
+			v, err := resolveSecret("env:SNAP_TEST_SECRET")
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "hunter2")
+		})
+		Convey("a variable matching an allowed prefix resolves to its value", func() {
+			defer allowSecrets(SecretsConfig{Env: []string{"SNAP_TEST_SECRET*"}})()
+			v, err := resolveSecret("env:SNAP_TEST_SECRET")
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, "hunter2")
+
+			_, err = resolveSecret("env:SNAP_TEST_SECRET_UNSET")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, ErrSecretNotFound.Error())
+		})
+		Convey("a variable which is not allowed is refused", func() {
+			defer allowSecrets(SecretsConfig{Env: []string{"SNAP_TEST_SECRET*"}})()
+			_, err := resolveSecret("env:SNAP_TEST_PRIVATE")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, ErrSecretNotAllowed.Error())
+		})
+		Convey("env references are refused when no variable is allowed", func() {
+			defer allowSecrets(SecretsConfig{})()
+			_, err := resolveSecret("env:SNAP_TEST_SECRET")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, ErrSecretNotAllowed.Error())
+		})
+	})
+	Convey("An unknown scheme is an error", t, func() {
+		_, err := resolveSecret("vault:secret/influx")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, ErrUnknownSecretScheme.Error())
+	})
+}
+
+func TestResolveConfig(t *testing.T) {
+	os.Setenv("SNAP_TEST_SECRET", "hunter2")
+	defer os.Unsetenv("SNAP_TEST_SECRET")
+	defer allowSecrets(SecretsConfig{Env: []string{"SNAP_TEST_SECRET*"}})()
+
+	Convey("A config without secrets is returned as is", t, func() {
+		cfg := map[string]ctypes.ConfigValue{"user": ctypes.ConfigValueStr{Value: "root"}}
+		resolved, err := resolveConfig(cfg)
+		So(err, ShouldBeNil)
+		So(resolved, ShouldEqual, cfg)
+	})
+	Convey("Secret references are resolved in a copy of the config", t, func() {
+		cfg := map[string]ctypes.ConfigValue{
+			"user":     ctypes.ConfigValueStr{Value: "root"},
+			"password": ctypes.ConfigValueSecret{Ref: "env:SNAP_TEST_SECRET"},
+		}
+		resolved, err := resolveConfig(cfg)
+		So(err, ShouldBeNil)
+		So(resolved["user"], ShouldResemble, ctypes.ConfigValueStr{Value: "root"})
+		So(resolved["password"], ShouldResemble, ctypes.ConfigValueStr{Value: "hunter2"})
+		So(cfg["password"], ShouldResemble, ctypes.ConfigValueSecret{Ref: "env:SNAP_TEST_SECRET"})
+	})
+	Convey("A reference that cannot be resolved names the config item", t, func() {
+		cfg := map[string]ctypes.ConfigValue{
+			"password": ctypes.ConfigValueSecret{Ref: "env:SNAP_TEST_SECRET_UNSET"},
+		}
+		_, err := resolveConfig(cfg)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "password")
+	})
+	Convey("Metrics are wrapped only when their config holds secrets", t, func() {
+		plain := plugin.MetricType{
+			Namespace_: core.NewNamespace("intel", "mock", "foo"),
+			Config_:    cdata.NewNode(),
+		}
+		node := cdata.NewNode()
+		node.AddItem("password", ctypes.ConfigValueSecret{Ref: "env:SNAP_TEST_SECRET"})
+		secret := plugin.MetricType{
+			Namespace_: core.NewNamespace("intel", "mock", "bar"),
+			Config_:    node,
+		}
+		mts, err := resolveMetricsConfig([]core.Metric{plain, secret})
+		So(err, ShouldBeNil)
+		So(mts, ShouldHaveLength, 2)
+		So(mts[0], ShouldResemble, plain)
+		So(mts[1].Namespace(), ShouldResemble, secret.Namespace())
+		So(mts[1].Config().Table()["password"], ShouldResemble, ctypes.ConfigValueStr{Value: "hunter2"})
+		So(secret.Config().Table()["password"], ShouldResemble, ctypes.ConfigValueSecret{Ref: "env:SNAP_TEST_SECRET"})
+	})
+}
+
+func TestMarkSensitiveKeys(t *testing.T) {
+	Convey("Keys marked sensitive by a config policy are redacted", t, func() {
+		r, err := cpolicy.NewStringRule("api_token", true)
+		So(err, ShouldBeNil)
+		r.SetSensitive(true)
+		node := cpolicy.NewPolicyNode()
+		node.Add(r)
+		cp := cpolicy.New()
+		cp.Add([]string{"intel", "mock"}, node)
+
+		markSensitiveKeys(cp)
+		So(ctypes.IsSensitive("api_token"), ShouldBeTrue)
+		So(ctypes.Redact("api_token", ctypes.ConfigValueStr{Value: "abc"}), ShouldResemble, ctypes.ConfigValueStr{Value: ctypes.Redacted})
+	})
+}
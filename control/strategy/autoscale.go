@@ -0,0 +1,167 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"fmt"
+	"time"
+)
+
+// AutoscalePolicy defines when the autoscaler grows and shrinks plugin pools
+type AutoscalePolicy struct {
+	// Enabled turns the autoscaler on
+	Enabled bool
+	// MaxInFlight is the mean number of calls in flight per running instance
+	// above which the pool grows. Zero disables this threshold.
+	MaxInFlight int
+	// MaxLatency is the mean call latency above which the pool grows.
+	// Zero disables this threshold.
+	MaxLatency time.Duration
+	// CoolDown is the minimum time between two scaling decisions for a pool,
+	// and how long a pool must stay under its thresholds before an idle
+	// instance is reaped.
+	CoolDown time.Duration
+}
+
+// Autoscaling is the policy applied to every plugin pool.
+// It is initialized at runtime via the control configuration.
+var Autoscaling = AutoscalePolicy{}
+
+// ScaleAction is the action decided by the autoscaler for a pool
+type ScaleAction int
+
+const (
+	// ScaleNone leaves the pool unchanged
+	ScaleNone ScaleAction = iota
+	// ScaleUp starts a new instance of the plugin
+	ScaleUp
+	// ScaleDown reaps an idle instance of the plugin
+	ScaleDown
+)
+
+var scaleActions = [...]string{
+	"none",
+	"up",
+	"down",
+}
+
+// String returns the name of the action
+func (s ScaleAction) String() string {
+	return scaleActions[s]
+}
+
+// ScaleDecision is the outcome of evaluating a pool against the autoscale policy
+type ScaleDecision struct {
+	Action ScaleAction
+	Reason string
+	// ID is the id of the instance to reap when scaling down
+	ID uint32
+}
+
+// autoscalable returns whether the routing strategy of the pool lets
+// requests move between instances. Sticky and config-based pools bind
+// each task or config to its own instance, so extra instances would stay unused.
+func (p *pool) autoscalable() bool {
+	if p.RoutingAndCaching == nil {
+		return false
+	}
+	switch p.Strategy().String() {
+	case "least-recently-used", "least-outstanding-requests", "consistent-hash":
+		return true
+	}
+	return false
+}
+
+// minInstances returns the number of instances needed to serve the
+// subscriptions of the pool without the autoscaler
+func (p *pool) minInstances() int {
+	cc := p.concurrencyCount
+	if cc < 1 {
+		cc = 1
+	}
+	n := (len(p.subs) + cc - 1) / cc
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// Autoscale evaluates the load of the pool against the autoscale policy.
+// latency is the mean latency of the calls made to the pool since the
+// previous evaluation. The pool never grows past its maximum size, which
+// is 1 for exclusive plugins and MaximumRunningPlugins otherwise.
+func (p *pool) Autoscale(latency time.Duration) ScaleDecision {
+	p.Lock()
+	defer p.Unlock()
+
+	policy := Autoscaling
+	if !policy.Enabled || !p.autoscalable() || len(p.plugins) == 0 || len(p.subs) == 0 {
+		return ScaleDecision{Action: ScaleNone}
+	}
+	now := time.Now()
+
+	inFlight := 0
+	for _, ap := range p.plugins {
+		inFlight += ap.InFlight()
+	}
+	var reason string
+	if policy.MaxInFlight > 0 && inFlight > policy.MaxInFlight*len(p.plugins) {
+		reason = fmt.Sprintf("%d calls in flight on %d instances", inFlight, len(p.plugins))
+	} else if policy.MaxLatency > 0 && latency > policy.MaxLatency {
+		reason = fmt.Sprintf("mean latency of %v above %v", latency, policy.MaxLatency)
+	}
+	if reason != "" {
+		p.lastBusy = now
+	}
+	if now.Sub(p.lastScale) < policy.CoolDown {
+		return ScaleDecision{Action: ScaleNone}
+	}
+
+	if reason != "" {
+		if len(p.plugins) >= p.max {
+			return ScaleDecision{Action: ScaleNone}
+		}
+		p.lastScale = now
+		return ScaleDecision{Action: ScaleUp, Reason: reason}
+	}
+
+	if len(p.plugins) <= p.minInstances() || now.Sub(p.lastBusy) < policy.CoolDown {
+		return ScaleDecision{Action: ScaleNone}
+	}
+	// reap the least recently used instance without calls in flight
+	var idle AvailablePlugin
+	for _, ap := range p.plugins {
+		if ap.InFlight() > 0 || now.Sub(ap.LastHit()) < policy.CoolDown {
+			continue
+		}
+		if idle == nil || ap.LastHit().Before(idle.LastHit()) {
+			idle = ap
+		}
+	}
+	if idle == nil {
+		return ScaleDecision{Action: ScaleNone}
+	}
+	p.lastScale = now
+	return ScaleDecision{
+		Action: ScaleDown,
+		Reason: fmt.Sprintf("idle for more than %v", policy.CoolDown),
+		ID:     idle.ID(),
+	}
+}
@@ -0,0 +1,125 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	. "github.com/intelsdi-x/snap/control/strategy/fixtures"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPoolAutoscale(t *testing.T) {
+	defaultPolicy := Autoscaling
+	defer func() { Autoscaling = defaultPolicy }()
+
+	Convey("Given an autoscale policy", t, func() {
+		Autoscaling = AutoscalePolicy{
+			Enabled:     true,
+			MaxInFlight: 2,
+			MaxLatency:  100 * time.Millisecond,
+			CoolDown:    time.Minute,
+		}
+		recent := time.Now()
+		old := time.Now().Add(-time.Hour)
+
+		Convey("A pool without subscriptions is left unchanged", func() {
+			p1 := NewMockAvailablePlugin().WithID(1).WithInFlight(10)
+			pool, _ := NewPool(p1.String(), p1)
+			So(pool.Autoscale(0).Action, ShouldEqual, ScaleNone)
+		})
+		Convey("A pool grows when there are too many calls in flight", func() {
+			p1 := NewMockAvailablePlugin().WithID(1).WithInFlight(3)
+			pool, _ := NewPool(p1.String(), p1)
+			pool.Subscribe("task1")
+			d := pool.Autoscale(0)
+			So(d.Action, ShouldEqual, ScaleUp)
+			So(d.Reason, ShouldContainSubstring, "in flight")
+			Convey("but not again during the cool-down", func() {
+				So(pool.Autoscale(0).Action, ShouldEqual, ScaleNone)
+			})
+		})
+		Convey("A pool grows when calls are too slow", func() {
+			p1 := NewMockAvailablePlugin().WithID(1)
+			pool, _ := NewPool(p1.String(), p1)
+			pool.Subscribe("task1")
+			So(pool.Autoscale(50*time.Millisecond).Action, ShouldEqual, ScaleNone)
+			d := pool.Autoscale(200 * time.Millisecond)
+			So(d.Action, ShouldEqual, ScaleUp)
+			So(d.Reason, ShouldContainSubstring, "latency")
+		})
+		Convey("A pool does not grow past MaximumRunningPlugins", func() {
+			plugins := []AvailablePlugin{}
+			for i := 1; i <= MaximumRunningPlugins; i++ {
+				plugins = append(plugins, NewMockAvailablePlugin().WithID(uint32(i)).WithInFlight(5))
+			}
+			pool, _ := NewPool(plugins[0].String(), plugins...)
+			pool.Subscribe("task1")
+			So(pool.Autoscale(time.Second).Action, ShouldEqual, ScaleNone)
+		})
+		Convey("An exclusive plugin is never scaled", func() {
+			p1 := NewMockAvailablePlugin().WithID(1).WithInFlight(5).WithExclusive(true)
+			pool, _ := NewPool(p1.String(), p1)
+			pool.Subscribe("task1")
+			So(pool.Autoscale(time.Second).Action, ShouldEqual, ScaleNone)
+		})
+		Convey("A sticky pool is never scaled", func() {
+			p1 := NewMockAvailablePlugin().WithID(1).WithInFlight(5).WithStrategy(plugin.StickyRouting)
+			pool, _ := NewPool(p1.String(), p1)
+			pool.Subscribe("task1")
+			So(pool.Autoscale(time.Second).Action, ShouldEqual, ScaleNone)
+		})
+		Convey("An idle instance is reaped once the pool is no longer busy", func() {
+			p1 := NewMockAvailablePlugin().WithID(1).WithLastHit(recent)
+			p2 := NewMockAvailablePlugin().WithID(2).WithLastHit(old)
+			pool, _ := NewPool(p1.String(), p1, p2)
+			pool.Subscribe("task1")
+			d := pool.Autoscale(0)
+			So(d.Action, ShouldEqual, ScaleDown)
+			So(d.ID, ShouldEqual, 2)
+		})
+		Convey("Instances serving calls are not reaped", func() {
+			p1 := NewMockAvailablePlugin().WithID(1).WithLastHit(recent)
+			p2 := NewMockAvailablePlugin().WithID(2).WithLastHit(old).WithInFlight(1)
+			pool, _ := NewPool(p1.String(), p1, p2)
+			pool.Subscribe("task1")
+			So(pool.Autoscale(0).Action, ShouldEqual, ScaleNone)
+		})
+		Convey("Instances needed by the subscriptions are not reaped", func() {
+			p1 := NewMockAvailablePlugin().WithID(1).WithLastHit(old)
+			p2 := NewMockAvailablePlugin().WithID(2).WithLastHit(old)
+			pool, _ := NewPool(p1.String(), p1, p2)
+			pool.Subscribe("task1")
+			pool.Subscribe("task2")
+			So(pool.Autoscale(0).Action, ShouldEqual, ScaleNone)
+		})
+		Convey("Nothing is done while the autoscaler is disabled", func() {
+			Autoscaling.Enabled = false
+			p1 := NewMockAvailablePlugin().WithID(1).WithInFlight(5)
+			pool, _ := NewPool(p1.String(), p1)
+			pool.Subscribe("task1")
+			So(pool.Autoscale(time.Second).Action, ShouldEqual, ScaleNone)
+		})
+	})
+}
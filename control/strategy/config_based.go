@@ -51,7 +51,11 @@ func NewConfigBased(cacheTTL time.Duration) *configBased {
 // Select selects an available plugin using the config based plugin strategy.
 func (cb *configBased) Select(aps []AvailablePlugin, id string) (AvailablePlugin, error) {
 	if ap, ok := cb.plugins[id]; ok && ap != nil {
-		return ap, nil
+		if containsAP(aps, ap) {
+			return ap, nil
+		}
+		// the instance is gone or failing, the id moves to another one
+		delete(cb.plugins, id)
 	}
 
 	// add first one in case it's new id
@@ -0,0 +1,219 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/snap/core"
+)
+
+// ConsistentHashReplicas is the number of points each available plugin
+// takes on the hash ring. More points spread the keys more evenly.
+var ConsistentHashReplicas = 64
+
+// consistentHash provides a strategy that selects the available plugin
+// owning the hash of the given id on a hash ring. Adding or removing an
+// available plugin only moves the ids it owns on the ring.
+type consistentHash struct {
+	ring        hashRing
+	members     []uint32
+	metricCache map[string]*cache
+	logger      *log.Entry
+	cacheTTL    time.Duration
+}
+
+type ringPoint struct {
+	hash uint32
+	ap   AvailablePlugin
+}
+
+// hashRing is a list of ring points sorted by hash
+type hashRing []ringPoint
+
+func (r hashRing) Len() int           { return len(r) }
+func (r hashRing) Less(i, j int) bool { return r[i].hash < r[j].hash }
+func (r hashRing) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+type pluginIDs []uint32
+
+func (p pluginIDs) Len() int           { return len(p) }
+func (p pluginIDs) Less(i, j int) bool { return p[i] < p[j] }
+func (p pluginIDs) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+func NewConsistentHash(cacheTTL time.Duration) *consistentHash {
+	return &consistentHash{
+		metricCache: make(map[string]*cache),
+		cacheTTL:    cacheTTL,
+		logger: log.WithFields(log.Fields{
+			"_module": "control-routing",
+		}),
+	}
+}
+
+// String returns the strategy name.
+func (c *consistentHash) String() string {
+	return "consistent-hash"
+}
+
+// CacheTTL returns the TTL for the cache.
+func (c *consistentHash) CacheTTL(taskID string) (time.Duration, error) {
+	return c.cacheTTL, nil
+}
+
+// Select selects an available plugin using the consistent-hash strategy.
+func (c *consistentHash) Select(aps []AvailablePlugin, id string) (AvailablePlugin, error) {
+	if len(aps) == 0 {
+		c.logger.WithFields(log.Fields{
+			"_block":   "select",
+			"strategy": c.String(),
+			"error":    ErrCouldNotSelect,
+		}).Error("error selecting")
+		return nil, ErrCouldNotSelect
+	}
+	c.updateRing(aps)
+	h := hashOf(id)
+	i := sort.Search(len(c.ring), func(i int) bool {
+		return c.ring[i].hash >= h
+	})
+	if i == len(c.ring) {
+		i = 0
+	}
+	ap := c.ring[i].ap
+	c.logger.WithFields(log.Fields{
+		"_block":    "select",
+		"strategy":  c.String(),
+		"pool size": len(aps),
+		"index":     ap.String(),
+		"id":        ap.ID(),
+	}).Debug("plugin selected")
+	return ap, nil
+}
+
+// Remove selects a plugin and removes the cache of the given id
+func (c *consistentHash) Remove(aps []AvailablePlugin, id string) (AvailablePlugin, error) {
+	ap, err := c.Select(aps, id)
+	if err != nil {
+		return nil, err
+	}
+	delete(c.metricCache, id)
+	return ap, nil
+}
+
+// updateRing rebuilds the hash ring when the available plugins have changed
+func (c *consistentHash) updateRing(aps []AvailablePlugin) {
+	ids := make(pluginIDs, len(aps))
+	for i, ap := range aps {
+		ids[i] = ap.ID()
+	}
+	sort.Sort(ids)
+	if len(ids) == len(c.members) {
+		same := true
+		for i := range ids {
+			if ids[i] != c.members[i] {
+				same = false
+				break
+			}
+		}
+		if same {
+			return
+		}
+	}
+	ring := make(hashRing, 0, len(aps)*ConsistentHashReplicas)
+	for _, ap := range aps {
+		for r := 0; r < ConsistentHashReplicas; r++ {
+			ring = append(ring, ringPoint{
+				hash: hashOf(fmt.Sprintf("%d-%d", ap.ID(), r)),
+				ap:   ap,
+			})
+		}
+	}
+	sort.Sort(ring)
+	c.ring = ring
+	c.members = ids
+	c.logger.WithFields(log.Fields{
+		"_block":    "update-ring",
+		"strategy":  c.String(),
+		"pool size": len(aps),
+	}).Debug("hash ring rebuilt")
+}
+
+func hashOf(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
+
+// checkCache checks the cache for metric types.
+// returns:
+//  - array of metrics that need to be collected
+//  - array of metrics that were returned from the cache
+func (c *consistentHash) CheckCache(mts []core.Metric, id string) ([]core.Metric, []core.Metric) {
+	if _, ok := c.metricCache[id]; !ok {
+		c.metricCache[id] = NewCache(c.cacheTTL)
+	}
+	return c.metricCache[id].checkCache(mts)
+}
+
+// updateCache updates the cache with the given array of metrics.
+func (c *consistentHash) UpdateCache(mts []core.Metric, id string) {
+	if _, ok := c.metricCache[id]; !ok {
+		c.metricCache[id] = NewCache(c.cacheTTL)
+	}
+	c.metricCache[id].updateCache(mts)
+}
+
+// AllCacheHits returns cache hits across all metrics.
+func (c *consistentHash) AllCacheHits() uint64 {
+	var total uint64
+	for _, cache := range c.metricCache {
+		total += cache.allCacheHits()
+	}
+	return total
+}
+
+// AllCacheMisses returns cache misses across all metrics.
+func (c *consistentHash) AllCacheMisses() uint64 {
+	var total uint64
+	for _, cache := range c.metricCache {
+		total += cache.allCacheMisses()
+	}
+	return total
+}
+
+// CacheHits returns the cache hits for a given metric namespace and version.
+func (c *consistentHash) CacheHits(ns string, version int, id string) (uint64, error) {
+	if cache, ok := c.metricCache[id]; ok {
+		return cache.cacheHits(ns, version)
+	}
+	return 0, ErrCacheDoesNotExist
+}
+
+// CacheMisses returns the cache misses for a given metric namespace and version.
+func (c *consistentHash) CacheMisses(ns string, version int, id string) (uint64, error) {
+	if cache, ok := c.metricCache[id]; ok {
+		return cache.cacheMisses(ns, version)
+	}
+	return 0, ErrCacheDoesNotExist
+}
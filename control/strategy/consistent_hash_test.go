@@ -0,0 +1,91 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/intelsdi-x/snap/control/strategy/fixtures"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConsistentHashRouter(t *testing.T) {
+	Convey("Given a consistent-hash router", t, func() {
+		router := NewConsistentHash(100 * time.Millisecond)
+		So(router, ShouldNotBeNil)
+		So(router.String(), ShouldEqual, "consistent-hash")
+		p1 := NewMockAvailablePlugin().WithID(1)
+		p2 := NewMockAvailablePlugin().WithID(2)
+		p3 := NewMockAvailablePlugin().WithID(3)
+		Convey("The same id is routed to the same plugin", func() {
+			sp1, err := router.Select([]AvailablePlugin{p1, p2, p3}, "task1")
+			So(err, ShouldBeNil)
+			So(sp1, ShouldNotBeNil)
+			// the order of the plugins does not matter
+			sp2, err := router.Select([]AvailablePlugin{p3, p1, p2}, "task1")
+			So(err, ShouldBeNil)
+			So(sp2, ShouldEqual, sp1)
+		})
+		Convey("Ids are spread over every plugin", func() {
+			selected := map[uint32]int{}
+			for i := 0; i < 300; i++ {
+				sp, err := router.Select([]AvailablePlugin{p1, p2, p3}, fmt.Sprintf("task%d", i))
+				So(err, ShouldBeNil)
+				selected[sp.ID()]++
+			}
+			So(selected, ShouldHaveLength, 3)
+		})
+		Convey("Only the ids of a removed plugin are moved", func() {
+			before := map[string]AvailablePlugin{}
+			for i := 0; i < 100; i++ {
+				id := fmt.Sprintf("task%d", i)
+				before[id], _ = router.Select([]AvailablePlugin{p1, p2, p3}, id)
+			}
+			for id, ap := range before {
+				sp, err := router.Select([]AvailablePlugin{p1, p2}, id)
+				So(err, ShouldBeNil)
+				if ap != AvailablePlugin(p3) {
+					So(sp, ShouldEqual, ap)
+				} else {
+					So(sp, ShouldNotEqual, p3)
+				}
+			}
+		})
+		Convey("Remove drops the cache of the id", func() {
+			router.UpdateCache(nil, "task1")
+			_, err := router.CacheHits("ns", 1, "task1")
+			So(err, ShouldNotEqual, ErrCacheDoesNotExist)
+			sp, err := router.Remove([]AvailablePlugin{p1, p2, p3}, "task1")
+			So(err, ShouldBeNil)
+			So(sp, ShouldNotBeNil)
+			_, err = router.CacheHits("ns", 1, "task1")
+			So(err, ShouldEqual, ErrCacheDoesNotExist)
+		})
+		Convey("Select a plugin when there are NONE available", func() {
+			sp, err := router.Select([]AvailablePlugin{}, "task1")
+			So(sp, ShouldBeNil)
+			So(err, ShouldEqual, ErrCouldNotSelect)
+		})
+	})
+}
@@ -60,6 +60,9 @@ type MockAvailablePlugin struct {
 	version    int
 	port       string
 	isRemote   bool
+	routingKey string
+	inFlight   int
+	breaker    core.CircuitBreakerStatus
 }
 
 func NewMockAvailablePlugin() *MockAvailablePlugin {
@@ -76,6 +79,7 @@ func NewMockAvailablePlugin() *MockAvailablePlugin {
 		version:    version,
 		port:       port,
 		isRemote:   remote,
+		breaker:    core.CircuitBreakerStatus{State: "closed", Accepting: true},
 	}
 	return mock
 }
@@ -130,6 +134,21 @@ func (m *MockAvailablePlugin) WithVersion(ver int) *MockAvailablePlugin {
 	return m
 }
 
+func (m *MockAvailablePlugin) WithRoutingKey(key string) *MockAvailablePlugin {
+	m.routingKey = key
+	return m
+}
+
+func (m *MockAvailablePlugin) WithInFlight(n int) *MockAvailablePlugin {
+	m.inFlight = n
+	return m
+}
+
+func (m *MockAvailablePlugin) WithCircuitBreaker(status core.CircuitBreakerStatus) *MockAvailablePlugin {
+	m.breaker = status
+	return m
+}
+
 func (m MockAvailablePlugin) HitCount() int {
 	return m.hitCount
 }
@@ -172,6 +191,14 @@ func (m MockAvailablePlugin) RoutingStrategy() plugin.RoutingStrategyType {
 	return m.strategy
 }
 
+func (m MockAvailablePlugin) RoutingKey() string {
+	return m.routingKey
+}
+
+func (m MockAvailablePlugin) InFlight() int {
+	return m.inFlight
+}
+
 func (m MockAvailablePlugin) SetID(id uint32) {
 	m.id = id
 }
@@ -196,6 +223,10 @@ func (m MockAvailablePlugin) Port() string {
 	return m.port
 }
 
+func (m MockAvailablePlugin) CircuitBreaker() core.CircuitBreakerStatus {
+	return m.breaker
+}
+
 func (m MockAvailablePlugin) IsRemote() bool {
 	return m.isRemote
 }
@@ -0,0 +1,121 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/snap/core"
+)
+
+// leastOutstanding provides a strategy that selects the available plugin
+// with the fewest calls in flight.
+type leastOutstanding struct {
+	*cache
+	logger *log.Entry
+}
+
+func NewLeastOutstanding(cacheTTL time.Duration) *leastOutstanding {
+	return &leastOutstanding{
+		NewCache(cacheTTL),
+		log.WithFields(log.Fields{
+			"_module": "control-routing",
+		}),
+	}
+}
+
+// String returns the strategy name.
+func (l *leastOutstanding) String() string {
+	return "least-outstanding-requests"
+}
+
+// CacheTTL returns the TTL for the cache.
+func (l *leastOutstanding) CacheTTL(taskID string) (time.Duration, error) {
+	return l.ttl, nil
+}
+
+// Select selects an available plugin using the least-outstanding-requests strategy.
+// Ties are broken by selecting the least recently used plugin.
+func (l *leastOutstanding) Select(aps []AvailablePlugin, _ string) (AvailablePlugin, error) {
+	index := -1
+	for i, ap := range aps {
+		if index == -1 ||
+			ap.InFlight() < aps[index].InFlight() ||
+			(ap.InFlight() == aps[index].InFlight() && ap.LastHit().Before(aps[index].LastHit())) {
+			index = i
+		}
+	}
+	if index > -1 {
+		l.logger.WithFields(log.Fields{
+			"block":     "select",
+			"strategy":  l.String(),
+			"pool size": len(aps),
+			"index":     aps[index].String(),
+			"in-flight": aps[index].InFlight(),
+		}).Debug("plugin selected")
+		return aps[index], nil
+	}
+	l.logger.WithFields(log.Fields{
+		"block":    "select",
+		"strategy": l.String(),
+		"error":    ErrCouldNotSelect,
+	}).Error("error selecting")
+	return nil, ErrCouldNotSelect
+}
+
+// Remove selects a plugin
+// Since there is no state to cleanup we only need to return the selected plugin
+func (l *leastOutstanding) Remove(aps []AvailablePlugin, taskID string) (AvailablePlugin, error) {
+	return l.Select(aps, taskID)
+}
+
+// checkCache checks the cache for metric types.
+// returns:
+//  - array of metrics that need to be collected
+//  - array of metrics that were returned from the cache
+func (l *leastOutstanding) CheckCache(mts []core.Metric, _ string) ([]core.Metric, []core.Metric) {
+	return l.checkCache(mts)
+}
+
+// updateCache updates the cache with the given array of metrics.
+func (l *leastOutstanding) UpdateCache(mts []core.Metric, _ string) {
+	l.updateCache(mts)
+}
+
+// AllCacheHits returns cache hits across all metrics.
+func (l *leastOutstanding) AllCacheHits() uint64 {
+	return l.allCacheHits()
+}
+
+// AllCacheMisses returns cache misses across all metrics.
+func (l *leastOutstanding) AllCacheMisses() uint64 {
+	return l.allCacheMisses()
+}
+
+// CacheHits returns the cache hits for a given metric namespace and version.
+func (l *leastOutstanding) CacheHits(ns string, version int, _ string) (uint64, error) {
+	return l.cacheHits(ns, version)
+}
+
+// CacheMisses returns the cache misses for a given metric namespace and version.
+func (l *leastOutstanding) CacheMisses(ns string, version int, _ string) (uint64, error) {
+	return l.cacheMisses(ns, version)
+}
@@ -0,0 +1,60 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/intelsdi-x/snap/control/strategy/fixtures"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLeastOutstandingRouter(t *testing.T) {
+	Convey("Given a least-outstanding-requests router", t, func() {
+		router := NewLeastOutstanding(100 * time.Millisecond)
+		So(router, ShouldNotBeNil)
+		So(router.String(), ShouldEqual, "least-outstanding-requests")
+		Convey("Select the plugin with the fewest calls in flight", func() {
+			p1 := NewMockAvailablePlugin().WithID(1).WithInFlight(3)
+			p2 := NewMockAvailablePlugin().WithID(2).WithInFlight(1)
+			p3 := NewMockAvailablePlugin().WithID(3).WithInFlight(2)
+			sp, err := router.Select([]AvailablePlugin{p1, p2, p3}, "")
+			So(err, ShouldBeNil)
+			So(sp, ShouldEqual, p2)
+		})
+		Convey("Select the least recently used plugin on a tie", func() {
+			now := time.Now()
+			p1 := NewMockAvailablePlugin().WithID(1).WithInFlight(1).WithLastHit(now)
+			p2 := NewMockAvailablePlugin().WithID(2).WithInFlight(1).WithLastHit(now.Add(-time.Minute))
+			p3 := NewMockAvailablePlugin().WithID(3).WithInFlight(2).WithLastHit(now.Add(-time.Hour))
+			sp, err := router.Select([]AvailablePlugin{p1, p2, p3}, "")
+			So(err, ShouldBeNil)
+			So(sp, ShouldEqual, p2)
+		})
+		Convey("Select a plugin when there are NONE available", func() {
+			sp, err := router.Select([]AvailablePlugin{}, "")
+			So(sp, ShouldBeNil)
+			So(err, ShouldEqual, ErrCouldNotSelect)
+		})
+	})
+}
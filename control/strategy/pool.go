@@ -48,6 +48,9 @@ var (
 	ErrBadType     = errors.New("bad plugin type")
 	ErrBadStrategy = errors.New("bad strategy")
 	ErrPoolEmpty   = errors.New("plugin pool is empty")
+	// ErrAllCircuitsOpen is returned when the circuit breakers of all the
+	// instances of a plugin stop the calls to them
+	ErrAllCircuitsOpen = errors.New("all plugin instances are failing, circuit breakers open")
 )
 
 type Pool interface {
@@ -69,6 +72,7 @@ type Pool interface {
 	RestartCount() int
 	IncRestartCount()
 	KillAll(string)
+	Autoscale(latency time.Duration) ScaleDecision
 }
 
 type AvailablePlugin interface {
@@ -79,12 +83,15 @@ type AvailablePlugin interface {
 	Exclusive() bool
 	Kill(r string) error
 	RoutingStrategy() plugin.RoutingStrategyType
+	RoutingKey() string
+	InFlight() int
 	SetID(id uint32)
 	String() string
 	Type() plugin.PluginType
 	Stop(string) error
 	IsRemote() bool
 	SetIsRemote(bool)
+	CircuitBreaker() core.CircuitBreakerStatus
 }
 
 type subscription struct {
@@ -122,9 +129,19 @@ type pool struct {
 	// strategy RoutingAndCaching
 	RoutingAndCaching
 
+	// The config key hashed by the consistent-hash strategy.
+	// The task ID is hashed when it is empty.
+	routingKey string
+
 	// restartCount the restart count of available plugins
 	// when the DeadAvailablePluginEvent occurs
 	restartCount int
+
+	// lastScale is the time of the last autoscaling decision
+	lastScale time.Time
+	// lastBusy is the last time the pool was found over the
+	// autoscale thresholds
+	lastBusy time.Time
 }
 
 func NewPool(key string, plugins ...AvailablePlugin) (Pool, error) {
@@ -225,6 +242,11 @@ func (p *pool) applyPluginMeta(a AvailablePlugin) error {
 		p.concurrencyCount = 1
 	case plugin.ConfigRouting:
 		p.RoutingAndCaching = NewConfigBased(cacheTTL)
+	case plugin.LeastOutstandingRouting:
+		p.RoutingAndCaching = NewLeastOutstanding(cacheTTL)
+	case plugin.ConsistentHashRouting:
+		p.RoutingAndCaching = NewConsistentHash(cacheTTL)
+		p.routingKey = a.RoutingKey()
 	default:
 		return ErrBadStrategy
 	}
@@ -375,17 +397,37 @@ func (p *pool) SelectAP(taskID string, config map[string]ctypes.ConfigValue) (Av
 		id = taskID
 	case "config-based":
 		id = idFromCfg(config)
+	case "least-outstanding-requests":
+		id = ""
+	case "consistent-hash":
+		id = p.hashKey(taskID, config)
 	default:
 		return nil, serror.New(ErrBadStrategy)
 	}
 
-	ap, err := p.Select(aps, id)
+	accepting := acceptingAPs(aps)
+	if len(accepting) == 0 && len(aps) > 0 {
+		return nil, serror.New(ErrAllCircuitsOpen)
+	}
+	ap, err := p.Select(accepting, id)
 	if err != nil {
 		return nil, serror.New(err)
 	}
 	return ap, nil
 }
 
+// acceptingAPs returns the available plugins whose circuit breaker lets a
+// call through, so failing instances are routed around until they recover
+func acceptingAPs(aps []AvailablePlugin) []AvailablePlugin {
+	accepting := make([]AvailablePlugin, 0, len(aps))
+	for _, ap := range aps {
+		if ap.CircuitBreaker().Accepting {
+			accepting = append(accepting, ap)
+		}
+	}
+	return accepting
+}
+
 func idFromCfg(cfg map[string]ctypes.ConfigValue) string {
 	//TODO: check for nil map
 	var buff bytes.Buffer
@@ -397,6 +439,29 @@ func idFromCfg(cfg map[string]ctypes.ConfigValue) string {
 	return string(buff.Bytes())
 }
 
+// hashKey returns the value routed on by the consistent-hash strategy:
+// the value of the routing key in config if any, the task ID otherwise
+func (p *pool) hashKey(taskID string, cfg map[string]ctypes.ConfigValue) string {
+	if p.routingKey == "" {
+		return taskID
+	}
+	switch v := cfg[p.routingKey].(type) {
+	case ctypes.ConfigValueStr:
+		return v.Value
+	case ctypes.ConfigValueInt:
+		return strconv.Itoa(v.Value)
+	case ctypes.ConfigValueFloat:
+		return strconv.FormatFloat(v.Value, 'g', -1, 64)
+	case ctypes.ConfigValueBool:
+		return strconv.FormatBool(v.Value)
+	case ctypes.ConfigValueDuration:
+		return v.Value.String()
+	case ctypes.ConfigValueStrList:
+		return strings.Join(v.Value, ",")
+	}
+	return taskID
+}
+
 // generatePID returns the next available pid for the pool
 func (p *pool) generatePID() uint32 {
 	atomic.AddUint32(&p.pidCounter, 1)
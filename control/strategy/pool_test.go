@@ -289,3 +289,115 @@ func TestPoolSelectAPStickyRouter(t *testing.T) {
 		})
 	})
 }
+
+func TestPoolSelectAPLeastOutstandingRouter(t *testing.T) {
+	Convey("For plugins defined with least-outstanding-requests strategy", t, func() {
+		p1 := NewMockAvailablePlugin().WithStrategy(plugin.LeastOutstandingRouting).WithID(1).WithInFlight(2)
+		p2 := NewMockAvailablePlugin().WithStrategy(plugin.LeastOutstandingRouting).WithID(2).WithInFlight(0)
+		pool, err := NewPool(p1.String(), p1, p2)
+		So(err, ShouldBeNil)
+		So(pool.String(), ShouldEqual, plugin.LeastOutstandingRouting.String())
+
+		Convey("Then the plugin with the fewest calls in flight is selected", func() {
+			ap, err := pool.SelectAP("TaskID", nil)
+			So(err, ShouldBeNil)
+			So(ap, ShouldEqual, p2)
+		})
+	})
+}
+
+func TestPoolSelectAPConsistentHashRouter(t *testing.T) {
+	Convey("For plugins defined with consistent-hash strategy", t, func() {
+		plugins := []AvailablePlugin{}
+		for i := 1; i <= 3; i++ {
+			plugins = append(plugins, NewMockAvailablePlugin().WithStrategy(plugin.ConsistentHashRouting).WithID(uint32(i)))
+		}
+
+		Convey("Without a routing key, the task ID is hashed", func() {
+			pool, err := NewPool(plugins[0].String(), plugins...)
+			So(err, ShouldBeNil)
+			So(pool.String(), ShouldEqual, plugin.ConsistentHashRouting.String())
+
+			ap1, err := pool.SelectAP("TaskID", nil)
+			So(ap1, ShouldNotBeNil)
+			So(err, ShouldBeNil)
+			cfg := map[string]ctypes.ConfigValue{"host": ctypes.ConfigValueStr{"a"}}
+			ap2, err := pool.SelectAP("TaskID", cfg)
+			So(err, ShouldBeNil)
+			So(ap2, ShouldEqual, ap1)
+		})
+
+		Convey("With a routing key, the value of the key in the config is hashed", func() {
+			plugins[0] = NewMockAvailablePlugin().WithStrategy(plugin.ConsistentHashRouting).WithID(1).WithRoutingKey("host")
+			pool, err := NewPool(plugins[0].String(), plugins...)
+			So(err, ShouldBeNil)
+
+			for i := 0; i < 10; i++ {
+				cfg := map[string]ctypes.ConfigValue{"host": ctypes.ConfigValueStr{fmt.Sprintf("host%d", i)}}
+				ap1, err := pool.SelectAP("TaskID", cfg)
+				So(err, ShouldBeNil)
+				ap2, err := pool.SelectAP("AnotherTaskID", cfg)
+				So(err, ShouldBeNil)
+				So(ap2, ShouldEqual, ap1)
+			}
+
+			Convey("Falling back to the task ID when the key is missing", func() {
+				ap1, err := pool.SelectAP("TaskID", map[string]ctypes.ConfigValue{})
+				So(err, ShouldBeNil)
+				ap2, err := pool.SelectAP("TaskID", nil)
+				So(err, ShouldBeNil)
+				So(ap2, ShouldEqual, ap1)
+			})
+		})
+	})
+}
+
+func TestPoolSelectAPCircuitBreaker(t *testing.T) {
+	open := core.CircuitBreakerStatus{State: "open"}
+	for _, routing := range []plugin.RoutingStrategyType{plugin.DefaultRouting, plugin.StickyRouting} {
+		Convey(fmt.Sprintf("Given a pool with the %v strategy of an open and a closed instance", routing), t, func() {
+			failing := NewMockAvailablePlugin().WithStrategy(routing).WithID(1).WithLastHit(lastHitAt(1)).WithCircuitBreaker(open)
+			healthy := NewMockAvailablePlugin().WithStrategy(routing).WithID(2).WithLastHit(lastHitAt(2))
+			pool, err := NewPool(failing.String(), failing, healthy)
+			So(err, ShouldBeNil)
+
+			Convey("Then the closed instance is selected", func() {
+				for i := 0; i < 3; i++ {
+					ap, err := pool.SelectAP("TaskID", nil)
+					So(err, ShouldBeNil)
+					So(ap, ShouldEqual, healthy)
+				}
+			})
+			Convey("Then an error is returned when all the instances are open", func() {
+				healthy.WithCircuitBreaker(open)
+				ap, err := pool.SelectAP("TaskID", nil)
+				So(ap, ShouldBeNil)
+				So(err.Error(), ShouldEqual, ErrAllCircuitsOpen.Error())
+			})
+		})
+	}
+
+	Convey("Given a sticky task whose instance opens its circuit breaker", t, func() {
+		first := NewMockAvailablePlugin().WithStrategy(plugin.StickyRouting).WithID(1)
+		second := NewMockAvailablePlugin().WithStrategy(plugin.StickyRouting).WithID(2)
+		pool, err := NewPool(first.String(), first)
+		So(err, ShouldBeNil)
+		ap, serr := pool.SelectAP("TaskID", nil)
+		So(serr, ShouldBeNil)
+		So(ap, ShouldEqual, first)
+		So(pool.Insert(second), ShouldBeNil)
+
+		first.WithCircuitBreaker(core.CircuitBreakerStatus{State: "half-open"})
+		Convey("Then the task moves to another instance", func() {
+			ap, serr := pool.SelectAP("TaskID", nil)
+			So(serr, ShouldBeNil)
+			So(ap, ShouldEqual, second)
+		})
+	})
+}
+
+// lastHitAt returns the time of the last hit of a plugin, i seconds after
+// an arbitrary time
+func lastHitAt(i int) time.Time {
+	return time.Unix(1460027570+int64(i), 0)
+}
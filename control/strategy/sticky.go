@@ -55,7 +55,11 @@ func NewSticky(cacheTTL time.Duration) *sticky {
 // Select selects an available plugin using the sticky plugin strategy.
 func (s *sticky) Select(aps []AvailablePlugin, taskID string) (AvailablePlugin, error) {
 	if ap, ok := s.plugins[taskID]; ok && ap != nil {
-		return ap, nil
+		if containsAP(aps, ap) {
+			return ap, nil
+		}
+		// the instance is gone or failing, the task moves to another one
+		delete(s.plugins, taskID)
 	}
 	return s.selectPlugin(aps, taskID)
 }
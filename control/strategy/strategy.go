@@ -47,6 +47,16 @@ type RoutingAndCaching interface {
 	String() string
 }
 
+// containsAP returns true if ap is one of aps
+func containsAP(aps []AvailablePlugin, ap AvailablePlugin) bool {
+	for _, a := range aps {
+		if a == ap {
+			return true
+		}
+	}
+	return false
+}
+
 // Values returns slice of map values
 func (sm MapAvailablePlugin) Values() []AvailablePlugin {
 	values := []AvailablePlugin{}
@@ -25,6 +25,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/intelsdi-x/snap/control/plugin"
@@ -46,6 +48,10 @@ var (
 	ErrSubscriptionGroupDoesNotExist = core.ErrSubscriptionGroupDoesNotExist
 
 	ErrConfigRequiredForMetric = errors.New("config required")
+
+	// ErrPluginConfigRejected - error message when a change to the global
+	// plugin config is invalid for running task(s)
+	ErrPluginConfigRejected = errors.New("Plugin config is invalid for running task(s)")
 )
 
 // ManagesSubscriptionGroups is the interface implemented by an object that can
@@ -62,6 +68,7 @@ type ManagesSubscriptionGroups interface {
 		configTree *cdata.ConfigDataTree, asserts ...core.SubscribedPluginAssert) (serrs []serror.SnapError)
 	validateMetric(metric core.Metric) (serrs []serror.SnapError)
 	validatePluginUnloading(*loadedPlugin) (errs []serror.SnapError)
+	validatePluginConfig(*pluginConfig) []serror.SnapError
 }
 
 type subscriptionGroup struct {
@@ -268,6 +275,35 @@ func (s *subscriptionGroups) validatePluginUnloading(pluginToUnload *loadedPlugi
 	return errs
 }
 
+// validatePluginConfig checks if the global plugin config cfg is valid for
+// every running task. If it is not, the first error returned lists the ids of
+// the impacted tasks and is followed by the validation errors of each task.
+func (s *subscriptionGroups) validatePluginConfig(cfg *pluginConfig) []serror.SnapError {
+	s.Lock()
+	defer s.Unlock()
+	ids := make([]string, 0, len(s.subscriptionMap))
+	for id := range s.subscriptionMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	impacted := []string{}
+	errs := []serror.SnapError{}
+	for _, id := range ids {
+		if serrs := s.subscriptionMap[id].validatePluginConfig(id, cfg); len(serrs) > 0 {
+			impacted = append(impacted, id)
+			errs = append(errs, serrs...)
+		}
+	}
+	if len(impacted) == 0 {
+		return nil
+	}
+	serr := serror.New(ErrPluginConfigRejected, map[string]interface{}{
+		"task-ids": strings.Join(impacted, ","),
+	})
+	return append([]serror.SnapError{serr}, errs...)
+}
+
 func (p *subscriptionGroups) validatePluginSubscription(pl core.SubscribedPlugin, mergedConfig *cdata.ConfigDataNode) []serror.SnapError {
 	var serrs = []serror.SnapError{}
 	controlLogger.WithFields(log.Fields{
@@ -423,6 +459,64 @@ func (s *subscriptionGroup) validatePluginUnloading(id string, plgToUnload *load
 	return serr
 }
 
+// validatePluginConfig checks the metrics and plugins of the subscription
+// group against their config merged with the global plugin config cfg
+func (s *subscriptionGroup) validatePluginConfig(id string, cfg *pluginConfig) (serrs []serror.SnapError) {
+	for _, pmt := range s.metrics {
+		for _, m := range pmt.metricTypes {
+			mt, ok := m.(*metricType)
+			if !ok || !mt.policy.HasRules() {
+				continue
+			}
+			typ, err := core.ToPluginType(mt.Plugin.TypeName())
+			if err != nil {
+				serrs = append(serrs, serror.New(err))
+				continue
+			}
+			config := cdata.NewNode()
+			if node := s.configTree.Get(mt.Namespace().Strings()); node != nil {
+				config = node.ReverseMerge(config)
+			}
+			config.ApplyDefaults(cfg.getPluginConfigDataNode(typ,
+				mt.Plugin.Name(), mt.Plugin.Version()).Table())
+			_, errs := mt.policy.Process(config.Table())
+			if errs != nil && errs.HasErrors() {
+				for _, e := range errs.Errors() {
+					serrs = append(serrs, serror.New(e, map[string]interface{}{
+						"task-id": id,
+						"metric":  mt.Namespace().String(),
+						"version": mt.Version(),
+					}))
+				}
+			}
+		}
+	}
+	for _, plg := range s.plugins {
+		typ, err := core.ToPluginType(plg.TypeName())
+		if err != nil {
+			serrs = append(serrs, serror.New(err))
+			continue
+		}
+		lp, err := s.pluginManager.get(key(plg))
+		if err != nil || lp.ConfigPolicy == nil {
+			continue
+		}
+		config := plg.Config().ReverseMerge(
+			cfg.getPluginConfigDataNode(typ, plg.Name(), plg.Version()))
+		_, errs := lp.ConfigPolicy.Get([]string{""}).Process(config.Table())
+		if errs != nil && errs.HasErrors() {
+			for _, e := range errs.Errors() {
+				serrs = append(serrs, serror.New(e, map[string]interface{}{
+					"task-id": id,
+					"name":    plg.Name(),
+					"version": plg.Version(),
+				}))
+			}
+		}
+	}
+	return serrs
+}
+
 func (s *subscriptionGroup) process(id string) (serrs []serror.SnapError) {
 	// gathers collectors based on requested metrics
 	pluginToMetricMap, plugins, serrs := s.getMetricsAndCollectors(s.requestedMetrics, s.configTree)
@@ -539,6 +633,7 @@ func (s *subscriptionGroup) subscribePlugins(id string,
 					return serrs
 				}
 				ap.SetIsRemote(true)
+				ap.poolStats = s.pluginRunner.AvailablePlugins().statsFor(plg.Key())
 				err = pool.Insert(ap)
 				if err != nil {
 					serrs = append(serrs, serror.New(err))
@@ -27,6 +27,8 @@ import (
 	"path"
 	"testing"
 
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
 	"github.com/intelsdi-x/snap/core/control_event"
@@ -167,6 +169,60 @@ func TestSubscriptionGroups_Process_GlobalPluginConfig(t *testing.T) {
 	})
 }
 
+func TestSubscriptionGroups_ValidatePluginConfig(t *testing.T) {
+	Convey("Given a task collecting a metric which requires a password", t, func() {
+		c := New(getTestSGConfig())
+		c.Config.Plugins.All.AddItem("password", ctypes.ConfigValueStr{Value: "secret"})
+
+		rule, _ := cpolicy.NewStringRule("password", true)
+		policy := cpolicy.NewPolicyNode()
+		policy.Add(rule)
+		mt := &metricType{
+			Plugin: &catalogedPlugin{
+				name:     "mock",
+				version:  1,
+				typeName: plugin.CollectorPluginType,
+			},
+			namespace: core.NewNamespace("intel", "mock", "foo"),
+			policy:    policy,
+		}
+		sg := c.subscriptionGroups.(*subscriptionGroups)
+		sg.subscriptionMap["task-id"] = &subscriptionGroup{
+			pluginControl: c,
+			configTree:    cdata.NewTree(),
+			metrics: map[string]metricTypes{
+				"collector" + core.Separator + "mock" + core.Separator + "1": {
+					metricTypes: []core.Metric{mt},
+				},
+			},
+		}
+
+		Convey("A valid change to the global plugin config is applied", func() {
+			cdn := cdata.NewNode()
+			cdn.AddItem("password", ctypes.ConfigValueStr{Value: "changed"})
+			res, serrs := c.Config.MergePluginConfigDataNode(core.CollectorPluginType, "mock", 1, cdn)
+			So(serrs, ShouldBeNil)
+			So(res.Table()["password"], ShouldResemble, ctypes.ConfigValueStr{Value: "changed"})
+		})
+		Convey("An invalid change to the global plugin config is refused", func() {
+			cdn := cdata.NewNode()
+			cdn.AddItem("password", ctypes.ConfigValueInt{Value: 1234})
+			_, serrs := c.Config.MergePluginConfigDataNodeAll(cdn)
+			So(serrs, ShouldNotBeEmpty)
+			So(serrs[0].Error(), ShouldEqual, ErrPluginConfigRejected.Error())
+			So(serrs[0].Fields()["task-ids"], ShouldEqual, "task-id")
+			So(c.Config.Plugins.getPluginConfigDataNode(core.CollectorPluginType, "mock", 1).Table()["password"],
+				ShouldResemble, ctypes.ConfigValueStr{Value: "secret"})
+		})
+		Convey("Deleting a required global plugin config item is refused", func() {
+			_, serrs := c.Config.DeletePluginConfigDataNodeFieldAll("password")
+			So(serrs, ShouldNotBeEmpty)
+			So(serrs[0].Fields()["task-ids"], ShouldEqual, "task-id")
+			So(c.Config.Plugins.All.Table(), ShouldContainKey, "password")
+		})
+	})
+}
+
 func TestSubscriptionGroups_ProcessStaticNegative(t *testing.T) {
 	c := New(getTestSGConfig())
 
@@ -0,0 +1,213 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/ctypes"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/scheduler/wmap"
+)
+
+// workflowSourcePrefix prefixes the namespace a config key of the collect node
+// is set at, e.g. "workflow:/intel/psutil"
+const workflowSourcePrefix = core.ConfigSourceWorkflow + ":"
+
+// WorkflowConfig returns the config each metric and each process and publish
+// node of the workflow receives, with the layer every key is taken from.  The
+// metrics can be restricted to those matching the namespace query ns.
+func (p *pluginControl) WorkflowConfig(wf *wmap.WorkflowMap, ns core.Namespace) (core.WorkflowConfig, []serror.SnapError) {
+	var wc core.WorkflowConfig
+	if wf == nil || wf.Collect == nil {
+		return wc, []serror.SnapError{serror.New(fmt.Errorf("workflow has no collect node"))}
+	}
+	configTree, err := wf.Collect.GetConfigTree()
+	if err != nil {
+		return wc, []serror.SnapError{serror.New(err)}
+	}
+	var exclusions []core.Namespace
+	for _, m := range wf.Collect.GetExcludedMetrics() {
+		exclusions = append(exclusions, core.NewNamespace(m.Namespace()...))
+	}
+
+	var serrs []serror.SnapError
+	var mts []*metricType
+	expanded := map[string]bool{}
+	for _, r := range wf.Collect.GetMetrics() {
+		newMetrics, err := p.metricCatalog.GetMetrics(core.NewNamespace(r.Namespace()...), r.Version())
+		if err != nil {
+			serrs = append(serrs, serror.New(err))
+			continue
+		}
+		for _, mt := range newMetrics {
+			if isExcluded(mt.Namespace(), exclusions) || expanded[mt.Key()] {
+				continue
+			}
+			if len(ns) > 0 && !matchesQuery(mt.Namespace().Strings(), ns.Strings()) {
+				continue
+			}
+			expanded[mt.Key()] = true
+			mts = append(mts, mt)
+		}
+	}
+	if len(serrs) > 0 {
+		return wc, serrs
+	}
+	sort.Sort(metricTypesByNamespace(mts))
+
+	for _, mt := range mts {
+		typ, _ := core.ToPluginType(mt.Plugin.TypeName())
+		settings := configSettings{}
+		if policy := mt.Plugin.Policy().Get(mt.Namespace().Strings()); policy != nil {
+			settings.set(policy.Defaults(), core.ConfigSourcePolicy)
+		}
+		p.setPluginConfig(settings, typ, mt.Plugin.Name(), mt.Plugin.Version())
+		// the config of the collect node is merged along the namespace, so a
+		// key is taken from the longest prefix setting it
+		elements := mt.Namespace().Strings()
+		for i := 1; i <= len(elements); i++ {
+			if node := configTree.Get(elements[:i]); node != nil {
+				settings.update(node.Table(), workflowSourcePrefix+core.NewNamespace(elements[:i]...).String())
+			}
+		}
+		wc.Metrics = append(wc.Metrics, core.MetricConfig{
+			Namespace:     mt.Namespace(),
+			Version:       mt.Version(),
+			PluginName:    mt.Plugin.Name(),
+			PluginVersion: mt.Plugin.Version(),
+			Config:        settings.redacted(),
+		})
+	}
+
+	wc.Nodes, serrs = p.nodesConfig(wf.Collect.Process, wf.Collect.Publish, wc.Nodes)
+	return wc, serrs
+}
+
+// nodesConfig appends the config of the process and publish nodes, walking
+// the workflow depth first
+func (p *pluginControl) nodesConfig(prs []wmap.ProcessWorkflowMapNode, pus []wmap.PublishWorkflowMapNode, nodes []core.NodeConfig) ([]core.NodeConfig, []serror.SnapError) {
+	var serrs []serror.SnapError
+	for _, pr := range prs {
+		cfg, err := pr.GetConfigNode()
+		if err != nil {
+			serrs = append(serrs, serror.New(err))
+			continue
+		}
+		nodes = append(nodes, p.nodeConfig(core.ProcessorPluginType, pr.PluginName, pr.PluginVersion, cfg))
+		var errs []serror.SnapError
+		nodes, errs = p.nodesConfig(pr.Process, pr.Publish, nodes)
+		serrs = append(serrs, errs...)
+	}
+	for _, pu := range pus {
+		cfg, err := pu.GetConfigNode()
+		if err != nil {
+			serrs = append(serrs, serror.New(err))
+			continue
+		}
+		nodes = append(nodes, p.nodeConfig(core.PublisherPluginType, pu.PluginName, pu.PluginVersion, cfg))
+	}
+	return nodes, serrs
+}
+
+func (p *pluginControl) nodeConfig(typ core.PluginType, name string, ver int, cfg *cdata.ConfigDataNode) core.NodeConfig {
+	settings := configSettings{}
+	lp, err := p.pluginManager.get(fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", typ.String(), name, ver))
+	if err == nil {
+		// as when subscribing, the latest version is used if none is given
+		ver = lp.Version()
+		if lp.ConfigPolicy != nil {
+			if policy := lp.ConfigPolicy.Get([]string{""}); policy != nil {
+				settings.set(policy.Defaults(), core.ConfigSourcePolicy)
+			}
+		}
+	}
+	p.setPluginConfig(settings, typ, name, ver)
+	settings.set(cfg.Table(), core.ConfigSourceWorkflow)
+	return core.NodeConfig{
+		PluginType:    typ,
+		PluginName:    name,
+		PluginVersion: ver,
+		Config:        settings.redacted(),
+	}
+}
+
+// setPluginConfig sets the layers of the global plugin config, in the order
+// they are merged by getPluginConfigDataNode
+func (p *pluginControl) setPluginConfig(settings configSettings, typ core.PluginType, name string, ver int) {
+	plugins := p.Config.Plugins
+	plugins.mutex.RLock()
+	defer plugins.mutex.RUnlock()
+	settings.set(plugins.All.Table(), "plugins.all")
+	configItem := plugins.switchPluginConfigType(typ)
+	if configItem == nil {
+		return
+	}
+	// streaming collectors share the config of collectors
+	typeName := "collector"
+	switch configItem {
+	case plugins.Processor:
+		typeName = "processor"
+	case plugins.Publisher:
+		typeName = "publisher"
+	}
+	settings.set(configItem.All.Table(), fmt.Sprintf("plugins.%s.all", typeName))
+	if res, ok := configItem.Plugins[name]; ok {
+		settings.set(res.ConfigDataNode.Table(), fmt.Sprintf("plugins.%s.%s.all", typeName, name))
+		if res2, ok := res.Versions[ver]; ok {
+			settings.set(res2.Table(), fmt.Sprintf("plugins.%s.%s.versions.%d", typeName, name, ver))
+		}
+	}
+}
+
+// configSettings maps config keys to their value and source
+type configSettings map[string]core.ConfigSetting
+
+// set sets the keys of table, which override those set by the previous layers
+func (c configSettings) set(table map[string]ctypes.ConfigValue, source string) {
+	for k, v := range table {
+		c[k] = core.ConfigSetting{Value: v, Source: source}
+	}
+}
+
+// update works like set for a table of the collect node config, which is
+// already merged with the config set at the shorter namespaces, so a key keeps
+// its source unless its value changes
+func (c configSettings) update(table map[string]ctypes.ConfigValue, source string) {
+	for k, v := range table {
+		if cs, ok := c[k]; ok && strings.HasPrefix(cs.Source, workflowSourcePrefix) && reflect.DeepEqual(cs.Value, v) {
+			continue
+		}
+		c[k] = core.ConfigSetting{Value: v, Source: source}
+	}
+}
+
+func (c configSettings) redacted() map[string]core.ConfigSetting {
+	r := make(map[string]core.ConfigSetting, len(c))
+	for k, cs := range c {
+		r[k] = core.ConfigSetting{Value: ctypes.Redact(k, cs.Value), Source: cs.Source}
+	}
+	return r
+}
@@ -0,0 +1,116 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/ctypes"
+	"github.com/intelsdi-x/snap/scheduler/wmap"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWorkflowConfig(t *testing.T) {
+	Convey("Given a workflow and config set at every layer", t, func() {
+		psutil := newRefreshTestPlugin("psutil", 1, time.Now())
+		policy := cpolicy.NewPolicyNode()
+		rule, _ := cpolicy.NewStringRule("path", false, "/proc")
+		policy.Add(rule)
+		rule, _ = cpolicy.NewStringRule("user", false, "nobody")
+		policy.Add(rule)
+		psutil.ConfigPolicy.Add([]string{"intel", "psutil"}, policy)
+		mc := newMetricCatalog()
+		for _, ns := range []string{"/intel/psutil/load/load1", "/intel/psutil/load/load5", "/intel/psutil/cpu/user"} {
+			mc.Add(newLoadedMetricType(psutil, plugin.MetricType{Namespace_: queryNamespace(ns), Version_: 1}))
+		}
+
+		c := New(GetDefaultConfig())
+		c.metricCatalog = mc
+		c.Config.Plugins.All.AddItem("user", ctypes.ConfigValueStr{Value: "root"})
+		c.Config.Plugins.All.AddItem("password", ctypes.ConfigValueStr{Value: "p@ssw0rd"})
+		c.Config.Plugins.Collector.All.AddItem("timeout", ctypes.ConfigValueInt{Value: 5})
+		c.Config.Plugins.Collector.Plugins["psutil"] = newPluginConfigItem(
+			optAddPluginConfigItem("timeout", ctypes.ConfigValueInt{Value: 10}))
+		c.Config.Plugins.Collector.Plugins["psutil"].Versions[1] = cdata.FromTable(
+			map[string]ctypes.ConfigValue{"interval": ctypes.ConfigValueInt{Value: 1}})
+		c.Config.Plugins.Publisher.All.AddItem("file", ctypes.ConfigValueStr{Value: "/tmp/all"})
+		ctypes.MarkSensitive("password")
+
+		wf := wmap.NewWorkflowMap()
+		wf.Collect.AddMetric("/intel/psutil/*", 0)
+		wf.Collect.Exclude = []string{"/intel/psutil/cpu/*"}
+		wf.Collect.AddConfigItem("/intel/psutil", "interval", 2)
+		wf.Collect.AddConfigItem("/intel/psutil/load", "interval", 2)
+		wf.Collect.AddConfigItem("/intel/psutil/load/load5", "interval", 5)
+		pu := wmap.NewPublishNode("file", 3)
+		pu.AddConfigItem("file", "/tmp/published")
+		pu.AddConfigItem("format", "json")
+		wf.Collect.Add(pu)
+
+		Convey("every key of a metric reports the layer it comes from", func() {
+			wc, serrs := c.WorkflowConfig(wf, nil)
+			So(serrs, ShouldBeEmpty)
+			So(wc.Metrics, ShouldHaveLength, 2)
+			load1 := wc.Metrics[0]
+			So(load1.Namespace.String(), ShouldEqual, "/intel/psutil/load/load1")
+			So(load1.PluginName, ShouldEqual, "psutil")
+			So(load1.Config, ShouldResemble, map[string]core.ConfigSetting{
+				"path":     {Value: ctypes.ConfigValueStr{Value: "/proc"}, Source: core.ConfigSourcePolicy},
+				"user":     {Value: ctypes.ConfigValueStr{Value: "root"}, Source: "plugins.all"},
+				"password": {Value: ctypes.ConfigValueStr{Value: ctypes.Redacted}, Source: "plugins.all"},
+				"timeout":  {Value: ctypes.ConfigValueInt{Value: 10}, Source: "plugins.collector.psutil.all"},
+				"interval": {Value: ctypes.ConfigValueInt{Value: 2}, Source: "workflow:/intel/psutil"},
+			})
+			load5 := wc.Metrics[1]
+			So(load5.Namespace.String(), ShouldEqual, "/intel/psutil/load/load5")
+			So(load5.Config["interval"], ShouldResemble,
+				core.ConfigSetting{Value: ctypes.ConfigValueInt{Value: 5}, Source: "workflow:/intel/psutil/load/load5"})
+		})
+		Convey("the metrics can be restricted to a namespace query", func() {
+			wc, serrs := c.WorkflowConfig(wf, queryNamespace("/intel/**/load5"))
+			So(serrs, ShouldBeEmpty)
+			So(wc.Metrics, ShouldHaveLength, 1)
+			So(wc.Metrics[0].Namespace.String(), ShouldEqual, "/intel/psutil/load/load5")
+			So(wc.Nodes, ShouldHaveLength, 1)
+		})
+		Convey("the config of a publish node overrides the global config", func() {
+			wc, serrs := c.WorkflowConfig(wf, nil)
+			So(serrs, ShouldBeEmpty)
+			So(wc.Nodes, ShouldHaveLength, 1)
+			So(wc.Nodes[0].PluginType, ShouldEqual, core.PublisherPluginType)
+			So(wc.Nodes[0].PluginName, ShouldEqual, "file")
+			So(wc.Nodes[0].Config["file"], ShouldResemble,
+				core.ConfigSetting{Value: ctypes.ConfigValueStr{Value: "/tmp/published"}, Source: core.ConfigSourceWorkflow})
+			So(wc.Nodes[0].Config["user"].Source, ShouldEqual, "plugins.all")
+		})
+		Convey("a requested metric missing from the catalog is an error", func() {
+			wf.Collect.AddMetric("/intel/procfs/load/min1", 0)
+			_, serrs := c.WorkflowConfig(wf, nil)
+			So(serrs, ShouldHaveLength, 1)
+		})
+	})
+}
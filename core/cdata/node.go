@@ -75,6 +75,22 @@ func (c *ConfigDataNode) UnmarshalJSON(data []byte) error {
 			c.table[k] = ctypes.ConfigValueStr{Value: t}
 		case bool:
 			c.table[k] = ctypes.ConfigValueBool{Value: t}
+		case map[string]interface{}:
+			sec, ok := ctypes.ParseSecret(t)
+			if !ok {
+				return fmt.Errorf("Error Unmarshalling JSON ConfigDataNode. Key: %v objects must be secret references ({\"%s\": \"<ref>\"}).", k, ctypes.SecretKey)
+			}
+			c.table[k] = sec
+		case []interface{}:
+			l := make([]string, 0, len(t))
+			for _, e := range t {
+				v, ok := e.(string)
+				if !ok {
+					return fmt.Errorf("Error Unmarshalling JSON ConfigDataNode. Key: %v list element %v is not a string.", k, e)
+				}
+				l = append(l, v)
+			}
+			c.table[k] = ctypes.ConfigValueStrList{Value: l}
 		case json.Number:
 			if v, err := t.Int64(); err == nil {
 				c.table[k] = ctypes.ConfigValueInt{Value: int(v)}
@@ -114,6 +130,12 @@ func (c *ConfigDataNode) Table() map[string]ctypes.ConfigValue {
 	return c.table
 }
 
+// Redacted returns a copy of the node with the values of sensitive keys
+// replaced, for use wherever config leaves control.
+func (c *ConfigDataNode) Redacted() *ConfigDataNode {
+	return FromTable(ctypes.RedactTable(c.Table()))
+}
+
 // Adds an item to the ConfigDataNode.
 func (c *ConfigDataNode) AddItem(k string, v ctypes.ConfigValue) {
 	// And empty is a noop
@@ -84,5 +84,44 @@ func TestConfigDataNode(t *testing.T) {
 			So(t["f"].(ctypes.ConfigValueFloat).Value, ShouldEqual, 2.3)
 			So(len(t), ShouldEqual, 3)
 		})
+
+		Convey("string lists are unmarshalled from JSON arrays", func() {
+			cd := &ConfigDataNode{}
+			err := cd.UnmarshalJSON([]byte(`{"hosts": ["a", "b"], "user": "root"}`))
+			So(err, ShouldBeNil)
+			t := cd.Table()
+			So(t["hosts"], ShouldResemble, ctypes.ConfigValueStrList{Value: []string{"a", "b"}})
+			So(t["user"], ShouldResemble, ctypes.ConfigValueStr{Value: "root"})
+		})
+
+		Convey("lists of non-strings fail to unmarshal", func() {
+			cd := &ConfigDataNode{}
+			So(cd.UnmarshalJSON([]byte(`{"ports": [1, 2]}`)), ShouldNotBeNil)
+		})
+
+		Convey("secret references are unmarshalled from JSON objects", func() {
+			cd := &ConfigDataNode{}
+			err := cd.UnmarshalJSON([]byte(`{"password": {"secret": "env:PASSWORD"}}`))
+			So(err, ShouldBeNil)
+			So(cd.Table()["password"], ShouldResemble, ctypes.ConfigValueSecret{Ref: "env:PASSWORD"})
+		})
+
+		Convey("objects other than secret references fail to unmarshal", func() {
+			cd := &ConfigDataNode{}
+			So(cd.UnmarshalJSON([]byte(`{"password": {"value": "x"}}`)), ShouldNotBeNil)
+		})
+
+		Convey("sensitive values are redacted", func() {
+			ctypes.MarkSensitive("cdata_test_password")
+			cd := NewNode()
+			cd.AddItem("user", ctypes.ConfigValueStr{Value: "root"})
+			cd.AddItem("cdata_test_password", ctypes.ConfigValueStr{Value: "hunter2"})
+			cd.AddItem("token", ctypes.ConfigValueSecret{Ref: "file:/etc/snap/token"})
+			t := cd.Redacted().Table()
+			So(t["user"], ShouldResemble, ctypes.ConfigValueStr{Value: "root"})
+			So(t["cdata_test_password"], ShouldResemble, ctypes.ConfigValueStr{Value: ctypes.Redacted})
+			So(t["token"], ShouldResemble, ctypes.ConfigValueSecret{Ref: "file:/etc/snap/token"})
+			So(cd.Table()["cdata_test_password"], ShouldResemble, ctypes.ConfigValueStr{Value: "hunter2"})
+		})
 	})
 }
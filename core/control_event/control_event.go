@@ -35,6 +35,9 @@ const (
 	MetricUnsubscribed       = "Control.MetricUnsubscribed"
 	HealthCheckFailed        = "Control.PluginHealthCheckFailed"
 	MoveSubscription         = "Control.PluginSubscriptionMoved"
+	PluginPoolScaled         = "Control.PluginPoolScaled"
+	MetricAdded              = "Control.MetricAdded"
+	MetricRemoved            = "Control.MetricRemoved"
 )
 
 type StartPluginEvent struct {
@@ -77,6 +80,8 @@ type DeadAvailablePluginEvent struct {
 	Key     string
 	Id      uint32
 	String  string
+	// Logs holds the last lines of output written by the plugin
+	Logs []string
 }
 
 func (e *DeadAvailablePluginEvent) Namespace() string {
@@ -150,3 +155,49 @@ type HealthCheckFailedEvent struct {
 func (hfe HealthCheckFailedEvent) Namespace() string {
 	return HealthCheckFailed
 }
+
+// ScalePluginPoolEvent is emitted when the autoscaler starts or reaps
+// an instance of a plugin
+type ScalePluginPoolEvent struct {
+	Name    string
+	Version int
+	Type    int
+	Key     string
+	// Action is either "up" or "down"
+	Action string
+	Reason string
+	// Id is the id of the reaped instance when scaling down
+	Id uint32
+}
+
+func (e *ScalePluginPoolEvent) Namespace() string {
+	return PluginPoolScaled
+}
+
+// MetricAddedEvent is emitted when a refresh of the metric types of
+// a collector adds a metric to the metric catalog
+type MetricAddedEvent struct {
+	PluginName    string
+	PluginVersion int
+	PluginType    int
+	Metric        string
+	MetricVersion int
+}
+
+func (e *MetricAddedEvent) Namespace() string {
+	return MetricAdded
+}
+
+// MetricRemovedEvent is emitted when a refresh of the metric types of
+// a collector removes a metric from the metric catalog
+type MetricRemovedEvent struct {
+	PluginName    string
+	PluginVersion int
+	PluginType    int
+	Metric        string
+	MetricVersion int
+}
+
+func (e *MetricRemovedEvent) Namespace() string {
+	return MetricRemoved
+}
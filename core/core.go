@@ -27,4 +27,18 @@ const (
 	TuplePrefix    = "("
 	TupleSuffix    = ")"
 	TupleSeparator = ";"
+
+	// RecursiveWildcard is a namespace element of a requested metric which
+	// matches any number of elements, ex: /intel/procfs/**
+	RecursiveWildcard = "**"
+
+	// Alternation is defined by its prefix, suffix and separator between
+	// alternatives, ex: /intel/{psutil,procfs}/load/*
+	AlternationPrefix    = "{"
+	AlternationSuffix    = "}"
+	AlternationSeparator = ","
+
+	// ExclusionPrefix is the first element of a requested namespace whose
+	// matching metrics are excluded from the other requested namespaces
+	ExclusionPrefix = "!"
 )
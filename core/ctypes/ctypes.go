@@ -19,7 +19,10 @@ limitations under the License.
 
 package ctypes
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // TODO constructors for each that have typing for value (and optionally validate)
 
@@ -75,6 +78,78 @@ func (c ConfigValueBool) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.Value)
 }
 
+// ConfigValueStrList holds an ordered list of strings.
+type ConfigValueStrList struct {
+	Value []string
+}
+
+func (c ConfigValueStrList) Type() string {
+	return "string_list"
+}
+
+func (c ConfigValueStrList) MarshalJSON() ([]byte, error) {
+	if c.Value == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal(c.Value)
+}
+
+// ConfigValueDuration holds a time.Duration. It is marshalled to JSON in
+// its string form (e.g. "1m30s").
+type ConfigValueDuration struct {
+	Value time.Duration
+}
+
+func (c ConfigValueDuration) Type() string {
+	return "duration"
+}
+
+func (c ConfigValueDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Value.String())
+}
+
+// ConfigValueSecret references a secret which is kept out of snap's
+// configuration, e.g. "file:/etc/snap/secrets/influx" or "env:INFLUX_PASSWORD".
+// Control resolves the reference into a ConfigValueStr just before calling a
+// plugin, so only the reference is ever stored or returned.
+type ConfigValueSecret struct {
+	Ref string
+}
+
+func (c ConfigValueSecret) Type() string {
+	return "secret"
+}
+
+func (c ConfigValueSecret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{SecretKey: c.Ref})
+}
+
+// SecretKey is the key of the single-entry object used to write a secret
+// reference in task manifests and config files, e.g. {"secret": "env:NAME"}.
+const SecretKey = "secret"
+
+// ParseSecret returns the secret referenced by v if it has the form of a
+// secret reference ({"secret": "<ref>"}).
+func ParseSecret(v interface{}) (ConfigValueSecret, bool) {
+	var ref interface{}
+	switch m := v.(type) {
+	case map[string]interface{}:
+		if len(m) != 1 {
+			return ConfigValueSecret{}, false
+		}
+		ref = m[SecretKey]
+	case map[interface{}]interface{}:
+		if len(m) != 1 {
+			return ConfigValueSecret{}, false
+		}
+		ref = m[SecretKey]
+	}
+	if s, ok := ref.(string); ok && s != "" {
+		return ConfigValueSecret{Ref: s}, true
+	}
+	return ConfigValueSecret{}, false
+}
+
 // Returns a slice of string keywords for the types supported by ConfigValue.
 func SupportedTypes() []string {
 	// This is kind of a hack but keeps the definition of types here in
@@ -90,6 +165,12 @@ func SupportedTypes() []string {
 		ConfigValueFloat{}.Type(),
 		// Bool
 		ConfigValueBool{}.Type(),
+		// String list
+		ConfigValueStrList{}.Type(),
+		// Duration
+		ConfigValueDuration{}.Type(),
+		// Secret
+		ConfigValueSecret{}.Type(),
 	}
 	return t
 }
@@ -0,0 +1,73 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctypes
+
+import "sync"
+
+// Redacted replaces the value of sensitive config keys wherever config leaves
+// control (REST responses, logs).
+const Redacted = "********"
+
+var sensitive = struct {
+	sync.RWMutex
+	keys map[string]struct{}
+}{keys: map[string]struct{}{}}
+
+// MarkSensitive records config keys whose values must never be shown.  Keys
+// are matched by name alone so a key marked sensitive by one plugin's policy
+// is redacted for every plugin.
+func MarkSensitive(keys ...string) {
+	sensitive.Lock()
+	defer sensitive.Unlock()
+	for _, k := range keys {
+		sensitive.keys[k] = struct{}{}
+	}
+}
+
+// IsSensitive returns true if the key has been marked sensitive.
+func IsSensitive(key string) bool {
+	sensitive.RLock()
+	defer sensitive.RUnlock()
+	_, ok := sensitive.keys[key]
+	return ok
+}
+
+// Redact returns the value to show for a config item.  Secret references are
+// safe to show since they only name where the secret lives; any other value of
+// a sensitive key is replaced by Redacted.
+func Redact(key string, cv ConfigValue) ConfigValue {
+	if _, ok := cv.(ConfigValueSecret); ok {
+		return cv
+	}
+	if IsSensitive(key) {
+		return ConfigValueStr{Value: Redacted}
+	}
+	return cv
+}
+
+// RedactTable returns a copy of table with the values of sensitive keys
+// redacted.
+func RedactTable(table map[string]ConfigValue) map[string]ConfigValue {
+	r := make(map[string]ConfigValue, len(table))
+	for k, v := range table {
+		r[k] = Redact(k, v)
+	}
+	return r
+}
@@ -0,0 +1,219 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrInvalidDistribution is returned when the buckets of a distribution
+	// are inconsistent
+	ErrInvalidDistribution = errors.New("invalid distribution")
+	// ErrInvalidBounds is returned when bucket bounds are not finite and
+	// strictly ascending
+	ErrInvalidBounds = errors.New("bucket bounds must be finite and strictly ascending")
+)
+
+// Distribution is a metric value summarizing a set of observed values in
+// buckets, e.g. the latencies of the requests served since the previous
+// collection.  Bucket i counts the values v such that Bounds[i-1] < v <= Bounds[i],
+// and the last bucket counts the values above the last bound, so there is
+// always one more count than there are bounds.
+type Distribution struct {
+	// Bounds are the ascending upper bounds of the buckets.
+	Bounds []float64 `json:"bounds"`
+	// Counts are the numbers of values in each bucket.
+	Counts []uint64 `json:"counts"`
+	// Sum is the sum of all the values.
+	Sum float64 `json:"sum"`
+	// Count is the number of values, the total of Counts.
+	Count uint64 `json:"count"`
+}
+
+// NewDistribution returns an empty distribution with the given bucket bounds
+func NewDistribution(bounds []float64) (Distribution, error) {
+	if err := validateBounds(bounds); err != nil {
+		return Distribution{}, err
+	}
+	return Distribution{
+		Bounds: append([]float64{}, bounds...),
+		Counts: make([]uint64, len(bounds)+1),
+	}, nil
+}
+
+func validateBounds(bounds []float64) error {
+	for i, b := range bounds {
+		if math.IsNaN(b) || math.IsInf(b, 0) || (i > 0 && b <= bounds[i-1]) {
+			return ErrInvalidBounds
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the bounds, the counts and the total count
+// of the distribution don't agree
+func (d Distribution) Validate() error {
+	if err := validateBounds(d.Bounds); err != nil {
+		return err
+	}
+	if len(d.Counts) != len(d.Bounds)+1 {
+		return fmt.Errorf("%v: %d counts for %d bounds", ErrInvalidDistribution, len(d.Counts), len(d.Bounds))
+	}
+	var count uint64
+	for _, c := range d.Counts {
+		count += c
+	}
+	if count != d.Count {
+		return fmt.Errorf("%v: buckets hold %d values, count is %d", ErrInvalidDistribution, count, d.Count)
+	}
+	if math.IsNaN(d.Sum) || math.IsInf(d.Sum, 0) {
+		return fmt.Errorf("%v: sum is %v", ErrInvalidDistribution, d.Sum)
+	}
+	return nil
+}
+
+// Observe adds a value to the distribution.  Values which are not finite
+// are ignored.  Empty counts are sized after the bounds, so the zero value
+// is a distribution with a single bucket.
+func (d *Distribution) Observe(v float64) error {
+	if len(d.Counts) == 0 {
+		d.Counts = make([]uint64, len(d.Bounds)+1)
+	}
+	if len(d.Counts) != len(d.Bounds)+1 {
+		return fmt.Errorf("%v: %d counts for %d bounds", ErrInvalidDistribution, len(d.Counts), len(d.Bounds))
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	d.Counts[sort.SearchFloat64s(d.Bounds, v)]++
+	d.Sum += v
+	d.Count++
+	return nil
+}
+
+// MarshalJSON encodes the distribution with a "kind" field set to
+// "distribution", which tells decoders of metric data to decode it back into
+// a Distribution.
+func (d Distribution) MarshalJSON() ([]byte, error) {
+	type distribution Distribution
+	return json.Marshal(struct {
+		Kind MetricKind `json:"kind"`
+		distribution
+	}{MetricKindDistribution, distribution(d)})
+}
+
+// Mean returns the mean of the values, 0 if the distribution is empty
+func (d Distribution) Mean() float64 {
+	if d.Count == 0 {
+		return 0
+	}
+	return d.Sum / float64(d.Count)
+}
+
+// Rebucket returns a copy of the distribution using the given bounds.  The
+// count of each bucket is moved to the new bucket holding its upper bound,
+// so the result is exact when the new bounds are a subset of the current
+// ones and an approximation otherwise.
+func (d Distribution) Rebucket(bounds []float64) (Distribution, error) {
+	if err := d.Validate(); err != nil {
+		return Distribution{}, err
+	}
+	r, err := NewDistribution(bounds)
+	if err != nil {
+		return Distribution{}, err
+	}
+	for i, c := range d.Counts {
+		j := len(bounds)
+		if i < len(d.Bounds) {
+			j = sort.SearchFloat64s(bounds, d.Bounds[i])
+		}
+		r.Counts[j] += c
+	}
+	r.Sum = d.Sum
+	r.Count = d.Count
+	return r, nil
+}
+
+// Merge returns the distribution of the values of both d and o.  When their
+// bounds differ both are first rebucketed to the bounds they have in common,
+// which keeps the result exact at the cost of resolution.
+func (d Distribution) Merge(o Distribution) (Distribution, error) {
+	bounds := commonBounds(d.Bounds, o.Bounds)
+	a, err := d.Rebucket(bounds)
+	if err != nil {
+		return Distribution{}, err
+	}
+	b, err := o.Rebucket(bounds)
+	if err != nil {
+		return Distribution{}, err
+	}
+	for i, c := range b.Counts {
+		a.Counts[i] += c
+	}
+	a.Sum += b.Sum
+	a.Count += b.Count
+	return a, nil
+}
+
+// commonBounds returns the bounds found in both a and b, which are ascending
+func commonBounds(a, b []float64) []float64 {
+	bounds := []float64{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			bounds = append(bounds, a[i])
+			i++
+			j++
+		}
+	}
+	return bounds
+}
+
+// String returns the buckets of the distribution in the following format
+// count=N sum=S buckets[<=b0:c0 <=b1:c1 >b1:c2]
+func (d Distribution) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "count=%d sum=%v buckets[", d.Count, d.Sum)
+	for i, c := range d.Counts {
+		if i > 0 {
+			buf.WriteString(" ")
+		}
+		switch {
+		case i < len(d.Bounds):
+			fmt.Fprintf(&buf, "<=%v:%d", d.Bounds[i], c)
+		case len(d.Bounds) > 0:
+			fmt.Fprintf(&buf, ">%v:%d", d.Bounds[len(d.Bounds)-1], c)
+		default:
+			fmt.Fprintf(&buf, "*:%d", c)
+		}
+	}
+	buf.WriteString("]")
+	return buf.String()
+}
@@ -0,0 +1,122 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDistribution(t *testing.T) {
+	Convey("Creating a distribution", t, func() {
+		Convey("requires strictly ascending finite bounds", func() {
+			_, err := NewDistribution([]float64{1, 1})
+			So(err, ShouldEqual, ErrInvalidBounds)
+			_, err = NewDistribution([]float64{2, 1})
+			So(err, ShouldEqual, ErrInvalidBounds)
+			d, err := NewDistribution(nil)
+			So(err, ShouldBeNil)
+			So(d.Counts, ShouldResemble, []uint64{0})
+		})
+		Convey("observed values land in the bucket of their upper bound", func() {
+			d, err := NewDistribution([]float64{1, 5})
+			So(err, ShouldBeNil)
+			for _, v := range []float64{0.5, 1, 3, 5, 7} {
+				So(d.Observe(v), ShouldBeNil)
+			}
+			So(d.Counts, ShouldResemble, []uint64{2, 2, 1})
+			So(d.Count, ShouldEqual, 5)
+			So(d.Sum, ShouldEqual, 16.5)
+			So(d.Mean(), ShouldEqual, 3.3)
+			So(d.Validate(), ShouldBeNil)
+			So(d.String(), ShouldEqual, "count=5 sum=16.5 buckets[<=1:2 <=5:2 >5:1]")
+		})
+		Convey("observing a value sizes the counts of the zero value", func() {
+			d := Distribution{Bounds: []float64{1}}
+			So(d.Observe(2), ShouldBeNil)
+			So(d.Counts, ShouldResemble, []uint64{0, 1})
+			So(d.Validate(), ShouldBeNil)
+		})
+		Convey("observing a value fails when counts don't match the bounds", func() {
+			d := Distribution{Bounds: []float64{1, 5}, Counts: []uint64{0, 0}}
+			So(d.Observe(2), ShouldNotBeNil)
+			So(d.Count, ShouldEqual, 0)
+		})
+	})
+	Convey("Encoding a distribution to JSON", t, func() {
+		d := Distribution{Bounds: []float64{1}, Counts: []uint64{1, 0}, Sum: 0.5, Count: 1}
+		b, err := json.Marshal(d)
+		So(err, ShouldBeNil)
+		So(string(b), ShouldEqual, `{"kind":"distribution","bounds":[1],"counts":[1,0],"sum":0.5,"count":1}`)
+	})
+	Convey("Validating a distribution", t, func() {
+		d := Distribution{Bounds: []float64{1}, Counts: []uint64{1}, Count: 1}
+		So(d.Validate(), ShouldNotBeNil)
+		d = Distribution{Bounds: []float64{1}, Counts: []uint64{1, 1}, Count: 3}
+		So(d.Validate(), ShouldNotBeNil)
+	})
+	Convey("Rebucketing a distribution", t, func() {
+		d := Distribution{Bounds: []float64{1, 2, 5, 10}, Counts: []uint64{1, 2, 3, 4, 5}, Sum: 100, Count: 15}
+		Convey("to a subset of its bounds is exact", func() {
+			r, err := d.Rebucket([]float64{2, 10})
+			So(err, ShouldBeNil)
+			So(r.Counts, ShouldResemble, []uint64{3, 7, 5})
+			So(r.Sum, ShouldEqual, 100)
+			So(r.Count, ShouldEqual, 15)
+		})
+		Convey("to other bounds uses the upper bound of each bucket", func() {
+			r, err := d.Rebucket([]float64{3})
+			So(err, ShouldBeNil)
+			So(r.Counts, ShouldResemble, []uint64{3, 12})
+		})
+		Convey("does not modify the distribution", func() {
+			_, err := d.Rebucket([]float64{2})
+			So(err, ShouldBeNil)
+			So(d.Counts, ShouldResemble, []uint64{1, 2, 3, 4, 5})
+		})
+	})
+	Convey("Merging distributions", t, func() {
+		a := Distribution{Bounds: []float64{1, 2, 5}, Counts: []uint64{1, 1, 1, 1}, Sum: 10, Count: 4}
+		Convey("with the same bounds adds their buckets", func() {
+			m, err := a.Merge(a)
+			So(err, ShouldBeNil)
+			So(m.Bounds, ShouldResemble, []float64{1, 2, 5})
+			So(m.Counts, ShouldResemble, []uint64{2, 2, 2, 2})
+			So(m.Sum, ShouldEqual, 20)
+			So(m.Count, ShouldEqual, 8)
+		})
+		Convey("with other bounds keeps the bounds they have in common", func() {
+			b := Distribution{Bounds: []float64{2, 10}, Counts: []uint64{2, 1, 0}, Sum: 5, Count: 3}
+			m, err := a.Merge(b)
+			So(err, ShouldBeNil)
+			So(m.Bounds, ShouldResemble, []float64{2})
+			So(m.Counts, ShouldResemble, []uint64{4, 3})
+			So(m.Count, ShouldEqual, 7)
+		})
+		Convey("fails on an invalid distribution", func() {
+			_, err := a.Merge(Distribution{})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
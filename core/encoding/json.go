@@ -0,0 +1,72 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encoding decodes JSON encoded metrics, keeping the types of their
+// data which encoding/json would turn into maps.
+package encoding
+
+import (
+	"encoding/json"
+
+	"github.com/intelsdi-x/snap/core"
+)
+
+// UnmarshalMetric decodes a JSON encoded metric into v and returns the value
+// of its "data" field decoded by UnmarshalMetricData.  v must not implement
+// json.Unmarshaler: metrics decoding themselves pass a pointer to a type
+// defined from their own.
+func UnmarshalMetric(data []byte, v interface{}) (interface{}, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	m := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if len(m.Data) == 0 {
+		return nil, nil
+	}
+	return UnmarshalMetricData(m.Data)
+}
+
+// UnmarshalMetricData decodes the JSON encoded data of a metric.  Objects
+// whose kind is "distribution" are decoded as a core.Distribution, any other
+// value as encoding/json decodes it into an interface{}.
+func UnmarshalMetricData(data []byte) (interface{}, error) {
+	var kinded struct {
+		Kind core.MetricKind `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &kinded); err == nil && kinded.Kind == core.MetricKindDistribution {
+		var d core.Distribution
+		if err := json.Unmarshal(data, &d); err != nil {
+			return nil, err
+		}
+		if err := d.Validate(); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
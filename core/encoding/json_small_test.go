@@ -0,0 +1,77 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encoding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/intelsdi-x/snap/core"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnmarshalMetricData(t *testing.T) {
+	Convey("Decoding the data of a metric", t, func() {
+		Convey("returns a distribution encoded with its kind", func() {
+			d := core.Distribution{Bounds: []float64{1, 10}, Counts: []uint64{1, 0, 1}, Sum: 20.5, Count: 2}
+			b, err := json.Marshal(d)
+			So(err, ShouldBeNil)
+			v, err := UnmarshalMetricData(b)
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, d)
+		})
+		Convey("leaves alone objects without the distribution kind", func() {
+			v, err := UnmarshalMetricData([]byte(`{"bounds":[1],"counts":"x"}`))
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, map[string]interface{}{"bounds": []interface{}{1.0}, "counts": "x"})
+			v, err = UnmarshalMetricData([]byte(`{"kind":"gauge","counts":[1]}`))
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, map[string]interface{}{"kind": "gauge", "counts": []interface{}{1.0}})
+		})
+		Convey("decodes other values as encoding/json does", func() {
+			v, err := UnmarshalMetricData([]byte(`[1,"a"]`))
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, []interface{}{1.0, "a"})
+		})
+		Convey("fails on an invalid distribution", func() {
+			_, err := UnmarshalMetricData([]byte(`{"kind":"distribution","bounds":[1],"counts":[1],"count":1}`))
+			So(err, ShouldNotBeNil)
+		})
+	})
+	Convey("Decoding a metric", t, func() {
+		m := struct {
+			Unit string      `json:"unit"`
+			Data interface{} `json:"-"`
+		}{}
+		Convey("returns its decoded data", func() {
+			d, err := UnmarshalMetric([]byte(`{"unit":"ms","data":{"kind":"distribution","bounds":[],"counts":[2],"sum":1,"count":2}}`), &m)
+			So(err, ShouldBeNil)
+			So(m.Unit, ShouldEqual, "ms")
+			So(d, ShouldResemble, core.Distribution{Bounds: []float64{}, Counts: []uint64{2}, Sum: 1, Count: 2})
+		})
+		Convey("returns nil without data", func() {
+			d, err := UnmarshalMetric([]byte(`{"unit":"ms"}`), &m)
+			So(err, ShouldBeNil)
+			So(d, ShouldBeNil)
+		})
+	})
+}
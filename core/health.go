@@ -0,0 +1,78 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+// ControlHealth holds the state of the plugin control module
+type ControlHealth struct {
+	Started bool
+	Pools   []PluginPoolHealth
+}
+
+// PluginPoolHealth holds the state of the pool of running instances of
+// a plugin
+type PluginPoolHealth struct {
+	Type          string
+	Name          string
+	Version       int
+	Instances     int
+	Subscriptions int
+	Restarts      int
+}
+
+// Dead returns true when tasks are subscribed to the plugin but none of
+// its instances is running, which happens once they exceeded their
+// restart limit
+func (p PluginPoolHealth) Dead() bool {
+	return p.Instances == 0 && p.Subscriptions > 0
+}
+
+// SchedulerHealth holds the state of the scheduler module
+type SchedulerHealth struct {
+	Started bool
+	Tasks   int
+	// DisabledTasks holds the IDs of the tasks disabled after failing
+	DisabledTasks []string
+	Queues        []QueueHealth
+}
+
+// QueueHealth holds the state of one of the job queues of the scheduler
+type QueueHealth struct {
+	Name   string
+	Length int
+	// Limit is the number of jobs the queue holds, 0 means unlimited
+	Limit uint
+	// Rejected is the number of jobs refused because the queue was full
+	Rejected uint64
+}
+
+// Saturation returns the fraction of the queue limit in use
+func (q QueueHealth) Saturation() float64 {
+	if q.Limit == 0 {
+		return 0
+	}
+	return float64(q.Length) / float64(q.Limit)
+}
+
+// TribeHealth holds the state of the tribe membership
+type TribeHealth struct {
+	Name    string
+	Seed    string
+	Members []string
+}
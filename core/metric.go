@@ -198,3 +198,43 @@ type CatalogedMetric interface {
 	Description() string
 	Unit() string
 }
+
+// CatalogRefresh lists the metrics added to and removed from the metric
+// catalog when the metric types of a collector are refreshed
+type CatalogRefresh struct {
+	Added   []CatalogedMetric
+	Removed []CatalogedMetric
+}
+
+// MetricSearch holds the criteria of a search in the metric catalog.
+// Criteria left to their zero value match every metric.
+type MetricSearch struct {
+	// Namespace restricts the search to the metrics below this namespace
+	Namespace Namespace
+	// Glob holds one pattern per namespace element (see path.Match)
+	// which the namespace of a metric has to match
+	Glob []string
+	// Regex is matched against the namespace of a metric, ex: /intel/mock/foo
+	Regex string
+	// Version of the metric, 0 matches every version
+	Version int
+	// PluginName and PluginVersion select the plugin exposing the metric
+	PluginName    string
+	PluginVersion int
+	// Text holds terms which all have to be found, ignoring case,
+	// in the description or the unit of a metric
+	Text string
+	// Dynamic selects either the dynamic or the static metrics when set
+	Dynamic *bool
+	// Offset is the number of matching metrics skipped
+	Offset int
+	// Limit caps the number of metrics returned, 0 returns every match
+	Limit int
+}
+
+// MetricSearchResult is the page of the metric catalog matching a MetricSearch
+type MetricSearchResult struct {
+	Metrics []CatalogedMetric
+	// Total is the number of metrics matching the search, ignoring pagination
+	Total int
+}
@@ -0,0 +1,238 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MetricKind tells how the values of a metric relate to each other over time.
+// Plugins which predate metric kinds leave it empty (MetricKindUnknown).
+type MetricKind string
+
+const (
+	// MetricKindUnknown is the kind of metrics which don't declare one
+	MetricKindUnknown MetricKind = ""
+	// MetricKindGauge is a value sampled at the time of collection
+	MetricKindGauge MetricKind = "gauge"
+	// MetricKindCounter is a monotonic total accumulated since a start time
+	MetricKindCounter MetricKind = "counter"
+	// MetricKindDelta is the change of a total since the previous collection
+	MetricKindDelta MetricKind = "delta"
+	// MetricKindDistribution is a set of values summarized in buckets
+	MetricKindDistribution MetricKind = "distribution"
+	// MetricKindState is a non numeric value, e.g. the state of a service
+	MetricKindState MetricKind = "state"
+)
+
+var metricKindAliases = map[string]MetricKind{
+	"histogram": MetricKindDistribution,
+	"string":    MetricKindState,
+}
+
+// KindedMetric is implemented by the metrics which declare their kind.  It is
+// kept out of Metric and CatalogedMetric so their implementations outside of
+// snap still satisfy them.
+type KindedMetric interface {
+	Kind() MetricKind
+}
+
+// KindOf returns the kind of a metric, MetricKindUnknown unless it
+// implements KindedMetric.
+func KindOf(m interface{}) MetricKind {
+	if km, ok := m.(KindedMetric); ok {
+		return km.Kind()
+	}
+	return MetricKindUnknown
+}
+
+// ErrUnknownMetricKind is returned when parsing an unknown metric kind
+var ErrUnknownMetricKind = errors.New("unknown metric kind")
+
+// ParseMetricKind returns the metric kind named by s, which is case
+// insensitive.  "histogram" and "string" are accepted as aliases of
+// "distribution" and "state".
+func ParseMetricKind(s string) (MetricKind, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch k := MetricKind(s); k {
+	case MetricKindUnknown, MetricKindGauge, MetricKindCounter, MetricKindDelta, MetricKindDistribution, MetricKindState:
+		return k, nil
+	}
+	if k, ok := metricKindAliases[s]; ok {
+		return k, nil
+	}
+	return MetricKindUnknown, fmt.Errorf("%v: %s", ErrUnknownMetricKind, s)
+}
+
+// IsNumeric returns true if the values of a metric of this kind are numbers
+func (k MetricKind) IsNumeric() bool {
+	return k == MetricKindGauge || k == MetricKindCounter || k == MetricKindDelta
+}
+
+// unitAtoms are the UCUM units accepted by ValidateUnit.  The metric ones can
+// be prefixed (e.g. "ms", "kBy", "MiBy").
+var unitAtoms = map[string]bool{
+	"%": false,
+	"s": true, "min": false, "h": false, "d": false, "wk": false, "mo": false, "a": false,
+	"m": true, "g": true, "l": true, "L": true, "t": true,
+	"By": true, "bit": true, "Bd": true, "Hz": true,
+	"W": true, "J": true, "V": true, "A": true, "Ohm": true, "Wh": true,
+	"Cel": true, "K": true, "[degF]": false,
+	"rad": true, "deg": false, "sr": true,
+	"Pa": true, "bar": true, "N": true, "cal": true,
+	"mol": true, "cd": true, "lx": true,
+}
+
+// unitPrefixes are the UCUM prefixes, binary ones included, longest first
+var unitPrefixes = []string{
+	"da", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei",
+	"Y", "Z", "E", "P", "T", "G", "M", "k", "h", "d", "c", "m", "u", "n", "p", "f", "a", "z", "y",
+}
+
+// ErrInvalidUnit is returned for a unit which is not a UCUM unit
+var ErrInvalidUnit = errors.New("invalid unit")
+
+// ValidateUnit checks that unit follows the UCUM case sensitive syntax, e.g.
+// "By", "ms", "By/s", "{packets}/s", "kW.h" or "m2".  An empty unit is valid.
+// Annotations in braces are free text; the units are limited to the common
+// SI, data and time units.
+func ValidateUnit(unit string) error {
+	if unit == "" {
+		return nil
+	}
+	p := unitParser{unit: unit}
+	if strings.HasPrefix(unit, "/") {
+		p.pos++
+	}
+	if err := p.term(); err != nil {
+		return err
+	}
+	if p.pos < len(unit) {
+		return p.error()
+	}
+	return nil
+}
+
+type unitParser struct {
+	unit string
+	pos  int
+}
+
+func (p *unitParser) error() error {
+	return fmt.Errorf("%v: %s (at %d)", ErrInvalidUnit, p.unit, p.pos)
+}
+
+// term := component (('.' | '/') component)*
+func (p *unitParser) term() error {
+	if err := p.component(); err != nil {
+		return err
+	}
+	for p.pos < len(p.unit) && (p.unit[p.pos] == '.' || p.unit[p.pos] == '/') {
+		p.pos++
+		if err := p.component(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// component := '(' term ')' | annotation | factor | atom exponent? annotation?
+func (p *unitParser) component() error {
+	if p.pos >= len(p.unit) {
+		return p.error()
+	}
+	switch c := p.unit[p.pos]; {
+	case c == '(':
+		p.pos++
+		if err := p.term(); err != nil {
+			return err
+		}
+		if p.pos >= len(p.unit) || p.unit[p.pos] != ')' {
+			return p.error()
+		}
+		p.pos++
+		return nil
+	case c == '{':
+		return p.annotation()
+	case unicode.IsDigit(rune(c)):
+		// a factor, "1" being the unity
+		for p.pos < len(p.unit) && unicode.IsDigit(rune(p.unit[p.pos])) {
+			p.pos++
+		}
+		return nil
+	}
+	if err := p.atom(); err != nil {
+		return err
+	}
+	p.exponent()
+	if p.pos < len(p.unit) && p.unit[p.pos] == '{' {
+		return p.annotation()
+	}
+	return nil
+}
+
+func (p *unitParser) annotation() error {
+	end := strings.IndexByte(p.unit[p.pos:], '}')
+	if end < 0 {
+		return p.error()
+	}
+	p.pos += end + 1
+	return nil
+}
+
+func (p *unitParser) exponent() {
+	start := p.pos
+	if p.pos < len(p.unit) && (p.unit[p.pos] == '-' || p.unit[p.pos] == '+') {
+		p.pos++
+	}
+	digits := p.pos
+	for p.pos < len(p.unit) && unicode.IsDigit(rune(p.unit[p.pos])) {
+		p.pos++
+	}
+	if p.pos == digits {
+		p.pos = start
+	}
+}
+
+// atom matches a unit, optionally prefixed, at the current position
+func (p *unitParser) atom() error {
+	end := p.pos
+	for end < len(p.unit) && !strings.ContainsRune("./(){}+-0123456789", rune(p.unit[end])) {
+		end++
+	}
+	symbol := p.unit[p.pos:end]
+	if _, ok := unitAtoms[symbol]; ok {
+		p.pos = end
+		return nil
+	}
+	for _, prefix := range unitPrefixes {
+		if !strings.HasPrefix(symbol, prefix) {
+			continue
+		}
+		if metric := unitAtoms[symbol[len(prefix):]]; metric {
+			p.pos = end
+			return nil
+		}
+	}
+	return p.error()
+}
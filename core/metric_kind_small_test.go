@@ -0,0 +1,88 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseMetricKind(t *testing.T) {
+	Convey("Parsing metric kinds", t, func() {
+		for s, kind := range map[string]MetricKind{
+			"":             MetricKindUnknown,
+			"gauge":        MetricKindGauge,
+			"Counter":      MetricKindCounter,
+			"delta":        MetricKindDelta,
+			"distribution": MetricKindDistribution,
+			"histogram":    MetricKindDistribution,
+			"state":        MetricKindState,
+			"string":       MetricKindState,
+		} {
+			k, err := ParseMetricKind(s)
+			So(err, ShouldBeNil)
+			So(k, ShouldEqual, kind)
+		}
+		_, err := ParseMetricKind("summary")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldStartWith, ErrUnknownMetricKind.Error())
+	})
+}
+
+func TestValidateUnit(t *testing.T) {
+	Convey("Validating UCUM units", t, func() {
+		for _, unit := range []string{
+			"", "1", "%", "s", "ms", "us", "min", "h", "mo",
+			"By", "kBy", "MiBy", "bit", "By/s", "{packets}/s", "{requests}",
+			"By{transmitted}", "kW.h", "m2", "m/s2", "s-1", "/s", "Cel", "[degF]",
+			"10", "(By/s)", "Pa", "dBy",
+		} {
+			So(ValidateUnit(unit), ShouldBeNil)
+		}
+		for _, unit := range []string{
+			"mock unit", "bytes", "percent", "kb", "By/", "By//s", "{packets", "(By", "s)", "xs", "kmin",
+		} {
+			err := ValidateUnit(unit)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrInvalidUnit.Error())
+		}
+	})
+}
+
+type kindedMetric struct {
+	kind MetricKind
+}
+
+func (m kindedMetric) Kind() MetricKind {
+	return m.kind
+}
+
+func TestKindOf(t *testing.T) {
+	Convey("The kind of a metric", t, func() {
+		So(KindOf(kindedMetric{MetricKindCounter}), ShouldEqual, MetricKindCounter)
+		Convey("is unknown unless the metric declares one", func() {
+			So(KindOf(struct{}{}), ShouldEqual, MetricKindUnknown)
+			So(KindOf(nil), ShouldEqual, MetricKindUnknown)
+		})
+	})
+}
@@ -109,6 +109,41 @@ type AvailablePlugin interface {
 	Port() string
 }
 
+// CircuitBreakerPlugin is implemented by the running plugins whose calls are
+// guarded by a circuit breaker.  It is kept out of AvailablePlugin so its
+// implementations outside of snap still satisfy it.
+type CircuitBreakerPlugin interface {
+	CircuitBreaker() CircuitBreakerStatus
+}
+
+// CircuitBreakerStatus holds the state of the circuit breaker
+// guarding the calls to a running plugin instance
+type CircuitBreakerStatus struct {
+	// State is one of closed, open or half-open
+	State string
+	// Calls and Failures are counted over the most recent calls
+	Calls    int
+	Failures int
+	// Trips is the number of times the breaker has opened
+	Trips    uint64
+	OpenedAt time.Time
+	// Accepting is true when a call would go through: the breaker is
+	// closed, its open timeout has passed or no probe call is pending
+	Accepting bool
+}
+
+// PluginLogEntry is a line of output written by a running plugin instance
+type PluginLogEntry struct {
+	PluginName    string
+	PluginVersion int
+	PluginType    string
+	InstanceID    uint32
+	// Stream is either "stdout" or "stderr"
+	Stream    string
+	Timestamp time.Time
+	Line      string
+}
+
 // the public interface for a plugin
 // this should be the contract for
 // how mgmt modules know a plugin
@@ -0,0 +1,145 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// List of plugin RPC calls for which statistics are recorded
+	CollectMetricsCall = "CollectMetrics"
+	ProcessCall        = "Process"
+	PublishCall        = "Publish"
+	PingCall           = "Ping"
+)
+
+// LatencyBuckets are the upper bounds of the latency histogram buckets.
+// Calls slower than the last bound are counted in an extra bucket.
+var LatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// PluginCallStats holds the statistics of one kind of RPC call to a plugin
+type PluginCallStats struct {
+	Calls        uint64
+	Errors       uint64
+	Timeouts     uint64
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+	// Buckets holds the number of calls per latency bucket, as defined
+	// by LatencyBuckets, with one extra bucket for slower calls
+	Buckets []uint64
+}
+
+// Add merges the statistics of s into the receiver
+func (c *PluginCallStats) Add(s PluginCallStats) {
+	c.Calls += s.Calls
+	c.Errors += s.Errors
+	c.Timeouts += s.Timeouts
+	c.TotalLatency += s.TotalLatency
+	if s.MaxLatency > c.MaxLatency {
+		c.MaxLatency = s.MaxLatency
+	}
+	if len(c.Buckets) < len(s.Buckets) {
+		b := make([]uint64, len(s.Buckets))
+		copy(b, c.Buckets)
+		c.Buckets = b
+	}
+	for i, n := range s.Buckets {
+		c.Buckets[i] += n
+	}
+}
+
+// AvgLatency returns the mean latency of the calls
+func (c PluginCallStats) AvgLatency() time.Duration {
+	if c.Calls == 0 {
+		return 0
+	}
+	return c.TotalLatency / time.Duration(c.Calls)
+}
+
+// Quantile returns an estimate of the latency under which the q
+// fraction of the calls completed, taken from the histogram buckets.
+func (c PluginCallStats) Quantile(q float64) time.Duration {
+	var total uint64
+	for _, n := range c.Buckets {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(total)))
+	if rank == 0 {
+		rank = 1
+	}
+	var seen uint64
+	for i, n := range c.Buckets {
+		seen += n
+		if seen >= rank {
+			if i < len(LatencyBuckets) && LatencyBuckets[i] < c.MaxLatency {
+				return LatencyBuckets[i]
+			}
+			return c.MaxLatency
+		}
+	}
+	return c.MaxLatency
+}
+
+// PluginStats holds the call statistics of a running plugin instance,
+// or of every instance of a plugin when ID is 0
+type PluginStats struct {
+	Name       string
+	Version    int
+	Type       string
+	ID         uint32
+	Calls      map[string]PluginCallStats
+	MetricsIn  uint64
+	MetricsOut uint64
+}
+
+// PluginPoolStats holds the call statistics of a plugin since it was first
+// started, along with the statistics of the instances currently running
+type PluginPoolStats struct {
+	Total     PluginStats
+	Instances []PluginStats
+	Scaling   PluginScalingStats
+}
+
+// PluginScalingStats holds the decisions taken by the autoscaler for a plugin
+type PluginScalingStats struct {
+	ScaleUps   uint64
+	ScaleDowns uint64
+	LastAction string
+	LastReason string
+	LastScaled time.Time
+}
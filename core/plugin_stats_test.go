@@ -0,0 +1,66 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPluginCallStats(t *testing.T) {
+	Convey("Given call statistics", t, func() {
+		c := PluginCallStats{
+			Calls:        10,
+			Errors:       2,
+			TotalLatency: 100 * time.Millisecond,
+			MaxLatency:   40 * time.Millisecond,
+			Buckets:      make([]uint64, len(LatencyBuckets)+1),
+		}
+		// 9 calls within 5ms, 1 call within 50ms
+		c.Buckets[1] = 9
+		c.Buckets[4] = 1
+
+		Convey("AvgLatency() returns the mean latency", func() {
+			So(c.AvgLatency(), ShouldEqual, 10*time.Millisecond)
+			So(PluginCallStats{}.AvgLatency(), ShouldEqual, 0)
+		})
+		Convey("Quantile() returns the bucket bound of the quantile", func() {
+			So(c.Quantile(0.5), ShouldEqual, 5*time.Millisecond)
+			So(c.Quantile(0.9), ShouldEqual, 5*time.Millisecond)
+			// the max latency is lower than the bucket bound
+			So(c.Quantile(0.99), ShouldEqual, 40*time.Millisecond)
+			So(PluginCallStats{}.Quantile(0.5), ShouldEqual, 0)
+		})
+		Convey("Add() merges statistics", func() {
+			total := PluginCallStats{}
+			total.Add(c)
+			total.Add(c)
+			So(total.Calls, ShouldEqual, 20)
+			So(total.Errors, ShouldEqual, 4)
+			So(total.MaxLatency, ShouldEqual, 40*time.Millisecond)
+			So(total.Buckets[1], ShouldEqual, 18)
+			So(c.Buckets[1], ShouldEqual, 9)
+		})
+	})
+}
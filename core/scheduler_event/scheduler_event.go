@@ -32,6 +32,7 @@ const (
 	TaskEnded              = "Scheduler.TaskEnded"
 	TaskDisabled           = "Scheduler.TaskDisabled"
 	MetricCollected        = "Scheduler.MetricsCollected"
+	MetricProcessed        = "Scheduler.MetricsProcessed"
 	MetricCollectionFailed = "Scheduler.MetricCollectionFailed"
 )
 
@@ -108,6 +109,17 @@ func (e MetricCollectedEvent) Namespace() string {
 	return MetricCollected
 }
 
+type MetricProcessedEvent struct {
+	TaskID        string
+	PluginName    string
+	PluginVersion int
+	Metrics       []core.Metric
+}
+
+func (e MetricProcessedEvent) Namespace() string {
+	return MetricProcessed
+}
+
 type MetricCollectionFailedEvent struct {
 	TaskID string
 	Errors []error
@@ -69,6 +69,13 @@ type TaskWatcherHandler interface {
 	CatchTaskDisabled(string)
 }
 
+// TaskProcessWatcherHandler is a TaskWatcherHandler which also catches the
+// metrics returned by the process nodes of the watched task.
+type TaskProcessWatcherHandler interface {
+	TaskWatcherHandler
+	CatchProcessed(pluginName string, pluginVersion int, m []Metric)
+}
+
 func (t TaskState) String() string {
 	return TaskStateLookup[t]
 }
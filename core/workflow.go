@@ -19,6 +19,8 @@ limitations under the License.
 
 package core
 
+import "github.com/intelsdi-x/snap/core/ctypes"
+
 type WorkflowState int
 
 const (
@@ -31,3 +33,46 @@ type Workflow interface {
 	Unmarshal([]byte) error
 	State() WorkflowState
 }
+
+// Sources of the config settings of a workflow which are not part of the
+// global plugin config (whose sources follow the layout of the config file,
+// e.g. "plugins.collector.psutil.versions.6").
+const (
+	// ConfigSourceWorkflow is the config of a process or publish node of the
+	// workflow. The config of the collect node is reported with the namespace
+	// it is set at, e.g. "workflow:/intel/psutil".
+	ConfigSourceWorkflow = "workflow"
+	// ConfigSourcePolicy is a default value of the config policy of a plugin.
+	ConfigSourcePolicy = "policy"
+)
+
+// ConfigSetting is the value a plugin receives for a config key and the
+// config layer it comes from.
+type ConfigSetting struct {
+	Value  ctypes.ConfigValue
+	Source string
+}
+
+// MetricConfig is the effective config of a metric collected by a workflow.
+type MetricConfig struct {
+	Namespace     Namespace
+	Version       int
+	PluginName    string
+	PluginVersion int
+	Config        map[string]ConfigSetting
+}
+
+// NodeConfig is the effective config of a process or publish node of a workflow.
+type NodeConfig struct {
+	PluginType    PluginType
+	PluginName    string
+	PluginVersion int
+	Config        map[string]ConfigSetting
+}
+
+// WorkflowConfig is the effective config of the metrics and of the process
+// and publish nodes of a workflow.
+type WorkflowConfig struct {
+	Metrics []MetricConfig
+	Nodes   []NodeConfig
+}
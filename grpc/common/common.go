@@ -46,6 +46,8 @@ func ToMetric(co core.Metric) *Metric {
 			Sec:  time.Now().Unix(),
 			Nsec: int64(time.Now().Nanosecond()),
 		},
+		Unit: co.Unit(),
+		Kind: string(core.KindOf(co)),
 	}
 	if co.Config() != nil {
 		cm.Config = ConfigToConfigMap(co.Config())
@@ -67,6 +69,10 @@ func ToMetric(co core.Metric) *Metric {
 		cm.Data = &Metric_Uint32Data{t}
 	case uint64:
 		cm.Data = &Metric_Uint64Data{t}
+	case core.Distribution:
+		cm.Data = &Metric_DistributionData{ToDistribution(t)}
+	case *core.Distribution:
+		cm.Data = &Metric_DistributionData{ToDistribution(*t)}
 	case []byte:
 		cm.Data = &Metric_BytesData{t}
 	case bool:
@@ -93,6 +99,26 @@ func ToNamespace(n core.Namespace) []*NamespaceElement {
 	return elements
 }
 
+// Convert core.Distribution to common.Distribution protobuf message
+func ToDistribution(d core.Distribution) *Distribution {
+	return &Distribution{
+		Bounds: d.Bounds,
+		Counts: d.Counts,
+		Sum:    d.Sum,
+		Count:  d.Count,
+	}
+}
+
+// Convert common.Distribution protobuf message to core.Distribution
+func ToCoreDistribution(d *Distribution) core.Distribution {
+	return core.Distribution{
+		Bounds: d.GetBounds(),
+		Counts: d.GetCounts(),
+		Sum:    d.GetSum(),
+		Count:  d.GetCount(),
+	}
+}
+
 func ToTime(t time.Time) *Time {
 	return &Time{
 		Nsec: t.Unix(),
@@ -119,6 +145,7 @@ type metric struct {
 	tags               map[string]string
 	description        string
 	unit               string
+	kind               core.MetricKind
 }
 
 func (m *metric) Namespace() core.Namespace     { return m.namespace }
@@ -130,6 +157,7 @@ func (m *metric) LastAdvertisedTime() time.Time { return m.lastAdvertisedTime }
 func (m *metric) Timestamp() time.Time          { return m.timeStamp }
 func (m *metric) Description() string           { return m.description }
 func (m *metric) Unit() string                  { return m.unit }
+func (m *metric) Kind() core.MetricKind         { return m.kind }
 
 // Convert common.Metric to core.Metric
 func ToCoreMetric(mt *Metric) core.Metric {
@@ -150,6 +178,7 @@ func ToCoreMetric(mt *Metric) core.Metric {
 		config:             ConfigMapToConfig(mt.Config),
 		description:        mt.Description,
 		unit:               mt.Unit,
+		kind:               core.MetricKind(mt.Kind),
 	}
 
 	switch mt.Data.(type) {
@@ -169,6 +198,8 @@ func ToCoreMetric(mt *Metric) core.Metric {
 		ret.data = mt.GetUint32Data()
 	case *Metric_Uint64Data:
 		ret.data = mt.GetUint64Data()
+	case *Metric_DistributionData:
+		ret.data = ToCoreDistribution(mt.GetDistributionData())
 	case *Metric_BoolData:
 		ret.data = mt.GetBoolData()
 	}
@@ -382,6 +413,18 @@ func ParseConfig(config *ConfigMap) map[string]ctypes.ConfigValue {
 		bval := ctypes.ConfigValueBool{Value: v}
 		c[k] = bval
 	}
+	for k, v := range config.DurationMap {
+		dval := ctypes.ConfigValueDuration{Value: time.Duration(v)}
+		c[k] = dval
+	}
+	for k, v := range config.StringListMap {
+		lval := ctypes.ConfigValueStrList{Value: v.GetValue()}
+		c[k] = lval
+	}
+	for k, v := range config.SecretMap {
+		sval := ctypes.ConfigValueSecret{Ref: v}
+		c[k] = sval
+	}
 	return c
 }
 
@@ -396,10 +439,13 @@ func ConfigToConfigMap(cd *cdata.ConfigDataNode) *ConfigMap {
 // Converts ConfigDataNode to ConfigMap protobuf message
 func ToConfigMap(cv map[string]ctypes.ConfigValue) *ConfigMap {
 	newConfig := &ConfigMap{
-		IntMap:    make(map[string]int64),
-		FloatMap:  make(map[string]float64),
-		StringMap: make(map[string]string),
-		BoolMap:   make(map[string]bool),
+		IntMap:        make(map[string]int64),
+		FloatMap:      make(map[string]float64),
+		StringMap:     make(map[string]string),
+		BoolMap:       make(map[string]bool),
+		DurationMap:   make(map[string]int64),
+		StringListMap: make(map[string]*StringList),
+		SecretMap:     make(map[string]string),
 	}
 	for k, v := range cv {
 		switch v.Type() {
@@ -411,6 +457,12 @@ func ToConfigMap(cv map[string]ctypes.ConfigValue) *ConfigMap {
 			newConfig.StringMap[k] = v.(ctypes.ConfigValueStr).Value
 		case "bool":
 			newConfig.BoolMap[k] = v.(ctypes.ConfigValueBool).Value
+		case "duration":
+			newConfig.DurationMap[k] = int64(v.(ctypes.ConfigValueDuration).Value)
+		case "string_list":
+			newConfig.StringListMap[k] = &StringList{Value: v.(ctypes.ConfigValueStrList).Value}
+		case "secret":
+			newConfig.SecretMap[k] = v.(ctypes.ConfigValueSecret).Ref
 		}
 	}
 	return newConfig
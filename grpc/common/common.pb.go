@@ -36,6 +36,8 @@ It has these top-level messages:
 	SubscribedPlugin
 	ConfigMap
 	Plugin
+	StringList
+	Distribution
 */
 package common
 
@@ -154,7 +156,11 @@ type Metric struct {
 	//	*Metric_BoolData
 	//	*Metric_Uint32Data
 	//	*Metric_Uint64Data
+	//	*Metric_DistributionData
 	Data isMetric_Data `protobuf_oneof:"data"`
+	// Kind is the metric kind (gauge, counter, delta, distribution or state),
+	// empty for plugins which don't declare one.
+	Kind string `protobuf:"bytes,18,opt,name=Kind" json:"Kind,omitempty"`
 }
 
 func (m *Metric) Reset()                    { *m = Metric{} }
@@ -193,16 +199,20 @@ type Metric_Uint32Data struct {
 type Metric_Uint64Data struct {
 	Uint64Data uint64 `protobuf:"varint,17,opt,name=uint64_data,json=uint64Data,oneof"`
 }
+type Metric_DistributionData struct {
+	DistributionData *Distribution `protobuf:"bytes,19,opt,name=distribution_data,json=distributionData,oneof"`
+}
 
-func (*Metric_StringData) isMetric_Data()  {}
-func (*Metric_Float32Data) isMetric_Data() {}
-func (*Metric_Float64Data) isMetric_Data() {}
-func (*Metric_Int32Data) isMetric_Data()   {}
-func (*Metric_Int64Data) isMetric_Data()   {}
-func (*Metric_BytesData) isMetric_Data()   {}
-func (*Metric_BoolData) isMetric_Data()    {}
-func (*Metric_Uint32Data) isMetric_Data()  {}
-func (*Metric_Uint64Data) isMetric_Data()  {}
+func (*Metric_StringData) isMetric_Data()       {}
+func (*Metric_Float32Data) isMetric_Data()      {}
+func (*Metric_Float64Data) isMetric_Data()      {}
+func (*Metric_Int32Data) isMetric_Data()        {}
+func (*Metric_Int64Data) isMetric_Data()        {}
+func (*Metric_BytesData) isMetric_Data()        {}
+func (*Metric_BoolData) isMetric_Data()         {}
+func (*Metric_Uint32Data) isMetric_Data()       {}
+func (*Metric_Uint64Data) isMetric_Data()       {}
+func (*Metric_DistributionData) isMetric_Data() {}
 
 func (m *Metric) GetData() isMetric_Data {
 	if m != nil {
@@ -330,6 +340,20 @@ func (m *Metric) GetUint64Data() uint64 {
 	return 0
 }
 
+func (m *Metric) GetDistributionData() *Distribution {
+	if x, ok := m.GetData().(*Metric_DistributionData); ok {
+		return x.DistributionData
+	}
+	return nil
+}
+
+func (m *Metric) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Metric) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Metric_OneofMarshaler, _Metric_OneofUnmarshaler, _Metric_OneofSizer, []interface{}{
@@ -342,6 +366,7 @@ func (*Metric) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error,
 		(*Metric_BoolData)(nil),
 		(*Metric_Uint32Data)(nil),
 		(*Metric_Uint64Data)(nil),
+		(*Metric_DistributionData)(nil),
 	}
 }
 
@@ -380,6 +405,11 @@ func _Metric_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 	case *Metric_Uint64Data:
 		b.EncodeVarint(17<<3 | proto.WireVarint)
 		b.EncodeVarint(uint64(x.Uint64Data))
+	case *Metric_DistributionData:
+		b.EncodeVarint(19<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DistributionData); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Metric.Data has unexpected type %T", x)
@@ -453,6 +483,14 @@ func _Metric_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer)
 		x, err := b.DecodeVarint()
 		m.Data = &Metric_Uint64Data{x}
 		return true, err
+	case 19: // data.distribution_data
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Distribution)
+		err := b.DecodeMessage(msg)
+		m.Data = &Metric_DistributionData{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -491,6 +529,11 @@ func _Metric_OneofSizer(msg proto.Message) (n int) {
 	case *Metric_Uint64Data:
 		n += proto.SizeVarint(17<<3 | proto.WireVarint)
 		n += proto.SizeVarint(uint64(x.Uint64Data))
+	case *Metric_DistributionData:
+		s := proto.Size(x.DistributionData)
+		n += proto.SizeVarint(19<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -577,6 +620,10 @@ type ConfigMap struct {
 	// double is float64
 	FloatMap map[string]float64 `protobuf:"bytes,3,rep,name=FloatMap" json:"FloatMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	BoolMap  map[string]bool    `protobuf:"bytes,4,rep,name=BoolMap" json:"BoolMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// durations are carried as nanoseconds
+	DurationMap   map[string]int64       `protobuf:"bytes,5,rep,name=DurationMap" json:"DurationMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	StringListMap map[string]*StringList `protobuf:"bytes,6,rep,name=StringListMap" json:"StringListMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SecretMap     map[string]string      `protobuf:"bytes,7,rep,name=SecretMap" json:"SecretMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *ConfigMap) Reset()                    { *m = ConfigMap{} }
@@ -612,6 +659,27 @@ func (m *ConfigMap) GetBoolMap() map[string]bool {
 	return nil
 }
 
+func (m *ConfigMap) GetDurationMap() map[string]int64 {
+	if m != nil {
+		return m.DurationMap
+	}
+	return nil
+}
+
+func (m *ConfigMap) GetStringListMap() map[string]*StringList {
+	if m != nil {
+		return m.StringListMap
+	}
+	return nil
+}
+
+func (m *ConfigMap) GetSecretMap() map[string]string {
+	if m != nil {
+		return m.SecretMap
+	}
+	return nil
+}
+
 // core.Plugin
 type Plugin struct {
 	TypeName string `protobuf:"bytes,1,opt,name=TypeName" json:"TypeName,omitempty"`
@@ -645,6 +713,66 @@ func (m *Plugin) GetVersion() int64 {
 	return 0
 }
 
+type StringList struct {
+	Value []string `protobuf:"bytes,1,rep,name=value" json:"value,omitempty"`
+}
+
+func (m *StringList) Reset()                    { *m = StringList{} }
+func (m *StringList) String() string            { return proto.CompactTextString(m) }
+func (*StringList) ProtoMessage()               {}
+func (*StringList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *StringList) GetValue() []string {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+// core.Distribution
+type Distribution struct {
+	// Bounds are the ascending upper bounds of the buckets.
+	Bounds []float64 `protobuf:"fixed64,1,rep,packed,name=Bounds" json:"Bounds,omitempty"`
+	// Counts holds one count per bound plus the count of the values above
+	// the last bound.
+	Counts []uint64 `protobuf:"varint,2,rep,packed,name=Counts" json:"Counts,omitempty"`
+	Sum    float64  `protobuf:"fixed64,3,opt,name=Sum" json:"Sum,omitempty"`
+	Count  uint64   `protobuf:"varint,4,opt,name=Count" json:"Count,omitempty"`
+}
+
+func (m *Distribution) Reset()                    { *m = Distribution{} }
+func (m *Distribution) String() string            { return proto.CompactTextString(m) }
+func (*Distribution) ProtoMessage()               {}
+func (*Distribution) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *Distribution) GetBounds() []float64 {
+	if m != nil {
+		return m.Bounds
+	}
+	return nil
+}
+
+func (m *Distribution) GetCounts() []uint64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+func (m *Distribution) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *Distribution) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Time)(nil), "common.Time")
 	proto.RegisterType((*Empty)(nil), "common.Empty")
@@ -655,6 +783,8 @@ func init() {
 	proto.RegisterType((*SubscribedPlugin)(nil), "common.SubscribedPlugin")
 	proto.RegisterType((*ConfigMap)(nil), "common.ConfigMap")
 	proto.RegisterType((*Plugin)(nil), "common.Plugin")
+	proto.RegisterType((*StringList)(nil), "common.StringList")
+	proto.RegisterType((*Distribution)(nil), "common.Distribution")
 }
 
 func init() {
@@ -662,54 +792,65 @@ func init() {
 }
 
 var fileDescriptor0 = []byte{
-	// 774 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x95, 0xd1, 0x4e, 0xdb, 0x30,
-	0x14, 0x86, 0x9b, 0x26, 0x4d, 0x9b, 0x93, 0xc2, 0x8a, 0xb5, 0x8b, 0xa8, 0x12, 0x23, 0x64, 0x37,
-	0xd9, 0xb4, 0xb5, 0x1a, 0x30, 0xc6, 0x00, 0x21, 0x8d, 0x51, 0xd4, 0x49, 0x30, 0x4d, 0x81, 0x71,
-	0x39, 0xe4, 0xb6, 0xa6, 0x8b, 0x96, 0x38, 0x51, 0xe2, 0x22, 0xfa, 0x04, 0x7b, 0xa2, 0x3d, 0xc4,
-	0x9e, 0x65, 0x2f, 0x31, 0xd9, 0x4e, 0xd2, 0x94, 0x32, 0x55, 0x48, 0xbb, 0x81, 0xe3, 0xdf, 0xdf,
-	0x7f, 0x72, 0x4e, 0x7c, 0x9c, 0xc2, 0xf6, 0xd8, 0x67, 0xdf, 0x27, 0x83, 0xce, 0x30, 0x0a, 0xbb,
-	0x3e, 0x65, 0x24, 0x48, 0x47, 0xfe, 0xeb, 0xbb, 0x6e, 0x4a, 0x71, 0xdc, 0x1d, 0x27, 0xf1, 0xb0,
-	0x3b, 0x8c, 0xc2, 0x30, 0xa2, 0xd9, 0xbf, 0x4e, 0x9c, 0x44, 0x2c, 0x42, 0xba, 0x5c, 0x39, 0xaf,
-	0x40, 0xbb, 0xf4, 0x43, 0x82, 0x5a, 0xa0, 0xa6, 0x64, 0x68, 0x29, 0xb6, 0xe2, 0xaa, 0x1e, 0x0f,
-	0x11, 0x02, 0x8d, 0x72, 0xa9, 0x2a, 0x24, 0x11, 0x3b, 0x75, 0xa8, 0xf5, 0xc2, 0x98, 0x4d, 0x9d,
-	0x5f, 0x0a, 0x18, 0x17, 0x14, 0xc7, 0xbd, 0x24, 0x89, 0x12, 0xb4, 0x09, 0x4d, 0xc2, 0x83, 0xeb,
-	0x94, 0x25, 0x3e, 0x1d, 0x8b, 0x2c, 0x86, 0x67, 0x0a, 0xed, 0x42, 0x48, 0xa8, 0x97, 0x23, 0x37,
-	0x3e, 0x09, 0x46, 0xa9, 0x55, 0xb5, 0x55, 0xd7, 0xdc, 0x72, 0x3a, 0x59, 0x51, 0x45, 0xae, 0x8e,
-	0xf8, 0x7b, 0x2a, 0xa0, 0x1e, 0x65, 0xc9, 0x34, 0x4b, 0x23, 0x95, 0xf6, 0x11, 0xb4, 0xee, 0x03,
-	0xbc, 0xf4, 0x1f, 0x64, 0x9a, 0x3d, 0x94, 0x87, 0xe8, 0x29, 0xd4, 0x6e, 0x71, 0x30, 0x21, 0xa2,
-	0x76, 0xc3, 0x93, 0x8b, 0xfd, 0xea, 0x9e, 0xe2, 0xbc, 0x81, 0xda, 0x19, 0x1e, 0x90, 0x80, 0x23,
-	0x3e, 0x1d, 0x91, 0x3b, 0x61, 0xd3, 0x3c, 0xb9, 0x10, 0x3d, 0xe3, 0x30, 0xf7, 0x89, 0xd8, 0xf9,
-	0x5d, 0x03, 0xfd, 0x9c, 0xb0, 0xc4, 0x1f, 0xa2, 0x5d, 0x30, 0x3e, 0xe3, 0x90, 0xa4, 0x31, 0x1e,
-	0x12, 0x4b, 0x11, 0x1d, 0x58, 0x79, 0x07, 0xc5, 0x46, 0x2f, 0x20, 0x21, 0xa1, 0xcc, 0x9b, 0xa1,
-	0xc8, 0x82, 0xfa, 0x15, 0x49, 0x52, 0x3f, 0xa2, 0xd9, 0xdb, 0xcc, 0x97, 0xe8, 0x05, 0xe8, 0x1f,
-	0x23, 0x7a, 0xe3, 0x8f, 0x2d, 0xd5, 0x56, 0x5c, 0x73, 0x6b, 0x2d, 0x4f, 0x27, 0xd5, 0x73, 0x1c,
-	0x7b, 0x19, 0x80, 0x0e, 0x01, 0x9d, 0xe1, 0x94, 0x7d, 0x18, 0xdd, 0x92, 0x84, 0xf9, 0x29, 0x19,
-	0xf1, 0x73, 0xb3, 0x34, 0x61, 0x6b, 0xe6, 0x36, 0xae, 0x79, 0x0f, 0x70, 0x88, 0x9f, 0x33, 0x1e,
-	0xa7, 0x56, 0x6d, 0xbe, 0x6a, 0xd9, 0x58, 0x87, 0x6f, 0xc9, 0xb7, 0x2d, 0x28, 0xf4, 0x12, 0x0c,
-	0xee, 0x4a, 0x19, 0x0e, 0x63, 0x4b, 0x7f, 0xe0, 0x11, 0xb3, 0x6d, 0xfe, 0xce, 0xbe, 0x52, 0x9f,
-	0x59, 0x75, 0xf9, 0xce, 0x78, 0x8c, 0x6c, 0x30, 0x4f, 0x48, 0x3a, 0x4c, 0xfc, 0x98, 0xf1, 0xa6,
-	0x1b, 0x72, 0x1e, 0x4a, 0x12, 0xda, 0x04, 0x53, 0x0e, 0xcb, 0xf5, 0x08, 0x33, 0x6c, 0x19, 0x9c,
-	0xe8, 0x57, 0x3c, 0x90, 0xe2, 0x09, 0x66, 0x18, 0x3d, 0x87, 0xe6, 0x4d, 0x10, 0x61, 0xb6, 0xbd,
-	0x25, 0x19, 0xb0, 0x15, 0xb7, 0xda, 0xaf, 0x78, 0x66, 0xa6, 0xce, 0x41, 0xbb, 0x3b, 0x12, 0x32,
-	0x6d, 0xc5, 0x55, 0x0a, 0x68, 0x77, 0x47, 0x40, 0x1b, 0x00, 0x3e, 0x2d, 0xf2, 0x34, 0x6d, 0xc5,
-	0xad, 0xf5, 0x2b, 0x9e, 0x21, 0xb4, 0x12, 0x90, 0xe7, 0x58, 0xe1, 0x67, 0x94, 0x01, 0xb3, 0x0c,
-	0x83, 0x29, 0x23, 0xa9, 0x04, 0x56, 0x6d, 0xc5, 0x6d, 0x72, 0x40, 0x68, 0x02, 0x58, 0x07, 0x63,
-	0x10, 0x45, 0x81, 0xdc, 0x7f, 0x62, 0x2b, 0x6e, 0xa3, 0x5f, 0xf1, 0x1a, 0x5c, 0x12, 0xdb, 0x9b,
-	0x60, 0x4e, 0x4a, 0x25, 0xb4, 0x6c, 0xc5, 0x5d, 0xe1, 0xed, 0x4e, 0x66, 0x35, 0x64, 0x48, 0x5e,
-	0xc4, 0x1a, 0x9f, 0xcb, 0x1c, 0x91, 0x55, 0xb4, 0xdf, 0x81, 0x51, 0x9c, 0xd4, 0x63, 0xc6, 0xfe,
-	0x58, 0x07, 0x8d, 0x27, 0x75, 0xbe, 0x41, 0xeb, 0xfe, 0x9c, 0x72, 0xd7, 0x95, 0x70, 0xc9, 0x4c,
-	0x72, 0x71, 0xff, 0x04, 0xab, 0x8b, 0x27, 0x88, 0x40, 0xe3, 0xb9, 0xc4, 0xe0, 0x1a, 0x9e, 0x88,
-	0x9d, 0x9f, 0x0a, 0xb4, 0x2e, 0x26, 0x03, 0x0e, 0x0d, 0xc8, 0xe8, 0x4b, 0x30, 0x19, 0xfb, 0x14,
-	0xb5, 0xa1, 0x71, 0x39, 0x8d, 0x89, 0x80, 0xe5, 0x33, 0x8a, 0x75, 0x91, 0xa4, 0x3a, 0x4b, 0x52,
-	0xbe, 0x2d, 0xea, 0xbf, 0x6e, 0x8b, 0xb6, 0xe4, 0xb6, 0x38, 0x7f, 0x54, 0x30, 0x0a, 0x15, 0xbd,
-	0x05, 0xfd, 0x13, 0x65, 0xe7, 0x38, 0xce, 0x6e, 0xed, 0xfa, 0x82, 0xb1, 0x23, 0xf7, 0xe5, 0x25,
-	0xc8, 0x60, 0x74, 0x04, 0x86, 0xfc, 0x7c, 0x71, 0xa7, 0xfc, 0x62, 0xd9, 0x8b, 0xce, 0x02, 0x91,
-	0xe6, 0x99, 0x05, 0x1d, 0x40, 0xe3, 0x94, 0x8f, 0x21, 0xb7, 0xab, 0xc2, 0xbe, 0xb1, 0x68, 0xcf,
-	0x09, 0xe9, 0x2e, 0x0c, 0x68, 0x0f, 0xea, 0xc7, 0x51, 0x14, 0x70, 0xaf, 0x26, 0xbc, 0xcf, 0x16,
-	0xbd, 0x19, 0x20, 0xad, 0x39, 0xde, 0x7e, 0x0f, 0x66, 0xa9, 0x9b, 0x65, 0x83, 0xa2, 0x96, 0x06,
-	0xa5, 0x7d, 0x08, 0xab, 0xf3, 0xed, 0x3c, 0x66, 0xcc, 0xda, 0x07, 0xb0, 0x32, 0xd7, 0xcd, 0x32,
-	0xb3, 0x52, 0x36, 0xef, 0x43, 0xb3, 0xdc, 0xce, 0x32, 0x6f, 0xa3, 0xfc, 0x59, 0xf7, 0x40, 0xff,
-	0xdf, 0xc3, 0x36, 0xd0, 0xc5, 0x0f, 0xe5, 0xf6, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd1, 0xc9,
-	0x60, 0x28, 0x5f, 0x07, 0x00, 0x00,
+	// 958 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6e, 0xe3, 0x54,
+	0x10, 0x8e, 0x13, 0xc7, 0x89, 0xc7, 0xe9, 0x92, 0x1e, 0x56, 0xc8, 0x8a, 0xb4, 0xac, 0x6b, 0xb8,
+	0x30, 0x08, 0x52, 0xd1, 0x2e, 0x65, 0xd9, 0x5d, 0x55, 0xa2, 0x4d, 0x56, 0x01, 0x5a, 0x84, 0xdc,
+	0xb2, 0x97, 0xac, 0x9c, 0xf8, 0x34, 0x58, 0xc4, 0x3f, 0xb2, 0x8f, 0x57, 0xcd, 0x13, 0x70, 0xcd,
+	0x2b, 0xf0, 0x0e, 0xbc, 0x1f, 0x9a, 0x39, 0xfe, 0x4b, 0xd2, 0xaa, 0xaa, 0xc4, 0x4d, 0x3b, 0x33,
+	0xe7, 0xfb, 0xe6, 0xcc, 0xef, 0x89, 0xe1, 0x78, 0x19, 0x88, 0x3f, 0xf2, 0xf9, 0x78, 0x11, 0x87,
+	0x87, 0x41, 0x24, 0xf8, 0x2a, 0xf3, 0x83, 0xaf, 0x6f, 0x0f, 0xb3, 0xc8, 0x4b, 0x0e, 0x97, 0x69,
+	0xb2, 0x38, 0x5c, 0xc4, 0x61, 0x18, 0x47, 0xc5, 0xbf, 0x71, 0x92, 0xc6, 0x22, 0x66, 0x9a, 0xd4,
+	0xec, 0xaf, 0x40, 0xbd, 0x0e, 0x42, 0xce, 0x86, 0xd0, 0xc9, 0xf8, 0xc2, 0x54, 0x2c, 0xc5, 0xe9,
+	0xb8, 0x28, 0x32, 0x06, 0x6a, 0x84, 0xa6, 0x36, 0x99, 0x48, 0xb6, 0x7b, 0xd0, 0x9d, 0x86, 0x89,
+	0x58, 0xdb, 0xff, 0x2a, 0xa0, 0x5f, 0x45, 0x5e, 0x32, 0x4d, 0xd3, 0x38, 0x65, 0x07, 0x30, 0xe0,
+	0x28, 0xbc, 0xcf, 0x44, 0x1a, 0x44, 0x4b, 0xf2, 0xa2, 0xbb, 0x06, 0xd9, 0xae, 0xc8, 0xc4, 0xa6,
+	0x25, 0xe4, 0x26, 0xe0, 0x2b, 0x3f, 0x33, 0xdb, 0x56, 0xc7, 0x31, 0x8e, 0xec, 0x71, 0x11, 0x54,
+	0xe5, 0x6b, 0x4c, 0x7f, 0xdf, 0x12, 0x68, 0x1a, 0x89, 0x74, 0x5d, 0xb8, 0x91, 0x96, 0xd1, 0x29,
+	0x0c, 0xb7, 0x01, 0x18, 0xfa, 0x9f, 0x7c, 0x5d, 0x5c, 0x8a, 0x22, 0x7b, 0x0a, 0xdd, 0x0f, 0xde,
+	0x2a, 0xe7, 0x14, 0xbb, 0xee, 0x4a, 0xe5, 0x55, 0xfb, 0xa5, 0x62, 0x7f, 0x03, 0xdd, 0x0b, 0x6f,
+	0xce, 0x57, 0x08, 0x09, 0x22, 0x9f, 0xdf, 0x12, 0x4d, 0x75, 0xa5, 0x42, 0x39, 0x7b, 0x61, 0xc9,
+	0x23, 0xd9, 0xfe, 0x47, 0x03, 0xed, 0x92, 0x8b, 0x34, 0x58, 0xb0, 0x13, 0xd0, 0x7f, 0xf1, 0x42,
+	0x9e, 0x25, 0xde, 0x82, 0x9b, 0x0a, 0x65, 0x60, 0x96, 0x19, 0x54, 0x07, 0xd3, 0x15, 0x0f, 0x79,
+	0x24, 0xdc, 0x1a, 0xca, 0x4c, 0xe8, 0xbd, 0xe3, 0x69, 0x16, 0xc4, 0x51, 0x51, 0xcd, 0x52, 0x65,
+	0x5f, 0x80, 0x76, 0x1e, 0x47, 0x37, 0xc1, 0xd2, 0xec, 0x58, 0x8a, 0x63, 0x1c, 0xed, 0x97, 0xee,
+	0xa4, 0xf5, 0xd2, 0x4b, 0xdc, 0x02, 0xc0, 0xde, 0x00, 0xbb, 0xf0, 0x32, 0xf1, 0x83, 0xff, 0x81,
+	0xa7, 0x22, 0xc8, 0xb8, 0x8f, 0x7d, 0x33, 0x55, 0xa2, 0x0d, 0x4a, 0x1a, 0xda, 0xdc, 0x3b, 0x70,
+	0x0c, 0xfb, 0xec, 0x2d, 0x33, 0xb3, 0xbb, 0x19, 0xb5, 0x4c, 0x6c, 0x8c, 0x47, 0xb2, 0xda, 0x84,
+	0x62, 0x5f, 0x82, 0x8e, 0xac, 0x4c, 0x78, 0x61, 0x62, 0x6a, 0x77, 0x5c, 0x51, 0x1f, 0x63, 0xcd,
+	0x7e, 0x8b, 0x02, 0x61, 0xf6, 0x64, 0xcd, 0x50, 0x66, 0x16, 0x18, 0x13, 0x9e, 0x2d, 0xd2, 0x20,
+	0x11, 0x98, 0x74, 0x5f, 0xce, 0x43, 0xc3, 0xc4, 0x0e, 0xc0, 0x90, 0xc3, 0xf2, 0xde, 0xf7, 0x84,
+	0x67, 0xea, 0x88, 0x98, 0xb5, 0x5c, 0x90, 0xc6, 0x89, 0x27, 0x3c, 0xf6, 0x19, 0x0c, 0x6e, 0x56,
+	0xb1, 0x27, 0x8e, 0x8f, 0x24, 0x06, 0x2c, 0xc5, 0x69, 0xcf, 0x5a, 0xae, 0x51, 0x58, 0x37, 0x40,
+	0x27, 0x2f, 0x24, 0xc8, 0xb0, 0x14, 0x47, 0xa9, 0x40, 0x27, 0x2f, 0x08, 0xf4, 0x1c, 0x20, 0x88,
+	0x2a, 0x3f, 0x03, 0x4b, 0x71, 0xba, 0xb3, 0x96, 0xab, 0x93, 0xad, 0x01, 0x28, 0x7d, 0xec, 0x61,
+	0x8f, 0x0a, 0x40, 0xed, 0x61, 0xbe, 0x16, 0x3c, 0x93, 0x80, 0x27, 0x96, 0xe2, 0x0c, 0x10, 0x40,
+	0x36, 0x02, 0x3c, 0x03, 0x7d, 0x1e, 0xc7, 0x2b, 0x79, 0xfe, 0x91, 0xa5, 0x38, 0xfd, 0x59, 0xcb,
+	0xed, 0xa3, 0x89, 0x8e, 0x0f, 0xc0, 0xc8, 0x1b, 0x21, 0x0c, 0x2d, 0xc5, 0xd9, 0xc3, 0x74, 0xf3,
+	0x3a, 0x86, 0x02, 0x52, 0x06, 0xb1, 0x8f, 0x73, 0x59, 0x42, 0x8a, 0x28, 0xce, 0x61, 0xdf, 0x0f,
+	0xb0, 0x42, 0xf3, 0x1c, 0x8b, 0x28, 0x81, 0x1f, 0x53, 0x7b, 0x9e, 0x96, 0xed, 0x99, 0x34, 0x00,
+	0xb3, 0x96, 0x3b, 0x6c, 0x12, 0xc8, 0x09, 0x03, 0xf5, 0xe7, 0x20, 0xf2, 0x4d, 0x26, 0xfb, 0x85,
+	0xf2, 0xe8, 0x3b, 0xd0, 0xab, 0x11, 0x78, 0xcc, 0x3e, 0x9d, 0x69, 0xa0, 0x62, 0x10, 0xf6, 0xef,
+	0x30, 0xdc, 0x5e, 0x00, 0x64, 0xbd, 0x23, 0x96, 0xf4, 0x24, 0x95, 0xed, 0xd1, 0x68, 0xef, 0x8e,
+	0x06, 0x03, 0x15, 0x7d, 0xd1, 0x46, 0xe8, 0x2e, 0xc9, 0xf6, 0x5f, 0x0a, 0x0c, 0xaf, 0xf2, 0x39,
+	0x82, 0xe6, 0xdc, 0xff, 0x75, 0x95, 0x2f, 0x83, 0x88, 0x8d, 0xa0, 0x7f, 0xbd, 0x4e, 0x38, 0x81,
+	0xe5, 0x1d, 0x95, 0x5e, 0x39, 0x69, 0xd7, 0x4e, 0x9a, 0x6b, 0xd8, 0xb9, 0x6f, 0x0d, 0xd5, 0x07,
+	0xd6, 0xd0, 0xfe, 0xbb, 0x07, 0x7a, 0x65, 0x65, 0xdf, 0x82, 0xf6, 0x63, 0x24, 0x2e, 0xbd, 0xa4,
+	0x78, 0x0e, 0x9e, 0xed, 0x10, 0xc7, 0xf2, 0x5c, 0x6e, 0x57, 0x01, 0x66, 0xa7, 0xa0, 0xcb, 0x77,
+	0x11, 0x99, 0xf2, 0x29, 0xb4, 0x76, 0x99, 0x15, 0x44, 0x92, 0x6b, 0x0a, 0x7b, 0x0d, 0xfd, 0xb7,
+	0x38, 0xdf, 0x48, 0xef, 0x10, 0xfd, 0xf9, 0x2e, 0xbd, 0x44, 0x48, 0x76, 0x45, 0x60, 0x2f, 0xa1,
+	0x77, 0x16, 0xc7, 0x2b, 0xe4, 0xaa, 0xc4, 0xfd, 0x74, 0x97, 0x5b, 0x00, 0x24, 0xb5, 0x84, 0xb3,
+	0x09, 0x18, 0x93, 0x3c, 0xf5, 0xb0, 0x4b, 0xc8, 0xee, 0x6e, 0xbe, 0xe1, 0x35, 0xbb, 0x01, 0x2a,
+	0xde, 0xf0, 0x86, 0x85, 0xfd, 0x04, 0x7b, 0x32, 0x93, 0x8b, 0x20, 0xa3, 0x0c, 0x34, 0xf2, 0xf3,
+	0xf9, 0x7d, 0x05, 0x28, 0x60, 0xd2, 0xd3, 0x26, 0x95, 0x0a, 0xc9, 0x17, 0x29, 0x27, 0x3f, 0xbd,
+	0x7b, 0x0b, 0x59, 0x42, 0xca, 0x42, 0x96, 0xfa, 0xe8, 0x7b, 0x30, 0x1a, 0xfd, 0x79, 0x68, 0xf4,
+	0x3b, 0x8d, 0xd1, 0x1f, 0xbd, 0x81, 0x27, 0x9b, 0x0d, 0x7a, 0xcc, 0xe2, 0x8c, 0x5e, 0xc3, 0xde,
+	0x46, 0x7f, 0x1e, 0x22, 0x2b, 0x4d, 0xf2, 0x2b, 0x18, 0x34, 0x1b, 0xf4, 0x10, 0xb7, 0xdf, 0xe4,
+	0x9e, 0xc2, 0x70, 0xbb, 0x3d, 0x8f, 0x4a, 0xfb, 0x1a, 0xd8, 0x6e, 0x5b, 0xee, 0xf0, 0xe0, 0x34,
+	0x3d, 0x18, 0x47, 0xac, 0xfa, 0xa5, 0xaf, 0xc8, 0xdb, 0xc5, 0xdc, 0x68, 0xd2, 0xa3, 0x7e, 0xd5,
+	0x5d, 0xd0, 0xfe, 0xef, 0x27, 0xc1, 0xb6, 0x01, 0xea, 0x50, 0xeb, 0xbb, 0x71, 0xcd, 0xcb, 0xbb,
+	0xed, 0x1b, 0x18, 0x34, 0x9f, 0x5b, 0xf6, 0x09, 0x68, 0x67, 0x71, 0x1e, 0xf9, 0x19, 0xc1, 0x14,
+	0xb7, 0xd0, 0xd0, 0x7e, 0x1e, 0xe7, 0x91, 0x90, 0x9f, 0x3d, 0xaa, 0x5b, 0x68, 0x98, 0xe3, 0x55,
+	0x1e, 0xd2, 0xcd, 0x8a, 0x8b, 0x22, 0xde, 0x43, 0x67, 0xf4, 0x0e, 0xa9, 0xae, 0x54, 0xe6, 0x1a,
+	0x7d, 0xb3, 0x1d, 0xff, 0x37, 0x00, 0x37, 0x39, 0xe3, 0xf1, 0xea, 0x09, 0x00, 0x00,
 }
@@ -0,0 +1,126 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// default configuration values
+const (
+	defaultMaxDisabledTasks       int     = -1
+	defaultMaxDeadPluginPools     int     = 0
+	defaultMaxQueueSaturation     float64 = 0.9
+	defaultRequireTribeMembership bool    = false
+)
+
+// holds the configuration passed in through the SNAP config file
+//   Note: if this struct is modified, then the switch statement in the
+//         UnmarshalJSON method in this same file needs to be modified to
+//         match the field mapping that is defined here
+type Config struct {
+	// MaxDisabledTasks is the number of disabled tasks above which snapteld
+	// isn't ready, -1 means disabled tasks only degrade it
+	MaxDisabledTasks int `json:"max_disabled_tasks"yaml:"max_disabled_tasks"`
+	// MaxDeadPluginPools is the number of plugins without running instance
+	// above which snapteld isn't ready, -1 means they only degrade it
+	MaxDeadPluginPools int `json:"max_dead_plugin_pools"yaml:"max_dead_plugin_pools"`
+	// MaxQueueSaturation is the fraction of the limit of a work queue from
+	// which snapteld isn't ready
+	MaxQueueSaturation float64 `json:"max_queue_saturation"yaml:"max_queue_saturation"`
+	// RequireTribeMembership makes snapteld unready, instead of degraded,
+	// while it is the only member of its tribe
+	RequireTribeMembership bool `json:"require_tribe_membership"yaml:"require_tribe_membership"`
+}
+
+const (
+	CONFIG_CONSTRAINTS = `
+			"health": {
+				"type": ["object", "null"],
+				"properties": {
+					"max_disabled_tasks": {
+						"type": "integer",
+						"minimum": -1
+					},
+					"max_dead_plugin_pools": {
+						"type": "integer",
+						"minimum": -1
+					},
+					"max_queue_saturation": {
+						"type": "number",
+						"minimum": 0,
+						"maximum": 1
+					},
+					"require_tribe_membership": {
+						"type": "boolean"
+					}
+				},
+				"additionalProperties": false
+			}
+	`
+)
+
+// get the default snapteld configuration
+func GetDefaultConfig() *Config {
+	return &Config{
+		MaxDisabledTasks:       defaultMaxDisabledTasks,
+		MaxDeadPluginPools:     defaultMaxDeadPluginPools,
+		MaxQueueSaturation:     defaultMaxQueueSaturation,
+		RequireTribeMembership: defaultRequireTribeMembership,
+	}
+}
+
+// UnmarshalJSON unmarshals valid json into a Config.  An example Config can be found
+// at github.com/intelsdi-x/snap/blob/master/examples/configs/snap-config-sample.json
+func (c *Config) UnmarshalJSON(data []byte) error {
+	// construct a map of strings to json.RawMessages (to defer the parsing of individual
+	// fields from the unmarshalled interface until later) and unmarshal the input
+	// byte array into that map
+	t := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	// loop through the individual map elements, parse each in turn, and set
+	// the appropriate field in this configuration
+	for k, v := range t {
+		switch k {
+		case "max_disabled_tasks":
+			if err := json.Unmarshal(v, &(c.MaxDisabledTasks)); err != nil {
+				return fmt.Errorf("%v (while parsing 'health::max_disabled_tasks')", err)
+			}
+		case "max_dead_plugin_pools":
+			if err := json.Unmarshal(v, &(c.MaxDeadPluginPools)); err != nil {
+				return fmt.Errorf("%v (while parsing 'health::max_dead_plugin_pools')", err)
+			}
+		case "max_queue_saturation":
+			if err := json.Unmarshal(v, &(c.MaxQueueSaturation)); err != nil {
+				return fmt.Errorf("%v (while parsing 'health::max_queue_saturation')", err)
+			}
+		case "require_tribe_membership":
+			if err := json.Unmarshal(v, &(c.RequireTribeMembership)); err != nil {
+				return fmt.Errorf("%v (while parsing 'health::require_tribe_membership')", err)
+			}
+		default:
+			return fmt.Errorf("Unrecognized key '%v' in global config file while parsing 'health'", k)
+		}
+	}
+	return nil
+}
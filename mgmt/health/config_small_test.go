@@ -0,0 +1,84 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"testing"
+
+	"github.com/intelsdi-x/snap/pkg/cfgfile"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	MOCK_CONSTRAINTS = `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"title": "snapteld global config schema",
+		"type": ["object", "null"],
+		"properties": {
+			"health": { "$ref": "#/definitions/health"}
+		},
+		"additionalProperties": true,
+		"definitions": { ` + CONFIG_CONSTRAINTS + `}` +
+		`}`
+)
+
+type mockConfig struct {
+	Health *Config
+}
+
+func TestHealthConfig(t *testing.T) {
+	for _, path := range []string{
+		"../../examples/configs/snap-config-sample.json",
+		"../../examples/configs/snap-config-sample.yaml",
+	} {
+		config := &mockConfig{
+			Health: GetDefaultConfig(),
+		}
+		err := cfgfile.Read(path, &config, MOCK_CONSTRAINTS)
+		Convey("Provided a valid config in "+path, t, func() {
+			So(err, ShouldBeNil)
+			So(config.Health.MaxDisabledTasks, ShouldEqual, 2)
+			So(config.Health.MaxDeadPluginPools, ShouldEqual, 1)
+			So(config.Health.MaxQueueSaturation, ShouldEqual, 0.8)
+			So(config.Health.RequireTribeMembership, ShouldBeTrue)
+		})
+	}
+	Convey("The settings missing from the config keep their default", t, func() {
+		c := GetDefaultConfig()
+		So(c.UnmarshalJSON([]byte(`{"max_disabled_tasks": 3}`)), ShouldBeNil)
+		So(c.MaxDisabledTasks, ShouldEqual, 3)
+		So(c.MaxDeadPluginPools, ShouldEqual, defaultMaxDeadPluginPools)
+		So(c.MaxQueueSaturation, ShouldEqual, defaultMaxQueueSaturation)
+		So(c.RequireTribeMembership, ShouldEqual, defaultRequireTribeMembership)
+		So(c.UnmarshalJSON([]byte(`{"max_disabled": 3}`)), ShouldNotBeNil)
+		So(c.UnmarshalJSON([]byte(`{"max_queue_saturation": "high"}`)), ShouldNotBeNil)
+	})
+	Convey("Thresholds are validated", t, func() {
+		for _, data := range []string{
+			`{"health": {"max_disabled_tasks": -2}}`,
+			`{"health": {"max_queue_saturation": 1.5}}`,
+		} {
+			So(cfgfile.ValidateSchema(MOCK_CONSTRAINTS, data), ShouldNotBeEmpty)
+		}
+		So(cfgfile.ValidateSchema(MOCK_CONSTRAINTS, `{"health": {"max_disabled_tasks": -1}}`), ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,319 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health checks the liveness and the readiness of snapteld from the
+// state of control, the scheduler and tribe.
+package health
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/intelsdi-x/snap/core"
+)
+
+// Status of snapteld or of one of its subsystems
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// Names of the subsystems checked
+const (
+	ControlSubsystem   = "control"
+	SchedulerSubsystem = "scheduler"
+	TribeSubsystem     = "tribe"
+	PluginsSubsystem   = "plugins"
+	TasksSubsystem     = "tasks"
+	QueuesSubsystem    = "queues"
+)
+
+// ManagesControl is implemented by the plugin control module
+type ManagesControl interface {
+	HealthCheck() core.ControlHealth
+}
+
+// ManagesScheduler is implemented by the scheduler module
+type ManagesScheduler interface {
+	HealthCheck() core.SchedulerHealth
+}
+
+// ManagesTribe is implemented by the tribe module
+type ManagesTribe interface {
+	HealthCheck() core.TribeHealth
+}
+
+// Report is the result of a health check, its status is the worst status of
+// its subsystems
+type Report struct {
+	Status     string      `json:"status"`
+	Time       time.Time   `json:"time"`
+	Subsystems []Subsystem `json:"subsystems"`
+}
+
+// Subsystem is the result of the check of a subsystem
+type Subsystem struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Message explains why the subsystem isn't up
+	Message string      `json:"message,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// Summary returns a copy of the report without the messages and the details
+// of the subsystems, which tell about the plugins, tasks and tribe members
+func (r Report) Summary() Report {
+	s := Report{Status: r.Status, Time: r.Time, Subsystems: make([]Subsystem, len(r.Subsystems))}
+	for i, sub := range r.Subsystems {
+		s.Subsystems[i] = Subsystem{Name: sub.Name, Status: sub.Status}
+	}
+	return s
+}
+
+// PluginPool is the state of the running instances of a plugin
+type PluginPool struct {
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+	Version       int    `json:"version"`
+	Instances     int    `json:"instances"`
+	Subscriptions int    `json:"subscriptions"`
+	Restarts      int    `json:"restarts"`
+	Dead          bool   `json:"dead"`
+}
+
+// Queue is the state of a work queue of the scheduler
+type Queue struct {
+	Name       string  `json:"name"`
+	Length     int     `json:"length"`
+	Limit      uint    `json:"limit"`
+	Saturation float64 `json:"saturation"`
+	Rejected   uint64  `json:"rejected"`
+}
+
+// Checker checks the health of snapteld
+type Checker struct {
+	config    *Config
+	control   ManagesControl
+	scheduler ManagesScheduler
+	tribe     ManagesTribe
+}
+
+// New returns a Checker of the given control and scheduler modules
+func New(cfg *Config, c ManagesControl, s ManagesScheduler) *Checker {
+	return &Checker{
+		config:    cfg,
+		control:   c,
+		scheduler: s,
+	}
+}
+
+// SetTribe adds the tribe membership to the readiness checks
+func (c *Checker) SetTribe(t ManagesTribe) {
+	c.tribe = t
+}
+
+// Live checks that control and the scheduler are started
+func (c *Checker) Live() Report {
+	ch := c.control.HealthCheck()
+	sh := c.scheduler.HealthCheck()
+	return newReport(
+		checkControl(ch),
+		checkScheduler(sh),
+	)
+}
+
+// Ready checks that control and the scheduler are started and that the
+// plugins, tasks, work queues and tribe membership are within the
+// thresholds of the configuration
+func (c *Checker) Ready() Report {
+	ch := c.control.HealthCheck()
+	sh := c.scheduler.HealthCheck()
+	subsystems := []Subsystem{
+		checkControl(ch),
+		checkScheduler(sh),
+		c.checkPlugins(ch.Pools),
+		c.checkTasks(sh),
+		c.checkQueues(sh.Queues),
+	}
+	if c.tribe != nil {
+		subsystems = append(subsystems, c.checkTribe(c.tribe.HealthCheck()))
+	}
+	return newReport(subsystems...)
+}
+
+func newReport(subsystems ...Subsystem) Report {
+	r := Report{
+		Status:     StatusUp,
+		Time:       time.Now(),
+		Subsystems: subsystems,
+	}
+	for _, s := range subsystems {
+		r.Status = worst(r.Status, s.Status)
+	}
+	return r
+}
+
+func worst(a, b string) string {
+	if a == StatusDown || b == StatusDown {
+		return StatusDown
+	}
+	if a == StatusDegraded || b == StatusDegraded {
+		return StatusDegraded
+	}
+	return StatusUp
+}
+
+// threshold returns the status of n failures given the maximum allowed,
+// -1 meaning failures never bring the subsystem down
+func threshold(n, max int) string {
+	switch {
+	case n == 0:
+		return StatusUp
+	case max < 0 || n <= max:
+		return StatusDegraded
+	}
+	return StatusDown
+}
+
+func checkControl(h core.ControlHealth) Subsystem {
+	s := Subsystem{
+		Name:    ControlSubsystem,
+		Status:  StatusUp,
+		Details: map[string]int{"plugin_pools": len(h.Pools)},
+	}
+	if !h.Started {
+		s.Status = StatusDown
+		s.Message = "control is not started"
+	}
+	return s
+}
+
+func checkScheduler(h core.SchedulerHealth) Subsystem {
+	s := Subsystem{
+		Name:    SchedulerSubsystem,
+		Status:  StatusUp,
+		Details: map[string]int{"tasks": h.Tasks},
+	}
+	if !h.Started {
+		s.Status = StatusDown
+		s.Message = "scheduler is not started"
+	}
+	return s
+}
+
+func (c *Checker) checkPlugins(pools []core.PluginPoolHealth) Subsystem {
+	details := make([]PluginPool, len(pools))
+	dead := []string{}
+	for i, p := range pools {
+		details[i] = PluginPool{
+			Type:          p.Type,
+			Name:          p.Name,
+			Version:       p.Version,
+			Instances:     p.Instances,
+			Subscriptions: p.Subscriptions,
+			Restarts:      p.Restarts,
+			Dead:          p.Dead(),
+		}
+		if p.Dead() {
+			dead = append(dead, fmt.Sprintf("%s:%s:%d", p.Type, p.Name, p.Version))
+		}
+	}
+	s := Subsystem{
+		Name:    PluginsSubsystem,
+		Status:  threshold(len(dead), c.config.MaxDeadPluginPools),
+		Details: map[string][]PluginPool{"pools": details},
+	}
+	if len(dead) > 0 {
+		s.Message = fmt.Sprintf("no running instance of subscribed plugins: %s", strings.Join(dead, ", "))
+	}
+	return s
+}
+
+func (c *Checker) checkTasks(h core.SchedulerHealth) Subsystem {
+	s := Subsystem{
+		Name:   TasksSubsystem,
+		Status: threshold(len(h.DisabledTasks), c.config.MaxDisabledTasks),
+		Details: map[string]interface{}{
+			"total":    h.Tasks,
+			"disabled": h.DisabledTasks,
+		},
+	}
+	if len(h.DisabledTasks) > 0 {
+		s.Message = fmt.Sprintf("%d of %d tasks are disabled", len(h.DisabledTasks), h.Tasks)
+	}
+	return s
+}
+
+func (c *Checker) checkQueues(queues []core.QueueHealth) Subsystem {
+	details := make([]Queue, len(queues))
+	saturated := []string{}
+	for i, q := range queues {
+		details[i] = Queue{
+			Name:       q.Name,
+			Length:     q.Length,
+			Limit:      q.Limit,
+			Saturation: q.Saturation(),
+			Rejected:   q.Rejected,
+		}
+		if q.Limit > 0 && q.Saturation() >= c.config.MaxQueueSaturation {
+			saturated = append(saturated, q.Name)
+		}
+	}
+	s := Subsystem{
+		Name:    QueuesSubsystem,
+		Status:  StatusUp,
+		Details: map[string][]Queue{"queues": details},
+	}
+	if len(saturated) > 0 {
+		s.Status = StatusDown
+		s.Message = fmt.Sprintf("work queues saturated: %s", strings.Join(saturated, ", "))
+	}
+	return s
+}
+
+func (c *Checker) checkTribe(h core.TribeHealth) Subsystem {
+	s := Subsystem{
+		Name:   TribeSubsystem,
+		Status: StatusUp,
+		Details: map[string]interface{}{
+			"name":    h.Name,
+			"seed":    h.Seed,
+			"members": h.Members,
+		},
+	}
+	if len(h.Members) > 1 {
+		return s
+	}
+	switch {
+	case c.config.RequireTribeMembership:
+		s.Status = StatusDown
+	case h.Seed != "":
+		s.Status = StatusDegraded
+	default:
+		return s
+	}
+	if h.Seed != "" {
+		s.Message = fmt.Sprintf("no other tribe member, seed %s was not joined", h.Seed)
+	} else {
+		s.Message = "no other tribe member"
+	}
+	return s
+}
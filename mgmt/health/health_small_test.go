@@ -0,0 +1,163 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"testing"
+
+	"github.com/intelsdi-x/snap/core"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type mockControl struct {
+	health core.ControlHealth
+}
+
+func (m *mockControl) HealthCheck() core.ControlHealth {
+	return m.health
+}
+
+type mockScheduler struct {
+	health core.SchedulerHealth
+}
+
+func (m *mockScheduler) HealthCheck() core.SchedulerHealth {
+	return m.health
+}
+
+type mockTribe struct {
+	health core.TribeHealth
+}
+
+func (m *mockTribe) HealthCheck() core.TribeHealth {
+	return m.health
+}
+
+func subsystem(r Report, name string) Subsystem {
+	for _, s := range r.Subsystems {
+		if s.Name == name {
+			return s
+		}
+	}
+	return Subsystem{}
+}
+
+func TestChecker(t *testing.T) {
+	Convey("Given a checker of started modules", t, func() {
+		c := &mockControl{core.ControlHealth{
+			Started: true,
+			Pools: []core.PluginPoolHealth{
+				{Type: "collector", Name: "mock", Version: 1, Instances: 1, Subscriptions: 1},
+				{Type: "publisher", Name: "file", Version: 2, Instances: 0, Subscriptions: 0},
+			},
+		}}
+		s := &mockScheduler{core.SchedulerHealth{
+			Started:       true,
+			Tasks:         2,
+			DisabledTasks: []string{},
+			Queues: []core.QueueHealth{
+				{Name: "collect", Length: 1, Limit: 5},
+				{Name: "process", Length: 0, Limit: 5},
+				{Name: "publish", Length: 100, Limit: 0},
+			},
+		}}
+		cfg := GetDefaultConfig()
+		checker := New(cfg, c, s)
+
+		Convey("snapteld is live and ready", func() {
+			live := checker.Live()
+			So(live.Status, ShouldEqual, StatusUp)
+			So(len(live.Subsystems), ShouldEqual, 2)
+			ready := checker.Ready()
+			So(ready.Status, ShouldEqual, StatusUp)
+			So(len(ready.Subsystems), ShouldEqual, 5)
+			for _, sub := range ready.Subsystems {
+				So(sub.Status, ShouldEqual, StatusUp)
+				So(sub.Message, ShouldBeEmpty)
+			}
+		})
+		Convey("snapteld isn't live while a module is stopped", func() {
+			s.health.Started = false
+			live := checker.Live()
+			So(live.Status, ShouldEqual, StatusDown)
+			So(subsystem(live, ControlSubsystem).Status, ShouldEqual, StatusUp)
+			So(subsystem(live, SchedulerSubsystem).Status, ShouldEqual, StatusDown)
+			So(checker.Ready().Status, ShouldEqual, StatusDown)
+		})
+		Convey("disabled tasks degrade snapteld unless they exceed the threshold", func() {
+			s.health.DisabledTasks = []string{"a", "b"}
+			ready := checker.Ready()
+			So(ready.Status, ShouldEqual, StatusDegraded)
+			So(subsystem(ready, TasksSubsystem).Message, ShouldEqual, "2 of 2 tasks are disabled")
+			cfg.MaxDisabledTasks = 2
+			So(checker.Ready().Status, ShouldEqual, StatusDegraded)
+			cfg.MaxDisabledTasks = 1
+			So(checker.Ready().Status, ShouldEqual, StatusDown)
+		})
+		Convey("subscribed plugins without running instance make snapteld unready", func() {
+			c.health.Pools[0].Instances = 0
+			c.health.Pools[0].Restarts = 3
+			ready := checker.Ready()
+			So(ready.Status, ShouldEqual, StatusDown)
+			plugins := subsystem(ready, PluginsSubsystem)
+			So(plugins.Message, ShouldContainSubstring, "collector:mock:1")
+			pools := plugins.Details.(map[string][]PluginPool)["pools"]
+			So(len(pools), ShouldEqual, 2)
+			So(pools[0].Dead, ShouldBeTrue)
+			So(pools[1].Dead, ShouldBeFalse)
+			cfg.MaxDeadPluginPools = -1
+			So(checker.Ready().Status, ShouldEqual, StatusDegraded)
+		})
+		Convey("saturated work queues make snapteld unready", func() {
+			s.health.Queues[1].Length = 5
+			s.health.Queues[1].Rejected = 4
+			ready := checker.Ready()
+			So(ready.Status, ShouldEqual, StatusDown)
+			queues := subsystem(ready, QueuesSubsystem)
+			So(queues.Message, ShouldEqual, "work queues saturated: process")
+			So(queues.Details.(map[string][]Queue)["queues"][1].Rejected, ShouldEqual, 4)
+			cfg.MaxQueueSaturation = 1
+			So(checker.Ready().Status, ShouldEqual, StatusDown)
+			s.health.Queues[1].Length = 4
+			So(checker.Ready().Status, ShouldEqual, StatusUp)
+		})
+		Convey("the tribe membership is checked when tribe is enabled", func() {
+			tr := &mockTribe{core.TribeHealth{Name: "node1", Members: []string{"node1"}}}
+			checker.SetTribe(tr)
+			ready := checker.Ready()
+			So(len(ready.Subsystems), ShouldEqual, 6)
+			So(ready.Status, ShouldEqual, StatusUp)
+
+			tr.health.Seed = "10.0.0.1:6000"
+			ready = checker.Ready()
+			So(ready.Status, ShouldEqual, StatusDegraded)
+			So(subsystem(ready, TribeSubsystem).Message, ShouldContainSubstring, "10.0.0.1:6000")
+
+			cfg.RequireTribeMembership = true
+			So(checker.Ready().Status, ShouldEqual, StatusDown)
+
+			tr.health.Members = []string{"node1", "node2"}
+			So(checker.Ready().Status, ShouldEqual, StatusUp)
+			So(checker.Live().Status, ShouldEqual, StatusUp)
+		})
+	})
+}
@@ -1,6 +1,10 @@
 package api
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -10,9 +14,83 @@ type API interface {
 	BindTaskManager(Tasks)
 	BindTribeManager(Tribe)
 	BindConfigManager(Config)
+	BindConfigReloader(ConfigReloader)
+	BindTokenManager(Tokens)
+	BindAuditLog(AuditLog)
+	BindEvents(Events)
+	BindWebhooks(Webhooks)
+	BindHealth(Health)
+	BindOrigins(Origins)
 }
 
 type Route struct {
 	Method, Path string
 	Handle       httprouter.Handle
+	// Role is the role needed to call the route when authentication is
+	// enabled.  When empty GET routes need RoleReadOnly and the others
+	// RoleOperator.
+	Role Role
+	// Public routes are called without authentication, like the health
+	// checks of orchestrators.  They should only reveal details to the
+	// callers which aren't Anonymous.
+	Public bool
+}
+
+// anonymousKey is the request context key marking the unauthenticated calls
+// of public routes when authentication is enabled
+var anonymousKey = &struct{ name string }{"anonymous"}
+
+// WithAnonymous returns r marked as an unauthenticated call of a public route
+func WithAnonymous(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), anonymousKey, true))
+}
+
+// Anonymous returns true if r is an unauthenticated call of a public route
+// while authentication is enabled
+func Anonymous(r *http.Request) bool {
+	anonymous, _ := r.Context().Value(anonymousKey).(bool)
+	return anonymous
+}
+
+// RequiredRole returns the role needed to call the route
+func (r Route) RequiredRole() Role {
+	if r.Role != "" {
+		return r.Role
+	}
+	if r.Method == "GET" {
+		return RoleReadOnly
+	}
+	return RoleOperator
+}
+
+// Role is the level of access granted to the caller of the REST API
+type Role string
+
+const (
+	// RoleReadOnly can read plugins, metrics and tasks and watch tasks
+	RoleReadOnly Role = "read-only"
+	// RoleOperator can also create, start, stop and remove tasks
+	RoleOperator Role = "operator"
+	// RoleAdmin can also load and unload plugins and change the config
+	RoleAdmin Role = "admin"
+)
+
+var roleLevels = map[Role]int{
+	RoleReadOnly: 1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// ParseRole returns the role named by s
+func ParseRole(s string) (Role, error) {
+	r := Role(s)
+	if _, ok := roleLevels[r]; !ok {
+		return "", fmt.Errorf("unknown role %q, expected one of %s, %s or %s", s, RoleReadOnly, RoleOperator, RoleAdmin)
+	}
+	return r, nil
+}
+
+// Allows returns true if the role grants the access needed by required
+func (r Role) Allows(required Role) bool {
+	return roleLevels[r] > 0 && roleLevels[r] >= roleLevels[required]
 }
@@ -0,0 +1,13 @@
+package api
+
+import (
+	"time"
+
+	"github.com/intelsdi-x/snap/pkg/audit"
+)
+
+// AuditLog records the mutating calls of the REST API and reads them back
+type AuditLog interface {
+	audit.Recorder
+	Entries(from, to time.Time, offset int64, limit int) (*audit.Page, error)
+}
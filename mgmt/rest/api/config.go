@@ -3,13 +3,27 @@ package api
 import (
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/serror"
 )
 
 type Config interface {
 	GetPluginConfigDataNode(core.PluginType, string, int) cdata.ConfigDataNode
 	GetPluginConfigDataNodeAll() cdata.ConfigDataNode
-	MergePluginConfigDataNode(pluginType core.PluginType, name string, ver int, cdn *cdata.ConfigDataNode) cdata.ConfigDataNode
-	MergePluginConfigDataNodeAll(cdn *cdata.ConfigDataNode) cdata.ConfigDataNode
-	DeletePluginConfigDataNodeField(pluginType core.PluginType, name string, ver int, fields ...string) cdata.ConfigDataNode
-	DeletePluginConfigDataNodeFieldAll(fields ...string) cdata.ConfigDataNode
+	MergePluginConfigDataNode(pluginType core.PluginType, name string, ver int, cdn *cdata.ConfigDataNode) (cdata.ConfigDataNode, []serror.SnapError)
+	MergePluginConfigDataNodeAll(cdn *cdata.ConfigDataNode) (cdata.ConfigDataNode, []serror.SnapError)
+	DeletePluginConfigDataNodeField(pluginType core.PluginType, name string, ver int, fields ...string) (cdata.ConfigDataNode, []serror.SnapError)
+	DeletePluginConfigDataNodeFieldAll(fields ...string) (cdata.ConfigDataNode, []serror.SnapError)
+}
+
+// ConfigReloader re-reads the snapteld config file and applies the settings
+// that can change at runtime.
+type ConfigReloader interface {
+	ReloadConfig() (*ConfigReload, []serror.SnapError)
+}
+
+// ConfigReload lists, by their config file names, the settings changed by a
+// reload: those applied and those that only take effect after a restart.
+type ConfigReload struct {
+	Applied         []string `json:"applied"`
+	RestartRequired []string `json:"restart_required"`
 }
@@ -0,0 +1,10 @@
+package api
+
+import "github.com/intelsdi-x/snap/pkg/eventstream"
+
+// Events is the stream of the events emitted by control, the scheduler and
+// tribe
+type Events interface {
+	Subscribe(after uint64) *eventstream.Subscription
+	LastID() uint64
+}
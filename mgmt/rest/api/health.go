@@ -0,0 +1,9 @@
+package api
+
+import "github.com/intelsdi-x/snap/mgmt/health"
+
+// Health checks the liveness and the readiness of snapteld
+type Health interface {
+	Live() health.Report
+	Ready() health.Report
+}
@@ -3,17 +3,25 @@ package api
 import (
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/scheduler/wmap"
 )
 
 type Metrics interface {
 	MetricCatalog() ([]core.CatalogedMetric, error)
 	FetchMetrics(core.Namespace, int) ([]core.CatalogedMetric, error)
+	SearchMetrics(core.MetricSearch) (core.MetricSearchResult, error)
+	ExpandedMetrics(string) ([]core.RequestedMetric, error)
+	WorkflowConfig(*wmap.WorkflowMap, core.Namespace) (core.WorkflowConfig, []serror.SnapError)
 	GetMetricVersions(core.Namespace) ([]core.CatalogedMetric, error)
 	GetMetric(core.Namespace, int) (core.CatalogedMetric, error)
 	Load(*core.RequestedPlugin) (core.CatalogedPlugin, serror.SnapError)
 	Unload(core.Plugin) (core.CatalogedPlugin, serror.SnapError)
 	PluginCatalog() core.PluginCatalog
 	AvailablePlugins() []core.AvailablePlugin
+	PluginLogs(core.PluginType, string, int, int) ([]core.PluginLogEntry, serror.SnapError)
+	WatchPluginLogs(core.PluginType, string, int) (<-chan core.PluginLogEntry, func(), serror.SnapError)
+	PluginStats(core.PluginType, string, int) (core.PluginPoolStats, serror.SnapError)
+	RefreshPlugin(core.PluginType, string, int) (core.CatalogRefresh, serror.SnapError)
 	GetAutodiscoverPaths() []string
 	GetTempDir() string
 }
@@ -0,0 +1,6 @@
+package api
+
+// Origins tells whether browsers on an origin are allowed to call the REST API
+type Origins interface {
+	AllowsOrigin(origin string) bool
+}
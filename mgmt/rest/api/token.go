@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var (
+	// ErrTokenExists is returned when creating a token with a name in use
+	ErrTokenExists = errors.New("token already exists")
+	// ErrTokenNotFound is returned when revoking an unknown token
+	ErrTokenNotFound = errors.New("token not found")
+	// ErrTokenName is returned when creating a token without a valid name
+	ErrTokenName = errors.New("token name must only hold letters, digits, '.', '_' and '-'")
+
+	tokenNameRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+)
+
+// ValidTokenName returns true if name can be given to a token.  A name is the
+// last element of the path a token is revoked at, so it is restricted to
+// letters, digits, '.', '_' and '-', and can't be "." or "..".
+func ValidTokenName(name string) bool {
+	return name != "." && name != ".." && tokenNameRegexp.MatchString(name)
+}
+
+// Token describes a named REST API token.  The token itself is only
+// returned once, when it is created.
+type Token struct {
+	Name    string    `json:"name"`
+	Role    Role      `json:"role"`
+	Created time.Time `json:"created"`
+}
+
+// Tokens manages the REST API tokens
+type Tokens interface {
+	Tokens() []Token
+	CreateToken(name string, role Role) (string, Token, error)
+	RevokeToken(name string) error
+}
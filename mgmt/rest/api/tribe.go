@@ -14,4 +14,8 @@ type Tribe interface {
 	LeaveAgreement(agreementName, memberName string) serror.SnapError
 	GetMembers() []string
 	GetMember(name string) *agreement.Member
+	AddPlugin(agreementName string, p agreement.Plugin) error
+	RemovePlugin(agreementName string, p agreement.Plugin) error
+	AddTask(agreementName string, task agreement.Task) serror.SnapError
+	RemoveTask(agreementName string, task agreement.Task) serror.SnapError
 }
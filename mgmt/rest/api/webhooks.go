@@ -0,0 +1,8 @@
+package api
+
+import "github.com/intelsdi-x/snap/mgmt/webhook"
+
+// Webhooks reports the delivery status of the webhook sinks
+type Webhooks interface {
+	Sinks() []webhook.Status
+}
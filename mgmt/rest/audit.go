@@ -0,0 +1,165 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/julienschmidt/httprouter"
+	"github.com/urfave/negroni"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
+	"github.com/intelsdi-x/snap/pkg/audit"
+)
+
+// maxAuditBody is the size of the response kept to find the error or the
+// created target of an audited call
+const maxAuditBody = 64 * 1024
+
+// auditedTargets are the fields of a response which identify what a call created
+var auditedTargets = []string{"id", "name", "type", "version"}
+
+// auditWriter keeps the beginning of the response of an audited call
+type auditWriter struct {
+	negroni.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditWriter) Write(b []byte) (int, error) {
+	if n := maxAuditBody - w.body.Len(); n > 0 {
+		if n > len(b) {
+			n = len(b)
+		}
+		w.body.Write(b[:n])
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// audited records the calls of the mutating routes in the audit log when
+// one is bound.  Calls refused by h are recorded too.
+func (s *Server) audited(route api.Route, h httprouter.Handle) httprouter.Handle {
+	if s.auditLog == nil || route.Method == "GET" {
+		return h
+	}
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		rw, ok := w.(negroni.ResponseWriter)
+		if !ok {
+			rw = negroni.NewResponseWriter(w)
+		}
+		aw := &auditWriter{ResponseWriter: rw}
+		h(aw, r, p)
+
+		e := audit.Entry{
+			Identity:   requestIdentity(r),
+			ClientCert: clientIdentity(r),
+			Source:     r.RemoteAddr,
+			Method:     r.Method,
+			Route:      route.Path,
+			Targets:    map[string]string{},
+			Status:     aw.Status(),
+			Outcome:    audit.OutcomeSuccess,
+		}
+		for _, param := range p {
+			e.Targets[param.Key] = param.Value
+		}
+		if e.Status >= 400 {
+			e.Outcome = audit.OutcomeFailure
+			e.Error = responseField(aw.body.Bytes(), "message")
+		} else if r.Method == "POST" {
+			for _, k := range auditedTargets {
+				if _, ok := e.Targets[k]; !ok {
+					if v := responseField(aw.body.Bytes(), k); v != "" {
+						e.Targets[k] = v
+					}
+				}
+			}
+		}
+		s.record(r, e)
+	}
+}
+
+// auditRejected records a call refused before reaching its route, e.g. for
+// failed authentication.  Such calls are recorded whatever their method.
+func (s *Server) auditRejected(r *http.Request, status int, message string) {
+	if s.auditLog == nil {
+		return
+	}
+	s.record(r, audit.Entry{
+		Identity:   requestIdentity(r),
+		ClientCert: clientIdentity(r),
+		Source:     r.RemoteAddr,
+		Method:     r.Method,
+		Route:      r.URL.Path,
+		Status:     status,
+		Outcome:    audit.OutcomeFailure,
+		Error:      message,
+	})
+}
+
+func (s *Server) record(r *http.Request, e audit.Entry) {
+	if err := s.auditLog.Record(e); err != nil {
+		restLogger.WithFields(log.Fields{
+			"_block": "record",
+			"method": r.Method,
+			"url":    r.URL.Path,
+			"error":  err,
+		}).Error("failed to record the call in the audit log")
+	}
+}
+
+// requestIdentity returns the identity of the caller of a request
+func requestIdentity(r *http.Request) string {
+	if identity, ok := r.Context().Value(identityKey).(string); ok {
+		return identity
+	}
+	if identity := clientIdentity(r); identity != "" {
+		return "cert:" + identity
+	}
+	return "anonymous"
+}
+
+// responseField returns a scalar field of a JSON response, from the top level
+// object of a v2 response or from the body of a v1 response
+func responseField(b []byte, field string) string {
+	res := map[string]interface{}{}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return ""
+	}
+	v, ok := res[field]
+	if !ok {
+		if body, isMap := res["body"].(map[string]interface{}); isMap {
+			v, ok = body[field]
+		}
+	}
+	if !ok {
+		return ""
+	}
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64, bool:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
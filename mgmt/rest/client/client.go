@@ -42,6 +42,7 @@ import (
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1/rbody"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
 )
 
 var (
@@ -79,6 +80,13 @@ type Client struct {
 	// Basic http auth username/password
 	Username string
 	Password string
+	// Token is the API token sent as a bearer token, it takes
+	// precedence over the password when set.
+	Token string
+	// certFile and keyFile are the paths to the certificate and key
+	// presented to a REST API which verifies its clients
+	certFile string
+	keyFile  string
 }
 
 // Checks validity of URL
@@ -105,6 +113,21 @@ func Username(u string) metaOp {
 	}
 }
 
+//Token is an option that can be provided to the func client.New in order to authenticate with an API token.
+func Token(t string) metaOp {
+	return func(c *Client) {
+		c.Token = strings.TrimSpace(t)
+	}
+}
+
+//ClientCert is an option that can be provided to the func client.New in order to present a client certificate to a REST API served over HTTPS.
+func ClientCert(certFile, keyFile string) metaOp {
+	return func(c *Client) {
+		c.certFile = certFile
+		c.keyFile = keyFile
+	}
+}
+
 //Timeout is an option that can be provided to the func client.New in order to set HTTP connection timeout.
 func Timeout(t time.Duration) metaOp {
 	return func(c *Client) {
@@ -149,6 +172,16 @@ func New(url, ver string, insecure bool, opts ...metaOp) (*Client, error) {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.certFile != "" || c.keyFile != "" {
+		cer, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to load the client certificate: %v", err)
+		}
+		c.http.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure, Certificates: []tls.Certificate{cer}},
+			IdleConnTimeout: time.Second,
+		}
+	}
 	c.prefix = url + "/" + ver
 	return c, nil
 }
@@ -159,15 +192,30 @@ func (t contentType) String() string {
 }
 
 /*
-   Add's auth info to request if password is set.
+   Add's auth info to request if a token or password is set.
 */
-func addAuth(req *http.Request, username, password string) {
-	if password != "" {
+func (c *Client) addAuth(req *http.Request) {
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+		return
+	}
+	if c.Password != "" {
+		username := c.Username
 		if username == "" {
 			username = "snap"
 		}
-		req.SetBasicAuth(username, password)
+		req.SetBasicAuth(username, c.Password)
+	}
+}
+
+// forbidden returns the error carried by a 403 response, which is
+// sent when the credentials don't grant the role a route requires.
+func forbidden(b []byte) error {
+	e := &v2.UnauthError{}
+	if err := json.Unmarshal(b, e); err != nil || e.Message == "" {
+		return fmt.Errorf("Insufficient permissions")
 	}
+	return errors.New(e.Message)
 }
 
 /*
@@ -188,7 +236,7 @@ func (c *Client) do(method, path string, ct contentType, body ...[]byte) (*rbody
 		if err != nil {
 			return nil, err
 		}
-		addAuth(req, c.Username, c.Password)
+		c.addAuth(req)
 		rsp, err = c.http.Do(req)
 		if err != nil {
 			if strings.Contains(err.Error(), "tls: oversized record") || strings.Contains(err.Error(), "malformed HTTP response") {
@@ -208,7 +256,7 @@ func (c *Client) do(method, path string, ct contentType, body ...[]byte) (*rbody
 		if err != nil {
 			return nil, fmt.Errorf("URL target is not available. %v", err)
 		}
-		addAuth(req, c.Username, c.Password)
+		c.addAuth(req)
 		req.Header.Add("Content-Type", ct.String())
 
 		rsp, err = c.http.Do(req)
@@ -231,7 +279,7 @@ func (c *Client) do(method, path string, ct contentType, body ...[]byte) (*rbody
 		if err != nil {
 			return nil, fmt.Errorf("URL target is not available. %v", err)
 		}
-		addAuth(req, c.Username, c.Password)
+		c.addAuth(req)
 		req.Header.Add("Content-Type", "application/json")
 		rsp, err = c.http.Do(req)
 		if err != nil {
@@ -252,7 +300,7 @@ func (c *Client) do(method, path string, ct contentType, body ...[]byte) (*rbody
 		if err != nil {
 			return nil, err
 		}
-		addAuth(req, c.Username, c.Password)
+		c.addAuth(req)
 		req.Header.Add("Content-Type", ct.String())
 		rsp, err = c.http.Do(req)
 		if err != nil {
@@ -275,6 +323,9 @@ func httpRespToAPIResp(rsp *http.Response) (*rbody.APIResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	if rsp.StatusCode == 403 {
+		return nil, forbidden(b)
+	}
 	jErr := json.Unmarshal(b, resp)
 	// If unmarshaling fails show first part of response to help debug
 	// connection issues.
@@ -297,6 +348,61 @@ func httpRespToAPIResp(rsp *http.Response) (*rbody.APIResponse, error) {
 	return resp, nil
 }
 
+// doV2 sends a request to an endpoint which is only served by the v2 API,
+// independently of the version the client was created with.
+// The caller is responsible for closing the response body.
+func (c *Client) doV2(method, path string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.URL+"/v2"+path, body)
+	if err != nil {
+		return nil, err
+	}
+	c.addAuth(req)
+	if body != nil {
+		req.Header.Add("Content-Type", ContentTypeJSON.String())
+	}
+	rsp, err := c.http.Do(req)
+	if err != nil {
+		if strings.Contains(err.Error(), "tls: oversized record") || strings.Contains(err.Error(), "malformed HTTP response") {
+			return nil, fmt.Errorf("error connecting to API URI: %s. Do you have an http/https mismatch?", c.URL)
+		}
+		return nil, fmt.Errorf("URL target is not available. %v", err)
+	}
+	return rsp, nil
+}
+
+// decodeV2 unmarshals a v2 API response into out, or returns
+// the error carried by the response.
+func decodeV2(rsp *http.Response, out interface{}) error {
+	if rsp.StatusCode == 401 {
+		return fmt.Errorf("Invalid credentials")
+	}
+	b, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+	if rsp.StatusCode == 403 {
+		return forbidden(b)
+	}
+	if rsp.StatusCode >= 300 {
+		e := &v2.Error{}
+		if err := json.Unmarshal(b, e); err != nil || e.ErrorMessage == "" {
+			return fmt.Errorf("Unknown API response: %s", rsp.Status)
+		}
+		return e
+	}
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		bound := 1000
+		if len(b) > bound {
+			b = b[:bound]
+		}
+		return fmt.Errorf("Unknown API response: %s\n\n Received: %s", err, string(b))
+	}
+	return nil
+}
+
 func (c *Client) pluginUploadRequest(pluginPaths []string) (*rbody.APIResponse, error) {
 	if core.IsUri(pluginPaths[0]) {
 		if _, err := url.ParseRequestURI(pluginPaths[0]); err == nil {
@@ -310,7 +416,7 @@ func (c *Client) pluginUploadRequest(pluginPaths []string) (*rbody.APIResponse,
 			if err != nil {
 				return nil, err
 			}
-			addAuth(req, c.Username, c.Password)
+			c.addAuth(req)
 			req.Header.Add("Content-Type", "application/json")
 			rsp, err := c.http.Do(req)
 			if err != nil {
@@ -354,7 +460,7 @@ func (c *Client) pluginUploadRequest(pluginPaths []string) (*rbody.APIResponse,
 	go writePluginToWriter(pw, bufins, writer, paths, errChan)
 
 	req, err := http.NewRequest("POST", c.prefix+"/plugins", pr)
-	addAuth(req, c.Username, c.Password)
+	c.addAuth(req)
 	if err != nil {
 		return nil, fmt.Errorf("URL target is not available. %v", err)
 	}
@@ -423,7 +529,7 @@ func (c *Client) TribeRequest() (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
-	addAuth(req, "snap", c.Password)
+	c.addAuth(req)
 	rsp, err := c.http.Do(req)
 	if err != nil {
 		return nil, err
@@ -27,6 +27,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -36,6 +38,7 @@ import (
 
 	"github.com/intelsdi-x/snap/control"
 	"github.com/intelsdi-x/snap/mgmt/rest"
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1"
 	"github.com/intelsdi-x/snap/plugin/helper"
 	"github.com/intelsdi-x/snap/scheduler"
@@ -120,6 +123,10 @@ func TestSnapClient(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(c.Version, ShouldEqual, "v1")
 			})
+			Convey("missing client certificate", func() {
+				_, err := New(uri, "v1", true, ClientCert("/no/cert.pem", "/no/key.pem"))
+				So(err, ShouldNotBeNil)
+			})
 			Convey("no loaded plugins", func() {
 				p := c.GetPlugins(false)
 				p2 := c.GetPlugins(true)
@@ -764,6 +771,67 @@ func TestClient_UnloadPlugin(t *testing.T) {
 	})
 }
 
+func TestClient_Tokens(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snap-client-tokens")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := rest.GetDefaultConfig()
+	cfg.RestAuthTokenFile = filepath.Join(dir, "tokens.json")
+	cfg.RestAuth = true
+	cfg.RestAuthPassword = "secret"
+	r, _ := rest.New(cfg)
+	r.SetAPIAuth(true)
+	r.SetAPIAuthPwd("secret")
+	c := control.New(control.GetDefaultConfig())
+	c.Start()
+	r.BindConfigManager(c.Config)
+	r.BindMetricManager(c)
+	r.SetAddress("127.0.0.1:0")
+	r.Start()
+	time.Sleep(100 * time.Millisecond)
+	uri := fmt.Sprintf("http://localhost:%d", r.Port())
+
+	Convey("API tokens can be managed and used through the client", t, func() {
+		admin, err := New(uri, "v1", true, Password("secret"))
+		So(err, ShouldBeNil)
+
+		ct := admin.CreateToken("reader", "read-only")
+		So(ct.Err, ShouldBeNil)
+		So(ct.Name, ShouldEqual, "reader")
+		So(ct.Role, ShouldEqual, "read-only")
+		So(ct.Token.Token, ShouldNotBeEmpty)
+
+		So(admin.CreateToken("reader", "admin").Err, ShouldNotBeNil)
+		So(admin.CreateToken("root", "root").Err, ShouldNotBeNil)
+
+		lt := admin.ListTokens()
+		So(lt.Err, ShouldBeNil)
+		So(len(lt.Tokens), ShouldEqual, 1)
+		So(lt.Tokens[0].Name, ShouldEqual, "reader")
+		So(lt.Tokens[0].Token, ShouldBeEmpty)
+
+		reader, err := New(uri, "v1", true, Token(ct.Token.Token))
+		So(err, ShouldBeNil)
+		So(reader.GetMetricCatalog().Err, ShouldBeNil)
+		lt = reader.ListTokens()
+		So(lt.Err, ShouldNotBeNil)
+		So(lt.Err.Error(), ShouldContainSubstring, "Forbidden")
+		up := reader.UnloadPlugin("collector", "mock", 1)
+		So(up.Err, ShouldNotBeNil)
+		So(up.Err.Error(), ShouldContainSubstring, "Forbidden")
+
+		So(admin.RevokeToken("reader").Err, ShouldBeNil)
+		So(admin.RevokeToken("reader").Err, ShouldNotBeNil)
+		for _, name := range []string{"a/b", "..", "a b"} {
+			So(admin.CreateToken(name, "read-only").Err, ShouldEqual, api.ErrTokenName)
+			So(admin.RevokeToken(name).Err, ShouldEqual, api.ErrTokenName)
+		}
+		So(reader.GetMetricCatalog().Err.Error(), ShouldEqual, "Invalid credentials")
+	})
+}
+
 type timeoutHandler struct{}
 
 //ServeHTTP implements http.Handler interface
@@ -27,6 +27,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -161,6 +162,24 @@ func TestSnapClientTribe(t *testing.T) {
 				})
 			})
 		})
+
+		Convey("Plugins and tasks are added to and removed from an agreement", func() {
+			agreement := "agreement2"
+			So(c.AddAgreement(agreement).Err, ShouldBeNil)
+			So(c.JoinAgreement(agreement, fmt.Sprintf("member-%d", ports[0])).Err, ShouldBeNil)
+			resp := c.AddAgreementPlugin(agreement, "collector", "mock", 1)
+			So(resp.Err, ShouldBeNil)
+			So(resp, ShouldHaveSameTypeAs, &client.AgreementResult{})
+			So(len(resp.Plugins), ShouldEqual, 1)
+			So(c.AddAgreementPlugin(agreement, "collector", "mock", 1).Err, ShouldNotBeNil)
+			So(c.RemoveAgreementPlugin(agreement, "collector", "mock", 1).Err, ShouldBeNil)
+			So(c.RemoveAgreementPlugin(agreement, "collector", "mock", 1).Err, ShouldNotBeNil)
+			Convey("A task which doesn't exist can't be added to the agreement", func() {
+				So(c.AddAgreementTask(agreement, "1234", false).Err, ShouldNotBeNil)
+				So(c.RemoveAgreementTask(agreement, "1234").Err, ShouldNotBeNil)
+				So(c.DeleteAgreement(agreement).Err, ShouldBeNil)
+			})
+		})
 	})
 }
 
@@ -231,3 +250,21 @@ func startTribes(count int) []int {
 	}
 	return uris
 }
+
+func TestClientAgreementPath(t *testing.T) {
+	Convey("Agreement names and path elements are escaped as single segments", t, func() {
+		var uri string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			uri = r.RequestURI
+			w.Write([]byte("{}"))
+		}))
+		defer ts.Close()
+		c, err := client.New(ts.URL, "v1", true)
+		So(err, ShouldBeNil)
+
+		So(c.RemoveAgreementTask("dc/east", "a b").Err, ShouldBeNil)
+		So(uri, ShouldEqual, "/v2/tribe/agreements/dc%2Feast/tasks/a%20b")
+		So(c.RemoveAgreementPlugin("dc/east", "collector", "mock/1", 1).Err, ShouldBeNil)
+		So(uri, ShouldEqual, "/v2/tribe/agreements/dc%2Feast/plugins/collector/mock%2F1/1")
+	})
+}
@@ -0,0 +1,131 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+)
+
+// WatchEvents streams the events of snapteld through an HTTP GET call to
+// the v2 API. Only the events of the given types are streamed, all of them
+// without types. The buffered events following lastID are replayed first,
+// lastID is 0 to only stream new events.
+func (c *Client) WatchEvents(types []string, lastID uint64) *WatchEventsResult {
+	r := &WatchEventsResult{
+		EventChan: make(chan *v2.StreamedEvent),
+		DoneChan:  make(chan struct{}),
+	}
+	q := url.Values{}
+	for _, t := range types {
+		q.Add("type", t)
+	}
+	if lastID > 0 {
+		q.Set("last_event_id", strconv.FormatUint(lastID, 10))
+	}
+	path := "/events"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	// the stream has no timeout, the timeout of a request is set when it
+	// is sent
+	oldTimeout := c.http.Timeout
+	c.http.Timeout = time.Duration(0)
+	rsp, err := c.doV2("GET", path, nil)
+	c.http.Timeout = oldTimeout
+	if err != nil {
+		r.Err = err
+		close(r.EventChan)
+		return r
+	}
+	if rsp.StatusCode != 200 {
+		defer rsp.Body.Close()
+		r.Err = decodeV2(rsp, nil)
+		if r.Err == nil {
+			r.Err = fmt.Errorf("Unknown API response: %s", rsp.Status)
+		}
+		close(r.EventChan)
+		return r
+	}
+	r.body = rsp.Body
+
+	go func() {
+		defer close(r.EventChan)
+		defer rsp.Body.Close()
+		reader := bufio.NewReader(rsp.Body)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				select {
+				case <-r.DoneChan:
+				default:
+					r.Err = err
+				}
+				return
+			}
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			e := &v2.StreamedEvent{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), e); err != nil {
+				r.Err = err
+				return
+			}
+			select {
+			case r.EventChan <- e:
+			case <-r.DoneChan:
+				return
+			}
+		}
+	}()
+	return r
+}
+
+// WatchEventsResult is the response from snap/client on a WatchEvents call.
+// EventChan is closed when the stream ends, Err then holds the reason unless
+// the stream was closed by Close.
+type WatchEventsResult struct {
+	Err       error
+	EventChan chan *v2.StreamedEvent
+	DoneChan  chan struct{}
+
+	body io.Closer
+	once sync.Once
+}
+
+// Close stops streaming the events
+func (w *WatchEventsResult) Close() {
+	w.once.Do(func() {
+		close(w.DoneChan)
+		if w.body != nil {
+			w.body.Close()
+		}
+	})
+}
@@ -0,0 +1,63 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/intelsdi-x/snap/mgmt/health"
+)
+
+// Liveness checks that control and the scheduler of snapteld are started
+// through an HTTP GET call to the v2 API.
+func (c *Client) Liveness() *HealthResult {
+	return c.health("/health/live")
+}
+
+// Readiness checks the subsystems of snapteld against its readiness
+// thresholds through an HTTP GET call to the v2 API.
+func (c *Client) Readiness() *HealthResult {
+	return c.health("/health/ready")
+}
+
+func (c *Client) health(path string) *HealthResult {
+	r := &HealthResult{}
+	rsp, err := c.doV2("GET", path, nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	// a subsystem is down, the report is still returned
+	if rsp.StatusCode == http.StatusServiceUnavailable {
+		r.Err = json.NewDecoder(rsp.Body).Decode(&r.Report)
+		return r
+	}
+	r.Err = decodeV2(rsp, &r.Report)
+	return r
+}
+
+// HealthResult is the response from snap/client on a Liveness or Readiness
+// call.
+type HealthResult struct {
+	health.Report
+	Err error
+}
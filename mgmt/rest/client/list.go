@@ -0,0 +1,158 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+)
+
+// ListOptions holds the pagination and sorting options of the list calls to
+// the v2 API.
+type ListOptions struct {
+	// Limit caps the number of items returned, 0 returns all of them
+	Limit int
+	// Cursor is the NextCursor of the previous page, empty for the first page
+	Cursor string
+	// Sort is the key the items are sorted by, prefixed with - to sort them
+	// in descending order
+	Sort string
+}
+
+func (o ListOptions) setQuery(q url.Values) {
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		q.Set("cursor", o.Cursor)
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+}
+
+// TaskFilter selects the tasks listed by ListTasks.
+type TaskFilter struct {
+	// States are the states of the tasks listed (ex: Running)
+	States []string
+	// NamePrefix is the prefix of the names of the tasks listed
+	NamePrefix string
+	// Labels is a label selector (ex: dc=us-east,env!=dev) matched against
+	// the tags the workflow of a task adds to its metrics
+	Labels string
+}
+
+// ListTasks retrieves a page of the tasks matching a filter through an HTTP
+// GET call to the v2 API.
+func (c *Client) ListTasks(filter TaskFilter, opts ListOptions) *ListTasksResult {
+	r := &ListTasksResult{}
+	q := url.Values{}
+	if len(filter.States) > 0 {
+		q.Set("state", strings.Join(filter.States, ","))
+	}
+	if filter.NamePrefix != "" {
+		q.Set("name_prefix", filter.NamePrefix)
+	}
+	if filter.Labels != "" {
+		q.Set("labels", filter.Labels)
+	}
+	opts.setQuery(q)
+	rsp, err := c.doV2("GET", "/tasks?"+q.Encode(), nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, &r.TasksResponse)
+	return r
+}
+
+// ListTasksResult is the response from snap/client on a ListTasks call.
+type ListTasksResult struct {
+	v2.TasksResponse
+	Err error
+}
+
+// PluginFilter selects the plugins listed by ListPlugins.
+type PluginFilter struct {
+	// Type is the type of the plugins listed
+	Type string
+	// Name is the name of the plugins listed
+	Name string
+	// NamePrefix is the prefix of the names of the plugins listed
+	NamePrefix string
+	// Running lists the running plugins instead of the loaded ones
+	Running bool
+}
+
+// ListPlugins retrieves a page of the plugins matching a filter through an
+// HTTP GET call to the v2 API.
+func (c *Client) ListPlugins(filter PluginFilter, opts ListOptions) *ListPluginsResult {
+	r := &ListPluginsResult{}
+	q := url.Values{}
+	if filter.Type != "" {
+		q.Set("type", filter.Type)
+	}
+	if filter.Name != "" {
+		q.Set("name", filter.Name)
+	}
+	if filter.NamePrefix != "" {
+		q.Set("name_prefix", filter.NamePrefix)
+	}
+	if filter.Running {
+		q.Set("running", "")
+	}
+	opts.setQuery(q)
+	rsp, err := c.doV2("GET", "/plugins?"+q.Encode(), nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, &r.PluginsResponse)
+	return r
+}
+
+// ListPluginsResult is the response from snap/client on a ListPlugins call.
+type ListPluginsResult struct {
+	v2.PluginsResponse
+	Err error
+}
+
+// ListMetrics retrieves a page of the metric catalog matching a search
+// through an HTTP GET call to the v2 API. The cursor of the options takes
+// precedence over the offset of the search.
+func (c *Client) ListMetrics(search core.MetricSearch, opts ListOptions) *SearchMetricsResult {
+	r := &SearchMetricsResult{}
+	q := metricSearchQuery(search)
+	opts.setQuery(q)
+	rsp, err := c.doV2("GET", "/metrics?"+q.Encode(), nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, &r.MetricSearchResponse)
+	return r
+}
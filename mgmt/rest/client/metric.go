@@ -22,8 +22,13 @@ package client
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1/rbody"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
 )
 
 var (
@@ -127,6 +132,60 @@ func (c *Client) GetMetric(ns string, ver int) interface{} {
 	return r
 }
 
+// SearchMetrics retrieves the page of the metric catalog matching a search
+// through an HTTP GET call to the v2 API.
+func (c *Client) SearchMetrics(search core.MetricSearch) *SearchMetricsResult {
+	r := &SearchMetricsResult{}
+	rsp, err := c.doV2("GET", "/metrics?"+metricSearchQuery(search).Encode(), nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, &r.MetricSearchResponse)
+	return r
+}
+
+func metricSearchQuery(search core.MetricSearch) url.Values {
+	q := url.Values{}
+	// the offset is always set, so that the request is a search
+	q.Set("offset", strconv.Itoa(search.Offset))
+	if search.Limit > 0 {
+		q.Set("limit", strconv.Itoa(search.Limit))
+	}
+	if len(search.Namespace) > 0 {
+		q.Set("ns", search.Namespace.String())
+	}
+	if len(search.Glob) > 0 {
+		q.Set("glob", "/"+strings.Join(search.Glob, "/"))
+	}
+	if search.Regex != "" {
+		q.Set("regex", search.Regex)
+	}
+	if search.Version > 0 {
+		q.Set("ver", strconv.Itoa(search.Version))
+	}
+	if search.PluginName != "" {
+		q.Set("plugin_name", search.PluginName)
+	}
+	if search.PluginVersion > 0 {
+		q.Set("plugin_version", strconv.Itoa(search.PluginVersion))
+	}
+	if search.Text != "" {
+		q.Set("text", search.Text)
+	}
+	if search.Dynamic != nil {
+		q.Set("dynamic", strconv.FormatBool(*search.Dynamic))
+	}
+	return q
+}
+
+// SearchMetricsResult is the response from snap/client on a SearchMetrics call.
+type SearchMetricsResult struct {
+	v2.MetricSearchResponse
+	Err error
+}
+
 // GetMetricsResult is the response from snap/client on a GetMetricCatalog call.
 type GetMetricsResult struct {
 	Catalog []*rbody.Metric
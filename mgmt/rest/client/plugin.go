@@ -20,14 +20,18 @@ limitations under the License.
 package client
 
 import (
+	"bufio"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/intelsdi-x/snap/core/serror"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1/rbody"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
 )
 
 // LoadPlugin loads plugins for the given plugin names.
@@ -185,6 +189,132 @@ func (c *Client) GetPlugin(typ, name string, ver int) *GetPluginResult {
 	return r
 }
 
+// GetPluginLogs retrieves up to lines of the most recent output of the running
+// instances of a plugin through an HTTP GET call to the v2 API.
+// An instance ID lower than 0 returns the output of every instance.
+func (c *Client) GetPluginLogs(typ, name string, ver, lines int, id int64) *GetPluginLogsResult {
+	r := &GetPluginLogsResult{}
+	rsp, err := c.doV2("GET", pluginLogsPath(typ, name, ver, lines, id, false), nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	lr := v2.PluginLogsResponse{}
+	if err := decodeV2(rsp, &lr); err != nil {
+		r.Err = err
+		return r
+	}
+	r.Logs = lr.Logs
+	return r
+}
+
+// WatchPluginLogs streams the output of the running instances of a plugin,
+// starting with up to lines of the most recent output.
+func (c *Client) WatchPluginLogs(typ, name string, ver, lines int, id int64) *WatchPluginLogsResult {
+	// during watch we don't want to have a timeout
+	// Store the old timeout so we can restore when we are through
+	oldTimeout := c.http.Timeout
+	c.http.Timeout = time.Duration(0)
+
+	r := &WatchPluginLogsResult{
+		LogChan:  make(chan v2.PluginLog),
+		DoneChan: make(chan struct{}),
+	}
+	rsp, err := c.doV2("GET", pluginLogsPath(typ, name, ver, lines, id, true), nil)
+	if err != nil {
+		c.http.Timeout = oldTimeout
+		r.Err = err
+		r.Close()
+		return r
+	}
+	if rsp.StatusCode != 200 {
+		defer rsp.Body.Close()
+		c.http.Timeout = oldTimeout
+		r.Err = decodeV2(rsp, nil)
+		r.Close()
+		return r
+	}
+
+	go func() {
+		defer func() { c.http.Timeout = oldTimeout }()
+		defer rsp.Body.Close()
+		defer close(r.LogChan)
+		reader := bufio.NewReader(rsp.Body)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				// the server closed the stream
+				return
+			}
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			l := v2.PluginLog{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), &l); err != nil {
+				r.Err = err
+				return
+			}
+			select {
+			case r.LogChan <- l:
+			case <-r.DoneChan:
+				return
+			}
+		}
+	}()
+	return r
+}
+
+// GetPluginStats retrieves the RPC call statistics of a plugin and of its
+// running instances through an HTTP GET call to the v2 API.
+func (c *Client) GetPluginStats(typ, name string, ver int) *GetPluginStatsResult {
+	r := &GetPluginStatsResult{}
+	rsp, err := c.doV2("GET", "/plugins/"+typ+"/"+name+"/"+strconv.Itoa(ver)+"/stats", nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, &r.PluginStatsResponse)
+	return r
+}
+
+func pluginLogsPath(typ, name string, ver, lines int, id int64, follow bool) string {
+	q := url.Values{}
+	q.Set("lines", strconv.Itoa(lines))
+	if id >= 0 {
+		q.Set("id", strconv.FormatInt(id, 10))
+	}
+	if follow {
+		q.Set("follow", "true")
+	}
+	return "/plugins/" + typ + "/" + name + "/" + strconv.Itoa(ver) + "/logs?" + q.Encode()
+}
+
+// GetPluginLogsResult is the response from snap/client on a GetPluginLogs call.
+type GetPluginLogsResult struct {
+	Logs []v2.PluginLog
+	Err  error
+}
+
+// GetPluginStatsResult is the response from snap/client on a GetPluginStats call.
+type GetPluginStatsResult struct {
+	v2.PluginStatsResponse
+	Err error
+}
+
+// WatchPluginLogsResult is the response from snap/client on a WatchPluginLogs call.
+// LogChan is closed when the stream ends.
+type WatchPluginLogsResult struct {
+	Err      error
+	LogChan  chan v2.PluginLog
+	DoneChan chan struct{}
+}
+
+func (w *WatchPluginLogsResult) Close() {
+	close(w.DoneChan)
+}
+
 // GetPluginResult
 type GetPluginResult struct {
 	ReturnedPlugin ReturnedPlugin
@@ -25,11 +25,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1/rbody"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
 	"github.com/intelsdi-x/snap/scheduler/wmap"
 )
 
@@ -108,7 +110,7 @@ func (c *Client) WatchTask(id string) *WatchTasksResult {
 
 	url := fmt.Sprintf("%s/tasks/%v/watch", c.prefix, id)
 	req, err := http.NewRequest("GET", url, nil)
-	addAuth(req, c.Username, c.Password)
+	c.addAuth(req)
 	if err != nil {
 		r.Err = err
 		r.Close()
@@ -213,6 +215,26 @@ func (c *Client) GetTask(id string) *GetTaskResult {
 	}
 }
 
+// GetTaskConfig retrieves the config of the metrics and of the process and
+// publish nodes of a task, with the source of every key, through an HTTP GET
+// call to the v2 API. The metrics can be restricted to those matching the
+// namespace query ns.
+func (c *Client) GetTaskConfig(id string, ns string) *GetTaskConfigResult {
+	r := &GetTaskConfigResult{}
+	path := fmt.Sprintf("/tasks/%v/config", id)
+	if ns != "" {
+		path += "?" + url.Values{"ns": {ns}}.Encode()
+	}
+	rsp, err := c.doV2("GET", path, nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, &r.TaskConfigResponse)
+	return r
+}
+
 // StartTask starts a task given a task id. The scheduled task will be in
 // the started state if it succeeds. Otherwise, an error is returned.
 func (c *Client) StartTask(id string) *StartTasksResult {
@@ -322,6 +344,12 @@ type GetTaskResult struct {
 	Err error
 }
 
+// GetTaskConfigResult is the response from snap/client on a GetTaskConfig call.
+type GetTaskConfigResult struct {
+	v2.TaskConfigResponse
+	Err error
+}
+
 // StartTasksResult is the response from snap/client on a StartTask call.
 type StartTasksResult struct {
 	*rbody.ScheduledTaskStarted
@@ -0,0 +1,105 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+	"github.com/intelsdi-x/snap/pkg/netutil"
+)
+
+// ListTokens retrieves the API tokens known to snapteld through an HTTP GET
+// call to the v2 API. Only the name, role and creation time of a token are
+// returned.
+func (c *Client) ListTokens() *ListTokensResult {
+	r := &ListTokensResult{}
+	rsp, err := c.doV2("GET", "/tokens", nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, &r.TokensResponse)
+	return r
+}
+
+// CreateToken creates an API token with the given name and role through an
+// HTTP POST call to the v2 API. The returned token is the only copy of its
+// secret, snapteld keeps its hash.  A name snapteld would refuse is an error
+// without any call being made.
+func (c *Client) CreateToken(name, role string) *CreateTokenResult {
+	r := &CreateTokenResult{}
+	if !api.ValidTokenName(name) {
+		r.Err = api.ErrTokenName
+		return r
+	}
+	b, err := json.Marshal(v2.TokenRequest{Name: name, Role: role})
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	rsp, err := c.doV2("POST", "/tokens", bytes.NewReader(b))
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, &r.Token)
+	return r
+}
+
+// RevokeToken revokes the API token with the given name through an HTTP
+// DELETE call to the v2 API.  As no token can have a name snapteld would
+// refuse, such a name is an error without any call being made.
+func (c *Client) RevokeToken(name string) *RevokeTokenResult {
+	r := &RevokeTokenResult{}
+	if !api.ValidTokenName(name) {
+		r.Err = api.ErrTokenName
+		return r
+	}
+	rsp, err := c.doV2("DELETE", "/tokens/"+netutil.PathSegmentEscape(name), nil)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, nil)
+	return r
+}
+
+// ListTokensResult is the response from snap/client on a ListTokens call.
+type ListTokensResult struct {
+	v2.TokensResponse
+	Err error
+}
+
+// CreateTokenResult is the response from snap/client on a CreateToken call.
+type CreateTokenResult struct {
+	v2.Token
+	Err error
+}
+
+// RevokeTokenResult is the response from snap/client on a RevokeToken call.
+type RevokeTokenResult struct {
+	Err error
+}
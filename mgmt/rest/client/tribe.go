@@ -20,10 +20,14 @@ limitations under the License.
 package client
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/intelsdi-x/snap/mgmt/rest/v1/rbody"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+	"github.com/intelsdi-x/snap/pkg/netutil"
 )
 
 // ListMembers retrieves a list of tribe members through an HTTP GET call.
@@ -237,3 +241,73 @@ type LeaveAgreementResult struct {
 	*rbody.TribeLeaveAgreement
 	Err error
 }
+
+// AddAgreementPlugin adds a plugin to a tribe agreement through an HTTP POST
+// call to the v2 API. The members of the agreement which don't have the
+// plugin load it from a member which has it.
+func (c *Client) AddAgreementPlugin(agreementName, pluginType, pluginName string, pluginVersion int) *AgreementResult {
+	b, err := json.Marshal(v2.AgreementPlugin{Type: pluginType, Name: pluginName, Version: pluginVersion})
+	if err != nil {
+		return &AgreementResult{Err: err}
+	}
+	return c.changeAgreement("POST", agreementPath(agreementName, "plugins"), b)
+}
+
+// RemoveAgreementPlugin removes a plugin from a tribe agreement through an
+// HTTP DELETE call to the v2 API.
+func (c *Client) RemoveAgreementPlugin(agreementName, pluginType, pluginName string, pluginVersion int) *AgreementResult {
+	path := agreementPath(agreementName, "plugins", pluginType, pluginName, fmt.Sprint(pluginVersion))
+	return c.changeAgreement("DELETE", path, nil)
+}
+
+// AddAgreementTask adds a task of this member to a tribe agreement through
+// an HTTP POST call to the v2 API. The other members of the agreement create
+// the task and start it when startOnCreate is set.
+func (c *Client) AddAgreementTask(agreementName, taskID string, startOnCreate bool) *AgreementResult {
+	b, err := json.Marshal(v2.AgreementTask{ID: taskID, StartOnCreate: startOnCreate})
+	if err != nil {
+		return &AgreementResult{Err: err}
+	}
+	return c.changeAgreement("POST", agreementPath(agreementName, "tasks"), b)
+}
+
+// RemoveAgreementTask removes a task from a tribe agreement through an HTTP
+// DELETE call to the v2 API.
+func (c *Client) RemoveAgreementTask(agreementName, taskID string) *AgreementResult {
+	return c.changeAgreement("DELETE", agreementPath(agreementName, "tasks", taskID), nil)
+}
+
+func (c *Client) changeAgreement(method, path string, body []byte) *AgreementResult {
+	r := &AgreementResult{}
+	var rsp *http.Response
+	var err error
+	if body != nil {
+		rsp, err = c.doV2(method, path, bytes.NewReader(body))
+	} else {
+		rsp, err = c.doV2(method, path, nil)
+	}
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	defer rsp.Body.Close()
+	r.Err = decodeV2(rsp, &r.Agreement)
+	return r
+}
+
+// agreementPath returns the v2 API path of a tribe agreement followed by
+// the given path elements
+func agreementPath(agreementName string, elems ...string) string {
+	path := "/tribe/agreements/" + netutil.PathSegmentEscape(agreementName)
+	for _, e := range elems {
+		path += "/" + netutil.PathSegmentEscape(e)
+	}
+	return path
+}
+
+// AgreementResult is the response from snap/client on a call changing the
+// plugins or tasks of an agreement. The agreement is empty after a removal.
+type AgreementResult struct {
+	v2.Agreement
+	Err error
+}
@@ -8,8 +8,10 @@ const (
 	defaultHTTPS           bool   = false
 	defaultRestCertificate string = ""
 	defaultRestKey         string = ""
+	defaultRestClientCA    string = ""
 	defaultAuth            bool   = false
 	defaultAuthPassword    string = ""
+	defaultAuthTokenFile   string = ""
 	defaultPortSetByConfig bool   = false
 	defaultPprof           bool   = false
 	defaultCorsd           string = ""
@@ -20,17 +22,20 @@ const (
 //         UnmarshalJSON method in this same file needs to be modified to
 //         match the field mapping that is defined here
 type Config struct {
-	Enable           bool   `json:"enable"yaml:"enable"`
-	Port             int    `json:"port"yaml:"port"`
-	Address          string `json:"addr"yaml:"addr"`
-	HTTPS            bool   `json:"https"yaml:"https"`
-	RestCertificate  string `json:"rest_certificate"yaml:"rest_certificate"`
-	RestKey          string `json:"rest_key"yaml:"rest_key"`
-	RestAuth         bool   `json:"rest_auth"yaml:"rest_auth"`
-	RestAuthPassword string `json:"rest_auth_password"yaml:"rest_auth_password"`
-	portSetByConfig  bool   ``
-	Pprof            bool   `json:"pprof"yaml:"pprof"`
-	Corsd            string `json:"allowed_origins"yaml:"allowed_origins"`
+	Enable              bool              `json:"enable"yaml:"enable"`
+	Port                int               `json:"port"yaml:"port"`
+	Address             string            `json:"addr"yaml:"addr"`
+	HTTPS               bool              `json:"https"yaml:"https"`
+	RestCertificate     string            `json:"rest_certificate"yaml:"rest_certificate"`
+	RestKey             string            `json:"rest_key"yaml:"rest_key"`
+	RestClientCA        string            `json:"rest_client_ca"yaml:"rest_client_ca"`
+	RestClientCertRoles map[string]string `json:"rest_client_cert_roles"yaml:"rest_client_cert_roles"`
+	RestAuth            bool              `json:"rest_auth"yaml:"rest_auth"`
+	RestAuthPassword    string            `json:"rest_auth_password"yaml:"rest_auth_password"`
+	RestAuthTokenFile   string            `json:"rest_auth_token_file"yaml:"rest_auth_token_file"`
+	portSetByConfig     bool              ``
+	Pprof               bool              `json:"pprof"yaml:"pprof"`
+	Corsd               string            `json:"allowed_origins"yaml:"allowed_origins"`
 }
 
 const (
@@ -50,12 +55,25 @@ const (
 					"rest_auth_password": {
 						"type": "string"
 					},
+					"rest_auth_token_file": {
+						"type": "string"
+					},
 					"rest_certificate": {
 						"type": "string"
 					},
 					"rest_key" : {
 						"type": "string"
 					},
+					"rest_client_ca" : {
+						"type": "string"
+					},
+					"rest_client_cert_roles" : {
+						"type": ["object", "null"],
+						"additionalProperties": {
+							"type": "string",
+							"enum": ["read-only", "operator", "admin"]
+						}
+					},
 					"port" : {
 						"type": "integer",
 						"minimum": 1,
@@ -79,17 +97,19 @@ const (
 // GetDefaultConfig gets the default snapteld configuration
 func GetDefaultConfig() *Config {
 	return &Config{
-		Enable:           defaultEnable,
-		Port:             defaultPort,
-		Address:          defaultAddress,
-		HTTPS:            defaultHTTPS,
-		RestCertificate:  defaultRestCertificate,
-		RestKey:          defaultRestKey,
-		RestAuth:         defaultAuth,
-		RestAuthPassword: defaultAuthPassword,
-		portSetByConfig:  defaultPortSetByConfig,
-		Pprof:            defaultPprof,
-		Corsd:            defaultCorsd,
+		Enable:            defaultEnable,
+		Port:              defaultPort,
+		Address:           defaultAddress,
+		HTTPS:             defaultHTTPS,
+		RestCertificate:   defaultRestCertificate,
+		RestKey:           defaultRestKey,
+		RestClientCA:      defaultRestClientCA,
+		RestAuth:          defaultAuth,
+		RestAuthPassword:  defaultAuthPassword,
+		RestAuthTokenFile: defaultAuthTokenFile,
+		portSetByConfig:   defaultPortSetByConfig,
+		Pprof:             defaultPprof,
+		Corsd:             defaultCorsd,
 	}
 }
 
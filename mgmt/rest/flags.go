@@ -52,10 +52,18 @@ var (
 		Name:  "rest-key",
 		Usage: "A path to a key file to use for HTTPS deployment of Snap's REST API",
 	}
+	flRestClientCA = cli.StringFlag{
+		Name:  "rest-client-ca",
+		Usage: "A path to the CA certificates which must have signed the certificates of Snap's REST API clients",
+	}
 	flRestAuth = cli.BoolFlag{
 		Name:  "rest-auth",
 		Usage: "Enables Snap's REST API authentication",
 	}
+	flRestAuthTokenFile = cli.StringFlag{
+		Name:  "rest-auth-token-file",
+		Usage: "A path to the file holding the API tokens of Snap's REST API",
+	}
 	flPProf = cli.BoolFlag{
 		Name:  "pprof",
 		Usage: "Enables profiling tools",
@@ -66,5 +74,5 @@ var (
 	}
 
 	// Flags consumed by snapteld
-	Flags = []cli.Flag{flAPIDisabled, flAPIAddr, flAPIPort, flRestHTTPS, flRestCert, flRestKey, flRestAuth, flPProf, flCorsd}
+	Flags = []cli.Flag{flAPIDisabled, flAPIAddr, flAPIPort, flRestHTTPS, flRestCert, flRestKey, flRestClientCA, flRestAuth, flRestAuthTokenFile, flPProf, flCorsd}
 )
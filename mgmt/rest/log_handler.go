@@ -38,17 +38,18 @@ func NewLogger() *Logger {
 
 func (l *Logger) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 	l.counter++
-	restLogger.WithFields(log.Fields{
+	fields := log.Fields{
 		"index":  l.counter,
 		"method": r.Method,
 		"url":    r.URL.Path,
-	}).Debug("API request")
+	}
+	if identity := clientIdentity(r); identity != "" {
+		fields["client-identity"] = identity
+	}
+	restLogger.WithFields(fields).Debug("API request")
 	next(rw, r)
 	res := rw.(negroni.ResponseWriter)
-	restLogger.WithFields(log.Fields{
-		"index":       l.counter,
-		"method":      r.Method,
-		"url":         r.URL.Path,
+	restLogger.WithFields(fields).WithFields(log.Fields{
 		"status-code": res.Status(),
 		"status":      http.StatusText(res.Status()),
 	}).Debug("API response")
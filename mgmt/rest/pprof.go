@@ -4,20 +4,21 @@ import (
 	"net/http"
 	"net/http/pprof"
 
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
 	"github.com/julienschmidt/httprouter"
 )
 
 func (s *Server) addPprofRoutes() {
 	if s.pprof {
-		s.r.GET("/debug/pprof/", s.index)
-		s.r.GET("/debug/pprof/block", s.index)
-		s.r.GET("/debug/pprof/goroutine", s.index)
-		s.r.GET("/debug/pprof/heap", s.index)
-		s.r.GET("/debug/pprof/threadcreate", s.index)
-		s.r.GET("/debug/pprof/cmdline", s.cmdline)
-		s.r.GET("/debug/pprof/profile", s.profile)
-		s.r.GET("/debug/pprof/symbol", s.symbol)
-		s.r.GET("/debug/pprof/trace", s.trace)
+		s.r.GET("/debug/pprof/", s.authorize(api.RoleAdmin, s.index))
+		s.r.GET("/debug/pprof/block", s.authorize(api.RoleAdmin, s.index))
+		s.r.GET("/debug/pprof/goroutine", s.authorize(api.RoleAdmin, s.index))
+		s.r.GET("/debug/pprof/heap", s.authorize(api.RoleAdmin, s.index))
+		s.r.GET("/debug/pprof/threadcreate", s.authorize(api.RoleAdmin, s.index))
+		s.r.GET("/debug/pprof/cmdline", s.authorize(api.RoleAdmin, s.cmdline))
+		s.r.GET("/debug/pprof/profile", s.authorize(api.RoleAdmin, s.profile))
+		s.r.GET("/debug/pprof/symbol", s.authorize(api.RoleAdmin, s.symbol))
+		s.r.GET("/debug/pprof/trace", s.authorize(api.RoleAdmin, s.trace))
 	}
 }
 
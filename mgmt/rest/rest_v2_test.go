@@ -36,8 +36,10 @@ import (
 	"testing"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
 	"github.com/intelsdi-x/snap/core/ctypes"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
 	"github.com/intelsdi-x/snap/mgmt/rest/v2/mock"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -56,7 +58,12 @@ func startV2API(cfg *mockConfig, testType string) *restAPIInstance {
 		r.BindMetricManager(mockMetricManager)
 	case "task":
 		mockTaskManager := &mock.MockTaskManager{}
+		mockMetricManager := &mock.MockManagesMetrics{}
 		r.BindTaskManager(mockTaskManager)
+		r.BindMetricManager(mockMetricManager)
+	case "tribe":
+		r.BindTribeManager(mock.NewMockTribeManager())
+		r.BindTaskManager(&mock.MockTaskManager{})
 	}
 	go func(ch <-chan error) {
 		// Block on the error channel. Will return exit status 1 for an error or
@@ -120,6 +127,17 @@ func TestV2Plugin(t *testing.T) {
 				fmt.Sprintf(mock.GET_PLUGINS_RESPONSE, r.port, r.port,
 					r.port, r.port, r.port, r.port))
 		})
+		Convey("Get running plugins - v2/plugins?running", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/plugins?running", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			plugins := v2.PluginsResponse{}
+			So(json.NewDecoder(resp.Body).Decode(&plugins), ShouldBeNil)
+			So(plugins.Plugins, ShouldNotBeEmpty)
+			So(plugins.Plugins[0].CircuitBreaker, ShouldNotBeNil)
+			So(plugins.Plugins[0].CircuitBreaker.State, ShouldEqual, "closed")
+		})
 		Convey("Get plugins - v2/plugins/:type", func() {
 			c := &http.Client{}
 			req, err := http.NewRequest("GET",
@@ -269,6 +287,91 @@ func TestV2Plugin(t *testing.T) {
 				ShouldResemble,
 				fmt.Sprintf(mock.DELETE_PLUGIN_CONFIG_ITEM))
 		})
+
+		Convey("Get plugin logs - /v2/plugins/:type/:name/:version/logs", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/plugins/collector/foo/2/logs?lines=2", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				string(body),
+				ShouldResemble,
+				mock.GET_PLUGIN_LOGS_RESPONSE)
+
+			resp, err = http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/plugins/collector/foo/2/logs?id=2", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			body, err = ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				string(body),
+				ShouldResemble,
+				mock.GET_PLUGIN_LOGS_RESPONSE_ID)
+
+			resp, err = http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/plugins/collector/foo/2/logs?lines=x", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 400)
+
+			resp, err = http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/plugins/collector/nope/2/logs", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 404)
+		})
+
+		Convey("Get plugin stats - /v2/plugins/:type/:name/:version/stats", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/plugins/collector/foo/2/stats", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			stats := v2.PluginStatsResponse{}
+			So(json.NewDecoder(resp.Body).Decode(&stats), ShouldBeNil)
+			So(stats.Instances, ShouldHaveLength, 1)
+			So(stats.Instances[0].ID, ShouldEqual, 1)
+			c := stats.Total.Calls[core.CollectMetricsCall]
+			So(c.Calls, ShouldEqual, 4)
+			So(c.Errors, ShouldEqual, 1)
+			So(c.Timeouts, ShouldEqual, 1)
+			So(c.AvgLatency, ShouldEqual, 10)
+			So(c.MaxLatency, ShouldEqual, 25)
+			So(c.P50Latency, ShouldEqual, 5)
+			So(c.P99Latency, ShouldEqual, 25)
+			So(c.Histogram, ShouldHaveLength, len(core.LatencyBuckets)+1)
+			So(c.Histogram[1], ShouldResemble, v2.LatencyBucket{LE: "5ms", Count: 2})
+			So(c.Histogram[len(core.LatencyBuckets)].LE, ShouldEqual, "+Inf")
+			So(stats.Total.MetricsIn, ShouldEqual, 8)
+			So(stats.Total.MetricsOut, ShouldEqual, 6)
+			So(stats.Scaling.ScaleUps, ShouldEqual, 2)
+			So(stats.Scaling.ScaleDowns, ShouldEqual, 1)
+			So(stats.Scaling.LastAction, ShouldEqual, "down")
+			So(stats.Scaling.LastScaled, ShouldNotBeNil)
+
+			resp, err = http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/plugins/collector/nope/2/stats", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 404)
+		})
+
+		Convey("Refresh plugin metric types - /v2/plugins/:type/:name/:version/refresh", func() {
+			resp, err := http.Post(
+				fmt.Sprintf("http://localhost:%d/v2/plugins/collector/foo/2/refresh", r.port),
+				"application/json", nil)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			refreshed := v2.CatalogRefreshResponse{}
+			So(json.NewDecoder(resp.Body).Decode(&refreshed), ShouldBeNil)
+			So(refreshed.Added, ShouldHaveLength, 1)
+			So(refreshed.Removed, ShouldBeEmpty)
+
+			resp, err = http.Post(
+				fmt.Sprintf("http://localhost:%d/v2/plugins/collector/nope/2/refresh", r.port),
+				"application/json", nil)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 404)
+		})
 	})
 }
 
@@ -314,6 +417,47 @@ func TestV2Task(t *testing.T) {
 			)
 		})
 
+		Convey("Get tasks filtered, sorted and paged - v2/tasks", func() {
+			get := func(query string) (*http.Response, v2.TasksResponse) {
+				resp, err := http.Get(
+					fmt.Sprintf("http://localhost:%d/v2/tasks?%s", r.port, query))
+				So(err, ShouldBeNil)
+				res := v2.TasksResponse{}
+				if resp.StatusCode == 200 {
+					So(json.NewDecoder(resp.Body).Decode(&res), ShouldBeNil)
+				}
+				return resp, res
+			}
+			resp, res := get("sort=-name&limit=1")
+			So(resp.StatusCode, ShouldEqual, 200)
+			So(res.Total, ShouldEqual, 2)
+			So(res.Tasks, ShouldHaveLength, 1)
+			So(res.Tasks[0].Name, ShouldEqual, "TASK2.0")
+			So(res.NextCursor, ShouldNotBeEmpty)
+
+			resp, res = get("sort=-name&limit=1&cursor=" + res.NextCursor)
+			So(resp.StatusCode, ShouldEqual, 200)
+			So(res.Tasks, ShouldHaveLength, 1)
+			So(res.Tasks[0].Name, ShouldEqual, "TASK1.0")
+			So(res.NextCursor, ShouldBeEmpty)
+
+			_, res = get("name_prefix=TASK1&state=running")
+			So(res.Total, ShouldEqual, 1)
+			So(res.Tasks[0].ID, ShouldEqual, "qwertyuiop")
+
+			_, res = get("state=Stopped,Disabled")
+			So(res.Total, ShouldEqual, 0)
+			So(res.Tasks, ShouldBeEmpty)
+
+			_, res = get("labels=dc=us-east")
+			So(res.Total, ShouldEqual, 0)
+
+			resp, _ = get("state=sleeping")
+			So(resp.StatusCode, ShouldEqual, 400)
+			resp, _ = get("sort=color")
+			So(resp.StatusCode, ShouldEqual, 400)
+		})
+
 		Convey("Get task - v2/tasks/:id", func() {
 			taskID := "1234"
 			resp, err := http.Get(
@@ -329,6 +473,31 @@ func TestV2Task(t *testing.T) {
 			)
 		})
 
+		Convey("Get task config - v2/tasks/:id/config", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/tasks/:1234/config", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			var tc v2.TaskConfigResponse
+			So(json.NewDecoder(resp.Body).Decode(&tc), ShouldBeNil)
+			So(tc.Metrics, ShouldHaveLength, 1)
+			So(tc.Metrics[0].Namespace, ShouldEqual, "/one/two/three")
+			So(tc.Metrics[0].Config["user"], ShouldResemble, v2.ConfigSetting{Value: "root", Source: "plugins.all"})
+			So(tc.Metrics[0].Config["password"].Value, ShouldEqual, ctypes.Redacted)
+			So(tc.Nodes, ShouldHaveLength, 1)
+			So(tc.Nodes[0].PluginType, ShouldEqual, "publisher")
+			So(tc.Nodes[0].Config["file"].Source, ShouldEqual, core.ConfigSourceWorkflow)
+
+			resp, err = http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/tasks/:1234/config?ns=/one/*/four", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			tc = v2.TaskConfigResponse{}
+			So(json.NewDecoder(resp.Body).Decode(&tc), ShouldBeNil)
+			So(tc.Metrics, ShouldBeEmpty)
+			So(tc.Nodes, ShouldHaveLength, 1)
+		})
+
 		Convey("Watch tasks - v2/tasks/:id/watch", func() {
 			taskID := "1234"
 			resp, err := http.Get(
@@ -462,5 +631,161 @@ func TestV2Metric(t *testing.T) {
 				ShouldResemble,
 				fmt.Sprintf(mock.GET_METRICS_RESPONSE, r.port))
 		})
+
+		Convey("Search metrics sorted and paged - v2/metrics?sort", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/metrics?sort=-version&limit=1", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			res := v2.MetricSearchResponse{}
+			So(json.NewDecoder(resp.Body).Decode(&res), ShouldBeNil)
+			So(res.Total, ShouldEqual, 1)
+			So(res.Metrics, ShouldHaveLength, 1)
+			So(res.NextCursor, ShouldBeEmpty)
+
+			resp, err = http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/metrics?sort=plugin", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 400)
+		})
+
+		Convey("Search metrics - v2/metrics?text", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/metrics?text=description&limit=10", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			res := v2.MetricSearchResponse{}
+			So(json.NewDecoder(resp.Body).Decode(&res), ShouldBeNil)
+			So(res.Total, ShouldEqual, 1)
+			So(res.Metrics, ShouldHaveLength, 1)
+			So(res.Metrics[0].Version, ShouldEqual, 5)
+
+			resp, err = http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/metrics?regex=%s", r.port, url.QueryEscape("(")))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 400)
+
+			resp, err = http.Get(
+				fmt.Sprintf("http://localhost:%d/v2/metrics?limit=ten", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 400)
+		})
+	})
+}
+
+func TestV2Tribe(t *testing.T) {
+	r := startV2API(getDefaultMockConfig(), "tribe")
+	do := func(method, path, body string) *http.Response {
+		req, err := http.NewRequest(method,
+			fmt.Sprintf("http://localhost:%d/v2/tribe%s", r.port, path),
+			strings.NewReader(body))
+		So(err, ShouldBeNil)
+		resp, err := http.DefaultClient.Do(req)
+		So(err, ShouldBeNil)
+		return resp
+	}
+	decode := func(resp *http.Response, out interface{}) {
+		defer resp.Body.Close()
+		So(json.NewDecoder(resp.Body).Decode(out), ShouldBeNil)
+	}
+	Convey("Test Tribe REST API V2", t, func() {
+
+		Convey("Create, get and delete agreements - v2/tribe/agreements", func() {
+			resp := do("POST", "/agreements", `{"name": "agreement1"}`)
+			So(resp.StatusCode, ShouldEqual, 201)
+			a := v2.Agreement{}
+			decode(resp, &a)
+			So(a.Name, ShouldEqual, "agreement1")
+			So(a.Plugins, ShouldBeEmpty)
+			So(a.Tasks, ShouldBeEmpty)
+			So(a.Members, ShouldBeEmpty)
+
+			So(do("POST", "/agreements", `{"name": "agreement1"}`).StatusCode, ShouldEqual, 409)
+			So(do("POST", "/agreements", `{}`).StatusCode, ShouldEqual, 400)
+			So(do("POST", "/agreements", `{"name": "agreement0"}`).StatusCode, ShouldEqual, 201)
+
+			resp = do("GET", "/agreements", "")
+			So(resp.StatusCode, ShouldEqual, 200)
+			res := v2.AgreementsResponse{}
+			decode(resp, &res)
+			So(len(res.Agreements), ShouldBeGreaterThanOrEqualTo, 2)
+			So(res.Agreements[0].Name, ShouldEqual, "agreement0")
+
+			So(do("GET", "/agreements/agreement1", "").StatusCode, ShouldEqual, 200)
+			So(do("DELETE", "/agreements/agreement1", "").StatusCode, ShouldEqual, 204)
+			So(do("DELETE", "/agreements/agreement0", "").StatusCode, ShouldEqual, 204)
+			resp = do("GET", "/agreements/agreement1", "")
+			So(resp.StatusCode, ShouldEqual, 404)
+			e := v2.Error{}
+			decode(resp, &e)
+			So(e.ErrorMessage, ShouldEqual, v2.ErrAgreementNotFound.Error())
+			So(e.Fields["agreement_name"], ShouldEqual, "agreement1")
+		})
+
+		Convey("Join and leave agreements - v2/tribe/agreements/:name/members/:member", func() {
+			So(do("POST", "/agreements", `{"name": "agreement2"}`).StatusCode, ShouldEqual, 201)
+			resp := do("PUT", "/agreements/agreement2/members/member1", "")
+			So(resp.StatusCode, ShouldEqual, 200)
+			a := v2.Agreement{}
+			decode(resp, &a)
+			So(a.Members, ShouldResemble, []string{"member1"})
+			So(do("PUT", "/agreements/agreement2/members/member9", "").StatusCode, ShouldEqual, 404)
+			So(do("PUT", "/agreements/agreement9/members/member1", "").StatusCode, ShouldEqual, 404)
+
+			resp = do("GET", "/members", "")
+			So(resp.StatusCode, ShouldEqual, 200)
+			members := v2.TribeMembersResponse{}
+			decode(resp, &members)
+			So(len(members.Members), ShouldEqual, 2)
+			So(members.Members[0].Name, ShouldEqual, "member1")
+			So(members.Members[0].PluginAgreement, ShouldEqual, "agreement2")
+			So(members.Members[0].TaskAgreements, ShouldResemble, []string{"agreement2"})
+			So(members.Members[1].PluginAgreement, ShouldBeEmpty)
+
+			resp = do("GET", "/members/member1", "")
+			So(resp.StatusCode, ShouldEqual, 200)
+			m := v2.TribeMember{}
+			decode(resp, &m)
+			So(m.Tags["rest_api_port"], ShouldEqual, "8181")
+			So(do("GET", "/members/member9", "").StatusCode, ShouldEqual, 404)
+
+			resp = do("DELETE", "/agreements/agreement2/members/member1", "")
+			So(resp.StatusCode, ShouldEqual, 200)
+			decode(resp, &a)
+			So(a.Members, ShouldBeEmpty)
+			So(do("DELETE", "/agreements/agreement2", "").StatusCode, ShouldEqual, 204)
+		})
+
+		Convey("Add and remove plugins and tasks - v2/tribe/agreements/:name/plugins|tasks", func() {
+			So(do("POST", "/agreements", `{"name": "agreement3"}`).StatusCode, ShouldEqual, 201)
+			resp := do("POST", "/agreements/agreement3/plugins", `{"type": "collector", "name": "mock", "version": 2}`)
+			So(resp.StatusCode, ShouldEqual, 201)
+			a := v2.Agreement{}
+			decode(resp, &a)
+			So(a.Plugins, ShouldResemble, []v2.AgreementPlugin{{Type: "collector", Name: "mock", Version: 2}})
+			So(do("POST", "/agreements/agreement3/plugins", `{"type": "collector", "name": "mock", "version": 2}`).StatusCode, ShouldEqual, 409)
+			So(do("POST", "/agreements/agreement3/plugins", `{"type": "eater", "name": "mock", "version": 2}`).StatusCode, ShouldEqual, 400)
+			So(do("POST", "/agreements/agreement3/plugins", `{"type": "collector", "name": "mock"}`).StatusCode, ShouldEqual, 400)
+
+			resp = do("POST", "/agreements/agreement3/tasks", `{"id": "1234", "start_on_create": true}`)
+			So(resp.StatusCode, ShouldEqual, 201)
+			decode(resp, &a)
+			So(a.Tasks, ShouldResemble, []v2.AgreementTask{{ID: "1234", StartOnCreate: true}})
+			So(do("POST", "/agreements/agreement3/tasks", `{"id": "1234"}`).StatusCode, ShouldEqual, 409)
+			So(do("POST", "/agreements/agreement3/tasks", `{}`).StatusCode, ShouldEqual, 400)
+
+			So(do("DELETE", "/agreements/agreement3/plugins/collector/mock/2", "").StatusCode, ShouldEqual, 204)
+			So(do("DELETE", "/agreements/agreement3/plugins/collector/mock/2", "").StatusCode, ShouldEqual, 404)
+			So(do("DELETE", "/agreements/agreement3/plugins/collector/mock/two", "").StatusCode, ShouldEqual, 400)
+			So(do("DELETE", "/agreements/agreement3/tasks/1234", "").StatusCode, ShouldEqual, 204)
+			So(do("DELETE", "/agreements/agreement3/tasks/1234", "").StatusCode, ShouldEqual, 404)
+
+			resp = do("GET", "/agreements/agreement3", "")
+			So(resp.StatusCode, ShouldEqual, 200)
+			decode(resp, &a)
+			So(a.Plugins, ShouldBeEmpty)
+			So(a.Tasks, ShouldBeEmpty)
+			So(do("DELETE", "/agreements/agreement3", "").StatusCode, ShouldEqual, 204)
+		})
 	})
 }
@@ -20,6 +20,7 @@ limitations under the License.
 package rest
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -31,6 +32,7 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/gomit"
 	"github.com/julienschmidt/httprouter"
 	"github.com/rs/cors"
 	"github.com/urfave/negroni"
@@ -40,16 +42,29 @@ import (
 	"github.com/intelsdi-x/snap/mgmt/rest/api"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1"
 	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+	"github.com/intelsdi-x/snap/pkg/eventstream"
 )
 
 const (
 	allowedMethods = "GET, POST, DELETE, PUT, OPTIONS"
 	allowedHeaders = "Origin, X-Requested-With, Content-Type, Accept"
 	maxAge         = 3600
+	// eventBufferSize is the number of events kept to resume event streams
+	eventBufferSize = 1000
 )
 
 var (
 	ErrBadCert = errors.New("Invalid certificate given")
+	// ErrBadClientCA is returned when the client CA file holds no PEM certificate
+	ErrBadClientCA = errors.New("Invalid client CA certificates given")
+	// ErrClientCAWithoutHTTPS is returned when client certificates are
+	// required while the REST API isn't served over HTTPS
+	ErrClientCAWithoutHTTPS = errors.New("Client certificates can only be verified when HTTPS is enabled")
+
+	// roleKey is the request context key of the role of an authenticated caller
+	roleKey = &struct{ name string }{"role"}
+	// identityKey is the request context key of the identity of an authenticated caller
+	identityKey = &struct{ name string }{"identity"}
 
 	restLogger     = log.WithField("_module", "_mgmt-rest")
 	protocolPrefix = "http"
@@ -63,12 +78,19 @@ type Server struct {
 	auth           bool
 	pprof          bool
 	authpwd        string
+	tokens         *tokenStore
+	certRoles      map[string]api.Role
+	auditLog       api.AuditLog
+	events         *eventstream.Stream
 	addrString     string
 	addr           net.Addr
 	wg             sync.WaitGroup
 	killChan       chan struct{}
 	err            chan error
 	allowedOrigins map[string]bool
+	public         map[string]bool // paths called without authentication
+	cors           *cors.Cors
+	corsMutex      sync.RWMutex
 	// the following instance variables are used to cleanly shutdown the server
 	serverListener net.Listener
 	closingChan    chan bool
@@ -92,11 +114,68 @@ func New(cfg *Config) (*Server, error) {
 		protocolPrefix = "https"
 	}
 	restLogger.Info(fmt.Sprintf("Configuring REST API with HTTPS set to: %v", cfg.HTTPS))
+	if cfg.RestClientCA != "" {
+		if s.snapTLS == nil {
+			return nil, ErrClientCAWithoutHTTPS
+		}
+		var err error
+		s.snapTLS.clientCAs, err = loadClientCAs(cfg.RestClientCA)
+		if err != nil {
+			return nil, err
+		}
+		restLogger.Info(fmt.Sprintf("REST API clients need a certificate signed by a CA from %v", cfg.RestClientCA))
+	}
+	if len(cfg.RestClientCertRoles) > 0 {
+		if cfg.RestClientCA == "" {
+			return nil, fmt.Errorf("Client certificate roles need the client CA to be set")
+		}
+		s.certRoles = make(map[string]api.Role, len(cfg.RestClientCertRoles))
+		for identity, r := range cfg.RestClientCertRoles {
+			role, err := api.ParseRole(r)
+			if err != nil {
+				return nil, fmt.Errorf("invalid role of client certificate %s: %v", identity, err)
+			}
+			s.certRoles[identity] = role
+		}
+	}
 
 	s.apis = []api.API{
 		v1.New(&s.wg, s.killChan, protocolPrefix),
 		v2.New(&s.wg, s.killChan, protocolPrefix),
 	}
+	// collected and processed metrics are only streamed by the watch of their task
+	s.events = eventstream.New(eventBufferSize)
+	for _, apiInstance := range s.apis {
+		apiInstance.BindEvents(s.events)
+		apiInstance.BindOrigins(s)
+	}
+	if cfg.RestAuthTokenFile != "" && !cfg.RestAuth {
+		restLogger.Error(fmt.Sprintf("API tokens from %v are ignored, REST API authentication is disabled", cfg.RestAuthTokenFile))
+	} else if cfg.RestAuthTokenFile != "" {
+		var err error
+		s.tokens, err = loadTokenStore(cfg.RestAuthTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		// without any other credential an empty token file would lock everyone out
+		if cfg.RestAuthPassword == "" && len(cfg.RestClientCertRoles) == 0 && s.tokens.empty() {
+			token, _, err := s.tokens.CreateToken(bootstrapTokenName, api.RoleAdmin)
+			if err != nil {
+				return nil, err
+			}
+			path, err := s.tokens.writeBootstrapToken(token)
+			if err != nil {
+				// the token could never be read, so don't leave it in the store
+				s.tokens.RevokeToken(bootstrapTokenName)
+				return nil, err
+			}
+			restLogger.Warning(fmt.Sprintf("No API token found in %v, created the %q admin token and wrote it to %v; "+
+				"use it to create other tokens, then delete that file", cfg.RestAuthTokenFile, bootstrapTokenName, path))
+		}
+		for _, apiInstance := range s.apis {
+			apiInstance.BindTokenManager(s.tokens)
+		}
+	}
 
 	s.n = negroni.New(
 		NewLogger(),
@@ -107,19 +186,10 @@ func New(cfg *Config) (*Server, error) {
 
 	// CORS has to be turned on explictly in the global config.
 	// Otherwise, it defauts to the same origin.
-	origins, err := s.getAllowedOrigins(cfg.Corsd)
-	if err != nil {
+	if err := s.SetAllowedOrigins(cfg.Corsd); err != nil {
 		return nil, err
 	}
-	if len(origins) > 0 {
-		c := cors.New(cors.Options{
-			AllowedOrigins: origins,
-			AllowedMethods: []string{allowedMethods},
-			AllowedHeaders: []string{allowedHeaders},
-			MaxAge:         maxAge,
-		})
-		s.n.Use(c)
-	}
+	s.n.Use(negroni.HandlerFunc(s.corsMiddleware))
 
 	// Use negroni to handle routes
 	s.n.UseHandler(s.r)
@@ -150,6 +220,77 @@ func (s *Server) BindConfigManager(c api.Config) {
 	}
 }
 
+func (s *Server) BindConfigReloader(r api.ConfigReloader) {
+	for _, apiInstance := range s.apis {
+		apiInstance.BindConfigReloader(r)
+	}
+}
+
+// BindAuditLog sets the audit log recording the mutating calls of the REST
+// API, it has to be bound before the server starts
+func (s *Server) BindAuditLog(l api.AuditLog) {
+	s.auditLog = l
+	for _, apiInstance := range s.apis {
+		apiInstance.BindAuditLog(l)
+	}
+}
+
+// BindHealth sets the checker of the liveness and readiness of snapteld
+func (s *Server) BindHealth(h api.Health) {
+	for _, apiInstance := range s.apis {
+		apiInstance.BindHealth(h)
+	}
+}
+
+// BindWebhooks sets the webhook sinks whose delivery status is reported
+func (s *Server) BindWebhooks(w api.Webhooks) {
+	for _, apiInstance := range s.apis {
+		apiInstance.BindWebhooks(w)
+	}
+}
+
+// HandleGomitEvent adds the events emitted by control, the scheduler and
+// tribe to the event stream of the REST API
+func (s *Server) HandleGomitEvent(e gomit.Event) {
+	s.events.HandleGomitEvent(e)
+}
+
+// SetAllowedOrigins replaces the CORS allowed origins with the comma separated
+// list in corsd.  An empty list turns CORS off.
+func (s *Server) SetAllowedOrigins(corsd string) error {
+	s.corsMutex.Lock()
+	defer s.corsMutex.Unlock()
+	prev := s.allowedOrigins
+	s.allowedOrigins = nil
+	origins, err := s.getAllowedOrigins(corsd)
+	if err != nil {
+		s.allowedOrigins = prev
+		return err
+	}
+	s.cors = nil
+	if len(origins) > 0 {
+		s.cors = cors.New(cors.Options{
+			AllowedOrigins: origins,
+			AllowedMethods: []string{allowedMethods},
+			AllowedHeaders: []string{allowedHeaders},
+			MaxAge:         maxAge,
+		})
+	}
+	return nil
+}
+
+// CORS Middleware for REST API
+func (s *Server) corsMiddleware(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	s.corsMutex.RLock()
+	c := s.cors
+	s.corsMutex.RUnlock()
+	if c == nil {
+		next(rw, r)
+		return
+	}
+	c.ServeHTTP(rw, r, next)
+}
+
 // SetAPIAuth sets API authentication to enabled or disabled
 func (s *Server) SetAPIAuth(auth bool) {
 	s.auth = auth
@@ -167,23 +308,81 @@ func (s *Server) authMiddleware(rw http.ResponseWriter, r *http.Request, next ht
 
 	defer r.Body.Close()
 	if s.auth {
-		_, password, ok := r.BasicAuth()
-		// If we have valid password or going to tribe/agreements endpoint
-		// go to next. tribe/agreements endpoint used for populating
-		// snaptel help page when tribe mode is turned on.
-		if ok && password == s.authpwd {
-			next(rw, r)
+		role, identity, ok := s.authenticate(r)
+		if ok {
+			ctx := context.WithValue(r.Context(), roleKey, role)
+			next(rw, r.WithContext(context.WithValue(ctx, identityKey, identity)))
+		} else if s.public[r.URL.Path] {
+			next(rw, api.WithAnonymous(r))
 		} else {
-			v2.Write(401, v2.UnauthError{Code: 401, Message: "Not authorized. Please specify the same password that used to start snapteld. E.g: [snaptel -p plugin list] or [curl http://localhost:8181/v2/plugins -u snap]"}, rw)
+			s.auditRejected(r, 401, "Not authorized")
+			v2.Write(401, v2.UnauthError{Code: 401, Message: "Not authorized. Please specify the same password that used to start snapteld or an API token. E.g: [snaptel -p plugin list], [snaptel --token <token> plugin list], [curl http://localhost:8181/v2/plugins -u snap] or [curl http://localhost:8181/v2/plugins -H 'Authorization: Bearer <token>']"}, rw)
 		}
 	} else {
 		next(rw, r)
 	}
 }
 
+// authenticate returns the role and the identity of the caller of the REST
+// API.  A client certificate whose identity is mapped to a role grants that
+// role, the password used to start snapteld grants the admin role, an API token
+// given as a bearer token or as the basic auth password grants its own role.
+func (s *Server) authenticate(r *http.Request) (api.Role, string, bool) {
+	if identity := clientIdentity(r); identity != "" {
+		if role, ok := s.certRoles[identity]; ok {
+			return role, "cert:" + identity, true
+		}
+	}
+	var secret string
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+		secret = strings.TrimSpace(strings.TrimPrefix(h, "Bearer "))
+	} else if username, password, ok := r.BasicAuth(); ok {
+		if s.authpwd != "" && password == s.authpwd {
+			return api.RoleAdmin, "user:" + username, true
+		}
+		secret = password
+	}
+	if secret == "" || s.tokens == nil {
+		return "", "", false
+	}
+	t, ok := s.tokens.lookup(secret)
+	return t.Role, "token:" + t.Name, ok
+}
+
+// authorize refuses the callers of h whose role doesn't grant the required
+// one when authentication is enabled
+func (s *Server) authorize(required api.Role, h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		if s.auth {
+			role, _ := r.Context().Value(roleKey).(api.Role)
+			if !role.Allows(required) {
+				restLogger.WithFields(log.Fields{
+					"_block":          "authorize",
+					"method":          r.Method,
+					"url":             r.URL.Path,
+					"role":            role,
+					"client-identity": clientIdentity(r),
+				}).Warn("API request forbidden")
+				v2.Write(403, v2.UnauthError{Code: 403, Message: fmt.Sprintf("Forbidden. This request needs the %s role, the caller has the %s role.", required, role)}, w)
+				return
+			}
+		}
+		h(w, r, p)
+	}
+}
+
+// AllowsOrigin returns true if origin is one of the CORS allowed origins
+func (s *Server) AllowsOrigin(origin string) bool {
+	s.corsMutex.RLock()
+	defer s.corsMutex.RUnlock()
+	return s.allowedOrigins[origin]
+}
+
 // CORS origins have to be turned on explictly in the global config.
 // Otherwise, it defaults to the same origin.
 func (s *Server) setAllowedOrigins(rw http.ResponseWriter, ro string) {
+	s.corsMutex.RLock()
+	defer s.corsMutex.RUnlock()
 	if len(s.allowedOrigins) > 0 {
 		if _, ok := s.allowedOrigins[ro]; ok {
 			// localhost CORS is not supported by all browsers. It has to use "*".
@@ -250,11 +449,16 @@ func (s *Server) run(addrString string) {
 			return
 		}
 		config := &tls.Config{Certificates: []tls.Certificate{cer}}
+		if s.snapTLS.clientCAs != nil {
+			config.ClientCAs = s.snapTLS.clientCAs
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
 		ln, err := tls.Listen("tcp", addrString, config)
 		if err != nil {
 			log.Fatal(err)
 		}
 		s.serverListener = ln
+		s.addr = ln.Addr()
 		s.wg.Add(1)
 		go s.serveTLS(ln)
 	} else {
@@ -300,9 +504,15 @@ func (s *Server) serve(ln net.Listener) {
 }
 
 func (s *Server) addRoutes() {
+	s.public = map[string]bool{}
 	for _, apiInstance := range s.apis {
 		for _, route := range apiInstance.GetRoutes() {
-			s.r.Handle(route.Method, route.Path, route.Handle)
+			if route.Public {
+				s.public[route.Path] = true
+				s.r.Handle(route.Method, route.Path, route.Handle)
+				continue
+			}
+			s.r.Handle(route.Method, route.Path, s.audited(route, s.authorize(route.RequiredRole(), route.Handle)))
 		}
 	}
 	s.addPprofRoutes()
@@ -27,12 +27,15 @@ import (
 	"encoding/pem"
 	"io/ioutil"
 	"math/big"
+	"net/http"
 	"os"
 	"time"
 )
 
 type snapTLS struct {
 	cert, key string
+	// clientCAs verify the certificates of the clients when set
+	clientCAs *x509.CertPool
 }
 
 func newtls(certPath, keyPath string) (*snapTLS, error) {
@@ -134,3 +137,42 @@ func generateCert(t *snapTLS) error {
 
 	return nil
 }
+
+// loadClientCAs reads the PEM encoded CA certificates which must have signed
+// the certificates of the REST API clients
+func loadClientCAs(caPath string) (*x509.CertPool, error) {
+	b, err := ioutil.ReadFile(caPath)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, ErrBadClientCA
+	}
+	return pool, nil
+}
+
+// certIdentity returns the identity of a client certificate: the common name
+// of its subject or, without one, its first subject alternative name
+func certIdentity(cert *x509.Certificate) string {
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0]
+	case len(cert.IPAddresses) > 0:
+		return cert.IPAddresses[0].String()
+	}
+	return ""
+}
+
+// clientIdentity returns the identity of the verified client certificate of
+// a request, or an empty string when the client didn't present one
+func clientIdentity(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return certIdentity(r.TLS.VerifiedChains[0][0])
+}
@@ -0,0 +1,228 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
+)
+
+const (
+	tokenHashPrefix = "sha256:"
+	// bootstrapTokenName is the name of the admin token created when the
+	// token file is the only credential and holds no token
+	bootstrapTokenName = "admin"
+)
+
+// storedToken is an API token as written in the token file.  Only the hash
+// of the token is stored.
+type storedToken struct {
+	api.Token
+	Hash string `json:"hash"`
+}
+
+// tokenStore holds the API tokens, persisted in a JSON file
+type tokenStore struct {
+	path   string
+	mutex  sync.RWMutex
+	tokens map[string]storedToken // by hash
+}
+
+type tokenFile struct {
+	Tokens []storedToken `json:"tokens"`
+}
+
+// loadTokenStore reads the tokens from path, which doesn't need to exist yet
+func loadTokenStore(path string) (*tokenStore, error) {
+	ts := &tokenStore{path: path, tokens: map[string]storedToken{}}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	tf := tokenFile{}
+	if err := json.Unmarshal(b, &tf); err != nil {
+		return nil, fmt.Errorf("invalid token file %s: %v", path, err)
+	}
+	for _, t := range tf.Tokens {
+		if _, err := api.ParseRole(string(t.Role)); err != nil {
+			return nil, fmt.Errorf("invalid token %s in %s: %v", t.Name, path, err)
+		}
+		if !strings.HasPrefix(t.Hash, tokenHashPrefix) {
+			return nil, fmt.Errorf("invalid token %s in %s: the hash must start with %s", t.Name, path, tokenHashPrefix)
+		}
+		ts.tokens[t.Hash] = t
+	}
+	return ts, nil
+}
+
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return tokenHashPrefix + hex.EncodeToString(h[:])
+}
+
+// lookup returns the API token matching token
+func (ts *tokenStore) lookup(token string) (api.Token, bool) {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+	t, ok := ts.tokens[hashToken(token)]
+	return t.Token, ok
+}
+
+// empty returns true if the store holds no token
+func (ts *tokenStore) empty() bool {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+	return len(ts.tokens) == 0
+}
+
+// Tokens returns the API tokens sorted by name
+func (ts *tokenStore) Tokens() []api.Token {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+	tokens := []api.Token{}
+	for _, t := range ts.sorted() {
+		tokens = append(tokens, t.Token)
+	}
+	return tokens
+}
+
+func (ts *tokenStore) sorted() []storedToken {
+	tokens := make([]storedToken, 0, len(ts.tokens))
+	for _, t := range ts.tokens {
+		tokens = append(tokens, t)
+	}
+	sort.Sort(tokensByName(tokens))
+	return tokens
+}
+
+// CreateToken adds a token with the given name and role and returns it
+func (ts *tokenStore) CreateToken(name string, role api.Role) (string, api.Token, error) {
+	if !api.ValidTokenName(name) {
+		return "", api.Token{}, api.ErrTokenName
+	}
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	for _, t := range ts.tokens {
+		if t.Name == name {
+			return "", api.Token{}, api.ErrTokenExists
+		}
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", api.Token{}, err
+	}
+	token := hex.EncodeToString(b)
+	t := storedToken{
+		Token: api.Token{Name: name, Role: role, Created: time.Now().UTC()},
+		Hash:  hashToken(token),
+	}
+	ts.tokens[t.Hash] = t
+	if err := ts.save(); err != nil {
+		delete(ts.tokens, t.Hash)
+		return "", api.Token{}, err
+	}
+	return token, t.Token, nil
+}
+
+// RevokeToken removes the token with the given name
+func (ts *tokenStore) RevokeToken(name string) error {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	for h, t := range ts.tokens {
+		if t.Name == name {
+			delete(ts.tokens, h)
+			if err := ts.save(); err != nil {
+				ts.tokens[h] = t
+				return err
+			}
+			return nil
+		}
+	}
+	return api.ErrTokenNotFound
+}
+
+// writeBootstrapToken writes token to a file only its owner can read, next to
+// the token file, and returns the path of that file.  A file left from an
+// earlier start is replaced rather than written through, so a symlink put in
+// its place is never followed.
+func (ts *tokenStore) writeBootstrapToken(token string) (string, error) {
+	path := ts.path + "." + bootstrapTokenName
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write([]byte(token + "\n")); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// save writes the tokens to a temporary file renamed over the token file,
+// so a failed write never leaves a truncated file behind
+func (ts *tokenStore) save() error {
+	b, err := json.MarshalIndent(tokenFile{Tokens: ts.sorted()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(ts.path), filepath.Base(ts.path))
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), ts.path)
+}
+
+type tokensByName []storedToken
+
+func (t tokensByName) Len() int           { return len(t) }
+func (t tokensByName) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t tokensByName) Less(i, j int) bool { return t[i].Name < t[j].Name }
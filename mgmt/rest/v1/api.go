@@ -46,11 +46,11 @@ func (s *apiV1) GetRoutes() []api.Route {
 		api.Route{Method: "GET", Path: prefix + "/plugins/:type", Handle: s.getPlugins},
 		api.Route{Method: "GET", Path: prefix + "/plugins/:type/:name", Handle: s.getPlugins},
 		api.Route{Method: "GET", Path: prefix + "/plugins/:type/:name/:version", Handle: s.getPlugin},
-		api.Route{Method: "POST", Path: prefix + "/plugins", Handle: s.loadPlugin},
-		api.Route{Method: "DELETE", Path: prefix + "/plugins/:type/:name/:version", Handle: s.unloadPlugin},
+		api.Route{Method: "POST", Path: prefix + "/plugins", Handle: s.loadPlugin, Role: api.RoleAdmin},
+		api.Route{Method: "DELETE", Path: prefix + "/plugins/:type/:name/:version", Handle: s.unloadPlugin, Role: api.RoleAdmin},
 		api.Route{Method: "GET", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.getPluginConfigItem},
-		api.Route{Method: "PUT", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.setPluginConfigItem},
-		api.Route{Method: "DELETE", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.deletePluginConfigItem},
+		api.Route{Method: "PUT", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.setPluginConfigItem, Role: api.RoleAdmin},
+		api.Route{Method: "DELETE", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.deletePluginConfigItem, Role: api.RoleAdmin},
 
 		// metric routes
 		api.Route{Method: "GET", Path: prefix + "/metrics", Handle: s.getMetrics},
@@ -70,11 +70,11 @@ func (s *apiV1) GetRoutes() []api.Route {
 	if s.tribeManager != nil {
 		routes = append(routes, []api.Route{
 			api.Route{Method: "GET", Path: prefix + "/tribe/agreements", Handle: s.getAgreements},
-			api.Route{Method: "POST", Path: prefix + "/tribe/agreements", Handle: s.addAgreement},
+			api.Route{Method: "POST", Path: prefix + "/tribe/agreements", Handle: s.addAgreement, Role: api.RoleAdmin},
 			api.Route{Method: "GET", Path: prefix + "/tribe/agreements/:name", Handle: s.getAgreement},
-			api.Route{Method: "DELETE", Path: prefix + "/tribe/agreements/:name", Handle: s.deleteAgreement},
-			api.Route{Method: "PUT", Path: prefix + "/tribe/agreements/:name/join", Handle: s.joinAgreement},
-			api.Route{Method: "DELETE", Path: prefix + "/tribe/agreements/:name/leave", Handle: s.leaveAgreement},
+			api.Route{Method: "DELETE", Path: prefix + "/tribe/agreements/:name", Handle: s.deleteAgreement, Role: api.RoleAdmin},
+			api.Route{Method: "PUT", Path: prefix + "/tribe/agreements/:name/join", Handle: s.joinAgreement, Role: api.RoleAdmin},
+			api.Route{Method: "DELETE", Path: prefix + "/tribe/agreements/:name/leave", Handle: s.leaveAgreement, Role: api.RoleAdmin},
 			api.Route{Method: "GET", Path: prefix + "/tribe/members", Handle: s.getMembers},
 			api.Route{Method: "GET", Path: prefix + "/tribe/member/:name", Handle: s.getMember},
 		}...)
@@ -97,3 +97,17 @@ func (s *apiV1) BindTribeManager(tribeManager api.Tribe) {
 func (s *apiV1) BindConfigManager(configManager api.Config) {
 	s.configManager = configManager
 }
+
+func (s *apiV1) BindConfigReloader(configReloader api.ConfigReloader) {}
+
+func (s *apiV1) BindTokenManager(tokenManager api.Tokens) {}
+
+func (s *apiV1) BindAuditLog(auditLog api.AuditLog) {}
+
+func (s *apiV1) BindEvents(events api.Events) {}
+
+func (s *apiV1) BindWebhooks(webhooks api.Webhooks) {}
+
+func (s *apiV1) BindHealth(health api.Health) {}
+
+func (s *apiV1) BindOrigins(origins api.Origins) {}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/serror"
 	"github.com/intelsdi-x/snap/mgmt/rest/v1/rbody"
 	"github.com/julienschmidt/httprouter"
 )
@@ -34,7 +35,7 @@ func (s *apiV1) getPluginConfigItem(w http.ResponseWriter, r *http.Request, p ht
 	styp := p.ByName("type")
 	if styp == "" {
 		cdn := s.configManager.GetPluginConfigDataNodeAll()
-		item := &rbody.PluginConfigItem{ConfigDataNode: cdn}
+		item := &rbody.PluginConfigItem{ConfigDataNode: *cdn.Redacted()}
 		rbody.Write(200, item, w)
 		return
 	}
@@ -58,7 +59,7 @@ func (s *apiV1) getPluginConfigItem(w http.ResponseWriter, r *http.Request, p ht
 	}
 
 	cdn := s.configManager.GetPluginConfigDataNode(typ, name, iver)
-	item := &rbody.PluginConfigItem{ConfigDataNode: cdn}
+	item := &rbody.PluginConfigItem{ConfigDataNode: *cdn.Redacted()}
 	rbody.Write(200, item, w)
 }
 
@@ -94,13 +95,18 @@ func (s *apiV1) deletePluginConfigItem(w http.ResponseWriter, r *http.Request, p
 	}
 
 	var res cdata.ConfigDataNode
+	var serrs []serror.SnapError
 	if styp == "" {
-		res = s.configManager.DeletePluginConfigDataNodeFieldAll(src...)
+		res, serrs = s.configManager.DeletePluginConfigDataNodeFieldAll(src...)
 	} else {
-		res = s.configManager.DeletePluginConfigDataNodeField(typ, name, iver, src...)
+		res, serrs = s.configManager.DeletePluginConfigDataNodeField(typ, name, iver, src...)
+	}
+	if serrs != nil {
+		rbody.Write(409, rbody.FromSnapErrors(serrs), w)
+		return
 	}
 
-	item := &rbody.DeletePluginConfigItem{ConfigDataNode: res}
+	item := &rbody.DeletePluginConfigItem{ConfigDataNode: *res.Redacted()}
 	rbody.Write(200, item, w)
 }
 
@@ -136,12 +142,17 @@ func (s *apiV1) setPluginConfigItem(w http.ResponseWriter, r *http.Request, p ht
 	}
 
 	var res cdata.ConfigDataNode
+	var serrs []serror.SnapError
 	if styp == "" {
-		res = s.configManager.MergePluginConfigDataNodeAll(src)
+		res, serrs = s.configManager.MergePluginConfigDataNodeAll(src)
 	} else {
-		res = s.configManager.MergePluginConfigDataNode(typ, name, iver, src)
+		res, serrs = s.configManager.MergePluginConfigDataNode(typ, name, iver, src)
+	}
+	if serrs != nil {
+		rbody.Write(409, rbody.FromSnapErrors(serrs), w)
+		return
 	}
 
-	item := &rbody.SetPluginConfigItem{ConfigDataNode: res}
+	item := &rbody.SetPluginConfigItem{ConfigDataNode: *res.Redacted()}
 	rbody.Write(200, item, w)
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/scheduler/wmap"
 )
 
 var pluginCatalog []core.CatalogedPlugin = []core.CatalogedPlugin{
@@ -70,6 +71,9 @@ func (m MockLoadedPlugin) Policy() *cpolicy.ConfigPolicy { return cpolicy.New()
 func (m MockLoadedPlugin) HitCount() int                 { return 0 }
 func (m MockLoadedPlugin) LastHit() time.Time            { return time.Now() }
 func (m MockLoadedPlugin) ID() uint32                    { return 0 }
+func (m MockLoadedPlugin) CircuitBreaker() core.CircuitBreakerStatus {
+	return core.CircuitBreakerStatus{State: "closed"}
+}
 
 //////MockCatalogedMetric/////
 
@@ -94,6 +98,15 @@ func (m MockManagesMetrics) MetricCatalog() ([]core.CatalogedMetric, error) {
 func (m MockManagesMetrics) FetchMetrics(core.Namespace, int) ([]core.CatalogedMetric, error) {
 	return metricCatalog, nil
 }
+func (m MockManagesMetrics) SearchMetrics(core.MetricSearch) (core.MetricSearchResult, error) {
+	return core.MetricSearchResult{Metrics: metricCatalog, Total: len(metricCatalog)}, nil
+}
+func (m MockManagesMetrics) ExpandedMetrics(string) ([]core.RequestedMetric, error) {
+	return nil, errors.New("subscription group does not exist")
+}
+func (m MockManagesMetrics) WorkflowConfig(*wmap.WorkflowMap, core.Namespace) (core.WorkflowConfig, []serror.SnapError) {
+	return core.WorkflowConfig{}, nil
+}
 func (m MockManagesMetrics) GetMetricVersions(core.Namespace) ([]core.CatalogedMetric, error) {
 	return metricCatalog, nil
 }
@@ -127,6 +140,18 @@ func (m MockManagesMetrics) AvailablePlugins() []core.AvailablePlugin {
 		MockLoadedPlugin{MyName: "foobar", MyType: "processor", MyVersion: 1},
 	}
 }
+func (m MockManagesMetrics) PluginLogs(core.PluginType, string, int, int) ([]core.PluginLogEntry, serror.SnapError) {
+	return nil, nil
+}
+func (m MockManagesMetrics) WatchPluginLogs(core.PluginType, string, int) (<-chan core.PluginLogEntry, func(), serror.SnapError) {
+	return nil, func() {}, nil
+}
+func (m MockManagesMetrics) PluginStats(core.PluginType, string, int) (core.PluginPoolStats, serror.SnapError) {
+	return core.PluginPoolStats{}, nil
+}
+func (m MockManagesMetrics) RefreshPlugin(core.PluginType, string, int) (core.CatalogRefresh, serror.SnapError) {
+	return core.CatalogRefresh{}, nil
+}
 func (m MockManagesMetrics) GetAutodiscoverPaths() []string {
 	return nil
 }
@@ -95,6 +95,18 @@ func (m *MockTribeManager) GetMembers() []string {
 func (m *MockTribeManager) GetMember(name string) *agreement.Member {
 	return mockTribeMember
 }
+func (m *MockTribeManager) AddPlugin(agreementName string, p agreement.Plugin) error {
+	return nil
+}
+func (m *MockTribeManager) RemovePlugin(agreementName string, p agreement.Plugin) error {
+	return nil
+}
+func (m *MockTribeManager) AddTask(agreementName string, task agreement.Task) serror.SnapError {
+	return nil
+}
+func (m *MockTribeManager) RemoveTask(agreementName string, task agreement.Task) serror.SnapError {
+	return nil
+}
 
 // These constants are the expected tribe responses from running
 // rest_v1_test.go on the tribe routes found in mgmt/rest/server.go
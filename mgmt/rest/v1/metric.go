@@ -155,7 +155,7 @@ func (s *apiV1) getMetricsFromTree(w http.ResponseWriter, r *http.Request, param
 		LastAdvertisedTimestamp: mt.LastAdvertisedTime().Unix(),
 		Href: catalogedMetricURI(r.Host, version, mt),
 	}
-	policies := rbody.PolicyTableSlice(mt.Policy().RulesAsTable())
+	policies := rbody.PolicyTableSlice(mt.Policy().RulesAsTable().Redacted())
 	mb.Policy = policies
 	b.Metric = mb
 	rbody.Write(200, b, w)
@@ -164,7 +164,7 @@ func (s *apiV1) getMetricsFromTree(w http.ResponseWriter, r *http.Request, param
 func respondWithMetrics(host string, mts []core.CatalogedMetric, w http.ResponseWriter) {
 	b := rbody.NewMetricsReturned()
 	for _, m := range mts {
-		policies := rbody.PolicyTableSlice(m.Policy().RulesAsTable())
+		policies := rbody.PolicyTableSlice(m.Policy().RulesAsTable().Redacted())
 		dyn, indexes := m.Namespace().IsDynamic()
 		var dynamicElements []rbody.DynamicElement
 		if dyn {
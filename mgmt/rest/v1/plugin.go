@@ -421,16 +421,19 @@ func (s *apiV1) getPlugin(w http.ResponseWriter, r *http.Request, p httprouter.P
 	d, _ := strconv.ParseBool(rd)
 	var configPolicy []rbody.PolicyTable
 	if plugin.TypeName() == "processor" || plugin.TypeName() == "publisher" {
-		rules := plugin.Policy().Get([]string{""}).RulesAsTable()
+		rules := plugin.Policy().Get([]string{""}).RulesAsTable().Redacted()
 		configPolicy = make([]rbody.PolicyTable, 0, len(rules))
 		for _, r := range rules {
 			configPolicy = append(configPolicy, rbody.PolicyTable{
-				Name:     r.Name,
-				Type:     r.Type,
-				Default:  r.Default,
-				Required: r.Required,
-				Minimum:  r.Minimum,
-				Maximum:  r.Maximum,
+				Name:      r.Name,
+				Type:      r.Type,
+				Default:   r.Default,
+				Required:  r.Required,
+				Minimum:   r.Minimum,
+				Maximum:   r.Maximum,
+				Allowed:   r.Allowed,
+				Pattern:   r.Pattern,
+				Sensitive: r.Sensitive,
 			})
 		}
 
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/encoding"
 	"github.com/intelsdi-x/snap/pkg/schedule"
 	"github.com/intelsdi-x/snap/scheduler/wmap"
 )
@@ -108,7 +109,7 @@ func AddSchedulerTaskFromTask(t core.Task) *AddScheduledTask {
 		FailedCount:        int(t.FailedCount()),
 		LastFailureMessage: t.LastFailureMessage(),
 		State:              t.State().String(),
-		Workflow:           t.WMap(),
+		Workflow:           t.WMap().Redacted(),
 	}
 	assertSchedule(t.Schedule(), st)
 	if st.LastRunTimestamp < 0 {
@@ -264,6 +265,18 @@ type StreamedMetric struct {
 	Tags      map[string]string `json:"tags"`
 }
 
+// UnmarshalJSON decodes a streamed metric, turning distributions found in
+// its data back into core.Distribution values.
+func (s *StreamedMetric) UnmarshalJSON(data []byte) error {
+	type streamedMetric StreamedMetric
+	d, err := encoding.UnmarshalMetric(data, (*streamedMetric)(s))
+	if err != nil {
+		return err
+	}
+	s.Data = d
+	return nil
+}
+
 type StreamedMetrics []StreamedMetric
 
 func (s StreamedMetrics) Len() int {
@@ -41,9 +41,17 @@ var (
 )
 
 type apiV2 struct {
-	metricManager api.Metrics
-	taskManager   api.Tasks
-	configManager api.Config
+	metricManager  api.Metrics
+	taskManager    api.Tasks
+	tribeManager   api.Tribe
+	configManager  api.Config
+	configReloader api.ConfigReloader
+	tokenManager   api.Tokens
+	auditLog       api.AuditLog
+	events         api.Events
+	webhooks       api.Webhooks
+	health         api.Health
+	origins        api.Origins
 
 	wg       *sync.WaitGroup
 	killChan chan struct{}
@@ -70,6 +78,7 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// Responses:
 		// 200: PluginsResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "GET", Path: prefix + "/plugins", Handle: s.getPlugins},
 		// swagger:route GET /plugins/{ptype}/{pname}/{pversion} plugins getPlugin
 		//
@@ -88,6 +97,7 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 404: ErrorResponse
 		// 500: ErrorResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "GET", Path: prefix + "/plugins/:type/:name/:version", Handle: s.getPlugin},
 		// swagger:route POST /plugins plugins loadPlugin
 		//
@@ -110,7 +120,8 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 415: ErrorResponse
 		// 500: ErrorResponse
 		// 401: UnauthResponse
-		api.Route{Method: "POST", Path: prefix + "/plugins", Handle: s.loadPlugin},
+		// 403: UnauthResponse
+		api.Route{Method: "POST", Path: prefix + "/plugins", Handle: s.loadPlugin, Role: api.RoleAdmin},
 		// swagger:route DELETE /plugins/{ptype}/{pname}/{pversion} plugins unloadPlugin
 		//
 		// Unload
@@ -129,7 +140,8 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 409: ErrorResponse
 		// 500: ErrorResponse
 		// 401: UnauthResponse
-		api.Route{Method: "DELETE", Path: prefix + "/plugins/:type/:name/:version", Handle: s.unloadPlugin},
+		// 403: UnauthResponse
+		api.Route{Method: "DELETE", Path: prefix + "/plugins/:type/:name/:version", Handle: s.unloadPlugin, Role: api.RoleAdmin},
 		// swagger:route GET /plugins/{ptype}/{pname}/{pversion}/config plugins getPluginConfigItem
 		//
 		// Get Config
@@ -145,6 +157,7 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 200: PluginConfigResponse
 		// 400: ErrorResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "GET", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.getPluginConfigItem},
 		// swagger:route PUT /plugins/{ptype}/{pname}/{pversion}/config plugins setPluginConfigItem
 		//
@@ -164,7 +177,9 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 200: PluginConfigResponse
 		// 400: ErrorResponse
 		// 401: UnauthResponse
-		api.Route{Method: "PUT", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.setPluginConfigItem},
+		// 403: UnauthResponse
+		// 409: ErrorResponse
+		api.Route{Method: "PUT", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.setPluginConfigItem, Role: api.RoleAdmin},
 		// swagger:route DELETE /plugins/{ptype}/{pname}/{pversion}/config plugins deletePluginConfigItem
 		//
 		// Delete Config
@@ -183,12 +198,77 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 200: PluginConfigResponse
 		// 400: ErrorResponse
 		// 401: UnauthResponse
-		api.Route{Method: "DELETE", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.deletePluginConfigItem},
+		// 403: UnauthResponse
+		// 409: ErrorResponse
+		api.Route{Method: "DELETE", Path: prefix + "/plugins/:type/:name/:version/config", Handle: s.deletePluginConfigItem, Role: api.RoleAdmin},
+		// swagger:route GET /plugins/{ptype}/{pname}/{pversion}/logs plugins getPluginLogs
+		//
+		// Get Plugin Logs
+		//
+		// Returns the most recent lines written to stdout and stderr by the running instances of a plugin.
+		// The follow query parameter streams new lines as Server Sent Events.
+		//
+		// Produces:
+		// application/json, text/event-stream
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: PluginLogsResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 500: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/plugins/:type/:name/:version/logs", Handle: s.getPluginLogs},
+		// swagger:route GET /plugins/{ptype}/{pname}/{pversion}/stats plugins getPluginStats
+		//
+		// Get Plugin Statistics
+		//
+		// Returns the call counts, error counts, timeouts and latency histograms of the RPC calls
+		// made to a plugin since it was first started, and to each of its running instances.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: PluginStatsResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 500: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/plugins/:type/:name/:version/stats", Handle: s.getPluginStats},
+		// swagger:route POST /plugins/{ptype}/{pname}/{pversion}/refresh plugins refreshPlugin
+		//
+		// Refresh Plugin Metrics
+		//
+		// Calls GetMetricTypes on a collector again and updates the metric catalog with the metrics
+		// it now exposes. Running tasks requesting namespaces which match the added metrics collect them.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: CatalogRefreshResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 500: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "POST", Path: prefix + "/plugins/:type/:name/:version/refresh", Handle: s.refreshPlugin},
 		// swagger:route GET /metrics plugins getMetrics
 		//
 		// Get Metrics
 		//
 		// An empty list returns if there is no loaded metrics.
+		// Any of the glob, regex, plugin_name, plugin_version, text, dynamic, offset or limit
+		// parameters searches the metric catalog instead, and returns a MetricSearchResponse
+		// holding the requested page of the matching metrics and their total count.
 		//
 		// Produces:
 		// application/json
@@ -197,10 +277,31 @@ func (s *apiV2) GetRoutes() []api.Route {
 		//
 		// Responses:
 		// 200: MetricsResponse
+		// 400: ErrorResponse
 		// 404: ErrorResponse
 		// 500: ErrorResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "GET", Path: prefix + "/metrics", Handle: s.getMetrics},
+		// swagger:route POST /config/reload config reloadConfig
+		//
+		// Reload
+		//
+		// Re-reads the snapteld config file and applies the settings that can change at runtime.
+		// The response lists the changed settings that were applied and those that need a restart.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: ConfigReloadResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "POST", Path: prefix + "/config/reload", Handle: s.reloadConfig, Role: api.RoleAdmin},
 		// swagger:route GET /tasks tasks getTasks
 		//
 		// Get All
@@ -215,6 +316,7 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// Responses:
 		// 200: TasksResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "GET", Path: prefix + "/tasks", Handle: s.getTasks},
 		// swagger:route GET /tasks/{id} tasks getTask
 		//
@@ -231,24 +333,49 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 200: TaskResponse
 		// 404: ErrorResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "GET", Path: prefix + "/tasks/:id", Handle: s.getTask},
 		// swagger:route GET /tasks/{id}/watch tasks watchTask
 		//
 		// Watch
 		//
-		// The task ID is required.
+		// The task ID is required. The metrics streamed can be filtered by namespace
+		// and tags, and sampled. A request asking for a connection upgrade is served
+		// over a WebSocket.
 		//
 		// Produces:
 		// text/event-stream
 		//
-		// Schemes: http, https
+		// Schemes: http, https, ws, wss
 		//
 		// Responses:
 		// 200: TaskWatchResponse
+		// 400: ErrorResponse
 		// 404: ErrorResponse
 		// 500: ErrorResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "GET", Path: prefix + "/tasks/:id/watch", Handle: s.watchTask},
+		// swagger:route GET /tasks/{id}/config tasks getTaskConfig
+		//
+		// Config
+		//
+		// Returns the config each metric and each process and publish node of the task
+		// receives, with the config layer each key is taken from. The metrics can be
+		// restricted to those matching the namespace query ns.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: TaskConfigResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/tasks/:id/config", Handle: s.getTaskConfig},
 		// swagger:route POST /tasks tasks addTask
 		//
 		// Add
@@ -267,6 +394,7 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 201: TaskResponse
 		// 500: ErrorResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "POST", Path: prefix + "/tasks", Handle: s.addTask},
 		// swagger:route PUT /tasks/{id} tasks updateTaskState
 		//
@@ -288,6 +416,7 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 409: ErrorResponse
 		// 500: ErrorResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "PUT", Path: prefix + "/tasks/:id", Handle: s.updateTaskState},
 		// swagger:route DELETE /tasks/{id} tasks removeTask
 		//
@@ -305,7 +434,379 @@ func (s *apiV2) GetRoutes() []api.Route {
 		// 404: ErrorResponse
 		// 500: TaskErrorResponse
 		// 401: UnauthResponse
+		// 403: UnauthResponse
 		api.Route{Method: "DELETE", Path: prefix + "/tasks/:id", Handle: s.removeTask},
+		// swagger:route GET /tokens tokens getTokens
+		//
+		// Get All Tokens
+		//
+		// Lists the names and roles of the REST API tokens.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: TokensResponse
+		// 404: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/tokens", Handle: s.getTokens, Role: api.RoleAdmin},
+		// swagger:route POST /tokens tokens createToken
+		//
+		// Create Token
+		//
+		// The token is only returned in this response, snapteld keeps its hash.
+		//
+		// Consumes:
+		// application/json
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 201: TokenResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "POST", Path: prefix + "/tokens", Handle: s.createToken, Role: api.RoleAdmin},
+		// swagger:route DELETE /tokens/{name} tokens revokeToken
+		//
+		// Revoke Token
+		//
+		// Requests using the token are refused from then on.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 204: TokenResponse
+		// 404: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "DELETE", Path: prefix + "/tokens/:name", Handle: s.revokeToken, Role: api.RoleAdmin},
+		// swagger:route GET /audit audit getAudit
+		//
+		// Get Audit Entries
+		//
+		// Lists the mutating operations recorded in the audit log, optionally within a time range.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: AuditResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 500: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/audit", Handle: s.getAudit, Role: api.RoleAdmin},
+		// swagger:route GET /events events watchEvents
+		//
+		// Watch Events
+		//
+		// Streams the events of plugins, tasks and tribe, optionally limited to some event types.
+		// The events following the one given by the Last-Event-ID header are replayed first while they are buffered.
+		//
+		// Produces:
+		// text/event-stream
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: EventsResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 500: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/events", Handle: s.watchEvents},
+		// swagger:route GET /webhooks webhooks getWebhooks
+		//
+		// Get Webhooks
+		//
+		// Lists the webhook sinks with the number of delivered and failed events and their last delivery failures.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: WebhooksResponse
+		// 404: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/webhooks", Handle: s.getWebhooks, Role: api.RoleAdmin},
+		// swagger:route GET /tribe/agreements tribe getAgreements
+		//
+		// Get All Agreements
+		//
+		// Lists the tribe agreements with their plugins, tasks and members.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: AgreementsResponse
+		// 404: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/tribe/agreements", Handle: s.getAgreements},
+		// swagger:route POST /tribe/agreements tribe addAgreement
+		//
+		// Create Agreement
+		//
+		// The agreement is created with no plugin, task or member.
+		//
+		// Consumes:
+		// application/json
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 201: AgreementResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "POST", Path: prefix + "/tribe/agreements", Handle: s.addAgreement, Role: api.RoleAdmin},
+		// swagger:route GET /tribe/agreements/{name} tribe getAgreement
+		//
+		// Get Agreement
+		//
+		// An error is returned if the agreement doesn't exist.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: AgreementResponse
+		// 404: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/tribe/agreements/:name", Handle: s.getAgreement},
+		// swagger:route DELETE /tribe/agreements/{name} tribe removeAgreement
+		//
+		// Delete Agreement
+		//
+		// The members of the agreement leave it.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 204: AgreementResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "DELETE", Path: prefix + "/tribe/agreements/:name", Handle: s.removeAgreement, Role: api.RoleAdmin},
+		// swagger:route PUT /tribe/agreements/{name}/members/{member} tribe joinAgreement
+		//
+		// Join Agreement
+		//
+		// The member loads the plugins and runs the tasks of the agreement. A member joins one agreement only.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: AgreementResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "PUT", Path: prefix + "/tribe/agreements/:name/members/:member", Handle: s.joinAgreement, Role: api.RoleAdmin},
+		// swagger:route DELETE /tribe/agreements/{name}/members/{member} tribe leaveAgreement
+		//
+		// Leave Agreement
+		//
+		// The member keeps the plugins and tasks it already has.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: AgreementResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "DELETE", Path: prefix + "/tribe/agreements/:name/members/:member", Handle: s.leaveAgreement, Role: api.RoleAdmin},
+		// swagger:route POST /tribe/agreements/{name}/plugins tribe addAgreementPlugin
+		//
+		// Add Plugin to Agreement
+		//
+		// The members of the agreement which don't have the plugin load it from a member which has it.
+		//
+		// Consumes:
+		// application/json
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 201: AgreementResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "POST", Path: prefix + "/tribe/agreements/:name/plugins", Handle: s.addAgreementPlugin, Role: api.RoleAdmin},
+		// swagger:route DELETE /tribe/agreements/{name}/plugins/{ptype}/{pname}/{pversion} tribe removeAgreementPlugin
+		//
+		// Remove Plugin from Agreement
+		//
+		// The members of the agreement unload the plugin.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 204: AgreementResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "DELETE", Path: prefix + "/tribe/agreements/:name/plugins/:ptype/:pname/:pversion", Handle: s.removeAgreementPlugin, Role: api.RoleAdmin},
+		// swagger:route POST /tribe/agreements/{name}/tasks tribe addAgreementTask
+		//
+		// Add Task to Agreement
+		//
+		// The task has to exist on this member, the other members of the agreement create it and start it when start_on_create is set.
+		//
+		// Consumes:
+		// application/json
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 201: AgreementResponse
+		// 400: ErrorResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "POST", Path: prefix + "/tribe/agreements/:name/tasks", Handle: s.addAgreementTask, Role: api.RoleAdmin},
+		// swagger:route DELETE /tribe/agreements/{name}/tasks/{id} tribe removeAgreementTask
+		//
+		// Remove Task from Agreement
+		//
+		// The members of the agreement remove the task.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 204: AgreementResponse
+		// 404: ErrorResponse
+		// 409: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "DELETE", Path: prefix + "/tribe/agreements/:name/tasks/:id", Handle: s.removeAgreementTask, Role: api.RoleAdmin},
+		// swagger:route GET /tribe/members tribe getTribeMembers
+		//
+		// Get All Members
+		//
+		// Lists the tribe members with the agreements they joined.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: TribeMembersResponse
+		// 404: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/tribe/members", Handle: s.getTribeMembers},
+		// swagger:route GET /tribe/members/{name} tribe getTribeMember
+		//
+		// Get Member
+		//
+		// An error is returned if the member doesn't exist.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: TribeMemberResponse
+		// 404: ErrorResponse
+		// 401: UnauthResponse
+		// 403: UnauthResponse
+		api.Route{Method: "GET", Path: prefix + "/tribe/members/:name", Handle: s.getTribeMember},
+		// swagger:route GET /health/live health getHealthLive
+		//
+		// Liveness
+		//
+		// Checks that control and the scheduler are started. It needs no authentication.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: HealthResponse
+		// 404: ErrorResponse
+		// 503: HealthResponse
+		api.Route{Method: "GET", Path: prefix + "/health/live", Handle: s.getHealthLive, Public: true},
+		// swagger:route GET /health/ready health getHealthReady
+		//
+		// Readiness
+		//
+		// Checks that control and the scheduler are started and that the dead plugins, the disabled tasks,
+		// the saturation of the work queues and the tribe membership are within the configured thresholds.
+		// It needs no authentication.
+		//
+		// Produces:
+		// application/json
+		//
+		// Schemes: http, https
+		//
+		// Responses:
+		// 200: HealthResponse
+		// 404: ErrorResponse
+		// 503: HealthResponse
+		api.Route{Method: "GET", Path: prefix + "/health/ready", Handle: s.getHealthReady, Public: true},
 	}
 	return routes
 }
@@ -318,12 +819,42 @@ func (s *apiV2) BindTaskManager(taskManager api.Tasks) {
 	s.taskManager = taskManager
 }
 
-func (s *apiV2) BindTribeManager(tribeManager api.Tribe) {}
+func (s *apiV2) BindTribeManager(tribeManager api.Tribe) {
+	s.tribeManager = tribeManager
+}
 
 func (s *apiV2) BindConfigManager(configManager api.Config) {
 	s.configManager = configManager
 }
 
+func (s *apiV2) BindConfigReloader(configReloader api.ConfigReloader) {
+	s.configReloader = configReloader
+}
+
+func (s *apiV2) BindTokenManager(tokenManager api.Tokens) {
+	s.tokenManager = tokenManager
+}
+
+func (s *apiV2) BindAuditLog(auditLog api.AuditLog) {
+	s.auditLog = auditLog
+}
+
+func (s *apiV2) BindEvents(events api.Events) {
+	s.events = events
+}
+
+func (s *apiV2) BindWebhooks(webhooks api.Webhooks) {
+	s.webhooks = webhooks
+}
+
+func (s *apiV2) BindHealth(health api.Health) {
+	s.health = health
+}
+
+func (s *apiV2) BindOrigins(origins api.Origins) {
+	s.origins = origins
+}
+
 func Write(code int, body interface{}, w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json; version=2; charset=utf-8")
 	w.Header().Set("Version", "beta")
@@ -0,0 +1,132 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/intelsdi-x/snap/pkg/audit"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	// defaultAuditLimit is the number of audit entries read when no limit is given
+	defaultAuditLimit = 1000
+	// maxAuditLimit is the largest number of audit entries read at once
+	maxAuditLimit = 10000
+)
+
+var (
+	// ErrAuditUnsupported is returned when snapteld keeps no audit log
+	ErrAuditUnsupported = errors.New("The audit log is not enabled, set audit_log_path")
+	// ErrAuditLimitInvalid is returned when the limit of entries read is out of range
+	ErrAuditLimitInvalid = fmt.Errorf("limit must be an integer between 1 and %d", maxAuditLimit)
+)
+
+// AuditResp represents the response of reading the audit log.
+//
+// swagger:response AuditResponse
+type AuditResp struct {
+	// in: body
+	Body AuditResponse
+}
+
+// AuditResponse lists the entries of the audit log.
+type AuditResponse struct {
+	Entries []audit.Entry `json:"entries"`
+	// Skipped is the number of lines of the audit log which are not an entry
+	Skipped int `json:"skipped,omitempty"`
+	// NextCursor is the cursor of the next page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// AuditParams defines the time range of the audit entries read.
+//
+// swagger:parameters getAudit
+type AuditParams struct {
+	// Entries recorded at or after this RFC 3339 time
+	// in: query
+	From string `json:"from"`
+	// Entries recorded before this RFC 3339 time
+	// in: query
+	To string `json:"to"`
+	// Maximum number of entries returned, 1000 by default and at most 10000
+	// in: query
+	Limit int `json:"limit"`
+	// Cursor of the page to return, given as next_cursor by the previous page
+	// in: query
+	Cursor string `json:"cursor"`
+}
+
+func (s *apiV2) getAudit(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.auditLog == nil {
+		Write(404, FromError(ErrAuditUnsupported), w)
+		return
+	}
+	q := r.URL.Query()
+	from, err := parseAuditTime(q.Get("from"))
+	if err != nil {
+		Write(400, FromError(fmt.Errorf("invalid from: %v", err)), w)
+		return
+	}
+	to, err := parseAuditTime(q.Get("to"))
+	if err != nil {
+		Write(400, FromError(fmt.Errorf("invalid to: %v", err)), w)
+		return
+	}
+	limit := defaultAuditLimit
+	if v := q.Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxAuditLimit {
+			Write(400, FromError(ErrAuditLimitInvalid), w)
+			return
+		}
+	}
+	offset := 0
+	if v := q.Get("cursor"); v != "" {
+		offset, err = decodeCursor(v)
+		if err != nil {
+			Write(400, FromError(err), w)
+			return
+		}
+	}
+	page, err := s.auditLog.Entries(from, to, int64(offset), limit)
+	if err != nil {
+		Write(500, FromError(err), w)
+		return
+	}
+	res := AuditResponse{Entries: page.Entries, Skipped: page.Skipped}
+	if page.Next > 0 {
+		res.NextCursor = encodeCursor(int(page.Next))
+	}
+	Write(200, res, w)
+}
+
+// parseAuditTime parses an RFC 3339 time, an empty string is the zero time
+func parseAuditTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
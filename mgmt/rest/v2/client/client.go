@@ -0,0 +1,460 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client is a Go client of the v2 REST API of snapteld, its requests
+// and responses are the types of mgmt/rest/v2.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+	"github.com/intelsdi-x/snap/pkg/netutil"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("Invalid credentials")
+	ErrURLInvalid         = errors.New("URL must be of the form http(s)://<host>:<port>")
+)
+
+// APIError is returned when the API answers a request with an error status.
+// It holds the v2 error response, the fields telling what the error is about.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Fields     map[string]string
+}
+
+// Error returns the message of the error response
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Client calls the v2 REST API of snapteld. It is safe for concurrent use.
+type Client struct {
+	// URL is the address of the REST API, ex: http://localhost:8181
+	URL string
+
+	http *http.Client
+	// sharedHTTP is true while http is the client given by HTTPClient
+	sharedHTTP bool
+	username   string
+	password   string
+	token      string
+	retries    int
+	backoff    time.Duration
+}
+
+// Option configures a Client created by New.
+type Option func(c *Client) error
+
+// Password is an option authenticating the requests with the REST API
+// password, using the username set by Username or "snap".
+func Password(p string) Option {
+	return func(c *Client) error {
+		c.password = strings.TrimSpace(p)
+		return nil
+	}
+}
+
+// Username is an option setting the username sent with the password.
+func Username(u string) Option {
+	return func(c *Client) error {
+		c.username = u
+		return nil
+	}
+}
+
+// Token is an option authenticating the requests with an API token, sent as
+// a bearer token. It takes precedence over the password.
+func Token(t string) Option {
+	return func(c *Client) error {
+		c.token = strings.TrimSpace(t)
+		return nil
+	}
+}
+
+// ClientCert is an option presenting a client certificate to a REST API
+// served over HTTPS which verifies its clients.
+func ClientCert(certFile, keyFile string) Option {
+	return func(c *Client) error {
+		cer, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("Unable to load the client certificate: %v", err)
+		}
+		tlsConfig(c).Certificates = []tls.Certificate{cer}
+		return nil
+	}
+}
+
+// Insecure is an option skipping the verification of the certificate of a
+// REST API served over HTTPS.
+func Insecure() Option {
+	return func(c *Client) error {
+		tlsConfig(c).InsecureSkipVerify = true
+		return nil
+	}
+}
+
+// Timeout is an option bounding the time a request takes, the response
+// included. Watching a task isn't bounded.
+func Timeout(t time.Duration) Option {
+	return func(c *Client) error {
+		ownHTTPClient(c).Timeout = t
+		return nil
+	}
+}
+
+// Retry is an option sending again the requests which failed to connect to
+// the API or were answered 502, 503 or 504, at most retries times. The first
+// retry waits for backoff, which doubles at each retry. Only the requests
+// reading or setting a state (GET, PUT and DELETE) are retried.
+func Retry(retries int, backoff time.Duration) Option {
+	return func(c *Client) error {
+		if retries < 0 || backoff < 0 {
+			return fmt.Errorf("Invalid retry options: %d retries, %v backoff", retries, backoff)
+		}
+		c.retries = retries
+		c.backoff = backoff
+		return nil
+	}
+}
+
+// HTTPClient is an option sending the requests with the given HTTP client
+// instead of a client owned by the Client. Timeout, ClientCert and Insecure,
+// given after it, change a copy of the HTTP client and of its transport.
+func HTTPClient(hc *http.Client) Option {
+	return func(c *Client) error {
+		c.http = hc
+		c.sharedHTTP = true
+		return nil
+	}
+}
+
+// ownHTTPClient returns the HTTP client of c, first replacing a client given
+// by HTTPClient, e.g. http.DefaultClient, with a copy of it and of its
+// transport so options never change them
+func ownHTTPClient(c *Client) *http.Client {
+	if c.sharedHTTP {
+		hc := *c.http
+		t := hc.Transport
+		if t == nil {
+			t = http.DefaultTransport
+		}
+		if ht, ok := t.(*http.Transport); ok {
+			hc.Transport = cloneTransport(ht)
+		}
+		c.http = &hc
+		c.sharedHTTP = false
+	}
+	return c.http
+}
+
+// cloneTransport returns a transport configured as t, which holds
+// connections and locks and can't be copied
+func cloneTransport(t *http.Transport) *http.Transport {
+	return &http.Transport{
+		Proxy:                  t.Proxy,
+		DialContext:            t.DialContext,
+		Dial:                   t.Dial,
+		DialTLS:                t.DialTLS,
+		TLSClientConfig:        cloneTLSConfig(t.TLSClientConfig),
+		TLSHandshakeTimeout:    t.TLSHandshakeTimeout,
+		DisableKeepAlives:      t.DisableKeepAlives,
+		DisableCompression:     t.DisableCompression,
+		MaxIdleConns:           t.MaxIdleConns,
+		MaxIdleConnsPerHost:    t.MaxIdleConnsPerHost,
+		IdleConnTimeout:        t.IdleConnTimeout,
+		ResponseHeaderTimeout:  t.ResponseHeaderTimeout,
+		ExpectContinueTimeout:  t.ExpectContinueTimeout,
+		TLSNextProto:           t.TLSNextProto,
+		MaxResponseHeaderBytes: t.MaxResponseHeaderBytes,
+	}
+}
+
+// cloneTLSConfig returns a copy of the client settings of cfg, which holds
+// locks and can't be copied
+func cloneTLSConfig(cfg *tls.Config) *tls.Config {
+	if cfg == nil {
+		return nil
+	}
+	return &tls.Config{
+		Rand:               cfg.Rand,
+		Time:               cfg.Time,
+		Certificates:       cfg.Certificates,
+		RootCAs:            cfg.RootCAs,
+		NextProtos:         cfg.NextProtos,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		CipherSuites:       cfg.CipherSuites,
+		ClientSessionCache: cfg.ClientSessionCache,
+		MinVersion:         cfg.MinVersion,
+		MaxVersion:         cfg.MaxVersion,
+		CurvePreferences:   cfg.CurvePreferences,
+		Renegotiation:      cfg.Renegotiation,
+	}
+}
+
+// tlsConfig returns the TLS config of the transport of the client, setting
+// one if needed
+func tlsConfig(c *Client) *tls.Config {
+	hc := ownHTTPClient(c)
+	t, ok := hc.Transport.(*http.Transport)
+	if !ok {
+		t = &http.Transport{IdleConnTimeout: time.Second}
+		hc.Transport = t
+	}
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+	return t.TLSClientConfig
+}
+
+// New returns a client of the v2 REST API served at url, ex:
+// http://localhost:8181.
+func New(u string, opts ...Option) (*Client, error) {
+	pu, err := url.Parse(u)
+	if err != nil || (pu.Scheme != "http" && pu.Scheme != "https") || pu.Host == "" {
+		return nil, ErrURLInvalid
+	}
+	c := &Client{
+		URL: strings.TrimSuffix(u, "/"),
+		http: &http.Client{
+			Transport: &http.Transport{IdleConnTimeout: time.Second},
+		},
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// request describes a call to the API. The body is sent again when the
+// request is retried, unless it is a stream.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	stream      io.Reader
+	contentType string
+	header      http.Header
+}
+
+// jsonRequest returns a request whose body is the JSON encoding of v
+func jsonRequest(method, path string, v interface{}) (*request, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &request{method: method, path: path, body: b, contentType: "application/json"}, nil
+}
+
+// retryable returns true if the request can be sent again after a failure
+func (r *request) retryable() bool {
+	return r.stream == nil && (r.method == "GET" || r.method == "PUT" || r.method == "DELETE")
+}
+
+// newHTTPRequest returns the HTTP request of a call to the API.
+func (c *Client) newHTTPRequest(ctx context.Context, r *request) (*http.Request, error) {
+	u := c.URL + "/v2" + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+	var body io.Reader
+	switch {
+	case r.stream != nil:
+		body = r.stream
+	case r.body != nil:
+		body = bytes.NewReader(r.body)
+	}
+	req, err := http.NewRequest(r.method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.header {
+		req.Header[k] = v
+	}
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.password != "" {
+		username := c.username
+		if username == "" {
+			username = "snap"
+		}
+		req.SetBasicAuth(username, c.password)
+	}
+	return req.WithContext(ctx), nil
+}
+
+// send sends a request to the API with hc, retrying it as set by the Retry
+// option. The caller is responsible for closing the response body.
+func (c *Client) send(ctx context.Context, hc *http.Client, r *request) (*http.Response, error) {
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		req, err := c.newHTTPRequest(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		rsp, err := hc.Do(req)
+		if attempt >= c.retries || !r.retryable() || !shouldRetry(rsp, err) {
+			if err != nil {
+				return nil, connectionError(ctx, c.URL, err)
+			}
+			return rsp, nil
+		}
+		if rsp != nil {
+			rsp.Body.Close()
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// shouldRetry returns true if the request failed to connect to the API or
+// the API is temporarily unavailable
+func shouldRetry(rsp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch rsp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// connectionError returns the error of a request which got no response
+func connectionError(ctx context.Context, u string, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if strings.Contains(err.Error(), "tls: oversized record") || strings.Contains(err.Error(), "malformed HTTP response") {
+		return fmt.Errorf("error connecting to API URI: %s. Do you have an http/https mismatch?", u)
+	}
+	return fmt.Errorf("URL target is not available. %v", err)
+}
+
+// do sends a request to the API and decodes the response into out, which
+// can be nil when the response has no body.
+func (c *Client) do(ctx context.Context, r *request, out interface{}) error {
+	rsp, err := c.send(ctx, c.http, r)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	return decode(rsp, out)
+}
+
+// decode unmarshals a response of the API into out, or returns the error
+// carried by the response.
+func decode(rsp *http.Response, out interface{}) error {
+	if rsp.StatusCode == http.StatusUnauthorized {
+		return ErrInvalidCredentials
+	}
+	b, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+	if rsp.StatusCode == http.StatusForbidden {
+		e := &v2.UnauthError{}
+		if err := json.Unmarshal(b, e); err != nil || e.Message == "" {
+			return &APIError{StatusCode: rsp.StatusCode, Message: "Insufficient permissions"}
+		}
+		return &APIError{StatusCode: rsp.StatusCode, Message: e.Message}
+	}
+	if rsp.StatusCode >= 300 {
+		e := &v2.Error{}
+		if err := json.Unmarshal(b, e); err != nil || e.ErrorMessage == "" {
+			return &APIError{StatusCode: rsp.StatusCode, Message: fmt.Sprintf("Unknown API response: %s", rsp.Status)}
+		}
+		return &APIError{StatusCode: rsp.StatusCode, Message: e.ErrorMessage, Fields: e.Fields}
+	}
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		bound := 1000
+		if len(b) > bound {
+			b = b[:bound]
+		}
+		return fmt.Errorf("Unknown API response: %s\n\n Received: %s", err, string(b))
+	}
+	return nil
+}
+
+// path returns an API path made of the given elements, each escaped as a
+// single segment
+func path(elems ...interface{}) string {
+	var p string
+	for _, e := range elems {
+		p += "/" + netutil.PathSegmentEscape(fmt.Sprint(e))
+	}
+	return p
+}
+
+// ListOptions holds the pagination and sorting options of a list request.
+// The zero value lists all the items in their default order.
+type ListOptions struct {
+	// Limit is the maximum number of items returned, all of them when 0
+	Limit int
+	// Cursor is the cursor of the page to return, given as NextCursor by
+	// the previous page
+	Cursor string
+	// Offset is the number of items skipped, when no cursor is given
+	Offset int
+	// Sort is the key the items are sorted by, in descending order when
+	// prefixed with -
+	Sort string
+}
+
+// addTo adds the list options to the query of a request
+func (o ListOptions) addTo(q url.Values) {
+	if o.Limit > 0 {
+		q.Set("limit", fmt.Sprint(o.Limit))
+	}
+	if o.Cursor != "" {
+		q.Set("cursor", o.Cursor)
+	} else if o.Offset > 0 {
+		q.Set("offset", fmt.Sprint(o.Offset))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+}
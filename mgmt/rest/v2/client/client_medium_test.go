@@ -0,0 +1,263 @@
+// +build medium
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/intelsdi-x/snap/mgmt/rest"
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2/mock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// startAPI starts a REST API bound to the mock managers and returns it with
+// its URL. A password is required to call it unless it is empty.
+func startAPI(metrics api.Metrics, tasks api.Tasks, password string) (*rest.Server, string) {
+	log.SetLevel(log.FatalLevel)
+	r, _ := rest.New(rest.GetDefaultConfig())
+	r.BindMetricManager(metrics)
+	r.BindConfigManager(&mock.MockConfigManager{})
+	r.BindTaskManager(tasks)
+	r.BindTribeManager(mock.NewMockTribeManager())
+	if password != "" {
+		r.SetAPIAuth(true)
+		r.SetAPIAuthPwd(password)
+	}
+	r.SetAddress("127.0.0.1:0")
+	r.Start()
+	return r, fmt.Sprintf("http://127.0.0.1:%d", r.Port())
+}
+
+// countingHandler answers the requests with the given status codes in turn,
+// the last one repeated, and counts them
+type countingHandler struct {
+	sync.Mutex
+	codes    []int
+	requests int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.Lock()
+	code := h.codes[len(h.codes)-1]
+	if h.requests < len(h.codes) {
+		code = h.codes[h.requests]
+	}
+	h.requests++
+	h.Unlock()
+	w.WriteHeader(code)
+	if code >= 300 {
+		fmt.Fprint(w, `{"message": "unavailable", "fields": {"reason": "test"}}`)
+		return
+	}
+	fmt.Fprint(w, `{"tasks": [], "total": 0}`)
+}
+
+func (h *countingHandler) count() int {
+	h.Lock()
+	defer h.Unlock()
+	return h.requests
+}
+
+func TestClientOptions(t *testing.T) {
+	Convey("Creating a client", t, func() {
+		Convey("fails with an invalid URL", func() {
+			_, err := New("localhost:8181")
+			So(err, ShouldEqual, ErrURLInvalid)
+			_, err = New("ftp://localhost:8181")
+			So(err, ShouldEqual, ErrURLInvalid)
+		})
+		Convey("fails with invalid retry options", func() {
+			_, err := New("http://localhost:8181", Retry(-1, time.Second))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("fails with a missing client certificate", func() {
+			_, err := New("https://localhost:8181", ClientCert("/no/cert.pem", "/no/key.pem"))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("skips the verification of the API certificate when insecure", func() {
+			c, err := New("https://localhost:8181/", Insecure(), Timeout(time.Second))
+			So(err, ShouldBeNil)
+			So(c.URL, ShouldEqual, "https://localhost:8181")
+			So(c.http.Timeout, ShouldEqual, time.Second)
+			So(c.http.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify, ShouldBeTrue)
+		})
+		Convey("leaves alone the HTTP client it is given", func() {
+			hc := &http.Client{Transport: &http.Transport{MaxIdleConns: 3}}
+			c, err := New("https://localhost:8181", HTTPClient(hc), Insecure(), Timeout(time.Second))
+			So(err, ShouldBeNil)
+			So(c.http, ShouldNotEqual, hc)
+			So(c.http.Timeout, ShouldEqual, time.Second)
+			t := c.http.Transport.(*http.Transport)
+			So(t.MaxIdleConns, ShouldEqual, 3)
+			So(t.TLSClientConfig.InsecureSkipVerify, ShouldBeTrue)
+			So(hc.Timeout, ShouldEqual, 0)
+			So(hc.Transport.(*http.Transport).TLSClientConfig, ShouldBeNil)
+		})
+		Convey("leaves alone the default HTTP client and transport", func() {
+			c, err := New("https://localhost:8181", HTTPClient(http.DefaultClient), Insecure())
+			So(err, ShouldBeNil)
+			So(c.http, ShouldNotEqual, http.DefaultClient)
+			So(c.http.Transport, ShouldNotEqual, http.DefaultTransport)
+			tc := http.DefaultTransport.(*http.Transport).TLSClientConfig
+			So(tc == nil || !tc.InsecureSkipVerify, ShouldBeTrue)
+		})
+		Convey("uses the HTTP client it is given as is without other options", func() {
+			c, err := New("http://localhost:8181", HTTPClient(http.DefaultClient))
+			So(err, ShouldBeNil)
+			So(c.http, ShouldEqual, http.DefaultClient)
+		})
+	})
+	Convey("API paths escape their elements", t, func() {
+		So(path("plugins", "collector", "my plugin", 1), ShouldEqual, "/plugins/collector/my%20plugin/1")
+		So(path("tribe", "agreements", "a+b"), ShouldEqual, "/tribe/agreements/a+b")
+		So(path("tribe", "agreements", "dc/east", "tasks"), ShouldEqual, "/tribe/agreements/dc%2Feast/tasks")
+	})
+}
+
+func TestClientAuth(t *testing.T) {
+	r, uri := startAPI(&mock.MockManagesMetrics{}, &mock.MockTaskManager{}, "secret")
+	defer r.Stop()
+	Convey("Calling a REST API requiring a password", t, func() {
+		ctx := context.Background()
+		Convey("fails without credentials", func() {
+			c, err := New(uri)
+			So(err, ShouldBeNil)
+			_, err = c.GetTasks(ctx, nil)
+			So(err, ShouldEqual, ErrInvalidCredentials)
+		})
+		Convey("fails with a wrong password", func() {
+			c, err := New(uri, Password("wrong"))
+			So(err, ShouldBeNil)
+			_, err = c.GetTasks(ctx, nil)
+			So(err, ShouldEqual, ErrInvalidCredentials)
+		})
+		Convey("succeeds with the password", func() {
+			c, err := New(uri, Username("snap"), Password("secret"))
+			So(err, ShouldBeNil)
+			res, err := c.GetTasks(ctx, nil)
+			So(err, ShouldBeNil)
+			So(res.Total, ShouldEqual, 2)
+		})
+	})
+	Convey("A token is sent as a bearer token", t, func() {
+		var auth string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			fmt.Fprint(w, `{"tasks": [], "total": 0}`)
+		}))
+		defer server.Close()
+		c, err := New(server.URL, Password("secret"), Token(" abcd "))
+		So(err, ShouldBeNil)
+		_, err = c.GetTasks(context.Background(), nil)
+		So(err, ShouldBeNil)
+		So(auth, ShouldEqual, "Bearer abcd")
+	})
+}
+
+func TestClientRetry(t *testing.T) {
+	Convey("A request answered 503", t, func() {
+		h := &countingHandler{codes: []int{503, 503, 200}}
+		server := httptest.NewServer(h)
+		defer server.Close()
+		ctx := context.Background()
+
+		Convey("fails with the error of the response without retries", func() {
+			c, err := New(server.URL)
+			So(err, ShouldBeNil)
+			_, err = c.GetTasks(ctx, nil)
+			So(err, ShouldNotBeNil)
+			apiErr, ok := err.(*APIError)
+			So(ok, ShouldBeTrue)
+			So(apiErr.StatusCode, ShouldEqual, 503)
+			So(apiErr.Message, ShouldEqual, "unavailable")
+			So(apiErr.Fields["reason"], ShouldEqual, "test")
+			So(h.count(), ShouldEqual, 1)
+		})
+		Convey("succeeds once retried", func() {
+			c, err := New(server.URL, Retry(2, time.Millisecond))
+			So(err, ShouldBeNil)
+			res, err := c.GetTasks(ctx, nil)
+			So(err, ShouldBeNil)
+			So(res.Total, ShouldEqual, 0)
+			So(h.count(), ShouldEqual, 3)
+		})
+		Convey("fails when retried fewer times than needed", func() {
+			c, err := New(server.URL, Retry(1, time.Millisecond))
+			So(err, ShouldBeNil)
+			_, err = c.GetTasks(ctx, nil)
+			So(err, ShouldNotBeNil)
+			So(h.count(), ShouldEqual, 2)
+		})
+		Convey("isn't retried when it creates something", func() {
+			c, err := New(server.URL, Retry(2, time.Millisecond))
+			So(err, ShouldBeNil)
+			_, err = c.CreateAgreement(ctx, "agreement1")
+			So(err, ShouldNotBeNil)
+			So(h.count(), ShouldEqual, 1)
+		})
+		Convey("stops being retried when the context is done", func() {
+			c, err := New(server.URL, Retry(2, time.Hour))
+			So(err, ShouldBeNil)
+			ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+			_, err = c.GetTasks(ctx, nil)
+			So(err == context.DeadlineExceeded, ShouldBeTrue)
+			So(h.count(), ShouldEqual, 1)
+		})
+	})
+	Convey("A request to an API which isn't listening", t, func() {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		c, err := New(server.URL, Retry(1, time.Millisecond))
+		So(err, ShouldBeNil)
+		_, err = c.GetTasks(context.Background(), nil)
+		So(err, ShouldNotBeNil)
+		_, ok := err.(*APIError)
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func TestClientContext(t *testing.T) {
+	Convey("A request is canceled with its context", t, func() {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+		c, err := New(server.URL)
+		So(err, ShouldBeNil)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		_, err = c.GetTasks(ctx, nil)
+		So(err, ShouldEqual, context.Canceled)
+	})
+}
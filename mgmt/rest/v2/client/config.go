@@ -0,0 +1,78 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
+)
+
+// GetPluginConfig returns the global config of a plugin. Sensitive values
+// are redacted.
+func (c *Client) GetPluginConfig(ctx context.Context, pluginType, name string, version int) (*cdata.ConfigDataNode, error) {
+	res := cdata.NewNode()
+	if err := c.do(ctx, &request{method: "GET", path: path("plugins", pluginType, name, version, "config")}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetPluginConfig merges the given config into the global config of a
+// plugin and returns the resulting config.
+func (c *Client) SetPluginConfig(ctx context.Context, pluginType, name string, version int, cfg *cdata.ConfigDataNode) (*cdata.ConfigDataNode, error) {
+	r, err := jsonRequest("PUT", path("plugins", pluginType, name, version, "config"), cfg)
+	if err != nil {
+		return nil, err
+	}
+	res := cdata.NewNode()
+	if err := c.do(ctx, r, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeletePluginConfig removes the given keys from the global config of a
+// plugin and returns the resulting config.
+func (c *Client) DeletePluginConfig(ctx context.Context, pluginType, name string, version int, keys ...string) (*cdata.ConfigDataNode, error) {
+	if keys == nil {
+		keys = []string{}
+	}
+	r, err := jsonRequest("DELETE", path("plugins", pluginType, name, version, "config"), keys)
+	if err != nil {
+		return nil, err
+	}
+	res := cdata.NewNode()
+	if err := c.do(ctx, r, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReloadConfig makes snapteld read its config file again and returns the
+// settings it applied and those needing a restart.
+func (c *Client) ReloadConfig(ctx context.Context) (*api.ConfigReload, error) {
+	res := &api.ConfigReload{}
+	if err := c.do(ctx, &request{method: "POST", path: "/config/reload"}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,88 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+)
+
+// MetricListOptions selects the metrics of the catalog listed, all of them
+// with the zero value.
+type MetricListOptions struct {
+	// Namespace only lists the metrics under this namespace, ex: /intel/mock/*
+	Namespace string
+	// Version only lists this version of the metrics, all of them when 0
+	Version int
+	// Glob only lists the metrics whose namespace matches it, element by
+	// element, ex: /intel/*/load/*
+	Glob string
+	// Regex only lists the metrics whose namespace matches it
+	Regex string
+	// PluginName and PluginVersion only list the metrics of this plugin
+	PluginName    string
+	PluginVersion int
+	// Text only lists the metrics having all its terms in their description
+	// or unit
+	Text string
+	// Dynamic only lists either the dynamic or the static metrics
+	Dynamic *bool
+	ListOptions
+}
+
+// GetMetrics returns the metrics of the catalog selected by the options,
+// which can be nil.
+func (c *Client) GetMetrics(ctx context.Context, opts *MetricListOptions) (*v2.MetricSearchResponse, error) {
+	q := url.Values{}
+	if opts != nil {
+		strs := map[string]string{
+			"ns":          opts.Namespace,
+			"glob":        opts.Glob,
+			"regex":       opts.Regex,
+			"plugin_name": opts.PluginName,
+			"text":        opts.Text,
+		}
+		for k, v := range strs {
+			if v != "" {
+				q.Set(k, v)
+			}
+		}
+		if opts.Version > 0 {
+			q.Set("ver", fmt.Sprint(opts.Version))
+		}
+		if opts.PluginVersion > 0 {
+			q.Set("plugin_version", fmt.Sprint(opts.PluginVersion))
+		}
+		if opts.Dynamic != nil {
+			q.Set("dynamic", strconv.FormatBool(*opts.Dynamic))
+		}
+		opts.ListOptions.addTo(q)
+	}
+	// the list of metrics has the fields of a search
+	res := &v2.MetricSearchResponse{}
+	if err := c.do(ctx, &request{method: "GET", path: "/metrics", query: q}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,232 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+)
+
+// PluginUpload is a plugin to load. A snapteld which only loads signed
+// plugins also needs the signature of the plugin.
+type PluginUpload struct {
+	// Name is the file name of the plugin
+	Name string
+	// Plugin is read to upload the plugin
+	Plugin io.Reader
+	// Signature is read to upload the armored signature of the plugin, it is
+	// nil for an unsigned plugin
+	Signature io.Reader
+	// Compress gzips the files while uploading them
+	Compress bool
+}
+
+// PluginListOptions selects the plugins listed, all of them with the zero value.
+type PluginListOptions struct {
+	// Name and Type only list the plugins with this name or type
+	Name string
+	Type string
+	// NamePrefix only lists the plugins whose name starts with it
+	NamePrefix string
+	// Running lists the running plugins instead of the loaded ones
+	Running bool
+	ListOptions
+}
+
+// GetPlugins returns the loaded plugins, or the running plugins if asked by
+// the options. The options can be nil.
+func (c *Client) GetPlugins(ctx context.Context, opts *PluginListOptions) (*v2.PluginsResponse, error) {
+	q := url.Values{}
+	if opts != nil {
+		if opts.Name != "" {
+			q.Set("name", opts.Name)
+		}
+		if opts.Type != "" {
+			q.Set("type", opts.Type)
+		}
+		if opts.NamePrefix != "" {
+			q.Set("name_prefix", opts.NamePrefix)
+		}
+		if opts.Running {
+			q.Set("running", "true")
+		}
+		opts.ListOptions.addTo(q)
+	}
+	res := &v2.PluginsResponse{}
+	if err := c.do(ctx, &request{method: "GET", path: "/plugins", query: q}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetPlugin returns a loaded plugin, with its config policy for processors
+// and publishers.
+func (c *Client) GetPlugin(ctx context.Context, pluginType, name string, version int) (*v2.Plugin, error) {
+	res := &v2.Plugin{}
+	if err := c.do(ctx, &request{method: "GET", path: path("plugins", pluginType, name, version)}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LoadPlugin uploads a plugin, and its signature, as a multipart form and
+// returns the loaded plugin. The upload is streamed, thus never retried.
+func (c *Client) LoadPlugin(ctx context.Context, p PluginUpload) (*v2.Plugin, error) {
+	if p.Plugin == nil {
+		return nil, fmt.Errorf("No plugin to load")
+	}
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	mw := multipart.NewWriter(pw)
+	werr := make(chan error, 1)
+	go func() {
+		err := writePluginUpload(mw, p)
+		pw.CloseWithError(err)
+		werr <- err
+	}()
+
+	r := &request{method: "POST", path: "/plugins", stream: pr, contentType: mw.FormDataContentType()}
+	if p.Compress {
+		r.header = http.Header{"Plugin-Compression": []string{"gzip"}}
+	}
+	rsp, err := c.send(ctx, c.http, r)
+	// the writer is unblocked if the request ended before reading the form
+	pr.Close()
+	if uerr := <-werr; uerr != nil && uerr != io.ErrClosedPipe {
+		if rsp != nil {
+			rsp.Body.Close()
+		}
+		return nil, uerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	res := &v2.Plugin{}
+	if err := decode(rsp, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LoadPluginFile loads the plugin at pluginPath, signed by the signature at
+// signaturePath unless it is empty. The files are uploaded compressed.
+func (c *Client) LoadPluginFile(ctx context.Context, pluginPath, signaturePath string) (*v2.Plugin, error) {
+	fi, err := os.Stat(pluginPath)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return nil, fmt.Errorf("Provided plugin path is a directory not file")
+	}
+	f, err := os.Open(pluginPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p := PluginUpload{Name: filepath.Base(pluginPath), Plugin: f, Compress: true}
+	if signaturePath != "" {
+		s, err := os.Open(signaturePath)
+		if err != nil {
+			return nil, err
+		}
+		defer s.Close()
+		p.Signature = s
+	}
+	return c.LoadPlugin(ctx, p)
+}
+
+// writePluginUpload writes the plugin and its signature as the parts of
+// the form, the signature file having the .asc extension the API expects
+func writePluginUpload(mw *multipart.Writer, p PluginUpload) error {
+	if err := writePluginPart(mw, p.Name, p.Plugin, p.Compress); err != nil {
+		return err
+	}
+	if p.Signature != nil {
+		if err := writePluginPart(mw, p.Name+".asc", p.Signature, p.Compress); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+func writePluginPart(mw *multipart.Writer, name string, r io.Reader, compress bool) error {
+	part, err := mw.CreateFormFile("snap-plugins", name)
+	if err != nil {
+		return err
+	}
+	if !compress {
+		_, err := io.Copy(part, r)
+		return err
+	}
+	gz := gzip.NewWriter(part)
+	if _, err := io.Copy(gz, r); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// UnloadPlugin unloads a plugin.
+func (c *Client) UnloadPlugin(ctx context.Context, pluginType, name string, version int) error {
+	return c.do(ctx, &request{method: "DELETE", path: path("plugins", pluginType, name, version)}, nil)
+}
+
+// GetPluginStats returns the RPC call statistics of a plugin and of each of
+// its running instances.
+func (c *Client) GetPluginStats(ctx context.Context, pluginType, name string, version int) (*v2.PluginStatsResponse, error) {
+	res := &v2.PluginStatsResponse{}
+	if err := c.do(ctx, &request{method: "GET", path: path("plugins", pluginType, name, version, "stats")}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetPluginLogs returns the last lines written by the running instances of
+// a plugin, all the lines kept when lines is 0.
+func (c *Client) GetPluginLogs(ctx context.Context, pluginType, name string, version, lines int) ([]v2.PluginLog, error) {
+	q := url.Values{}
+	if lines > 0 {
+		q.Set("lines", fmt.Sprint(lines))
+	}
+	res := &v2.PluginLogsResponse{}
+	if err := c.do(ctx, &request{method: "GET", path: path("plugins", pluginType, name, version, "logs"), query: q}, res); err != nil {
+		return nil, err
+	}
+	return res.Logs, nil
+}
+
+// RefreshPlugin asks a collector for its metric types again and returns the
+// metrics added to and removed from the metric catalog.
+func (c *Client) RefreshPlugin(ctx context.Context, pluginType, name string, version int) (*v2.CatalogRefreshResponse, error) {
+	res := &v2.CatalogRefreshResponse{}
+	if err := c.do(ctx, &request{method: "POST", path: path("plugins", pluginType, name, version, "refresh")}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
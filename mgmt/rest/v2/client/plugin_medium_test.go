@@ -0,0 +1,228 @@
+// +build medium
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/ctypes"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2/mock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// loadRecorder records the plugins loaded through the REST API
+type loadRecorder struct {
+	mock.MockManagesMetrics
+	sync.Mutex
+	loaded    []byte
+	signature []byte
+}
+
+func (l *loadRecorder) Load(rp *core.RequestedPlugin) (core.CatalogedPlugin, serror.SnapError) {
+	l.Lock()
+	defer l.Unlock()
+	l.loaded, _ = ioutil.ReadFile(rp.Path())
+	l.signature = rp.Signature()
+	os.RemoveAll(filepath.Dir(rp.Path()))
+	return l.MockManagesMetrics.Load(rp)
+}
+
+func TestClientPlugins(t *testing.T) {
+	metrics := &loadRecorder{}
+	r, uri := startAPI(metrics, &mock.MockTaskManager{}, "")
+	defer r.Stop()
+	c, err := New(uri)
+	ctx := context.Background()
+
+	Convey("Plugins are managed through the client", t, func() {
+		So(err, ShouldBeNil)
+
+		Convey("the plugins are listed", func() {
+			res, err := c.GetPlugins(ctx, nil)
+			So(err, ShouldBeNil)
+			So(res.Total, ShouldEqual, 6)
+			So(res.Plugins, ShouldHaveLength, 6)
+
+			res, err = c.GetPlugins(ctx, &PluginListOptions{Name: "foo", ListOptions: ListOptions{Limit: 2, Sort: "-version"}})
+			So(err, ShouldBeNil)
+			So(res.Total, ShouldEqual, 3)
+			So(res.Plugins, ShouldHaveLength, 2)
+			So(res.Plugins[0].Version, ShouldEqual, 6)
+			So(res.NextCursor, ShouldNotBeEmpty)
+
+			res, err = c.GetPlugins(ctx, &PluginListOptions{Name: "foo", ListOptions: ListOptions{Cursor: res.NextCursor, Sort: "-version"}})
+			So(err, ShouldBeNil)
+			So(res.Plugins, ShouldHaveLength, 1)
+			So(res.Plugins[0].Version, ShouldEqual, 2)
+
+			_, err = c.GetPlugins(ctx, &PluginListOptions{ListOptions: ListOptions{Sort: "size"}})
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).StatusCode, ShouldEqual, 400)
+		})
+		Convey("a plugin is returned", func() {
+			p, err := c.GetPlugin(ctx, "publisher", "bar", 3)
+			So(err, ShouldBeNil)
+			So(p.Name, ShouldEqual, "bar")
+			So(p.Type, ShouldEqual, "publisher")
+			So(p.Version, ShouldEqual, 3)
+
+			_, err = c.GetPlugin(ctx, "publisher", "bar", 4)
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).StatusCode, ShouldEqual, 404)
+		})
+		Convey("a plugin is loaded with its signature", func() {
+			for _, compress := range []bool{false, true} {
+				p, err := c.LoadPlugin(ctx, PluginUpload{
+					Name:      "snap-plugin-collector-foo",
+					Plugin:    bytes.NewBufferString("plugin binary"),
+					Signature: bytes.NewBufferString("plugin signature"),
+					Compress:  compress,
+				})
+				So(err, ShouldBeNil)
+				So(p.Name, ShouldEqual, "foo")
+				metrics.Lock()
+				So(string(metrics.loaded), ShouldEqual, "plugin binary")
+				So(string(metrics.signature), ShouldEqual, "plugin signature")
+				metrics.Unlock()
+			}
+		})
+		Convey("a plugin file is loaded", func() {
+			dir, err := ioutil.TempDir("", "snap-client-test-")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "snap-plugin-collector-foo")
+			So(ioutil.WriteFile(path, []byte("plugin file"), 0600), ShouldBeNil)
+
+			p, err := c.LoadPluginFile(ctx, path, "")
+			So(err, ShouldBeNil)
+			So(p.Name, ShouldEqual, "foo")
+			metrics.Lock()
+			So(string(metrics.loaded), ShouldEqual, "plugin file")
+			So(metrics.signature, ShouldBeNil)
+			metrics.Unlock()
+
+			_, err = c.LoadPluginFile(ctx, dir, "")
+			So(err, ShouldNotBeNil)
+			_, err = c.LoadPluginFile(ctx, path, filepath.Join(dir, "missing.asc"))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("a plugin is unloaded", func() {
+			So(c.UnloadPlugin(ctx, "collector", "foo", 2), ShouldBeNil)
+			err := c.UnloadPlugin(ctx, "collector", "foo", 3)
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).Message, ShouldEqual, "plugin not found")
+		})
+		Convey("the statistics and logs of a plugin are returned", func() {
+			stats, err := c.GetPluginStats(ctx, "collector", "foo", 2)
+			So(err, ShouldBeNil)
+			So(stats.Total.Name, ShouldEqual, "foo")
+
+			logs, err := c.GetPluginLogs(ctx, "collector", "foo", 2, 2)
+			So(err, ShouldBeNil)
+			So(logs, ShouldHaveLength, 2)
+			So(logs[1].Line, ShouldEqual, "failed to read")
+		})
+		Convey("the metrics of a collector are refreshed", func() {
+			res, err := c.RefreshPlugin(ctx, "collector", "foo", 2)
+			So(err, ShouldBeNil)
+			So(res, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestClientMetrics(t *testing.T) {
+	r, uri := startAPI(&mock.MockManagesMetrics{}, &mock.MockTaskManager{}, "")
+	defer r.Stop()
+	c, err := New(uri)
+	ctx := context.Background()
+
+	Convey("Metrics are returned through the client", t, func() {
+		So(err, ShouldBeNil)
+
+		Convey("the metric catalog is listed", func() {
+			res, err := c.GetMetrics(ctx, nil)
+			So(err, ShouldBeNil)
+			So(res.Total, ShouldEqual, 1)
+			So(res.Metrics[0].Namespace, ShouldEqual, "/one/two/three")
+			So(res.Metrics[0].Version, ShouldEqual, 5)
+		})
+		Convey("the metrics are searched", func() {
+			dynamic := false
+			res, err := c.GetMetrics(ctx, &MetricListOptions{Glob: "/one/*/three", Dynamic: &dynamic, ListOptions: ListOptions{Limit: 10}})
+			So(err, ShouldBeNil)
+			So(res.Total, ShouldEqual, 1)
+
+			_, err = c.GetMetrics(ctx, &MetricListOptions{Regex: "("})
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).StatusCode, ShouldEqual, 400)
+		})
+	})
+}
+
+func TestClientConfig(t *testing.T) {
+	r, uri := startAPI(&mock.MockManagesMetrics{}, &mock.MockTaskManager{}, "")
+	defer r.Stop()
+	c, err := New(uri)
+	ctx := context.Background()
+
+	Convey("Plugin config is managed through the client", t, func() {
+		So(err, ShouldBeNil)
+
+		Convey("the config of a plugin is returned", func() {
+			cfg, err := c.GetPluginConfig(ctx, "publisher", "bar", 3)
+			So(err, ShouldBeNil)
+			So(cfg.Table()["User"], ShouldResemble, ctypes.ConfigValueStr{Value: "KELLY"})
+			So(cfg.Table()["Port"], ShouldResemble, ctypes.ConfigValueInt{Value: 2})
+		})
+		Convey("the config of a plugin is set", func() {
+			src := cdata.NewNode()
+			src.AddItem("user", ctypes.ConfigValueStr{Value: "Jane"})
+			cfg, err := c.SetPluginConfig(ctx, "publisher", "bar", 3, src)
+			So(err, ShouldBeNil)
+			So(cfg.Table()["user"], ShouldResemble, ctypes.ConfigValueStr{Value: "Jane"})
+
+			_, err = c.SetPluginConfig(ctx, "unknown", "bar", 3, src)
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).StatusCode, ShouldEqual, 400)
+		})
+		Convey("keys are deleted from the config of a plugin", func() {
+			cfg, err := c.DeletePluginConfig(ctx, "publisher", "bar", 3, "password")
+			So(err, ShouldBeNil)
+			So(cfg.Table(), ShouldContainKey, "User")
+			So(cfg.Table(), ShouldNotContainKey, "password")
+		})
+		Convey("reloading the config is unsupported without a config reloader", func() {
+			_, err := c.ReloadConfig(ctx)
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).StatusCode, ShouldEqual, 404)
+		})
+	})
+}
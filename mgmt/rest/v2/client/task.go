@@ -0,0 +1,150 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+)
+
+// TaskAction is a change of the state of a task.
+type TaskAction string
+
+const (
+	// TaskStart starts a stopped task
+	TaskStart TaskAction = "start"
+	// TaskStop stops a running task
+	TaskStop TaskAction = "stop"
+	// TaskEnable enables a disabled task, which can then be started
+	TaskEnable TaskAction = "enable"
+)
+
+// TaskListOptions selects the tasks listed, all of them with the zero value.
+type TaskListOptions struct {
+	// States only lists the tasks in one of these states, ex: Running
+	States []string
+	// NamePrefix only lists the tasks whose name starts with it
+	NamePrefix string
+	// Labels is a label selector matched against the tags the workflow of
+	// the tasks adds to their metrics, ex: dc=us-east,env!=dev,team
+	Labels string
+	ListOptions
+}
+
+// GetTasks returns the tasks selected by the options, which can be nil.
+func (c *Client) GetTasks(ctx context.Context, opts *TaskListOptions) (*v2.TasksResponse, error) {
+	q := url.Values{}
+	if opts != nil {
+		if len(opts.States) > 0 {
+			q.Set("state", strings.Join(opts.States, ","))
+		}
+		if opts.NamePrefix != "" {
+			q.Set("name_prefix", opts.NamePrefix)
+		}
+		if opts.Labels != "" {
+			q.Set("labels", opts.Labels)
+		}
+		opts.ListOptions.addTo(q)
+	}
+	res := &v2.TasksResponse{}
+	if err := c.do(ctx, &request{method: "GET", path: "/tasks", query: q}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTask returns a task, with its workflow and, when it runs, the metrics
+// its requested metrics expand to.
+func (c *Client) GetTask(ctx context.Context, id string) (*v2.Task, error) {
+	res := &v2.Task{}
+	if err := c.do(ctx, &request{method: "GET", path: path("tasks", id)}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateTask creates a task from its schedule, workflow and options, and
+// starts it if Start is set. The created task is returned.
+func (c *Client) CreateTask(ctx context.Context, task core.TaskCreationRequest) (*v2.Task, error) {
+	r, err := jsonRequest("POST", "/tasks", task)
+	if err != nil {
+		return nil, err
+	}
+	res := &v2.Task{}
+	if err := c.do(ctx, r, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateTaskFromManifest creates a task from a task manifest, written in JSON
+// or YAML. The created task is returned.
+func (c *Client) CreateTaskFromManifest(ctx context.Context, manifest []byte) (*v2.Task, error) {
+	res := &v2.Task{}
+	if err := c.do(ctx, &request{method: "POST", path: "/tasks", body: manifest}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateTaskState starts, stops or enables a task.
+func (c *Client) UpdateTaskState(ctx context.Context, id string, action TaskAction) error {
+	q := url.Values{"action": []string{string(action)}}
+	return c.do(ctx, &request{method: "PUT", path: path("tasks", id), query: q}, nil)
+}
+
+// StartTask starts a task.
+func (c *Client) StartTask(ctx context.Context, id string) error {
+	return c.UpdateTaskState(ctx, id, TaskStart)
+}
+
+// StopTask stops a task.
+func (c *Client) StopTask(ctx context.Context, id string) error {
+	return c.UpdateTaskState(ctx, id, TaskStop)
+}
+
+// EnableTask enables a disabled task.
+func (c *Client) EnableTask(ctx context.Context, id string) error {
+	return c.UpdateTaskState(ctx, id, TaskEnable)
+}
+
+// RemoveTask removes a stopped task.
+func (c *Client) RemoveTask(ctx context.Context, id string) error {
+	return c.do(ctx, &request{method: "DELETE", path: path("tasks", id)}, nil)
+}
+
+// GetTaskConfig returns the config each metric and each process and publish
+// node of a task receives. The metrics are restricted to those under the
+// namespace ns, unless it is empty.
+func (c *Client) GetTaskConfig(ctx context.Context, id, ns string) (*v2.TaskConfigResponse, error) {
+	q := url.Values{}
+	if ns != "" {
+		q.Set("ns", ns)
+	}
+	res := &v2.TaskConfigResponse{}
+	if err := c.do(ctx, &request{method: "GET", path: path("tasks", id, "config"), query: q}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
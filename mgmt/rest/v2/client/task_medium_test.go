@@ -0,0 +1,205 @@
+// +build medium
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2/mock"
+	"github.com/intelsdi-x/snap/scheduler/wmap"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// watchedTasks hands the watchers of the tasks to the test
+type watchedTasks struct {
+	mock.MockTaskManager
+	handlers chan core.TaskWatcherHandler
+	closed   chan struct{}
+}
+
+func (w *watchedTasks) WatchTask(id string, h core.TaskWatcherHandler) (core.TaskWatcherCloser, error) {
+	w.handlers <- h
+	return w, nil
+}
+
+// Close may be called more than once by the API, only the first call is
+// recorded
+func (w *watchedTasks) Close() error {
+	select {
+	case w.closed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func TestClientTasks(t *testing.T) {
+	r, uri := startAPI(&mock.MockManagesMetrics{}, &mock.MockTaskManager{}, "")
+	defer r.Stop()
+	c, err := New(uri)
+	ctx := context.Background()
+
+	Convey("Tasks are managed through the client", t, func() {
+		So(err, ShouldBeNil)
+
+		Convey("the tasks are listed", func() {
+			res, err := c.GetTasks(ctx, nil)
+			So(err, ShouldBeNil)
+			So(res.Total, ShouldEqual, 2)
+
+			res, err = c.GetTasks(ctx, &TaskListOptions{NamePrefix: "TASK2", States: []string{"Running", "Stopped"}})
+			So(err, ShouldBeNil)
+			So(res.Total, ShouldEqual, 1)
+			So(res.Tasks[0].ID, ShouldEqual, "asdfghjkl")
+
+			_, err = c.GetTasks(ctx, &TaskListOptions{States: []string{"Sleeping"}})
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).StatusCode, ShouldEqual, 400)
+		})
+		Convey("a task is returned", func() {
+			task, err := c.GetTask(ctx, ":1234")
+			So(err, ShouldBeNil)
+			So(task.ID, ShouldEqual, ":1234")
+			So(task.ExpandedMetrics, ShouldResemble, []v2.ExpandedMetric{{Namespace: "/one/two/three", Version: 5}})
+		})
+		Convey("a task is created", func() {
+			wf := wmap.NewWorkflowMap()
+			wf.Collect.AddMetric("/one/two/three", 1)
+			task, err := c.CreateTask(ctx, core.TaskCreationRequest{
+				Version:  1,
+				Schedule: &core.Schedule{Type: "simple", Interval: "1s"},
+				Workflow: wf,
+				Start:    true,
+			})
+			So(err, ShouldBeNil)
+			So(task.ID, ShouldEqual, "MyTaskID")
+
+			_, err = c.CreateTask(ctx, core.TaskCreationRequest{Version: 1, Workflow: wf})
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).StatusCode, ShouldEqual, 500)
+		})
+		Convey("a task is created from a manifest", func() {
+			task, err := c.CreateTaskFromManifest(ctx, []byte(mock.TASK))
+			So(err, ShouldBeNil)
+			So(task.ID, ShouldEqual, "MyTaskID")
+		})
+		Convey("the state of a task is changed", func() {
+			So(c.StartTask(ctx, "MyTaskID"), ShouldBeNil)
+			So(c.StopTask(ctx, "MyTaskID"), ShouldBeNil)
+			So(c.EnableTask(ctx, "MyTaskID"), ShouldBeNil)
+			err := c.UpdateTaskState(ctx, "MyTaskID", TaskAction("pause"))
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).StatusCode, ShouldEqual, 400)
+		})
+		Convey("a task is removed", func() {
+			So(c.RemoveTask(ctx, "MyTaskID"), ShouldBeNil)
+		})
+		Convey("the config of a task is returned", func() {
+			res, err := c.GetTaskConfig(ctx, ":1234", "/one/*/four")
+			So(err, ShouldBeNil)
+			So(res.Nodes, ShouldNotBeEmpty)
+		})
+	})
+}
+
+func TestClientWatchTask(t *testing.T) {
+	tasks := &watchedTasks{
+		handlers: make(chan core.TaskWatcherHandler, 1),
+		closed:   make(chan struct{}, 1),
+	}
+	// every event is flushed
+	v2.StreamingBufferWindow = 0
+	r, uri := startAPI(&mock.MockManagesMetrics{}, tasks, "")
+	defer r.Stop()
+	c, err := New(uri, Timeout(100*time.Millisecond))
+	ctx := context.Background()
+
+	Convey("A task is watched through the client", t, func() {
+		So(err, ShouldBeNil)
+
+		Convey("until it is stopped", func() {
+			w, err := c.WatchTask(ctx, "1234", &WatchOptions{
+				Namespaces: []string{"/intel/mock/*"},
+				Tags:       map[string]string{"host": "node*"},
+				Processed:  true,
+			})
+			So(err, ShouldBeNil)
+			h := (<-tasks.handlers).(core.TaskProcessWatcherHandler)
+
+			go h.CatchCollection([]core.Metric{
+				plugin.MetricType{Namespace_: core.NewNamespace("intel", "psutil", "load"), Tags_: map[string]string{"host": "node1"}},
+				plugin.MetricType{Namespace_: core.NewNamespace("intel", "mock", "foo"), Tags_: map[string]string{"host": "node1"}},
+			})
+			e := <-w.Events
+			So(e.EventType, ShouldEqual, v2.TaskWatchMetricEvent)
+			So(e.Event, ShouldHaveLength, 1)
+			So(e.Event[0].Namespace, ShouldEqual, "/intel/mock/foo")
+
+			// the watch outlives the timeout of the requests
+			time.Sleep(200 * time.Millisecond)
+			go h.CatchProcessed("passthru", 1, []core.Metric{
+				plugin.MetricType{Namespace_: core.NewNamespace("intel", "mock", "bar"), Tags_: map[string]string{"host": "node2"}},
+			})
+			e = <-w.Events
+			So(e.EventType, ShouldEqual, v2.TaskWatchProcessEvent)
+			So(e.Node, ShouldEqual, "passthru:1")
+
+			go h.CatchTaskStopped()
+			e = <-w.Events
+			So(e.EventType, ShouldEqual, v2.TaskWatchTaskStopped)
+			_, open := <-w.Events
+			So(open, ShouldBeFalse)
+			So(w.Err(), ShouldBeNil)
+			<-tasks.closed
+		})
+		Convey("until it is closed", func() {
+			w, err := c.WatchTask(ctx, "1234", nil)
+			So(err, ShouldBeNil)
+			<-tasks.handlers
+			w.Close()
+			_, open := <-w.Events
+			So(open, ShouldBeFalse)
+			So(w.Err(), ShouldBeNil)
+			<-tasks.closed
+		})
+		Convey("until its context is done", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			w, err := c.WatchTask(ctx, "1234", nil)
+			So(err, ShouldBeNil)
+			<-tasks.handlers
+			cancel()
+			_, open := <-w.Events
+			So(open, ShouldBeFalse)
+			So(w.Err(), ShouldEqual, context.Canceled)
+			<-tasks.closed
+		})
+		Convey("unless the options are invalid", func() {
+			_, err := c.WatchTask(ctx, "1234", &WatchOptions{Namespaces: []string{"/intel/[/foo"}})
+			So(err, ShouldNotBeNil)
+			So(err.(*APIError).StatusCode, ShouldEqual, 400)
+		})
+	})
+}
@@ -0,0 +1,138 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+)
+
+// GetAgreements returns the tribe agreements.
+func (c *Client) GetAgreements(ctx context.Context) ([]v2.Agreement, error) {
+	res := &v2.AgreementsResponse{}
+	if err := c.do(ctx, &request{method: "GET", path: "/tribe/agreements"}, res); err != nil {
+		return nil, err
+	}
+	return res.Agreements, nil
+}
+
+// GetAgreement returns a tribe agreement.
+func (c *Client) GetAgreement(ctx context.Context, name string) (*v2.Agreement, error) {
+	res := &v2.Agreement{}
+	if err := c.do(ctx, &request{method: "GET", path: path("tribe", "agreements", name)}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateAgreement creates a tribe agreement and returns it.
+func (c *Client) CreateAgreement(ctx context.Context, name string) (*v2.Agreement, error) {
+	r, err := jsonRequest("POST", "/tribe/agreements", v2.AgreementRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	res := &v2.Agreement{}
+	if err := c.do(ctx, r, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteAgreement deletes a tribe agreement.
+func (c *Client) DeleteAgreement(ctx context.Context, name string) error {
+	return c.do(ctx, &request{method: "DELETE", path: path("tribe", "agreements", name)}, nil)
+}
+
+// JoinAgreement makes a tribe member join an agreement and returns the
+// agreement.
+func (c *Client) JoinAgreement(ctx context.Context, name, member string) (*v2.Agreement, error) {
+	res := &v2.Agreement{}
+	if err := c.do(ctx, &request{method: "PUT", path: path("tribe", "agreements", name, "members", member)}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LeaveAgreement makes a tribe member leave an agreement and returns the
+// agreement.
+func (c *Client) LeaveAgreement(ctx context.Context, name, member string) (*v2.Agreement, error) {
+	res := &v2.Agreement{}
+	if err := c.do(ctx, &request{method: "DELETE", path: path("tribe", "agreements", name, "members", member)}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddAgreementPlugin adds a plugin to a tribe agreement and returns the
+// agreement.
+func (c *Client) AddAgreementPlugin(ctx context.Context, name string, p v2.AgreementPlugin) (*v2.Agreement, error) {
+	r, err := jsonRequest("POST", path("tribe", "agreements", name, "plugins"), p)
+	if err != nil {
+		return nil, err
+	}
+	res := &v2.Agreement{}
+	if err := c.do(ctx, r, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RemoveAgreementPlugin removes a plugin from a tribe agreement.
+func (c *Client) RemoveAgreementPlugin(ctx context.Context, name string, p v2.AgreementPlugin) error {
+	return c.do(ctx, &request{method: "DELETE", path: path("tribe", "agreements", name, "plugins", p.Type, p.Name, p.Version)}, nil)
+}
+
+// AddAgreementTask adds a task to a tribe agreement and returns the
+// agreement. The task has to exist on the member called.
+func (c *Client) AddAgreementTask(ctx context.Context, name string, t v2.AgreementTask) (*v2.Agreement, error) {
+	r, err := jsonRequest("POST", path("tribe", "agreements", name, "tasks"), t)
+	if err != nil {
+		return nil, err
+	}
+	res := &v2.Agreement{}
+	if err := c.do(ctx, r, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RemoveAgreementTask removes a task from a tribe agreement.
+func (c *Client) RemoveAgreementTask(ctx context.Context, name, taskID string) error {
+	return c.do(ctx, &request{method: "DELETE", path: path("tribe", "agreements", name, "tasks", taskID)}, nil)
+}
+
+// GetTribeMembers returns the members of the tribe.
+func (c *Client) GetTribeMembers(ctx context.Context) ([]v2.TribeMember, error) {
+	res := &v2.TribeMembersResponse{}
+	if err := c.do(ctx, &request{method: "GET", path: "/tribe/members"}, res); err != nil {
+		return nil, err
+	}
+	return res.Members, nil
+}
+
+// GetTribeMember returns a member of the tribe.
+func (c *Client) GetTribeMember(ctx context.Context, name string) (*v2.TribeMember, error) {
+	res := &v2.TribeMember{}
+	if err := c.do(ctx, &request{method: "GET", path: path("tribe", "members", name)}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
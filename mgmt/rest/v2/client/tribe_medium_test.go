@@ -0,0 +1,111 @@
+// +build medium
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+	"github.com/intelsdi-x/snap/mgmt/rest/v2/mock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClientTribe(t *testing.T) {
+	r, uri := startAPI(&mock.MockManagesMetrics{}, &mock.MockTaskManager{}, "")
+	defer r.Stop()
+	c, err := New(uri)
+	ctx := context.Background()
+
+	Convey("An agreement is managed through the client", t, func() {
+		So(err, ShouldBeNil)
+
+		a, err := c.CreateAgreement(ctx, "agreement1")
+		So(err, ShouldBeNil)
+		So(a.Name, ShouldEqual, "agreement1")
+
+		Convey("it is listed", func() {
+			as, err := c.GetAgreements(ctx)
+			So(err, ShouldBeNil)
+			So(as, ShouldHaveLength, 1)
+			So(as[0].Name, ShouldEqual, "agreement1")
+
+			Convey("a member joins it", func() {
+				a, err := c.JoinAgreement(ctx, "agreement1", "member1")
+				So(err, ShouldBeNil)
+				So(a.Members, ShouldResemble, []string{"member1"})
+
+				m, err := c.GetTribeMember(ctx, "member1")
+				So(err, ShouldBeNil)
+				So(m.PluginAgreement, ShouldEqual, "agreement1")
+
+				Convey("a plugin and a task are added to it", func() {
+					p := v2.AgreementPlugin{Type: "collector", Name: "mock", Version: 1}
+					a, err := c.AddAgreementPlugin(ctx, "agreement1", p)
+					So(err, ShouldBeNil)
+					So(a.Plugins, ShouldResemble, []v2.AgreementPlugin{p})
+
+					a, err = c.AddAgreementTask(ctx, "agreement1", v2.AgreementTask{ID: "MyTaskID", StartOnCreate: true})
+					So(err, ShouldBeNil)
+					So(a.Tasks, ShouldHaveLength, 1)
+					So(a.Tasks[0].ID, ShouldEqual, "MyTaskID")
+
+					_, err = c.AddAgreementTask(ctx, "agreement1", v2.AgreementTask{ID: "MyTaskID"})
+					So(err, ShouldNotBeNil)
+
+					Convey("they are removed from it", func() {
+						So(c.RemoveAgreementPlugin(ctx, "agreement1", p), ShouldBeNil)
+						So(c.RemoveAgreementTask(ctx, "agreement1", "MyTaskID"), ShouldBeNil)
+						a, err := c.GetAgreement(ctx, "agreement1")
+						So(err, ShouldBeNil)
+						So(a.Plugins, ShouldBeEmpty)
+						So(a.Tasks, ShouldBeEmpty)
+
+						Convey("the member leaves it", func() {
+							a, err := c.LeaveAgreement(ctx, "agreement1", "member1")
+							So(err, ShouldBeNil)
+							So(a.Members, ShouldBeEmpty)
+
+							Convey("it is deleted", func() {
+								So(c.DeleteAgreement(ctx, "agreement1"), ShouldBeNil)
+								_, err := c.GetAgreement(ctx, "agreement1")
+								So(err, ShouldNotBeNil)
+								So(err.(*APIError).StatusCode, ShouldEqual, 404)
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+
+	Convey("The members of the tribe are listed through the client", t, func() {
+		ms, err := c.GetTribeMembers(ctx)
+		So(err, ShouldBeNil)
+		So(ms, ShouldHaveLength, 2)
+		So(ms[0].Name, ShouldEqual, "member1")
+
+		_, err = c.GetTribeMember(ctx, "member3")
+		So(err, ShouldNotBeNil)
+		So(err.(*APIError).StatusCode, ShouldEqual, 404)
+	})
+}
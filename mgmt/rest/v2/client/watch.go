@@ -0,0 +1,161 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/v2"
+)
+
+// WatchOptions selects the metrics streamed by a task watch, the zero value
+// streams all the collected metrics.
+type WatchOptions struct {
+	// Namespaces only streams the metrics whose namespace matches one of
+	// these globs, element by element, ex: /intel/*/load/*
+	Namespaces []string
+	// Tags only streams the metrics having all these tags, the values being
+	// globs
+	Tags map[string]string
+	// Interval streams each metric at most once per interval
+	Interval time.Duration
+	// Processed also streams the metrics returned by the process nodes of
+	// the task
+	Processed bool
+}
+
+// TaskWatcher streams the events of a watched task. Events is closed when the
+// stream ends, after the task is stopped, ended or disabled, when the watch
+// is closed or its context done, or when the connection fails.
+type TaskWatcher struct {
+	Events <-chan v2.StreamedTaskEvent
+
+	cancel context.CancelFunc
+	mutex  sync.Mutex
+	err    error
+}
+
+// Err returns why the stream ended, nil when it ended with the task or was
+// closed. It is the error of the context when the context is done.
+func (w *TaskWatcher) Err() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.err
+}
+
+// Close stops watching the task
+func (w *TaskWatcher) Close() {
+	w.cancel()
+}
+
+func (w *TaskWatcher) setErr(err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.err = err
+}
+
+// WatchTask streams the events of a task, the metrics it collects and the
+// changes of its state. The options can be nil. The watch isn't bounded by
+// the Timeout option, it ends with the context or when closed.
+func (c *Client) WatchTask(ctx context.Context, id string, opts *WatchOptions) (*TaskWatcher, error) {
+	q := url.Values{}
+	if opts != nil {
+		for _, ns := range opts.Namespaces {
+			q.Add("namespace", ns)
+		}
+		for k, v := range opts.Tags {
+			q.Add("tag", k+":"+v)
+		}
+		if opts.Interval > 0 {
+			q.Set("interval", opts.Interval.String())
+		}
+		if opts.Processed {
+			q.Set("processed", "true")
+		}
+	}
+	// the stream has no timeout
+	hc := *c.http
+	hc.Timeout = 0
+	parent := ctx
+	ctx, cancel := context.WithCancel(parent)
+	rsp, err := c.send(ctx, &hc, &request{method: "GET", path: path("tasks", id, "watch"), query: q})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if rsp.StatusCode != 200 {
+		defer rsp.Body.Close()
+		defer cancel()
+		if err := decode(rsp, nil); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Unknown API response: %s", rsp.Status)
+	}
+
+	events := make(chan v2.StreamedTaskEvent)
+	w := &TaskWatcher{Events: events, cancel: cancel}
+	go func() {
+		defer close(events)
+		defer cancel()
+		defer rsp.Body.Close()
+		reader := bufio.NewReader(rsp.Body)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				// the stream ends without error when the watch is closed
+				if parent.Err() != nil {
+					w.setErr(parent.Err())
+				} else if ctx.Err() == nil {
+					w.setErr(err)
+				}
+				return
+			}
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			e := v2.StreamedTaskEvent{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), &e); err != nil {
+				w.setErr(err)
+				return
+			}
+			if e.EventType == v2.TaskWatchStreamOpen {
+				continue
+			}
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				w.setErr(parent.Err())
+				return
+			}
+			switch e.EventType {
+			case v2.TaskWatchTaskDisabled, v2.TaskWatchTaskStopped, v2.TaskWatchTaskEnded:
+				return
+			}
+		}
+	}()
+	return w, nil
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -37,6 +39,14 @@ type PluginConfigItem struct {
 	cdata.ConfigDataNode
 }
 
+// ConfigReloadResponse represents the response of a config reload.
+//
+// swagger:response ConfigReloadResponse
+type ConfigReloadResponse struct {
+	// in: body
+	Body api.ConfigReload
+}
+
 // PluginConfigResponse represents the response of a plugin config items.
 //
 // swagger:response PluginConfigResponse
@@ -77,7 +87,7 @@ func (s *apiV2) getPluginConfigItem(w http.ResponseWriter, r *http.Request, p ht
 	styp := p.ByName("type")
 	if styp == "" {
 		cfg := s.configManager.GetPluginConfigDataNodeAll()
-		item := &PluginConfigItem{cfg}
+		item := &PluginConfigItem{*cfg.Redacted()}
 		Write(200, item, w)
 		return
 	}
@@ -99,7 +109,7 @@ func (s *apiV2) getPluginConfigItem(w http.ResponseWriter, r *http.Request, p ht
 	}
 
 	cfg := s.configManager.GetPluginConfigDataNode(typ, name, iver)
-	item := &PluginConfigItem{cfg}
+	item := &PluginConfigItem{*cfg.Redacted()}
 	Write(200, item, w)
 }
 
@@ -133,13 +143,18 @@ func (s *apiV2) deletePluginConfigItem(w http.ResponseWriter, r *http.Request, p
 	}
 
 	var res cdata.ConfigDataNode
+	var serrs []serror.SnapError
 	if styp == "" {
-		res = s.configManager.DeletePluginConfigDataNodeFieldAll(src...)
+		res, serrs = s.configManager.DeletePluginConfigDataNodeFieldAll(src...)
 	} else {
-		res = s.configManager.DeletePluginConfigDataNodeField(typ, name, iver, src...)
+		res, serrs = s.configManager.DeletePluginConfigDataNodeField(typ, name, iver, src...)
+	}
+	if serrs != nil {
+		Write(409, FromSnapErrors(serrs), w)
+		return
 	}
 
-	item := &PluginConfigItem{res}
+	item := &PluginConfigItem{*res.Redacted()}
 	Write(200, item, w)
 }
 
@@ -173,12 +188,30 @@ func (s *apiV2) setPluginConfigItem(w http.ResponseWriter, r *http.Request, p ht
 	}
 
 	var res cdata.ConfigDataNode
+	var serrs []serror.SnapError
 	if styp == "" {
-		res = s.configManager.MergePluginConfigDataNodeAll(src)
+		res, serrs = s.configManager.MergePluginConfigDataNodeAll(src)
 	} else {
-		res = s.configManager.MergePluginConfigDataNode(typ, name, iver, src)
+		res, serrs = s.configManager.MergePluginConfigDataNode(typ, name, iver, src)
+	}
+	if serrs != nil {
+		Write(409, FromSnapErrors(serrs), w)
+		return
 	}
 
-	item := &PluginConfigItem{res}
+	item := &PluginConfigItem{*res.Redacted()}
 	Write(200, item, w)
 }
+
+func (s *apiV2) reloadConfig(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.configReloader == nil {
+		Write(404, FromError(ErrConfigReloadUnsupported), w)
+		return
+	}
+	res, serrs := s.configReloader.ReloadConfig()
+	if serrs != nil {
+		Write(400, FromSnapErrors(serrs), w)
+		return
+	}
+	Write(200, res, w)
+}
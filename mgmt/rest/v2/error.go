@@ -34,10 +34,11 @@ const (
 )
 
 var (
-	ErrPluginNotFound       = errors.New("plugin not found")
-	ErrStreamingUnsupported = errors.New("streaming unsupported")
-	ErrNoActionSpecified    = errors.New("no action was specified in the request")
-	ErrWrongAction          = errors.New("wrong action requested")
+	ErrPluginNotFound          = errors.New("plugin not found")
+	ErrStreamingUnsupported    = errors.New("streaming unsupported")
+	ErrNoActionSpecified       = errors.New("no action was specified in the request")
+	ErrWrongAction             = errors.New("wrong action requested")
+	ErrConfigReloadUnsupported = errors.New("config reload is not supported")
 )
 
 // ErrorResponse represents the Snap error response type.
@@ -69,6 +70,11 @@ type Error struct {
 	Fields       map[string]string `json:"fields"`
 }
 
+// Error returns the error message.
+func (e *Error) Error() string {
+	return e.ErrorMessage
+}
+
 func FromSnapError(pe serror.SnapError) *Error {
 	e := &Error{ErrorMessage: pe.Error(), Fields: make(map[string]string)}
 	// Convert into string format
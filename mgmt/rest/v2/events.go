@@ -0,0 +1,176 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/intelsdi-x/snap/pkg/eventstream"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	// Event types of the event stream besides the events of snapteld
+	EventStreamOpen   = "stream-open"
+	EventStreamMissed = "events-missed"
+)
+
+// ErrEventsUnsupported is returned when snapteld streams no events
+var ErrEventsUnsupported = errors.New("The event stream is not available")
+
+// EventsResp defines the response of the event stream.
+//
+// swagger:response EventsResponse
+type EventsResp struct {
+	// in: body
+	Body struct {
+		Event StreamedEvent `json:"event"`
+	}
+}
+
+// EventsParams defines the filters of the event stream.
+//
+// swagger:parameters watchEvents
+type EventsParams struct {
+	// Types of the events streamed, like Control.PluginLoaded, or Control.*
+	// for all the events of a module. All the event types are streamed
+	// without types.
+	// in: query
+	Type []string `json:"type"`
+	// ID of the last event received, the buffered events following it are
+	// replayed before the new ones.
+	// in: header
+	LastEventID string `json:"Last-Event-ID"`
+}
+
+// StreamedEvent defines an event of the event stream.
+type StreamedEvent struct {
+	// ID is the ID to resume the stream from, it is only set on events of snapteld
+	ID      uint64      `json:"id,omitempty"`
+	Time    time.Time   `json:"time"`
+	Type    string      `json:"type"`
+	Message string      `json:"message,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (s *apiV2) watchEvents(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	s.wg.Add(1)
+	defer s.wg.Done()
+
+	if s.events == nil {
+		Write(404, FromError(ErrEventsUnsupported), w)
+		return
+	}
+	filter, err := eventstream.ParseFilter(r.URL.Query()["type"])
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	var after uint64
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("last_event_id")
+	}
+	if lastID != "" {
+		after, err = strconv.ParseUint(lastID, 10, 64)
+		if err != nil {
+			Write(400, FromError(fmt.Errorf("invalid last event ID %q", lastID)), w)
+			return
+		}
+	}
+
+	// get a flusher type
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		// This only works on ResponseWriters that support streaming
+		Write(500, FromError(ErrStreamingUnsupported), w)
+		return
+	}
+	sub := s.events.Subscribe(after)
+	defer sub.Close()
+
+	// Make this Server Sent Events compatible
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	writeStreamedEvent(w, StreamedEvent{
+		Time:    time.Now(),
+		Type:    EventStreamOpen,
+		Message: "Stream opened",
+	})
+	if sub.Missed {
+		writeStreamedEvent(w, StreamedEvent{
+			Time:    time.Now(),
+			Type:    EventStreamMissed,
+			Message: "Events following the last event ID are no longer buffered",
+		})
+	}
+	for _, e := range sub.Replay {
+		if filter.Matches(e.Type) {
+			writeStreamedEvent(w, newStreamedEvent(e))
+		}
+	}
+	flusher.Flush()
+
+	// Get a channel for if the client notifies us it is closing the connection
+	n := w.(http.CloseNotifier).CloseNotify()
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				// the client fell behind, it resumes from its last event
+				return
+			}
+			if filter.Matches(e.Type) {
+				writeStreamedEvent(w, newStreamedEvent(e))
+				flusher.Flush()
+			}
+		case <-n:
+			return
+		case <-s.killChan:
+			return
+		}
+	}
+}
+
+func newStreamedEvent(e eventstream.Event) StreamedEvent {
+	return StreamedEvent{
+		ID:   e.ID,
+		Time: e.Time,
+		Type: e.Type,
+		Data: eventstream.Data(e.Body),
+	}
+}
+
+func writeStreamedEvent(w io.Writer, e StreamedEvent) {
+	j, _ := json.Marshal(e)
+	if e.ID > 0 {
+		fmt.Fprintf(w, "id: %d\n", e.ID)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", j)
+}
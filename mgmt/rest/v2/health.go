@@ -0,0 +1,70 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/intelsdi-x/snap/mgmt/health"
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
+	"github.com/julienschmidt/httprouter"
+)
+
+// ErrHealthUnsupported is returned when snapteld checks no health
+var ErrHealthUnsupported = errors.New("Health checks are not available")
+
+// HealthResp represents the response of a health check, its status code is
+// 503 when a subsystem is down.
+//
+// swagger:response HealthResponse
+type HealthResp struct {
+	// in: body
+	Body health.Report
+}
+
+func (s *apiV2) getHealthLive(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.health == nil {
+		Write(404, FromError(ErrHealthUnsupported), w)
+		return
+	}
+	writeHealth(s.health.Live(), r, w)
+}
+
+func (s *apiV2) getHealthReady(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.health == nil {
+		Write(404, FromError(ErrHealthUnsupported), w)
+		return
+	}
+	writeHealth(s.health.Ready(), r, w)
+}
+
+// writeHealth writes the report, only the statuses of the subsystems when the
+// caller isn't authenticated
+func writeHealth(report health.Report, r *http.Request, w http.ResponseWriter) {
+	if api.Anonymous(r) {
+		report = report.Summary()
+	}
+	code := 200
+	if report.Status == health.StatusDown {
+		code = 503
+	}
+	Write(code, report, w)
+}
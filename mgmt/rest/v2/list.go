@@ -0,0 +1,246 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrListLimitInvalid  = errors.New("limit must be a positive integer")
+	ErrListOffsetInvalid = errors.New("offset must be a positive integer")
+	ErrListCursorInvalid = errors.New("invalid cursor")
+)
+
+// ListParams defines the pagination and sorting query parameters of the
+// task and plugin lists.
+//
+// swagger:parameters getTasks getPlugins
+type ListParams struct {
+	// Maximum number of items returned, all of them when 0
+	// in: query
+	Limit int `json:"limit"`
+	// Cursor of the page to return, given as next_cursor by the previous page
+	// in: query
+	Cursor string `json:"cursor"`
+	// Number of items skipped, when no cursor is given
+	// in: query
+	Offset int `json:"offset"`
+	// Key the items are sorted by, in descending order when prefixed with -
+	// in: query
+	Sort string `json:"sort"`
+}
+
+// listOptions holds the pagination and sorting options of a list request.
+type listOptions struct {
+	limit  int
+	offset int
+	sort   string
+	desc   bool
+}
+
+// parseListOptions reads the limit, cursor, offset and sort query parameters,
+// the sort key having to be one of keys.
+func parseListOptions(q url.Values, keys []string) (listOptions, error) {
+	o := listOptions{}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return o, ErrListLimitInvalid
+		}
+		o.limit = n
+	}
+	if v := q.Get("cursor"); v != "" {
+		n, err := decodeCursor(v)
+		if err != nil {
+			return o, err
+		}
+		o.offset = n
+	} else if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return o, ErrListOffsetInvalid
+		}
+		o.offset = n
+	}
+	if v := q.Get("sort"); v != "" {
+		o.sort = strings.TrimPrefix(v, "-")
+		o.desc = strings.HasPrefix(v, "-")
+		valid := false
+		for _, k := range keys {
+			if k == o.sort {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return o, fmt.Errorf("Invalid sort key `%s`, must be one of: %s", o.sort, strings.Join(keys, ", "))
+		}
+	}
+	return o, nil
+}
+
+// sortList sorts the n items of a list, already in their default order,
+// with the given less function. Items comparing equal keep their order.
+func (o listOptions) sortList(n int, swap func(i, j int), less func(i, j int) bool) {
+	if o.sort == "" {
+		return
+	}
+	s := &listSorter{n: n, swap: swap, less: less}
+	if o.desc {
+		s.less = func(i, j int) bool { return less(j, i) }
+	}
+	sort.Stable(s)
+}
+
+// page returns the bounds of the page of a list of n items, and the cursor of
+// the next page, which is empty on the last page.
+func (o listOptions) page(n int) (start, end int, next string) {
+	start, end = o.offset, n
+	if start > n {
+		start = n
+	}
+	if o.limit > 0 && start+o.limit < n {
+		end = start + o.limit
+		next = encodeCursor(end)
+	}
+	return start, end, next
+}
+
+// encodeCursor returns the opaque cursor of the page starting at offset
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrListCursorInvalid
+	}
+	n, err := strconv.Atoi(string(b))
+	if err != nil || n < 0 {
+		return 0, ErrListCursorInvalid
+	}
+	return n, nil
+}
+
+// listSorter sorts any list through its swap and less functions
+type listSorter struct {
+	n    int
+	swap func(i, j int)
+	less func(i, j int) bool
+}
+
+func (s *listSorter) Len() int {
+	return s.n
+}
+
+func (s *listSorter) Less(i, j int) bool {
+	return s.less(i, j)
+}
+
+func (s *listSorter) Swap(i, j int) {
+	s.swap(i, j)
+}
+
+// splitValues returns the values of a repeatable query parameter, which can
+// also be comma separated
+func splitValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// labelRequirement is a requirement of a label selector on one label
+type labelRequirement struct {
+	key    string
+	value  string
+	op     string
+	exists bool
+}
+
+// labelSelector selects the items whose labels meet all its requirements.
+type labelSelector []labelRequirement
+
+// parseLabelSelector reads a comma separated list of requirements, each one
+// of key=value, key==value, key!=value, key (the label is set) or !key (the
+// label isn't set).
+func parseLabelSelector(s string) (labelSelector, error) {
+	var sel labelSelector
+	for _, req := range splitValues([]string{s}) {
+		var r labelRequirement
+		switch {
+		case strings.Contains(req, "!="):
+			kv := strings.SplitN(req, "!=", 2)
+			r = labelRequirement{key: kv[0], value: kv[1], op: "!="}
+		case strings.Contains(req, "=="):
+			kv := strings.SplitN(req, "==", 2)
+			r = labelRequirement{key: kv[0], value: kv[1], op: "="}
+		case strings.Contains(req, "="):
+			kv := strings.SplitN(req, "=", 2)
+			r = labelRequirement{key: kv[0], value: kv[1], op: "="}
+		case strings.HasPrefix(req, "!"):
+			r = labelRequirement{key: req[1:], exists: false}
+		default:
+			r = labelRequirement{key: req, exists: true}
+		}
+		r.key = strings.TrimSpace(r.key)
+		r.value = strings.TrimSpace(r.value)
+		if r.key == "" {
+			return nil, fmt.Errorf("Invalid label selector `%s`", req)
+		}
+		sel = append(sel, r)
+	}
+	return sel, nil
+}
+
+// matches returns true if the labels meet all the requirements
+func (sel labelSelector) matches(labels map[string]string) bool {
+	for _, r := range sel {
+		v, ok := labels[r.key]
+		switch r.op {
+		case "=":
+			if !ok || v != r.value {
+				return false
+			}
+		case "!=":
+			if ok && v == r.value {
+				return false
+			}
+		default:
+			if ok != r.exists {
+				return false
+			}
+		}
+	}
+	return true
+}
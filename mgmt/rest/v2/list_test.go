@@ -0,0 +1,138 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseListOptions(t *testing.T) {
+	keys := []string{"name", "version"}
+	Convey("Parsing the list options", t, func() {
+		Convey("No options list everything in the default order", func() {
+			o, err := parseListOptions(url.Values{}, keys)
+			So(err, ShouldBeNil)
+			So(o, ShouldResemble, listOptions{})
+		})
+		Convey("All the options are read", func() {
+			o, err := parseListOptions(url.Values{
+				"limit":  {"10"},
+				"cursor": {encodeCursor(20)},
+				"offset": {"5"},
+				"sort":   {"-name"},
+			}, keys)
+			So(err, ShouldBeNil)
+			So(o, ShouldResemble, listOptions{limit: 10, offset: 20, sort: "name", desc: true})
+		})
+		Convey("The offset is used without a cursor", func() {
+			o, err := parseListOptions(url.Values{"offset": {"5"}}, keys)
+			So(err, ShouldBeNil)
+			So(o.offset, ShouldEqual, 5)
+		})
+		Convey("Invalid options are rejected", func() {
+			_, err := parseListOptions(url.Values{"limit": {"-1"}}, keys)
+			So(err, ShouldEqual, ErrListLimitInvalid)
+			_, err = parseListOptions(url.Values{"offset": {"x"}}, keys)
+			So(err, ShouldEqual, ErrListOffsetInvalid)
+			_, err = parseListOptions(url.Values{"cursor": {"!!"}}, keys)
+			So(err, ShouldEqual, ErrListCursorInvalid)
+			_, err = parseListOptions(url.Values{"cursor": {encodeCursor(-1)}}, keys)
+			So(err, ShouldEqual, ErrListCursorInvalid)
+			_, err = parseListOptions(url.Values{"sort": {"type"}}, keys)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "name, version")
+		})
+	})
+}
+
+func TestListOptions(t *testing.T) {
+	Convey("Sorting and paging a list", t, func() {
+		items := []string{"c", "a", "b", "a2"}
+		swap := func(i, j int) { items[i], items[j] = items[j], items[i] }
+		// the items are compared on their first letter only
+		less := func(i, j int) bool { return items[i][0] < items[j][0] }
+		Convey("Items keep their order without a sort key", func() {
+			listOptions{}.sortList(len(items), swap, less)
+			So(items, ShouldResemble, []string{"c", "a", "b", "a2"})
+		})
+		Convey("Items are sorted stably", func() {
+			listOptions{sort: "name"}.sortList(len(items), swap, less)
+			So(items, ShouldResemble, []string{"a", "a2", "b", "c"})
+		})
+		Convey("Items are sorted in descending order", func() {
+			listOptions{sort: "name", desc: true}.sortList(len(items), swap, less)
+			So(items, ShouldResemble, []string{"c", "b", "a", "a2"})
+		})
+		Convey("The pages are walked through with the cursors", func() {
+			o := listOptions{limit: 3}
+			start, end, next := o.page(7)
+			So([]int{start, end}, ShouldResemble, []int{0, 3})
+			So(next, ShouldNotBeEmpty)
+			o.offset, _ = decodeCursor(next)
+			start, end, next = o.page(7)
+			So([]int{start, end}, ShouldResemble, []int{3, 6})
+			o.offset, _ = decodeCursor(next)
+			start, end, next = o.page(7)
+			So([]int{start, end}, ShouldResemble, []int{6, 7})
+			So(next, ShouldBeEmpty)
+		})
+		Convey("A page past the end is empty", func() {
+			start, end, next := listOptions{offset: 10}.page(7)
+			So([]int{start, end}, ShouldResemble, []int{7, 7})
+			So(next, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{"dc": "us-east", "env": "prod"}
+	Convey("Selecting items by their labels", t, func() {
+		for selector, match := range map[string]bool{
+			"":                      true,
+			"dc=us-east":            true,
+			"dc==us-east,env=prod":  true,
+			"dc=eu-west":            false,
+			"env!=dev":              true,
+			"env!=prod":             false,
+			"team!=ops":             true,
+			"dc":                    true,
+			"team":                  false,
+			"!team":                 true,
+			"!dc":                   false,
+			" dc = us-east , env ":  true,
+			"dc=us-east,env=canary": false,
+		} {
+			sel, err := parseLabelSelector(selector)
+			So(err, ShouldBeNil)
+			So(sel.matches(labels), ShouldEqual, match)
+		}
+		Convey("A requirement without a key is rejected", func() {
+			_, err := parseLabelSelector("=us-east")
+			So(err, ShouldNotBeNil)
+			_, err = parseLabelSelector("!")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -51,10 +51,65 @@ type MetricParams struct {
 	Ns string `json:"ns"`
 	// in: query
 	Ver int `json:"ver"`
+	// Namespace glob, matched element by element, ex: /intel/*/load/*
+	// in: query
+	Glob string `json:"glob"`
+	// Regular expression matched against the namespace
+	// in: query
+	Regex string `json:"regex"`
+	// in: query
+	PluginName string `json:"plugin_name"`
+	// in: query
+	PluginVersion int `json:"plugin_version"`
+	// Terms which all have to be found in the description or the unit
+	// in: query
+	Text string `json:"text"`
+	// Selects either the dynamic or the static metrics
+	// in: query
+	Dynamic bool `json:"dynamic"`
+	// Number of matching metrics skipped, when no cursor is given
+	// in: query
+	Offset int `json:"offset"`
+	// Maximum number of metrics returned
+	// in: query
+	Limit int `json:"limit"`
+	// Cursor of the page to return, given as next_cursor by the previous page
+	// in: query
+	Cursor string `json:"cursor"`
+	// Key the metrics are sorted by, in descending order when prefixed with -
+	// in: query
+	Sort string `json:"sort"`
+}
+
+// MetricSearchResp is the representation of a metric search response.
+//
+// swagger:response MetricSearchResponse
+type MetricSearchResp struct {
+	// in: body
+	Body MetricSearchResponse
+}
+
+// MetricSearchResponse holds a page of the metrics matching a search and
+// the number of matching metrics.
+type MetricSearchResponse struct {
+	Metrics Metrics `json:"metrics"`
+	Total   int     `json:"total"`
+	// NextCursor is the cursor of the next page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
+// metricSearchParams lists the query parameters which turn a metrics
+// request into a search of the metric catalog
+var metricSearchParams = []string{"glob", "regex", "plugin_name", "plugin_version", "text", "dynamic", "offset", "limit", "cursor", "sort"}
+
+// metricSortKeys are the keys the metrics can be sorted by, they are sorted
+// by namespace and version by default
+var metricSortKeys = []string{"namespace", "version", "last_advertised", "kind"}
+
 type MetricsResonse struct {
 	Metrics Metrics `json:"metrics,omitempty"`
+	// Total is the number of metrics returned
+	Total int `json:"total"`
 }
 
 type Metrics []Metric
@@ -71,6 +126,9 @@ type Metric struct {
 	DynamicElements []DynamicElement `json:"dynamic_elements,omitempty"`
 	Description     string           `json:"description,omitempty"`
 	Unit            string           `json:"unit,omitempty"`
+	// Kind of the metric: gauge, counter, delta, distribution or state.
+	// It is omitted for the metrics of plugins which don't declare one.
+	Kind string `json:"kind,omitempty"`
 	// Policy a slice of metric rules.
 	Policy PolicyTableSlice `json:"policy,omitempty"`
 	Href   string           `json:"href"`
@@ -101,6 +159,12 @@ func (s *apiV2) getMetrics(w http.ResponseWriter, r *http.Request, _ httprouter.
 	// If we are provided a parameter with the name 'ns' we need to
 	// perform a query
 	q := r.URL.Query()
+	for _, p := range metricSearchParams {
+		if _, ok := q[p]; ok {
+			s.searchMetrics(w, r)
+			return
+		}
+	}
 	v := q.Get("ver")
 	ns_query := q.Get("ns")
 	if ns_query != "" {
@@ -137,25 +201,137 @@ func (s *apiV2) getMetrics(w http.ResponseWriter, r *http.Request, _ httprouter.
 	respondWithMetrics(r.Host, mts, w)
 }
 
+func (s *apiV2) searchMetrics(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	search, err := parseMetricSearch(q)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	opts, err := parseListOptions(q, metricSortKeys)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	// the catalog pages the matches in their default order, otherwise they
+	// are all sorted and paged here
+	if opts.sort == "" {
+		search.Offset, search.Limit = opts.offset, opts.limit
+	}
+	res, err := s.metricManager.SearchMetrics(search)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	mts := res.Metrics
+	var next string
+	if opts.sort == "" {
+		if opts.offset+len(mts) < res.Total {
+			next = encodeCursor(opts.offset + len(mts))
+		}
+	} else {
+		opts.sortList(len(mts), func(i, j int) { mts[i], mts[j] = mts[j], mts[i] }, catalogedMetricsLess(mts, opts.sort))
+		var start, end int
+		start, end, next = opts.page(len(mts))
+		mts = mts[start:end]
+	}
+	// the metrics keep the order of the page
+	b := make(Metrics, len(mts))
+	for i, m := range mts {
+		b[i] = metricBody(r.Host, m)
+	}
+	Write(200, MetricSearchResponse{
+		Metrics:    b,
+		Total:      res.Total,
+		NextCursor: next,
+	}, w)
+}
+
+// catalogedMetricsLess returns the less function of the metrics for a sort key
+func catalogedMetricsLess(mts []core.CatalogedMetric, key string) func(i, j int) bool {
+	switch key {
+	case "version":
+		return func(i, j int) bool { return mts[i].Version() < mts[j].Version() }
+	case "last_advertised":
+		return func(i, j int) bool { return mts[i].LastAdvertisedTime().Before(mts[j].LastAdvertisedTime()) }
+	case "kind":
+		return func(i, j int) bool { return core.KindOf(mts[i]) < core.KindOf(mts[j]) }
+	}
+	return func(i, j int) bool { return mts[i].Namespace().String() < mts[j].Namespace().String() }
+}
+
+func parseMetricSearch(q url.Values) (core.MetricSearch, error) {
+	search := core.MetricSearch{
+		Regex:      q.Get("regex"),
+		PluginName: q.Get("plugin_name"),
+		Text:       q.Get("text"),
+	}
+	if ns := q.Get("ns"); ns != "" {
+		elems := parseNamespace(ns)
+		if elems[len(elems)-1] == "*" {
+			elems = elems[:len(elems)-1]
+		}
+		search.Namespace = core.NewNamespace(elems...)
+	}
+	if glob := q.Get("glob"); glob != "" {
+		search.Glob = parseNamespace(glob)
+	}
+	// the offset and limit are read with the other list options
+	ints := map[string]*int{
+		"ver":            &search.Version,
+		"plugin_version": &search.PluginVersion,
+	}
+	for name, i := range ints {
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return search, fmt.Errorf("Invalid value of %s: %s", name, v)
+		}
+		*i = n
+	}
+	if v := q.Get("dynamic"); v != "" {
+		dyn, err := strconv.ParseBool(v)
+		if err != nil {
+			return search, fmt.Errorf("Invalid value of dynamic: %s", v)
+		}
+		search.Dynamic = &dyn
+	}
+	return search, nil
+}
+
 func respondWithMetrics(host string, mts []core.CatalogedMetric, w http.ResponseWriter) {
-	b := MetricsResonse{Metrics: make(Metrics, 0)}
+	m := metricsBody(host, mts)
+	b := MetricsResonse{Metrics: m, Total: len(m)}
+	Write(200, b, w)
+}
+
+func metricsBody(host string, mts []core.CatalogedMetric) Metrics {
+	b := make(Metrics, 0, len(mts))
 	for _, m := range mts {
-		policies := PolicyTableSlice(m.Policy().RulesAsTable())
-		dyn, indexes := m.Namespace().IsDynamic()
-		b.Metrics = append(b.Metrics, Metric{
-			Namespace:               m.Namespace().String(),
-			Version:                 m.Version(),
-			LastAdvertisedTimestamp: m.LastAdvertisedTime().Unix(),
-			Description:             m.Description(),
-			Dynamic:                 dyn,
-			DynamicElements:         getDynamicElements(m.Namespace(), indexes),
-			Unit:                    m.Unit(),
-			Policy:                  policies,
-			Href:                    catalogedMetricURI(host, m),
-		})
+		b = append(b, metricBody(host, m))
+	}
+	sort.Sort(b)
+	return b
+}
+
+func metricBody(host string, m core.CatalogedMetric) Metric {
+	policies := PolicyTableSlice(m.Policy().RulesAsTable().Redacted())
+	dyn, indexes := m.Namespace().IsDynamic()
+	return Metric{
+		Namespace:               m.Namespace().String(),
+		Version:                 m.Version(),
+		LastAdvertisedTimestamp: m.LastAdvertisedTime().Unix(),
+		Description:             m.Description(),
+		Dynamic:                 dyn,
+		DynamicElements:         getDynamicElements(m.Namespace(), indexes),
+		Unit:                    m.Unit(),
+		Kind:                    string(core.KindOf(m)),
+		Policy:                  policies,
+		Href:                    catalogedMetricURI(host, m),
 	}
-	sort.Sort(b.Metrics)
-	Write(200, b, w)
 }
 
 func catalogedMetricURI(host string, mt core.CatalogedMetric) string {
@@ -24,6 +24,7 @@ import (
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
 	"github.com/intelsdi-x/snap/core/ctypes"
+	"github.com/intelsdi-x/snap/core/serror"
 )
 
 var mockConfig *cdata.ConfigDataNode
@@ -43,27 +44,27 @@ func (MockConfigManager) GetPluginConfigDataNodeAll() cdata.ConfigDataNode {
 	return *mockConfig
 }
 func (MockConfigManager) MergePluginConfigDataNode(
-	pluginType core.PluginType, name string, ver int, cdn *cdata.ConfigDataNode) cdata.ConfigDataNode {
-	return *cdn
+	pluginType core.PluginType, name string, ver int, cdn *cdata.ConfigDataNode) (cdata.ConfigDataNode, []serror.SnapError) {
+	return *cdn, nil
 }
-func (MockConfigManager) MergePluginConfigDataNodeAll(cdn *cdata.ConfigDataNode) cdata.ConfigDataNode {
-	return cdata.ConfigDataNode{}
+func (MockConfigManager) MergePluginConfigDataNodeAll(cdn *cdata.ConfigDataNode) (cdata.ConfigDataNode, []serror.SnapError) {
+	return cdata.ConfigDataNode{}, nil
 }
 func (MockConfigManager) DeletePluginConfigDataNodeField(
-	pluginType core.PluginType, name string, ver int, fields ...string) cdata.ConfigDataNode {
+	pluginType core.PluginType, name string, ver int, fields ...string) (cdata.ConfigDataNode, []serror.SnapError) {
 	for _, field := range fields {
 		mockConfig.DeleteItem(field)
 
 	}
-	return *mockConfig
+	return *mockConfig, nil
 }
 
-func (MockConfigManager) DeletePluginConfigDataNodeFieldAll(fields ...string) cdata.ConfigDataNode {
+func (MockConfigManager) DeletePluginConfigDataNodeFieldAll(fields ...string) (cdata.ConfigDataNode, []serror.SnapError) {
 	for _, field := range fields {
 		mockConfig.DeleteItem(field)
 
 	}
-	return *mockConfig
+	return *mockConfig, nil
 }
 
 // These constants are the expected plugin config responses from running
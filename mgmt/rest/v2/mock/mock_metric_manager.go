@@ -27,7 +27,9 @@ import (
 
 	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
 	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/ctypes"
 	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/scheduler/wmap"
 )
 
 var pluginCatalog []core.CatalogedPlugin = []core.CatalogedPlugin{
@@ -39,6 +41,12 @@ var pluginCatalog []core.CatalogedPlugin = []core.CatalogedPlugin{
 	MockLoadedPlugin{MyName: "foobar", MyType: "processor", MyVersion: 1},
 }
 
+var pluginLogs = []core.PluginLogEntry{
+	{PluginName: "foo", PluginVersion: 2, PluginType: "collector", InstanceID: 1, Stream: "stderr", Timestamp: time.Date(2016, time.September, 6, 0, 0, 0, 0, time.UTC), Line: "starting"},
+	{PluginName: "foo", PluginVersion: 2, PluginType: "collector", InstanceID: 1, Stream: "stdout", Timestamp: time.Date(2016, time.September, 6, 0, 0, 1, 0, time.UTC), Line: "collecting"},
+	{PluginName: "foo", PluginVersion: 2, PluginType: "collector", InstanceID: 2, Stream: "stderr", Timestamp: time.Date(2016, time.September, 6, 0, 0, 2, 0, time.UTC), Line: "failed to read"},
+}
+
 var metricCatalog []core.CatalogedMetric = []core.CatalogedMetric{
 	MockCatalogedMetric{},
 }
@@ -70,6 +78,9 @@ func (m MockLoadedPlugin) Policy() *cpolicy.ConfigPolicy { return cpolicy.New()
 func (m MockLoadedPlugin) HitCount() int                 { return 0 }
 func (m MockLoadedPlugin) LastHit() time.Time            { return time.Now() }
 func (m MockLoadedPlugin) ID() uint32                    { return 0 }
+func (m MockLoadedPlugin) CircuitBreaker() core.CircuitBreakerStatus {
+	return core.CircuitBreakerStatus{State: "closed"}
+}
 
 //////MockCatalogedMetric/////
 
@@ -94,6 +105,44 @@ func (m MockManagesMetrics) MetricCatalog() ([]core.CatalogedMetric, error) {
 func (m MockManagesMetrics) FetchMetrics(core.Namespace, int) ([]core.CatalogedMetric, error) {
 	return metricCatalog, nil
 }
+func (m MockManagesMetrics) SearchMetrics(search core.MetricSearch) (core.MetricSearchResult, error) {
+	if search.Regex == "(" {
+		return core.MetricSearchResult{}, errors.New("Invalid namespace regular expression")
+	}
+	return core.MetricSearchResult{Metrics: metricCatalog, Total: len(metricCatalog)}, nil
+}
+func (m MockManagesMetrics) ExpandedMetrics(id string) ([]core.RequestedMetric, error) {
+	if id != ":1234" {
+		return nil, errors.New("subscription group does not exist")
+	}
+	return []core.RequestedMetric{MockCatalogedMetric{}}, nil
+}
+func (m MockManagesMetrics) WorkflowConfig(wf *wmap.WorkflowMap, ns core.Namespace) (core.WorkflowConfig, []serror.SnapError) {
+	wc := core.WorkflowConfig{
+		Nodes: []core.NodeConfig{{
+			PluginType:    core.PublisherPluginType,
+			PluginName:    "file",
+			PluginVersion: 3,
+			Config: map[string]core.ConfigSetting{
+				"file": {Value: ctypes.ConfigValueStr{Value: "/tmp/published"}, Source: core.ConfigSourceWorkflow},
+			},
+		}},
+	}
+	if len(ns) > 0 && ns.String() != "/one/two/three" {
+		return wc, nil
+	}
+	wc.Metrics = []core.MetricConfig{{
+		Namespace:     core.NewNamespace("one", "two", "three"),
+		Version:       5,
+		PluginName:    "mock",
+		PluginVersion: 5,
+		Config: map[string]core.ConfigSetting{
+			"user":     {Value: ctypes.ConfigValueStr{Value: "root"}, Source: "plugins.all"},
+			"password": {Value: ctypes.ConfigValueStr{Value: ctypes.Redacted}, Source: "workflow:/one"},
+		},
+	}}
+	return wc, nil
+}
 func (m MockManagesMetrics) GetMetricVersions(core.Namespace) ([]core.CatalogedMetric, error) {
 	return metricCatalog, nil
 }
@@ -127,6 +176,80 @@ func (m MockManagesMetrics) AvailablePlugins() []core.AvailablePlugin {
 		MockLoadedPlugin{MyName: "foobar", MyType: "processor", MyVersion: 1},
 	}
 }
+func (m MockManagesMetrics) PluginLogs(pluginType core.PluginType, name string, ver int, n int) ([]core.PluginLogEntry, serror.SnapError) {
+	for _, pl := range pluginCatalog {
+		if name == pl.Name() &&
+			ver == pl.Version() &&
+			pluginType.String() == pl.TypeName() {
+			entries := []core.PluginLogEntry{}
+			for _, e := range pluginLogs {
+				if e.PluginName == name && e.PluginVersion == ver {
+					entries = append(entries, e)
+				}
+			}
+			if n > 0 && len(entries) > n {
+				entries = entries[len(entries)-n:]
+			}
+			return entries, nil
+		}
+	}
+	return nil, serror.New(errors.New("plugin not found"))
+}
+func (m MockManagesMetrics) WatchPluginLogs(pluginType core.PluginType, name string, ver int) (<-chan core.PluginLogEntry, func(), serror.SnapError) {
+	entries, err := m.PluginLogs(pluginType, name, ver, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	c := make(chan core.PluginLogEntry, len(entries))
+	for _, e := range entries {
+		c <- e
+	}
+	return c, func() {}, nil
+}
+func (m MockManagesMetrics) PluginStats(pluginType core.PluginType, name string, ver int) (core.PluginPoolStats, serror.SnapError) {
+	if name != "foo" || ver != 2 || pluginType != core.CollectorPluginType {
+		return core.PluginPoolStats{}, serror.New(errors.New("plugin not found"))
+	}
+	collect := core.PluginCallStats{
+		Calls:        4,
+		Errors:       1,
+		Timeouts:     1,
+		TotalLatency: 40 * time.Millisecond,
+		MaxLatency:   25 * time.Millisecond,
+		Buckets:      make([]uint64, len(core.LatencyBuckets)+1),
+	}
+	collect.Buckets[1] = 2
+	collect.Buckets[2] = 1
+	collect.Buckets[3] = 1
+	instance := core.PluginStats{
+		Name:       "foo",
+		Version:    2,
+		Type:       "collector",
+		ID:         1,
+		Calls:      map[string]core.PluginCallStats{core.CollectMetricsCall: collect},
+		MetricsIn:  8,
+		MetricsOut: 6,
+	}
+	total := instance
+	total.ID = 0
+	scaling := core.PluginScalingStats{
+		ScaleUps:   2,
+		ScaleDowns: 1,
+		LastAction: "down",
+		LastReason: "idle for more than 1m0s",
+		LastScaled: time.Unix(1473120000, 0),
+	}
+	return core.PluginPoolStats{Total: total, Instances: []core.PluginStats{instance}, Scaling: scaling}, nil
+}
+func (m MockManagesMetrics) RefreshPlugin(pluginType core.PluginType, name string, ver int) (core.CatalogRefresh, serror.SnapError) {
+	if name != "foo" || ver != 2 || pluginType != core.CollectorPluginType {
+		return core.CatalogRefresh{}, serror.New(errors.New("plugin not found"))
+	}
+	return core.CatalogRefresh{
+		Added:   []core.CatalogedMetric{MockCatalogedMetric{}},
+		Removed: []core.CatalogedMetric{},
+	}, nil
+}
 func (m MockManagesMetrics) GetAutodiscoverPaths() []string {
 	return nil
 }
@@ -194,7 +317,8 @@ const (
       "loaded_timestamp": 1473120000,
       "href": "http://localhost:%d/v2/plugins/processor/foobar/1"
     }
-  ]
+  ],
+  "total": 6
 }
 `
 
@@ -218,7 +342,8 @@ const (
       "loaded_timestamp": 1473120000,
       "href": "http://localhost:%d/v2/plugins/collector/foo/4"
     }
-  ]
+  ],
+  "total": 2
 }
 `
 
@@ -233,7 +358,8 @@ const (
       "loaded_timestamp": 1473120000,
       "href": "http://localhost:%d/v2/plugins/publisher/bar/3"
     }
-  ]
+  ],
+  "total": 1
 }
 `
 
@@ -258,9 +384,49 @@ const (
       "description": "This Is A Description",
       "href": "http://localhost:%d/v2/metrics?ns=/one/two/three&ver=5"
     }
-  ]
+  ],
+  "total": 1
 }
 `
 
 	UNLOAD_PLUGIN_RESPONSE = ``
+
+	GET_PLUGIN_LOGS_RESPONSE = `{
+  "logs": [
+    {
+      "name": "foo",
+      "version": 2,
+      "type": "collector",
+      "id": 1,
+      "stream": "stdout",
+      "timestamp": "2016-09-06T00:00:01Z",
+      "line": "collecting"
+    },
+    {
+      "name": "foo",
+      "version": 2,
+      "type": "collector",
+      "id": 2,
+      "stream": "stderr",
+      "timestamp": "2016-09-06T00:00:02Z",
+      "line": "failed to read"
+    }
+  ]
+}
+`
+
+	GET_PLUGIN_LOGS_RESPONSE_ID = `{
+  "logs": [
+    {
+      "name": "foo",
+      "version": 2,
+      "type": "collector",
+      "id": 2,
+      "stream": "stderr",
+      "timestamp": "2016-09-06T00:00:02Z",
+      "line": "failed to read"
+    }
+  ]
+}
+`
 )
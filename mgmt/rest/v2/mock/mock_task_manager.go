@@ -191,7 +191,8 @@ const (
       "task_state": "Running",
       "href": "http://localhost:%d/v2/tasks/asdfghjkl"
     }
-  ]
+  ],
+  "total": 2
 }
 `
 
@@ -215,7 +216,8 @@ const (
       "task_state": "Running",
       "href": "http://localhost:%d/v2/tasks/qwertyuiop"
     }
-  ]
+  ],
+  "total": 2
 }
 `
 
@@ -228,6 +230,12 @@ const (
       "metrics": {}
     }
   },
+  "expanded_metrics": [
+    {
+      "namespace": "/one/two/three",
+      "version": 5
+    }
+  ],
   "schedule": {
     "type": "windowed",
     "interval": "1s"
@@ -0,0 +1,178 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+)
+
+var (
+	errMockAgreement = errors.New("Agreement does not exist")
+	errMockMember    = errors.New("Unknown member")
+	errMockTask      = errors.New("Task already exists")
+)
+
+// MockTribeManager keeps the agreements it is given in memory, its members
+// are "member1" and "member2"
+type MockTribeManager struct {
+	sync.Mutex
+	agreements map[string]*agreement.Agreement
+	members    map[string]*agreement.Member
+}
+
+func NewMockTribeManager() *MockTribeManager {
+	m := &MockTribeManager{
+		agreements: map[string]*agreement.Agreement{},
+		members:    map[string]*agreement.Member{},
+	}
+	for i, name := range []string{"member1", "member2"} {
+		m.members[name] = agreement.NewMember(&memberlist.Node{
+			Name: name,
+			Addr: net.IPv4(10, 0, 0, byte(i+1)),
+		})
+		m.members[name].Tags = map[string]string{agreement.RestPort: "8181"}
+	}
+	return m
+}
+
+func (m *MockTribeManager) GetAgreement(name string) (*agreement.Agreement, serror.SnapError) {
+	m.Lock()
+	defer m.Unlock()
+	a, ok := m.agreements[name]
+	if !ok {
+		return nil, serror.New(errMockAgreement)
+	}
+	return a, nil
+}
+func (m *MockTribeManager) GetAgreements() map[string]*agreement.Agreement {
+	m.Lock()
+	defer m.Unlock()
+	agreements := map[string]*agreement.Agreement{}
+	for k, v := range m.agreements {
+		agreements[k] = v
+	}
+	return agreements
+}
+func (m *MockTribeManager) AddAgreement(name string) serror.SnapError {
+	m.Lock()
+	defer m.Unlock()
+	m.agreements[name] = agreement.New(name)
+	return nil
+}
+func (m *MockTribeManager) RemoveAgreement(name string) serror.SnapError {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.agreements, name)
+	return nil
+}
+func (m *MockTribeManager) JoinAgreement(agreementName, memberName string) serror.SnapError {
+	m.Lock()
+	defer m.Unlock()
+	a, ok := m.agreements[agreementName]
+	if !ok {
+		return serror.New(errMockAgreement)
+	}
+	member, ok := m.members[memberName]
+	if !ok {
+		return serror.New(errMockMember)
+	}
+	a.Members[memberName] = member
+	member.PluginAgreement = a.PluginAgreement
+	member.TaskAgreements[agreementName] = a.TaskAgreement
+	return nil
+}
+func (m *MockTribeManager) LeaveAgreement(agreementName, memberName string) serror.SnapError {
+	m.Lock()
+	defer m.Unlock()
+	a, ok := m.agreements[agreementName]
+	if !ok {
+		return serror.New(errMockAgreement)
+	}
+	member, ok := m.members[memberName]
+	if !ok {
+		return serror.New(errMockMember)
+	}
+	delete(a.Members, memberName)
+	member.PluginAgreement = nil
+	delete(member.TaskAgreements, agreementName)
+	return nil
+}
+func (m *MockTribeManager) GetMembers() []string {
+	m.Lock()
+	defer m.Unlock()
+	members := []string{}
+	for name := range m.members {
+		members = append(members, name)
+	}
+	return members
+}
+func (m *MockTribeManager) GetMember(name string) *agreement.Member {
+	m.Lock()
+	defer m.Unlock()
+	return m.members[name]
+}
+func (m *MockTribeManager) AddPlugin(agreementName string, p agreement.Plugin) error {
+	m.Lock()
+	defer m.Unlock()
+	a, ok := m.agreements[agreementName]
+	if !ok {
+		return errMockAgreement
+	}
+	a.PluginAgreement.Add(p)
+	return nil
+}
+func (m *MockTribeManager) RemovePlugin(agreementName string, p agreement.Plugin) error {
+	m.Lock()
+	defer m.Unlock()
+	a, ok := m.agreements[agreementName]
+	if !ok {
+		return errMockAgreement
+	}
+	a.PluginAgreement.Remove(p)
+	return nil
+}
+func (m *MockTribeManager) AddTask(agreementName string, task agreement.Task) serror.SnapError {
+	m.Lock()
+	defer m.Unlock()
+	a, ok := m.agreements[agreementName]
+	if !ok {
+		return serror.New(errMockAgreement)
+	}
+	if !a.TaskAgreement.Add(task) {
+		return serror.New(errMockTask)
+	}
+	return nil
+}
+func (m *MockTribeManager) RemoveTask(agreementName string, task agreement.Task) serror.SnapError {
+	m.Lock()
+	defer m.Unlock()
+	a, ok := m.agreements[agreementName]
+	if !ok {
+		return serror.New(errMockAgreement)
+	}
+	a.TaskAgreement.Remove(task)
+	return nil
+}
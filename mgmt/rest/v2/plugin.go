@@ -58,29 +58,42 @@ type PluginsResp struct {
 	// List of plugins
 	//
 	// in: body
-	Body struct {
-		Plugins []Plugin `json:"plugins,omitempty"`
-	}
+	Body PluginsResponse
 }
 
 type PluginsResponse struct {
 	Plugins []Plugin `json:"plugins,omitempty"`
+	// Total is the number of plugins matching the filters
+	Total int `json:"total"`
+	// NextCursor is the cursor of the next page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // Plugin represents a plugin type definition.
 type Plugin struct {
-	Name             string        `json:"name"`
-	Version          int           `json:"version"`
-	Type             string        `json:"type"`
-	Signed           bool          `json:"signed"`
-	Status           string        `json:"status"`
-	LoadedTimestamp  int64         `json:"loaded_timestamp,omitempty"`
-	Href             string        `json:"href,omitempty"`
-	ConfigPolicy     []PolicyTable `json:"config_policy,omitempty"`
-	HitCount         int           `json:"hitcount,omitempty"`
-	LastHitTimestamp int64         `json:"last_hit_timestamp,omitempty"`
-	ID               uint32        `json:"id,omitempty"`
-	PprofPort        string        `json:"pprof_port,omitempty"`
+	Name             string          `json:"name"`
+	Version          int             `json:"version"`
+	Type             string          `json:"type"`
+	Signed           bool            `json:"signed"`
+	Status           string          `json:"status"`
+	LoadedTimestamp  int64           `json:"loaded_timestamp,omitempty"`
+	Href             string          `json:"href,omitempty"`
+	ConfigPolicy     []PolicyTable   `json:"config_policy,omitempty"`
+	HitCount         int             `json:"hitcount,omitempty"`
+	LastHitTimestamp int64           `json:"last_hit_timestamp,omitempty"`
+	ID               uint32          `json:"id,omitempty"`
+	PprofPort        string          `json:"pprof_port,omitempty"`
+	CircuitBreaker   *CircuitBreaker `json:"circuit_breaker,omitempty"`
+}
+
+// CircuitBreaker represents the state of the circuit breaker of a running plugin.
+// Calls and failures are counted over the most recent calls.
+type CircuitBreaker struct {
+	State           string `json:"state"`
+	Calls           int    `json:"calls"`
+	Failures        int    `json:"failures"`
+	Trips           uint64 `json:"trips"`
+	OpenedTimestamp int64  `json:"opened_timestamp,omitempty"`
 }
 
 // PluginParams represents the request path plugin name, version and type.
@@ -110,6 +123,9 @@ type PluginsParams struct {
 	Type string `json:"type"`
 	// in: query
 	Running bool `json:"running"`
+	// Prefix of the names of the plugins listed
+	// in: query
+	NamePrefix string `json:"name_prefix"`
 }
 
 // PluginPostParams defines type for loading a plugin.
@@ -300,6 +316,9 @@ func (s *apiV2) unloadPlugin(w http.ResponseWriter, r *http.Request, p httproute
 	Write(204, nil, w)
 }
 
+// pluginSortKeys are the keys the plugins can be sorted by
+var pluginSortKeys = []string{"name", "version", "type", "status", "loaded", "hitcount", "last_hit"}
+
 func (s *apiV2) getPlugins(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 
 	// filter by plugin name or plugin type
@@ -307,6 +326,13 @@ func (s *apiV2) getPlugins(w http.ResponseWriter, r *http.Request, params httpro
 	plName := q.Get("name")
 	plType := q.Get("type")
 	nbFilter := Btoi(plName != "") + Btoi(plType != "")
+	namePrefix := q.Get("name_prefix")
+
+	opts, err := parseListOptions(q, pluginSortKeys)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
 
 	var plugins []Plugin
 	if _, detail := r.URL.Query()["running"]; detail {
@@ -318,16 +344,51 @@ func (s *apiV2) getPlugins(w http.ResponseWriter, r *http.Request, params httpro
 	}
 
 	filteredPlugins := []Plugin{}
-	if nbFilter > 0 {
-		for _, p := range plugins {
-			if nbFilter == 1 && (p.Name == plName || p.Type == plType) || nbFilter == 2 && (p.Name == plName && p.Type == plType) {
-				filteredPlugins = append(filteredPlugins, p)
-			}
+	for _, p := range plugins {
+		if !strings.HasPrefix(p.Name, namePrefix) {
+			continue
+		}
+		if nbFilter == 0 || nbFilter == 1 && (p.Name == plName || p.Type == plType) || nbFilter == 2 && (p.Name == plName && p.Type == plType) {
+			filteredPlugins = append(filteredPlugins, p)
 		}
-	} else {
-		filteredPlugins = plugins
 	}
-	Write(200, PluginsResponse{Plugins: filteredPlugins}, w)
+	pl := Plugins(filteredPlugins)
+	opts.sortList(len(pl), pl.Swap, pl.lessBy(opts.sort))
+	start, end, next := opts.page(len(pl))
+	Write(200, PluginsResponse{
+		Plugins:    pl[start:end],
+		Total:      len(pl),
+		NextCursor: next,
+	}, w)
+}
+
+// Plugins defines a list of plugins.
+type Plugins []Plugin
+
+func (p Plugins) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// lessBy returns the less function of the plugins for a sort key, the
+// plugins being in the order of the catalog by default
+func (p Plugins) lessBy(key string) func(i, j int) bool {
+	switch key {
+	case "name":
+		return func(i, j int) bool { return p[i].Name < p[j].Name }
+	case "version":
+		return func(i, j int) bool { return p[i].Version < p[j].Version }
+	case "type":
+		return func(i, j int) bool { return p[i].Type < p[j].Type }
+	case "status":
+		return func(i, j int) bool { return p[i].Status < p[j].Status }
+	case "loaded":
+		return func(i, j int) bool { return p[i].LoadedTimestamp < p[j].LoadedTimestamp }
+	case "hitcount":
+		return func(i, j int) bool { return p[i].HitCount < p[j].HitCount }
+	case "last_hit":
+		return func(i, j int) bool { return p[i].LastHitTimestamp < p[j].LastHitTimestamp }
+	}
+	return nil
 }
 
 func Btoi(b bool) int {
@@ -369,11 +430,32 @@ func runningPluginsBody(host string, c []core.AvailablePlugin) []Plugin {
 			ID:               p.ID(),
 			Href:             pluginURI(host, p),
 			PprofPort:        p.Port(),
+			CircuitBreaker:   circuitBreakerBody(p),
 		}
 	}
 	return plugins
 }
 
+// circuitBreakerBody returns the state of the circuit breaker of a running
+// plugin, nil when its calls aren't guarded by one
+func circuitBreakerBody(p core.AvailablePlugin) *CircuitBreaker {
+	cbp, ok := p.(core.CircuitBreakerPlugin)
+	if !ok {
+		return nil
+	}
+	s := cbp.CircuitBreaker()
+	cb := &CircuitBreaker{
+		State:    s.State,
+		Calls:    s.Calls,
+		Failures: s.Failures,
+		Trips:    s.Trips,
+	}
+	if !s.OpenedAt.IsZero() {
+		cb.OpenedTimestamp = s.OpenedAt.Unix()
+	}
+	return cb
+}
+
 func pluginURI(host string, c core.Plugin) string {
 	return fmt.Sprintf("%s://%s/%s/plugins/%s/%s/%d", protocolPrefix, host, version, c.TypeName(), c.Name(), c.Version())
 }
@@ -405,16 +487,19 @@ func (s *apiV2) getPlugin(w http.ResponseWriter, r *http.Request, p httprouter.P
 	d, _ := strconv.ParseBool(rd)
 	var configPolicy []PolicyTable
 	if plugin.TypeName() == "processor" || plugin.TypeName() == "publisher" {
-		rules := plugin.Policy().Get([]string{""}).RulesAsTable()
+		rules := plugin.Policy().Get([]string{""}).RulesAsTable().Redacted()
 		configPolicy = make([]PolicyTable, 0, len(rules))
 		for _, r := range rules {
 			configPolicy = append(configPolicy, PolicyTable{
-				Name:     r.Name,
-				Type:     r.Type,
-				Default:  r.Default,
-				Required: r.Required,
-				Minimum:  r.Minimum,
-				Maximum:  r.Maximum,
+				Name:      r.Name,
+				Type:      r.Type,
+				Default:   r.Default,
+				Required:  r.Required,
+				Minimum:   r.Minimum,
+				Maximum:   r.Maximum,
+				Allowed:   r.Allowed,
+				Pattern:   r.Pattern,
+				Sensitive: r.Sensitive,
 			})
 		}
 
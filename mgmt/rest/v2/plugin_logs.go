@@ -0,0 +1,238 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/intelsdi-x/snap/control"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/julienschmidt/httprouter"
+)
+
+// DefaultPluginLogLines is the number of lines returned when the
+// lines query parameter is not given.
+const DefaultPluginLogLines = 100
+
+// PluginLogsResponse represents the response of the plugin logs operation.
+//
+// swagger:response PluginLogsResponse
+type PluginLogsResp struct {
+	// in: body
+	Body struct {
+		Logs []PluginLog `json:"logs"`
+	}
+}
+
+type PluginLogsResponse struct {
+	Logs []PluginLog `json:"logs"`
+}
+
+// PluginLog represents a line written by a running plugin instance.
+type PluginLog struct {
+	Name      string    `json:"name"`
+	Version   int       `json:"version"`
+	Type      string    `json:"type"`
+	ID        uint32    `json:"id"`
+	Stream    string    `json:"stream"`
+	Timestamp time.Time `json:"timestamp"`
+	Line      string    `json:"line"`
+}
+
+// ToJSON returns the JSON encoding of a log line.
+func (l *PluginLog) ToJSON() string {
+	j, _ := json.Marshal(l)
+	return string(j)
+}
+
+// PluginLogsParams represents the query parameters for getting plugin logs.
+//
+// swagger:parameters getPluginLogs
+type PluginLogsParams struct {
+	// required: true
+	// in: path
+	PName string `json:"pname"`
+	// required: true
+	// in: path
+	PVersion int `json:"pversion"`
+	// required: true
+	// in: path
+	// enum: collector, processor, publisher, streaming-collector
+	PType string `json:"ptype"`
+	// in: query
+	Lines int `json:"lines"`
+	// in: query
+	ID uint32 `json:"id"`
+	// in: query
+	Follow bool `json:"follow"`
+}
+
+func (s *apiV2) getPluginLogs(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	plType, plName, plVersion, f, se := pluginParameters(p)
+	if se != nil {
+		Write(400, FromSnapError(se), w)
+		return
+	}
+	pType, err := core.ToPluginType(plType)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+
+	q := r.URL.Query()
+	lines := DefaultPluginLogLines
+	if l := q.Get("lines"); l != "" {
+		lines, err = strconv.Atoi(l)
+		if err != nil {
+			se := serror.New(errors.New("invalid value for lines"), f)
+			Write(400, FromSnapError(se), w)
+			return
+		}
+	}
+	var id uint64
+	_, filterID := q["id"]
+	if filterID {
+		id, err = strconv.ParseUint(q.Get("id"), 10, 32)
+		if err != nil {
+			se := serror.New(errors.New("invalid value for id"), f)
+			Write(400, FromSnapError(se), w)
+			return
+		}
+	}
+	match := func(e core.PluginLogEntry) bool {
+		return !filterID || e.InstanceID == uint32(id)
+	}
+
+	if _, follow := q["follow"]; follow {
+		s.followPluginLogs(w, pType, plName, plVersion, lines, match, f)
+		return
+	}
+
+	entries, se := s.metricManager.PluginLogs(pType, plName, plVersion, 0)
+	if se != nil {
+		se.SetFields(f)
+		Write(runningPluginErrorCode(se), FromSnapError(se), w)
+		return
+	}
+	Write(200, PluginLogsResponse{Logs: pluginLogsBody(entries, lines, match)}, w)
+}
+
+// followPluginLogs sends the most recent lines of the plugin followed by
+// each new line as Server Sent Events until the client goes away.
+func (s *apiV2) followPluginLogs(w http.ResponseWriter, pType core.PluginType, name string, ver int, lines int, match func(core.PluginLogEntry) bool, f map[string]interface{}) {
+	s.wg.Add(1)
+	defer s.wg.Done()
+
+	watch, cancel, se := s.metricManager.WatchPluginLogs(pType, name, ver)
+	if se != nil {
+		se.SetFields(f)
+		Write(runningPluginErrorCode(se), FromSnapError(se), w)
+		return
+	}
+	defer cancel()
+	entries, se := s.metricManager.PluginLogs(pType, name, ver, 0)
+	if se != nil {
+		se.SetFields(f)
+		Write(runningPluginErrorCode(se), FromSnapError(se), w)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		// This only works on ResponseWriters that support streaming
+		Write(500, FromError(ErrStreamingUnsupported), w)
+		return
+	}
+	// Make this Server Sent Events compatible
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	for _, l := range pluginLogsBody(entries, lines, match) {
+		fmt.Fprintf(w, "data: %s\n\n", l.ToJSON())
+	}
+	flusher.Flush()
+
+	// Get a channel for if the client notifies us it is closing the connection
+	n := w.(http.CloseNotifier).CloseNotify()
+	for {
+		select {
+		case e, ok := <-watch:
+			if !ok {
+				// every followed plugin instance is gone
+				return
+			}
+			if !match(e) {
+				continue
+			}
+			l := pluginLogBody(e)
+			fmt.Fprintf(w, "data: %s\n\n", l.ToJSON())
+			flusher.Flush()
+		case <-n:
+			return
+		case <-s.killChan:
+			return
+		}
+	}
+}
+
+// runningPluginErrorCode returns the status code for an error
+// raised while looking up the running instances of a plugin
+func runningPluginErrorCode(se serror.SnapError) int {
+	switch se.Error() {
+	case control.ErrPluginNotFound.Error(), control.ErrPoolNotFound.Error(), control.ErrBadKey.Error():
+		return 404
+	}
+	return 500
+}
+
+// pluginLogsBody returns up to n of the most recent matching entries.
+// A value of n lower than 1 returns every matching entry.
+func pluginLogsBody(entries []core.PluginLogEntry, n int, match func(core.PluginLogEntry) bool) []PluginLog {
+	logs := []PluginLog{}
+	for _, e := range entries {
+		if match(e) {
+			logs = append(logs, pluginLogBody(e))
+		}
+	}
+	if n > 0 && len(logs) > n {
+		logs = logs[len(logs)-n:]
+	}
+	return logs
+}
+
+func pluginLogBody(e core.PluginLogEntry) PluginLog {
+	return PluginLog{
+		Name:      e.PluginName,
+		Version:   e.PluginVersion,
+		Type:      e.PluginType,
+		ID:        e.InstanceID,
+		Stream:    e.Stream,
+		Timestamp: e.Timestamp,
+		Line:      e.Line,
+	}
+}
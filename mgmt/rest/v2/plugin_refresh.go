@@ -0,0 +1,87 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/intelsdi-x/snap/control"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/julienschmidt/httprouter"
+)
+
+// CatalogRefreshResp represents the response of the plugin refresh operation.
+//
+// swagger:response CatalogRefreshResponse
+type CatalogRefreshResp struct {
+	// in: body
+	Body CatalogRefreshResponse
+}
+
+// CatalogRefreshResponse lists the metrics added to and removed from the
+// metric catalog by a refresh of the metric types of a collector.
+type CatalogRefreshResponse struct {
+	Added   Metrics `json:"added"`
+	Removed Metrics `json:"removed"`
+}
+
+// CatalogRefreshParams represents the request path of the plugin refresh operation.
+//
+// swagger:parameters refreshPlugin
+type CatalogRefreshParams struct {
+	// required: true
+	// in: path
+	PName string `json:"pname"`
+	// required: true
+	// in: path
+	PVersion int `json:"pversion"`
+	// required: true
+	// in: path
+	// enum: collector, streaming-collector
+	PType string `json:"ptype"`
+}
+
+func (s *apiV2) refreshPlugin(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	plType, plName, plVersion, f, se := pluginParameters(p)
+	if se != nil {
+		Write(400, FromSnapError(se), w)
+		return
+	}
+	pType, err := core.ToPluginType(plType)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+
+	res, se := s.metricManager.RefreshPlugin(pType, plName, plVersion)
+	if se != nil {
+		se.SetFields(f)
+		code := runningPluginErrorCode(se)
+		if se.Error() == control.ErrCatalogRefreshUnsupported.Error() {
+			code = 400
+		}
+		Write(code, FromSnapError(se), w)
+		return
+	}
+	Write(200, CatalogRefreshResponse{
+		Added:   metricsBody(r.Host, res.Added),
+		Removed: metricsBody(r.Host, res.Removed),
+	}, w)
+}
@@ -0,0 +1,175 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/julienschmidt/httprouter"
+)
+
+// PluginStatsResp represents the response of the plugin statistics operation.
+//
+// swagger:response PluginStatsResponse
+type PluginStatsResp struct {
+	// in: body
+	Body PluginStatsResponse
+}
+
+// PluginStatsResponse holds the statistics of a plugin since it was first
+// started and the statistics of each of its running instances.
+type PluginStatsResponse struct {
+	Total     PluginStats   `json:"total"`
+	Instances []PluginStats `json:"instances"`
+	Scaling   PluginScaling `json:"scaling"`
+}
+
+// PluginScaling represents the decisions taken by the autoscaler for a plugin.
+type PluginScaling struct {
+	ScaleUps   uint64     `json:"scale_ups"`
+	ScaleDowns uint64     `json:"scale_downs"`
+	LastAction string     `json:"last_action,omitempty"`
+	LastReason string     `json:"last_reason,omitempty"`
+	LastScaled *time.Time `json:"last_scaled,omitempty"`
+}
+
+// PluginStats represents the RPC call statistics of a plugin.
+type PluginStats struct {
+	Name       string                     `json:"name"`
+	Version    int                        `json:"version"`
+	Type       string                     `json:"type"`
+	ID         uint32                     `json:"id,omitempty"`
+	Calls      map[string]PluginCallStats `json:"calls"`
+	MetricsIn  uint64                     `json:"metrics_in"`
+	MetricsOut uint64                     `json:"metrics_out"`
+}
+
+// PluginCallStats represents the statistics of one kind of RPC call.
+// Latencies are given in milliseconds.
+type PluginCallStats struct {
+	Calls      uint64          `json:"calls"`
+	Errors     uint64          `json:"errors"`
+	Timeouts   uint64          `json:"timeouts"`
+	AvgLatency float64         `json:"avg_latency_ms"`
+	MaxLatency float64         `json:"max_latency_ms"`
+	P50Latency float64         `json:"p50_latency_ms"`
+	P99Latency float64         `json:"p99_latency_ms"`
+	Histogram  []LatencyBucket `json:"histogram"`
+}
+
+// LatencyBucket holds the number of calls which completed within
+// the bucket upper bound (le) and above the previous bucket bound.
+type LatencyBucket struct {
+	LE    string `json:"le"`
+	Count uint64 `json:"count"`
+}
+
+// PluginStatsParams represents the request path of the plugin statistics operation.
+//
+// swagger:parameters getPluginStats
+type PluginStatsParams struct {
+	// required: true
+	// in: path
+	PName string `json:"pname"`
+	// required: true
+	// in: path
+	PVersion int `json:"pversion"`
+	// required: true
+	// in: path
+	// enum: collector, processor, publisher, streaming-collector
+	PType string `json:"ptype"`
+}
+
+func (s *apiV2) getPluginStats(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	plType, plName, plVersion, f, se := pluginParameters(p)
+	if se != nil {
+		Write(400, FromSnapError(se), w)
+		return
+	}
+	pType, err := core.ToPluginType(plType)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+
+	ps, se := s.metricManager.PluginStats(pType, plName, plVersion)
+	if se != nil {
+		se.SetFields(f)
+		Write(runningPluginErrorCode(se), FromSnapError(se), w)
+		return
+	}
+	resp := PluginStatsResponse{
+		Total:     pluginStatsBody(ps.Total),
+		Instances: make([]PluginStats, len(ps.Instances)),
+		Scaling: PluginScaling{
+			ScaleUps:   ps.Scaling.ScaleUps,
+			ScaleDowns: ps.Scaling.ScaleDowns,
+			LastAction: ps.Scaling.LastAction,
+			LastReason: ps.Scaling.LastReason,
+		},
+	}
+	if !ps.Scaling.LastScaled.IsZero() {
+		t := ps.Scaling.LastScaled
+		resp.Scaling.LastScaled = &t
+	}
+	for i, in := range ps.Instances {
+		resp.Instances[i] = pluginStatsBody(in)
+	}
+	Write(200, resp, w)
+}
+
+func pluginStatsBody(s core.PluginStats) PluginStats {
+	ps := PluginStats{
+		Name:       s.Name,
+		Version:    s.Version,
+		Type:       s.Type,
+		ID:         s.ID,
+		Calls:      make(map[string]PluginCallStats, len(s.Calls)),
+		MetricsIn:  s.MetricsIn,
+		MetricsOut: s.MetricsOut,
+	}
+	for call, c := range s.Calls {
+		cs := PluginCallStats{
+			Calls:      c.Calls,
+			Errors:     c.Errors,
+			Timeouts:   c.Timeouts,
+			AvgLatency: toMillis(c.AvgLatency()),
+			MaxLatency: toMillis(c.MaxLatency),
+			P50Latency: toMillis(c.Quantile(0.5)),
+			P99Latency: toMillis(c.Quantile(0.99)),
+			Histogram:  make([]LatencyBucket, len(c.Buckets)),
+		}
+		for i, n := range c.Buckets {
+			le := "+Inf"
+			if i < len(core.LatencyBuckets) {
+				le = core.LatencyBuckets[i].String()
+			}
+			cs.Histogram[i] = LatencyBucket{LE: le, Count: n}
+		}
+		ps.Calls[call] = cs
+	}
+	return ps
+}
+
+func toMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
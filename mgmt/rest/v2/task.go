@@ -22,6 +22,7 @@ package v2
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -38,9 +39,7 @@ import (
 // swagger:response TasksResponse
 type TasksResp struct {
 	// in: body
-	Body struct {
-		Tasks Tasks `json:"tasks"`
-	}
+	Body TasksResponse
 }
 
 // TaskResponse returns a task.
@@ -61,6 +60,10 @@ type RemoveTaskError struct {
 
 type TasksResponse struct {
 	Tasks Tasks `json:"tasks"`
+	// Total is the number of tasks matching the filters
+	Total int `json:"total"`
+	// NextCursor is the cursor of the next page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // TaskParam defines the API path task id.
@@ -103,6 +106,7 @@ type Task struct {
 	Version            int               `json:"version,omitempty"`
 	Deadline           string            `json:"deadline,omitempty"`
 	Workflow           *wmap.WorkflowMap `json:"workflow,omitempty"`
+	ExpandedMetrics    []ExpandedMetric  `json:"expanded_metrics,omitempty"`
 	Schedule           *core.Schedule    `json:"schedule,omitempty"`
 	CreationTimestamp  int64             `json:"creation_timestamp,omitempty"`
 	LastRunTimestamp   int64             `json:"last_run_timestamp,omitempty"`
@@ -116,6 +120,13 @@ type Task struct {
 	MaxFailures        int               `json:"max-failures,omitempty"`
 }
 
+// ExpandedMetric is a metric collected by a running task, one of those its
+// requested metrics expand to.
+type ExpandedMetric struct {
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+}
+
 type Tasks []Task
 
 func (s Tasks) Len() int {
@@ -130,6 +141,27 @@ func (s Tasks) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 
+// lessBy returns the less function of the tasks for a sort key
+func (s Tasks) lessBy(key string) func(i, j int) bool {
+	switch key {
+	case "id":
+		return func(i, j int) bool { return s[i].ID < s[j].ID }
+	case "name":
+		return func(i, j int) bool { return s[i].Name < s[j].Name }
+	case "state":
+		return func(i, j int) bool { return s[i].TaskState < s[j].TaskState }
+	case "last_run":
+		return func(i, j int) bool { return s[i].LastRunTimestamp < s[j].LastRunTimestamp }
+	case "hit_count":
+		return func(i, j int) bool { return s[i].HitCount < s[j].HitCount }
+	case "miss_count":
+		return func(i, j int) bool { return s[i].MissCount < s[j].MissCount }
+	case "failed_count":
+		return func(i, j int) bool { return s[i].FailedCount < s[j].FailedCount }
+	}
+	return s.Less
+}
+
 func (s *Task) CreationTime() time.Time {
 	return time.Unix(s.CreationTimestamp, 0)
 }
@@ -145,21 +177,131 @@ func (s *apiV2) addTask(w http.ResponseWriter, r *http.Request, _ httprouter.Par
 	Write(201, taskB, w)
 }
 
+// taskSortKeys are the keys the tasks can be sorted by, they are sorted by
+// creation time by default
+var taskSortKeys = []string{"created", "id", "name", "state", "last_run", "hit_count", "miss_count", "failed_count"}
+
 func (s *apiV2) getTasks(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	q := r.URL.Query()
+	opts, err := parseListOptions(q, taskSortKeys)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	filter, err := parseTaskFilter(q)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+
 	// get tasks from the task manager
 	sts := s.taskManager.GetTasks()
 
 	// create the task list response
-	tasks := make(Tasks, len(sts))
-	i := 0
+	tasks := make(Tasks, 0, len(sts))
 	for _, t := range sts {
-		tasks[i] = SchedulerTaskFromTask(t)
-		tasks[i].Href = taskURI(r.Host, t)
-		i++
+		if !filter.matches(t) {
+			continue
+		}
+		task := SchedulerTaskFromTask(t)
+		task.Href = taskURI(r.Host, t)
+		tasks = append(tasks, task)
 	}
 	sort.Sort(tasks)
+	opts.sortList(len(tasks), tasks.Swap, tasks.lessBy(opts.sort))
+	start, end, next := opts.page(len(tasks))
+
+	Write(200, TasksResponse{
+		Tasks:      tasks[start:end],
+		Total:      len(tasks),
+		NextCursor: next,
+	}, w)
+}
+
+// TaskFilterParams defines the filters of the task list.
+//
+// swagger:parameters getTasks
+type TaskFilterParams struct {
+	// States of the tasks listed, repeated or comma separated
+	// in: query
+	State []string `json:"state"`
+	// Prefix of the names of the tasks listed
+	// in: query
+	NamePrefix string `json:"name_prefix"`
+	// Label selector (ex: dc=us-east,env!=dev,team) matched against the tags
+	// the workflow of the task adds to its metrics
+	// in: query
+	Labels string `json:"labels"`
+}
+
+// taskFilter selects the tasks listed
+type taskFilter struct {
+	states     []string
+	namePrefix string
+	labels     labelSelector
+}
 
-	Write(200, TasksResponse{Tasks: tasks}, w)
+// parseTaskFilter reads the task filters from the query values state,
+// name_prefix and labels
+func parseTaskFilter(q url.Values) (*taskFilter, error) {
+	f := &taskFilter{namePrefix: q.Get("name_prefix")}
+	for _, state := range splitValues(q["state"]) {
+		valid := false
+		for _, st := range core.TaskStateLookup {
+			if strings.EqualFold(state, st) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("Invalid task state `%s`", state)
+		}
+		f.states = append(f.states, state)
+	}
+	labels, err := parseLabelSelector(q.Get("labels"))
+	if err != nil {
+		return nil, err
+	}
+	f.labels = labels
+	return f, nil
+}
+
+func (f *taskFilter) matches(t core.Task) bool {
+	if !strings.HasPrefix(t.GetName(), f.namePrefix) {
+		return false
+	}
+	if len(f.states) > 0 {
+		matched := false
+		for _, state := range f.states {
+			if strings.EqualFold(state, t.State().String()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(f.labels) > 0 && !f.labels.matches(taskLabels(t)) {
+		return false
+	}
+	return true
+}
+
+// taskLabels returns the labels of a task, which are the tags its workflow
+// adds to the collected metrics
+func taskLabels(t core.Task) map[string]string {
+	labels := map[string]string{}
+	wf := t.WMap()
+	if wf == nil || wf.Collect == nil {
+		return labels
+	}
+	for _, tags := range wf.Collect.GetTags() {
+		for k, v := range tags {
+			labels[k] = v
+		}
+	}
+	return labels
 }
 
 func (s *apiV2) getTask(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
@@ -171,6 +313,16 @@ func (s *apiV2) getTask(w http.ResponseWriter, r *http.Request, p httprouter.Par
 	}
 	task := AddSchedulerTaskFromTask(t)
 	task.Href = taskURI(r.Host, t)
+	// only the running tasks have their requested metrics expanded
+	if mts, err := s.metricManager.ExpandedMetrics(t.ID()); err == nil {
+		task.ExpandedMetrics = make([]ExpandedMetric, len(mts))
+		for i, mt := range mts {
+			task.ExpandedMetrics[i] = ExpandedMetric{
+				Namespace: mt.Namespace().String(),
+				Version:   mt.Version(),
+			}
+		}
+	}
 	Write(200, task, w)
 }
 
@@ -236,7 +388,7 @@ func taskURI(host string, t core.Task) string {
 func AddSchedulerTaskFromTask(t core.Task) Task {
 	st := SchedulerTaskFromTask(t)
 	(&st).assertSchedule(t.Schedule())
-	st.Workflow = t.WMap()
+	st.Workflow = t.WMap().Redacted()
 	return st
 }
 
@@ -0,0 +1,131 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/julienschmidt/httprouter"
+)
+
+// TaskConfigResp represents the response of the task config inspection.
+//
+// swagger:response TaskConfigResponse
+type TaskConfigResp struct {
+	// in: body
+	Body TaskConfigResponse
+}
+
+// TaskConfigResponse holds the config of every metric and of every process and
+// publish node of a task, as received by the plugins.
+type TaskConfigResponse struct {
+	Metrics []MetricConfig `json:"metrics"`
+	Nodes   []NodeConfig   `json:"nodes"`
+}
+
+// MetricConfig is the config of a metric collected by a task.
+type MetricConfig struct {
+	Namespace     string                   `json:"namespace"`
+	Version       int                      `json:"version"`
+	PluginName    string                   `json:"plugin_name"`
+	PluginVersion int                      `json:"plugin_version"`
+	Config        map[string]ConfigSetting `json:"config"`
+}
+
+// NodeConfig is the config of a process or publish node of a task.
+type NodeConfig struct {
+	PluginType    string                   `json:"plugin_type"`
+	PluginName    string                   `json:"plugin_name"`
+	PluginVersion int                      `json:"plugin_version"`
+	Config        map[string]ConfigSetting `json:"config"`
+}
+
+// ConfigSetting is the value of a config key and the layer it comes from:
+// the global plugin config ("plugins.all", "plugins.collector.all",
+// "plugins.collector.<name>.all", "plugins.collector.<name>.versions.<version>"),
+// the config of the collect node at a namespace ("workflow:/intel/psutil"),
+// the config of a process or publish node ("workflow") or a default value of
+// the plugin's config policy ("policy").
+type ConfigSetting struct {
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// TaskConfigParams represents the request path and query of the task config inspection.
+//
+// swagger:parameters getTaskConfig
+type TaskConfigParams struct {
+	// in: path
+	// required: true
+	ID string `json:"id"`
+	// Restricts the metrics to those matching the namespace query.
+	//
+	// in: query
+	Namespace string `json:"ns"`
+}
+
+func (s *apiV2) getTaskConfig(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id := p.ByName("id")
+	t, err := s.taskManager.GetTask(id)
+	if err != nil {
+		Write(404, FromError(err), w)
+		return
+	}
+	var ns core.Namespace
+	if q := r.URL.Query().Get("ns"); q != "" {
+		ns = core.NewNamespace(parseNamespace(q)...)
+	}
+	wc, serrs := s.metricManager.WorkflowConfig(t.WMap(), ns)
+	if len(serrs) > 0 {
+		Write(409, FromSnapErrors(serrs), w)
+		return
+	}
+	resp := TaskConfigResponse{
+		Metrics: make([]MetricConfig, len(wc.Metrics)),
+		Nodes:   make([]NodeConfig, len(wc.Nodes)),
+	}
+	for i, m := range wc.Metrics {
+		resp.Metrics[i] = MetricConfig{
+			Namespace:     m.Namespace.String(),
+			Version:       m.Version,
+			PluginName:    m.PluginName,
+			PluginVersion: m.PluginVersion,
+			Config:        configSettings(m.Config),
+		}
+	}
+	for i, n := range wc.Nodes {
+		resp.Nodes[i] = NodeConfig{
+			PluginType:    n.PluginType.String(),
+			PluginName:    n.PluginName,
+			PluginVersion: n.PluginVersion,
+			Config:        configSettings(n.Config),
+		}
+	}
+	Write(200, resp, w)
+}
+
+func configSettings(settings map[string]core.ConfigSetting) map[string]ConfigSetting {
+	r := make(map[string]ConfigSetting, len(settings))
+	for k, cs := range settings {
+		r[k] = ConfigSetting{Value: cs.Value, Source: cs.Source}
+	}
+	return r
+}
@@ -0,0 +1,158 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
+	"github.com/julienschmidt/httprouter"
+)
+
+// ErrTokensUnsupported is returned when snapteld has no token file
+var ErrTokensUnsupported = errors.New("API tokens are not enabled, set restapi.rest_auth_token_file")
+
+// TokensResp represents the response of listing the REST API tokens.
+//
+// swagger:response TokensResponse
+type TokensResp struct {
+	// in: body
+	Body TokensResponse
+}
+
+// TokensResponse lists the REST API tokens.
+type TokensResponse struct {
+	Tokens []Token `json:"tokens"`
+}
+
+// TokenResp represents the response of creating a REST API token.
+//
+// swagger:response TokenResponse
+type TokenResp struct {
+	// in: body
+	Body Token
+}
+
+// Token describes a REST API token.  The token itself is only set in the
+// response of its creation.
+type Token struct {
+	Name    string    `json:"name"`
+	Role    string    `json:"role"`
+	Created time.Time `json:"created"`
+	Token   string    `json:"token,omitempty"`
+}
+
+// TokenParams represents the request body of creating a REST API token.
+//
+// swagger:parameters createToken
+type TokenParams struct {
+	// in: body
+	// required: true
+	Token TokenRequest
+}
+
+// TokenRequest names the token to create and its role: read-only,
+// operator or admin.
+type TokenRequest struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// TokenNameParams represents the request path of revoking a REST API token.
+//
+// swagger:parameters revokeToken
+type TokenNameParams struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
+func (s *apiV2) getTokens(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.tokenManager == nil {
+		Write(404, FromError(ErrTokensUnsupported), w)
+		return
+	}
+	res := TokensResponse{Tokens: []Token{}}
+	for _, t := range s.tokenManager.Tokens() {
+		res.Tokens = append(res.Tokens, tokenFromAPI(t))
+	}
+	Write(200, res, w)
+}
+
+func (s *apiV2) createToken(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.tokenManager == nil {
+		Write(404, FromError(ErrTokensUnsupported), w)
+		return
+	}
+	tr := TokenRequest{}
+	errCode, err := core.UnmarshalBody(&tr, r.Body)
+	if errCode != 0 && err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	if !api.ValidTokenName(tr.Name) {
+		Write(400, FromError(api.ErrTokenName), w)
+		return
+	}
+	role, err := api.ParseRole(tr.Role)
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	token, t, err := s.tokenManager.CreateToken(tr.Name, role)
+	switch err {
+	case nil:
+	case api.ErrTokenName:
+		Write(400, FromError(err), w)
+		return
+	case api.ErrTokenExists:
+		Write(409, FromError(err), w)
+		return
+	default:
+		Write(500, FromError(err), w)
+		return
+	}
+	res := tokenFromAPI(t)
+	res.Token = token
+	Write(201, res, w)
+}
+
+func (s *apiV2) revokeToken(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	if s.tokenManager == nil {
+		Write(404, FromError(ErrTokensUnsupported), w)
+		return
+	}
+	err := s.tokenManager.RevokeToken(p.ByName("name"))
+	switch err {
+	case nil:
+		Write(204, nil, w)
+	case api.ErrTokenNotFound:
+		Write(404, FromError(err), w)
+	default:
+		Write(500, FromError(err), w)
+	}
+}
+
+func tokenFromAPI(t api.Token) Token {
+	return Token{Name: t.Name, Role: string(t.Role), Created: t.Created}
+}
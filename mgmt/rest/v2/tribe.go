@@ -0,0 +1,554 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+	"github.com/julienschmidt/httprouter"
+)
+
+var (
+	// ErrTribeUnsupported is returned when tribe is not enabled
+	ErrTribeUnsupported = errors.New("Tribe is not enabled, start snapteld with --tribe")
+	// ErrAgreementNotFound is returned when the agreement doesn't exist
+	ErrAgreementNotFound = errors.New("agreement not found")
+	// ErrAgreementExists is returned when creating an agreement which exists
+	ErrAgreementExists = errors.New("agreement already exists")
+	// ErrAgreementName is returned when creating an agreement without name
+	ErrAgreementName = errors.New("agreement name is required")
+	// ErrMemberNotFound is returned when the tribe member doesn't exist
+	ErrMemberNotFound = errors.New("member not found")
+	// ErrAgreementPluginNotFound is returned when the plugin isn't part of
+	// the agreement
+	ErrAgreementPluginNotFound = errors.New("plugin not found in agreement")
+	// ErrPluginAlreadyInAgreement is returned when adding a plugin which is
+	// part of the agreement
+	ErrPluginAlreadyInAgreement = errors.New("plugin already in agreement")
+	// ErrAgreementTaskNotFound is returned when the task isn't part of the
+	// agreement
+	ErrAgreementTaskNotFound = errors.New("task not found in agreement")
+	// ErrTaskIDRequired is returned when adding a task without ID to an
+	// agreement
+	ErrTaskIDRequired = errors.New("task id is required")
+)
+
+// AgreementsResp represents the response of listing the tribe agreements.
+//
+// swagger:response AgreementsResponse
+type AgreementsResp struct {
+	// in: body
+	Body AgreementsResponse
+}
+
+// AgreementsResponse lists the tribe agreements sorted by name.
+type AgreementsResponse struct {
+	Agreements []Agreement `json:"agreements"`
+}
+
+// AgreementResp represents the response of a tribe agreement.
+//
+// swagger:response AgreementResponse
+type AgreementResp struct {
+	// in: body
+	Body Agreement
+}
+
+// Agreement is a tribe agreement: its members share its plugins and tasks.
+type Agreement struct {
+	Name    string            `json:"name"`
+	Plugins []AgreementPlugin `json:"plugins"`
+	Tasks   []AgreementTask   `json:"tasks"`
+	Members []string          `json:"members"`
+}
+
+// AgreementPlugin is a plugin shared by the members of an agreement.
+type AgreementPlugin struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+// AgreementTask is a task shared by the members of an agreement.
+type AgreementTask struct {
+	ID            string `json:"id"`
+	StartOnCreate bool   `json:"start_on_create"`
+}
+
+// TribeMembersResp represents the response of listing the tribe members.
+//
+// swagger:response TribeMembersResponse
+type TribeMembersResp struct {
+	// in: body
+	Body TribeMembersResponse
+}
+
+// TribeMembersResponse lists the tribe members sorted by name.
+type TribeMembersResponse struct {
+	Members []TribeMember `json:"members"`
+}
+
+// TribeMemberResp represents the response of a tribe member.
+//
+// swagger:response TribeMemberResponse
+type TribeMemberResp struct {
+	// in: body
+	Body TribeMember
+}
+
+// TribeMember is a member of the tribe with the agreements it joined.
+type TribeMember struct {
+	Name string            `json:"name"`
+	Tags map[string]string `json:"tags,omitempty"`
+	// PluginAgreement is the agreement whose plugins the member shares
+	PluginAgreement string `json:"plugin_agreement,omitempty"`
+	// TaskAgreements are the agreements whose tasks the member runs
+	TaskAgreements []string `json:"task_agreements"`
+}
+
+// AgreementParams represents the request body of creating a tribe agreement.
+//
+// swagger:parameters addAgreement
+type AgreementParams struct {
+	// in: body
+	// required: true
+	Agreement AgreementRequest
+}
+
+// AgreementRequest names the agreement to create.
+type AgreementRequest struct {
+	Name string `json:"name"`
+}
+
+// AgreementNameParams represents the request path of a tribe agreement.
+//
+// swagger:parameters getAgreement removeAgreement
+type AgreementNameParams struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
+// AgreementMemberParams represents the request path of joining or leaving
+// a tribe agreement.
+//
+// swagger:parameters joinAgreement leaveAgreement
+type AgreementMemberParams struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+	// in: path
+	// required: true
+	Member string `json:"member"`
+}
+
+// AgreementPluginParams represents the request of adding a plugin to a
+// tribe agreement.
+//
+// swagger:parameters addAgreementPlugin
+type AgreementPluginParams struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+	// in: body
+	// required: true
+	Plugin AgreementPlugin
+}
+
+// AgreementPluginPathParams represents the request path of removing a
+// plugin from a tribe agreement.
+//
+// swagger:parameters removeAgreementPlugin
+type AgreementPluginPathParams struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+	// in: path
+	// required: true
+	PType string `json:"ptype"`
+	// in: path
+	// required: true
+	PName string `json:"pname"`
+	// in: path
+	// required: true
+	PVersion int `json:"pversion"`
+}
+
+// AgreementTaskParams represents the request of adding a task to a tribe
+// agreement.
+//
+// swagger:parameters addAgreementTask
+type AgreementTaskParams struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+	// in: body
+	// required: true
+	Task AgreementTask
+}
+
+// AgreementTaskPathParams represents the request path of removing a task
+// from a tribe agreement.
+//
+// swagger:parameters removeAgreementTask
+type AgreementTaskPathParams struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+	// in: path
+	// required: true
+	ID string `json:"id"`
+}
+
+// TribeMemberParams represents the request path of a tribe member.
+//
+// swagger:parameters getTribeMember
+type TribeMemberParams struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
+func (s *apiV2) getAgreements(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.tribeManager == nil {
+		Write(404, FromError(ErrTribeUnsupported), w)
+		return
+	}
+	res := AgreementsResponse{Agreements: []Agreement{}}
+	for _, a := range s.tribeManager.GetAgreements() {
+		res.Agreements = append(res.Agreements, agreementFromTribe(a))
+	}
+	sort.Sort(agreementsByName(res.Agreements))
+	Write(200, res, w)
+}
+
+func (s *apiV2) addAgreement(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.tribeManager == nil {
+		Write(404, FromError(ErrTribeUnsupported), w)
+		return
+	}
+	ar := AgreementRequest{}
+	errCode, err := core.UnmarshalBody(&ar, r.Body)
+	if errCode != 0 && err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	if ar.Name == "" {
+		Write(400, FromError(ErrAgreementName), w)
+		return
+	}
+	if _, ok := s.tribeManager.GetAgreements()[ar.Name]; ok {
+		Write(409, FromSnapError(serror.New(ErrAgreementExists, map[string]interface{}{"agreement_name": ar.Name})), w)
+		return
+	}
+	if serr := s.tribeManager.AddAgreement(ar.Name); serr != nil {
+		Write(409, FromSnapError(serr), w)
+		return
+	}
+	s.writeAgreement(201, ar.Name, w)
+}
+
+func (s *apiV2) getAgreement(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	a, ok := s.agreement(p.ByName("name"), w)
+	if !ok {
+		return
+	}
+	Write(200, agreementFromTribe(a), w)
+}
+
+func (s *apiV2) removeAgreement(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	a, ok := s.agreement(p.ByName("name"), w)
+	if !ok {
+		return
+	}
+	if serr := s.tribeManager.RemoveAgreement(a.Name); serr != nil {
+		Write(409, FromSnapError(serr), w)
+		return
+	}
+	Write(204, nil, w)
+}
+
+func (s *apiV2) joinAgreement(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	a, ok := s.agreement(p.ByName("name"), w)
+	if !ok {
+		return
+	}
+	m, ok := s.member(p.ByName("member"), w)
+	if !ok {
+		return
+	}
+	if serr := s.tribeManager.JoinAgreement(a.Name, m.Name); serr != nil {
+		Write(409, FromSnapError(serr), w)
+		return
+	}
+	s.writeAgreement(200, a.Name, w)
+}
+
+func (s *apiV2) leaveAgreement(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	a, ok := s.agreement(p.ByName("name"), w)
+	if !ok {
+		return
+	}
+	m, ok := s.member(p.ByName("member"), w)
+	if !ok {
+		return
+	}
+	if serr := s.tribeManager.LeaveAgreement(a.Name, m.Name); serr != nil {
+		Write(409, FromSnapError(serr), w)
+		return
+	}
+	s.writeAgreement(200, a.Name, w)
+}
+
+func (s *apiV2) addAgreementPlugin(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	a, ok := s.agreement(p.ByName("name"), w)
+	if !ok {
+		return
+	}
+	ap := AgreementPlugin{}
+	errCode, err := core.UnmarshalBody(&ap, r.Body)
+	if errCode != 0 && err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	plugin, serr := agreementPlugin(ap.Type, ap.Name, ap.Version)
+	if serr != nil {
+		Write(400, FromSnapError(serr), w)
+		return
+	}
+	if ok, _ := a.PluginAgreement.Plugins.Contains(plugin); ok {
+		Write(409, FromSnapError(serror.New(ErrPluginAlreadyInAgreement, pluginFields(a.Name, ap))), w)
+		return
+	}
+	if err := s.tribeManager.AddPlugin(a.Name, plugin); err != nil {
+		Write(409, FromError(err), w)
+		return
+	}
+	s.writeAgreement(201, a.Name, w)
+}
+
+func (s *apiV2) removeAgreementPlugin(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	a, ok := s.agreement(p.ByName("name"), w)
+	if !ok {
+		return
+	}
+	ap := AgreementPlugin{Type: p.ByName("ptype"), Name: p.ByName("pname")}
+	ap.Version, _ = strconv.Atoi(p.ByName("pversion"))
+	plugin, serr := agreementPlugin(ap.Type, ap.Name, ap.Version)
+	if serr != nil {
+		Write(400, FromSnapError(serr), w)
+		return
+	}
+	if ok, _ := a.PluginAgreement.Plugins.Contains(plugin); !ok {
+		Write(404, FromSnapError(serror.New(ErrAgreementPluginNotFound, pluginFields(a.Name, ap))), w)
+		return
+	}
+	if err := s.tribeManager.RemovePlugin(a.Name, plugin); err != nil {
+		Write(409, FromError(err), w)
+		return
+	}
+	Write(204, nil, w)
+}
+
+func (s *apiV2) addAgreementTask(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	a, ok := s.agreement(p.ByName("name"), w)
+	if !ok {
+		return
+	}
+	at := AgreementTask{}
+	errCode, err := core.UnmarshalBody(&at, r.Body)
+	if errCode != 0 && err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
+	if at.ID == "" {
+		Write(400, FromError(ErrTaskIDRequired), w)
+		return
+	}
+	if s.taskManager != nil {
+		if _, err := s.taskManager.GetTask(at.ID); err != nil {
+			Write(404, FromError(err), w)
+			return
+		}
+	}
+	task := agreement.Task{ID: at.ID, StartOnCreate: at.StartOnCreate}
+	if serr := s.tribeManager.AddTask(a.Name, task); serr != nil {
+		Write(409, FromSnapError(serr), w)
+		return
+	}
+	s.writeAgreement(201, a.Name, w)
+}
+
+func (s *apiV2) removeAgreementTask(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	a, ok := s.agreement(p.ByName("name"), w)
+	if !ok {
+		return
+	}
+	task := agreement.Task{ID: p.ByName("id")}
+	if ok, _ := a.TaskAgreement.Tasks.Contains(task); !ok {
+		Write(404, FromSnapError(serror.New(ErrAgreementTaskNotFound, map[string]interface{}{
+			"agreement_name": a.Name,
+			"task_id":        task.ID,
+		})), w)
+		return
+	}
+	if serr := s.tribeManager.RemoveTask(a.Name, task); serr != nil {
+		Write(409, FromSnapError(serr), w)
+		return
+	}
+	Write(204, nil, w)
+}
+
+func (s *apiV2) getTribeMembers(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.tribeManager == nil {
+		Write(404, FromError(ErrTribeUnsupported), w)
+		return
+	}
+	names := s.tribeManager.GetMembers()
+	sort.Strings(names)
+	res := TribeMembersResponse{Members: []TribeMember{}}
+	for _, name := range names {
+		if m := s.tribeManager.GetMember(name); m != nil {
+			res.Members = append(res.Members, memberFromTribe(m))
+		}
+	}
+	Write(200, res, w)
+}
+
+func (s *apiV2) getTribeMember(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	m, ok := s.member(p.ByName("name"), w)
+	if !ok {
+		return
+	}
+	Write(200, memberFromTribe(m), w)
+}
+
+// agreement returns the agreement with the given name, or writes the error
+// response when it doesn't exist
+func (s *apiV2) agreement(name string, w http.ResponseWriter) (*agreement.Agreement, bool) {
+	if s.tribeManager == nil {
+		Write(404, FromError(ErrTribeUnsupported), w)
+		return nil, false
+	}
+	a, ok := s.tribeManager.GetAgreements()[name]
+	if !ok {
+		Write(404, FromSnapError(serror.New(ErrAgreementNotFound, map[string]interface{}{"agreement_name": name})), w)
+		return nil, false
+	}
+	return a, true
+}
+
+// member returns the tribe member with the given name, or writes the error
+// response when it doesn't exist
+func (s *apiV2) member(name string, w http.ResponseWriter) (*agreement.Member, bool) {
+	if s.tribeManager == nil {
+		Write(404, FromError(ErrTribeUnsupported), w)
+		return nil, false
+	}
+	m := s.tribeManager.GetMember(name)
+	if m == nil {
+		Write(404, FromSnapError(serror.New(ErrMemberNotFound, map[string]interface{}{"member_name": name})), w)
+		return nil, false
+	}
+	return m, true
+}
+
+func (s *apiV2) writeAgreement(code int, name string, w http.ResponseWriter) {
+	a, serr := s.tribeManager.GetAgreement(name)
+	if serr != nil {
+		Write(500, FromSnapError(serr), w)
+		return
+	}
+	Write(code, agreementFromTribe(a), w)
+}
+
+func agreementPlugin(pluginType, name string, version int) (agreement.Plugin, serror.SnapError) {
+	f := map[string]interface{}{
+		"plugin-name":    name,
+		"plugin-version": version,
+		"plugin-type":    pluginType,
+	}
+	t, err := core.ToPluginType(pluginType)
+	if err != nil || name == "" || version < 1 {
+		return agreement.Plugin{}, serror.New(errors.New("missing or invalid parameter(s)"), f)
+	}
+	return agreement.Plugin{Name_: name, Version_: version, Type_: t}, nil
+}
+
+func pluginFields(agreementName string, p AgreementPlugin) map[string]interface{} {
+	return map[string]interface{}{
+		"agreement_name": agreementName,
+		"plugin-name":    p.Name,
+		"plugin-version": p.Version,
+		"plugin-type":    p.Type,
+	}
+}
+
+func agreementFromTribe(a *agreement.Agreement) Agreement {
+	res := Agreement{
+		Name:    a.Name,
+		Plugins: []AgreementPlugin{},
+		Tasks:   []AgreementTask{},
+		Members: []string{},
+	}
+	if a.PluginAgreement != nil {
+		for _, p := range a.PluginAgreement.Plugins {
+			res.Plugins = append(res.Plugins, AgreementPlugin{Type: p.TypeName(), Name: p.Name(), Version: p.Version()})
+		}
+	}
+	if a.TaskAgreement != nil {
+		for _, t := range a.TaskAgreement.Tasks {
+			res.Tasks = append(res.Tasks, AgreementTask{ID: t.ID, StartOnCreate: t.StartOnCreate})
+		}
+	}
+	for name := range a.Members {
+		res.Members = append(res.Members, name)
+	}
+	sort.Strings(res.Members)
+	return res
+}
+
+func memberFromTribe(m *agreement.Member) TribeMember {
+	res := TribeMember{
+		Name:           m.Name,
+		Tags:           m.Tags,
+		TaskAgreements: []string{},
+	}
+	if m.PluginAgreement != nil {
+		res.PluginAgreement = m.PluginAgreement.Name
+	}
+	for name := range m.TaskAgreements {
+		res.TaskAgreements = append(res.TaskAgreements, name)
+	}
+	sort.Strings(res.TaskAgreements)
+	return res
+}
+
+type agreementsByName []Agreement
+
+func (a agreementsByName) Len() int           { return len(a) }
+func (a agreementsByName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a agreementsByName) Less(i, j int) bool { return a[i].Name < a[j].Name }
@@ -21,12 +21,22 @@ package v2
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/net/websocket"
+
 	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/encoding"
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -34,6 +44,7 @@ const (
 	// Event types for task watcher streaming
 	TaskWatchStreamOpen   = "stream-open"
 	TaskWatchMetricEvent  = "metric-event"
+	TaskWatchProcessEvent = "process-event"
 	TaskWatchTaskDisabled = "task-disabled"
 	TaskWatchTaskStarted  = "task-started"
 	TaskWatchTaskStopped  = "task-stopped"
@@ -43,15 +54,50 @@ const (
 // The amount of time to buffer streaming events before flushing in seconds
 var StreamingBufferWindow = 0.1
 
+var (
+	ErrWatchTagInvalid      = errors.New("tag must have the form key:value")
+	ErrWatchIntervalInvalid = errors.New("interval must be a positive duration")
+)
+
+// TaskWatchParams defines the options of a task watch.
+//
+// swagger:parameters watchTask
+type TaskWatchParams struct {
+	// Only streams the metrics whose namespace matches one of these globs,
+	// element by element (ex: /intel/*/load/*).
+	//
+	// in: query
+	Namespace []string `json:"namespace"`
+	// Only streams the metrics having all these tags, given as key:value where
+	// the value can be a glob.
+	//
+	// in: query
+	Tag []string `json:"tag"`
+	// Streams each metric at most once per interval (ex: 10s).
+	//
+	// in: query
+	Interval string `json:"interval"`
+	// Also streams the metrics returned by the process nodes of the task.
+	//
+	// in: query
+	Processed bool `json:"processed"`
+}
+
 func (s *apiV2) watchTask(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 	s.wg.Add(1)
 	defer s.wg.Done()
 
 	id := p.ByName("id")
 
+	filter, err := parseWatchFilter(r.URL.Query())
+	if err != nil {
+		Write(400, FromError(err), w)
+		return
+	}
 	tw := &TaskWatchHandler{
-		alive: true,
-		mChan: make(chan StreamedTaskEvent),
+		alive:  true,
+		mChan:  make(chan StreamedTaskEvent),
+		filter: filter,
 	}
 	tc, err1 := s.taskManager.WatchTask(id, tw)
 	if err1 != nil {
@@ -63,6 +109,11 @@ func (s *apiV2) watchTask(w http.ResponseWriter, r *http.Request, p httprouter.P
 		return
 	}
 
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		s.watchTaskWebSocket(w, r, tw, tc)
+		return
+	}
+
 	// Make this Server Sent Events compatible
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -92,7 +143,7 @@ func (s *apiV2) watchTask(w http.ResponseWriter, r *http.Request, p httprouter.P
 		select {
 		case e := <-tw.mChan:
 			switch e.EventType {
-			case TaskWatchMetricEvent, TaskWatchTaskStarted:
+			case TaskWatchMetricEvent, TaskWatchProcessEvent, TaskWatchTaskStarted:
 				// The client can decide to stop receiving on the stream on Task Stopped.
 				// We write the event to the buffer
 				fmt.Fprintf(w, "data: %s\n\n", e.ToJSON())
@@ -102,7 +153,7 @@ func (s *apiV2) watchTask(w http.ResponseWriter, r *http.Request, p httprouter.P
 				// Flush since we are sending nothing new
 				flusher.Flush()
 				// Close out watcher removing it from the scheduler
-				tc.Close()
+				tw.close(tc)
 				// exit since this client is no longer listening
 				Write(204, nil, w)
 			}
@@ -115,7 +166,7 @@ func (s *apiV2) watchTask(w http.ResponseWriter, r *http.Request, p httprouter.P
 			// Flush since we are sending nothing new
 			flusher.Flush()
 			// Close out watcher removing it from the scheduler
-			tc.Close()
+			tw.close(tc)
 			// exit since this client is no longer listening
 			Write(204, nil, w)
 			return
@@ -123,7 +174,7 @@ func (s *apiV2) watchTask(w http.ResponseWriter, r *http.Request, p httprouter.P
 			// Flush since we are sending nothing new
 			flusher.Flush()
 			// Close out watcher removing it from the scheduler
-			tc.Close()
+			tw.close(tc)
 			// exit since this client is no longer listening
 			Write(204, nil, w)
 			return
@@ -131,21 +182,96 @@ func (s *apiV2) watchTask(w http.ResponseWriter, r *http.Request, p httprouter.P
 	}
 }
 
+// watchTaskWebSocket streams the events of a task watch as JSON text messages
+// over a WebSocket connection.
+func (s *apiV2) watchTaskWebSocket(w http.ResponseWriter, r *http.Request, tw *TaskWatchHandler, tc core.TaskWatcherCloser) {
+	// the watcher is also closed when the handshake fails
+	defer tw.close(tc)
+	websocket.Server{
+		Handshake: func(_ *websocket.Config, r *http.Request) error {
+			return s.checkOrigin(r)
+		},
+		Handler: func(ws *websocket.Conn) {
+			// The client isn't expected to send anything, reading only
+			// detects the closing of the connection
+			closed := make(chan struct{})
+			go func() {
+				io.Copy(ioutil.Discard, ws)
+				close(closed)
+			}()
+			so := StreamedTaskEvent{
+				EventType: TaskWatchStreamOpen,
+				Message:   "Stream opened",
+			}
+			if err := websocket.JSON.Send(ws, so); err != nil {
+				return
+			}
+			for {
+				select {
+				case e := <-tw.mChan:
+					if err := websocket.JSON.Send(ws, e); err != nil {
+						return
+					}
+					switch e.EventType {
+					case TaskWatchTaskDisabled, TaskWatchTaskStopped, TaskWatchTaskEnded:
+						return
+					}
+				case <-closed:
+					return
+				case <-s.killChan:
+					return
+				}
+			}
+		},
+	}.ServeHTTP(w, r)
+}
+
+// checkOrigin refuses the requests of browsers on another origin than snapteld
+// itself or the CORS allowed origins.  Clients which aren't browsers send no
+// Origin and are accepted.
+func (s *apiV2) checkOrigin(r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	if u, err := url.Parse(origin); err == nil && u.Host == r.Host {
+		return nil
+	}
+	if s.origins != nil && s.origins.AllowsOrigin(origin) {
+		return nil
+	}
+	return fmt.Errorf("origin %s is not allowed", origin)
+}
+
 type TaskWatchHandler struct {
 	streamCount int
 	alive       bool
 	mChan       chan StreamedTaskEvent
+	filter      *watchFilter
 }
 
-func (t *TaskWatchHandler) CatchCollection(m []core.Metric) {
-	sm := make([]StreamedMetric, len(m))
-	for i := range m {
-		sm[i] = StreamedMetric{
-			Namespace: m[i].Namespace().String(),
-			Data:      m[i].Data(),
-			Timestamp: m[i].Timestamp(),
-			Tags:      m[i].Tags(),
+// close removes the watcher from the scheduler. Events are received meanwhile
+// as the scheduler may be blocked handing one to the watcher.
+func (t *TaskWatchHandler) close(tc core.TaskWatcherCloser) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-t.mChan:
+			case <-done:
+				return
+			}
 		}
+	}()
+	tc.Close()
+	close(done)
+}
+
+func (t *TaskWatchHandler) CatchCollection(m []core.Metric) {
+	sm := t.filter.apply("", m)
+	// nothing is sent when all the metrics are filtered out
+	if len(sm) == 0 && len(m) != 0 {
+		return
 	}
 	t.mChan <- StreamedTaskEvent{
 		EventType: TaskWatchMetricEvent,
@@ -154,6 +280,24 @@ func (t *TaskWatchHandler) CatchCollection(m []core.Metric) {
 	}
 }
 
+// CatchProcessed streams the metrics returned by a process node when the
+// watch asked for them.
+func (t *TaskWatchHandler) CatchProcessed(pluginName string, pluginVersion int, m []core.Metric) {
+	if t.filter == nil || !t.filter.processed {
+		return
+	}
+	node := fmt.Sprintf("%s:%d", pluginName, pluginVersion)
+	sm := t.filter.apply(node, m)
+	if len(sm) == 0 && len(m) != 0 {
+		return
+	}
+	t.mChan <- StreamedTaskEvent{
+		EventType: TaskWatchProcessEvent,
+		Node:      node,
+		Event:     sm,
+	}
+}
+
 func (t *TaskWatchHandler) CatchTaskStarted() {
 	t.mChan <- StreamedTaskEvent{
 		EventType: TaskWatchTaskStarted,
@@ -191,9 +335,12 @@ type TaskWatchResponse struct {
 
 // StreamedTaskEvent defines the task watching data type.
 type StreamedTaskEvent struct {
-	EventType string          `json:"type"`
-	Message   string          `json:"message"`
-	Event     StreamedMetrics `json:"event,omitempty"`
+	EventType string `json:"type"`
+	Message   string `json:"message"`
+	// Node is the process node (name:version) having returned the metrics
+	// of a process event.
+	Node  string          `json:"node,omitempty"`
+	Event StreamedMetrics `json:"event,omitempty"`
 }
 
 func (s *StreamedTaskEvent) ToJSON() string {
@@ -208,6 +355,18 @@ type StreamedMetric struct {
 	Tags      map[string]string `json:"tags"`
 }
 
+// UnmarshalJSON decodes a streamed metric, turning distributions found in
+// its data back into core.Distribution values.
+func (s *StreamedMetric) UnmarshalJSON(data []byte) error {
+	type streamedMetric StreamedMetric
+	d, err := encoding.UnmarshalMetric(data, (*streamedMetric)(s))
+	if err != nil {
+		return err
+	}
+	s.Data = d
+	return nil
+}
+
 // StreamedMetrics defines a slice of streamed metrics.
 type StreamedMetrics []StreamedMetric
 
@@ -222,3 +381,153 @@ func (s StreamedMetrics) Less(i, j int) bool {
 func (s StreamedMetrics) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
+
+// watchFilter holds the options of a task watch restricting the metrics
+// streamed to the client.
+type watchFilter struct {
+	namespaces [][]string
+	tags       map[string]string
+	interval   time.Duration
+	processed  bool
+
+	mutex sync.Mutex
+	// last holds when each metric of each node was last streamed
+	last map[string]time.Time
+	// swept is when the entries of last older than the interval were last
+	// dropped
+	swept time.Time
+}
+
+// parseWatchFilter reads the options of a task watch from the query values
+// namespace, tag, interval and processed.
+func parseWatchFilter(q url.Values) (*watchFilter, error) {
+	f := &watchFilter{
+		tags: map[string]string{},
+		last: map[string]time.Time{},
+	}
+	for _, ns := range q["namespace"] {
+		if ns == "" {
+			continue
+		}
+		patterns := parseNamespace(ns)
+		for _, p := range patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, fmt.Errorf("Invalid namespace glob element `%s`: %v", p, err)
+			}
+		}
+		f.namespaces = append(f.namespaces, patterns)
+	}
+	for _, tag := range q["tag"] {
+		kv := strings.SplitN(tag, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, ErrWatchTagInvalid
+		}
+		if _, err := path.Match(kv[1], ""); err != nil {
+			return nil, fmt.Errorf("Invalid tag glob `%s`: %v", tag, err)
+		}
+		f.tags[kv[0]] = kv[1]
+	}
+	if v := q.Get("interval"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, ErrWatchIntervalInvalid
+		}
+		f.interval = d
+	}
+	if v := q.Get("processed"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid processed value `%s`", v)
+		}
+		f.processed = b
+	}
+	return f, nil
+}
+
+// apply returns the metrics, collected or returned by the given process node,
+// which are to be streamed.
+func (f *watchFilter) apply(node string, m []core.Metric) StreamedMetrics {
+	sm := make([]StreamedMetric, 0, len(m))
+	now := time.Now()
+	for i := range m {
+		if f != nil && !f.matches(m[i]) {
+			continue
+		}
+		ns := m[i].Namespace().String()
+		if f != nil && f.interval > 0 && !f.sample(node+ns, now) {
+			continue
+		}
+		sm = append(sm, StreamedMetric{
+			Namespace: ns,
+			Data:      m[i].Data(),
+			Timestamp: m[i].Timestamp(),
+			Tags:      m[i].Tags(),
+		})
+	}
+	return sm
+}
+
+// matches returns true if the metric matches one of the namespace globs and
+// all the tags
+func (f *watchFilter) matches(m core.Metric) bool {
+	if len(f.namespaces) > 0 {
+		ns := m.Namespace().Strings()
+		matched := false
+		for _, patterns := range f.namespaces {
+			if matchNamespace(patterns, ns) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	tags := m.Tags()
+	for k, pattern := range f.tags {
+		v, ok := tags[k]
+		if !ok {
+			return false
+		}
+		if ok, _ := path.Match(pattern, v); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// sample returns true if the metric with the given key wasn't streamed during
+// the last interval, recording it as streamed now.  Once per interval the
+// metrics streamed before the last interval are forgotten, as they would be
+// sampled anyway, so metrics which stopped being collected don't pile up.
+func (f *watchFilter) sample(key string, now time.Time) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if now.Sub(f.swept) >= f.interval {
+		for k, t := range f.last {
+			if now.Sub(t) >= f.interval {
+				delete(f.last, k)
+			}
+		}
+		f.swept = now
+	}
+	if t, ok := f.last[key]; ok && now.Sub(t) < f.interval {
+		return false
+	}
+	f.last[key] = now
+	return true
+}
+
+// matchNamespace returns true if the namespace elements match the patterns,
+// element by element
+func matchNamespace(patterns, ns []string) bool {
+	if len(patterns) != len(ns) {
+		return false
+	}
+	for i, pattern := range patterns {
+		if ok, _ := path.Match(pattern, ns[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,195 @@
+// +build medium
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
+	"github.com/julienschmidt/httprouter"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type mockWatchedTasks struct {
+	api.Tasks
+	handler chan core.TaskWatcherHandler
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func (m *mockWatchedTasks) WatchTask(id string, h core.TaskWatcherHandler) (core.TaskWatcherCloser, error) {
+	m.handler <- h
+	return m, nil
+}
+
+// Close may be called more than once by the event stream
+func (m *mockWatchedTasks) Close() error {
+	m.once.Do(func() { close(m.closed) })
+	return nil
+}
+
+func TestWatchTaskWebSocket(t *testing.T) {
+	Convey("Watching a task over a WebSocket", t, func() {
+		tasks := &mockWatchedTasks{
+			handler: make(chan core.TaskWatcherHandler, 1),
+			closed:  make(chan struct{}),
+		}
+		s := New(&sync.WaitGroup{}, make(chan struct{}), "http")
+		s.taskManager = tasks
+		router := httprouter.New()
+		router.GET("/v2/tasks/:id/watch", s.watchTask)
+		server := httptest.NewServer(router)
+		defer server.Close()
+
+		u := "ws" + strings.TrimPrefix(server.URL, "http") + "/v2/tasks/1234/watch?namespace=/intel/mock/*&processed=true"
+		ws, err := websocket.Dial(u, "", server.URL)
+		So(err, ShouldBeNil)
+		defer ws.Close()
+
+		var e StreamedTaskEvent
+		So(websocket.JSON.Receive(ws, &e), ShouldBeNil)
+		So(e.EventType, ShouldEqual, TaskWatchStreamOpen)
+
+		h := (<-tasks.handler).(core.TaskProcessWatcherHandler)
+		h.CatchCollection([]core.Metric{
+			plugin.MetricType{Namespace_: core.NewNamespace("intel", "psutil", "load")},
+			plugin.MetricType{Namespace_: core.NewNamespace("intel", "mock", "foo")},
+		})
+		So(websocket.JSON.Receive(ws, &e), ShouldBeNil)
+		So(e.EventType, ShouldEqual, TaskWatchMetricEvent)
+		So(e.Event, ShouldHaveLength, 1)
+		So(e.Event[0].Namespace, ShouldEqual, "/intel/mock/foo")
+
+		h.CatchProcessed("passthru", 1, []core.Metric{plugin.MetricType{Namespace_: core.NewNamespace("intel", "mock", "bar")}})
+		So(websocket.JSON.Receive(ws, &e), ShouldBeNil)
+		So(e.EventType, ShouldEqual, TaskWatchProcessEvent)
+		So(e.Node, ShouldEqual, "passthru:1")
+		So(e.Event[0].Namespace, ShouldEqual, "/intel/mock/bar")
+
+		h.CatchTaskStopped()
+		So(websocket.JSON.Receive(ws, &e), ShouldBeNil)
+		So(e.EventType, ShouldEqual, TaskWatchTaskStopped)
+		<-tasks.closed
+	})
+}
+
+type mockOrigins map[string]bool
+
+func (m mockOrigins) AllowsOrigin(origin string) bool {
+	return m[origin]
+}
+
+func TestWatchTaskWebSocketOrigin(t *testing.T) {
+	Convey("Watching a task over a WebSocket", t, func() {
+		tasks := &mockWatchedTasks{
+			handler: make(chan core.TaskWatcherHandler, 1),
+			closed:  make(chan struct{}),
+		}
+		s := New(&sync.WaitGroup{}, make(chan struct{}), "http")
+		s.taskManager = tasks
+		s.BindOrigins(mockOrigins{"http://dashboard.example.com": true})
+		router := httprouter.New()
+		router.GET("/v2/tasks/:id/watch", s.watchTask)
+		server := httptest.NewServer(router)
+		defer server.Close()
+		u := "ws" + strings.TrimPrefix(server.URL, "http") + "/v2/tasks/1234/watch"
+
+		Convey("is refused to the origins which are not allowed", func() {
+			_, err := websocket.Dial(u, "", "http://evil.example.com")
+			So(err, ShouldNotBeNil)
+			<-tasks.closed
+		})
+		Convey("is accepted from an allowed origin", func() {
+			ws, err := websocket.Dial(u, "", "http://dashboard.example.com")
+			So(err, ShouldBeNil)
+			defer ws.Close()
+			var e StreamedTaskEvent
+			So(websocket.JSON.Receive(ws, &e), ShouldBeNil)
+			So(e.EventType, ShouldEqual, TaskWatchStreamOpen)
+		})
+		Convey("is accepted from clients which aren't browsers", func() {
+			r := httptest.NewRequest("GET", "/v2/tasks/1234/watch", nil)
+			So(s.checkOrigin(r), ShouldBeNil)
+			r.Header.Set("Origin", "http://evil.example.com")
+			So(s.checkOrigin(r), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestWatchTaskEventStream(t *testing.T) {
+	Convey("Watching a task over an event stream", t, func() {
+		tasks := &mockWatchedTasks{
+			handler: make(chan core.TaskWatcherHandler, 1),
+			closed:  make(chan struct{}),
+		}
+		s := New(&sync.WaitGroup{}, make(chan struct{}), "http")
+		s.taskManager = tasks
+		router := httprouter.New()
+		router.GET("/v2/tasks/:id/watch", s.watchTask)
+		server := httptest.NewServer(router)
+		defer server.Close()
+		// every event is flushed
+		window := StreamingBufferWindow
+		StreamingBufferWindow = 0
+		defer func() { StreamingBufferWindow = window }()
+
+		rsp, err := http.Get(server.URL + "/v2/tasks/1234/watch?namespace=/intel/mock/*&processed=true")
+		So(err, ShouldBeNil)
+		defer rsp.Body.Close()
+		So(rsp.Header.Get("Content-Type"), ShouldEqual, "text/event-stream")
+
+		reader := bufio.NewReader(rsp.Body)
+		next := func() StreamedTaskEvent {
+			var e StreamedTaskEvent
+			for {
+				line, err := reader.ReadString('\n')
+				So(err, ShouldBeNil)
+				if strings.HasPrefix(line, "data: ") {
+					So(json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &e), ShouldBeNil)
+					return e
+				}
+			}
+		}
+		So(next().EventType, ShouldEqual, TaskWatchStreamOpen)
+
+		h := (<-tasks.handler).(core.TaskProcessWatcherHandler)
+		go h.CatchProcessed("passthru", 1, []core.Metric{plugin.MetricType{Namespace_: core.NewNamespace("intel", "mock", "bar")}})
+		e := next()
+		So(e.EventType, ShouldEqual, TaskWatchProcessEvent)
+		So(e.Node, ShouldEqual, "passthru:1")
+		So(e.Event[0].Namespace, ShouldEqual, "/intel/mock/bar")
+
+		go h.CatchTaskStopped()
+		So(next().EventType, ShouldEqual, TaskWatchTaskStopped)
+		<-tasks.closed
+	})
+}
@@ -0,0 +1,154 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func watchedMetric(tags map[string]string, ns ...string) core.Metric {
+	return plugin.MetricType{
+		Namespace_: core.NewNamespace(ns...),
+		Tags_:      tags,
+		Data_:      1,
+	}
+}
+
+func TestParseWatchFilter(t *testing.T) {
+	Convey("Parsing the task watch options", t, func() {
+		Convey("No options let all the metrics through", func() {
+			f, err := parseWatchFilter(url.Values{})
+			So(err, ShouldBeNil)
+			So(f.namespaces, ShouldBeEmpty)
+			So(f.tags, ShouldBeEmpty)
+			So(f.interval, ShouldEqual, 0)
+			So(f.processed, ShouldBeFalse)
+		})
+		Convey("All the options are read", func() {
+			f, err := parseWatchFilter(url.Values{
+				"namespace": {"/intel/*/foo", "/intel/mock/bar"},
+				"tag":       {"dc:us-*", "rack:1"},
+				"interval":  {"10s"},
+				"processed": {"true"},
+			})
+			So(err, ShouldBeNil)
+			So(f.namespaces, ShouldResemble, [][]string{{"intel", "*", "foo"}, {"intel", "mock", "bar"}})
+			So(f.tags, ShouldResemble, map[string]string{"dc": "us-*", "rack": "1"})
+			So(f.interval, ShouldEqual, 10*time.Second)
+			So(f.processed, ShouldBeTrue)
+		})
+		Convey("Invalid options are rejected", func() {
+			_, err := parseWatchFilter(url.Values{"namespace": {"/intel/[/foo"}})
+			So(err, ShouldNotBeNil)
+			_, err = parseWatchFilter(url.Values{"tag": {"dc"}})
+			So(err, ShouldEqual, ErrWatchTagInvalid)
+			_, err = parseWatchFilter(url.Values{"interval": {"-1s"}})
+			So(err, ShouldEqual, ErrWatchIntervalInvalid)
+			_, err = parseWatchFilter(url.Values{"interval": {"often"}})
+			So(err, ShouldEqual, ErrWatchIntervalInvalid)
+			_, err = parseWatchFilter(url.Values{"processed": {"maybe"}})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestWatchFilter(t *testing.T) {
+	metrics := []core.Metric{
+		watchedMetric(map[string]string{"dc": "us-east"}, "intel", "mock", "foo"),
+		watchedMetric(map[string]string{"dc": "eu-west"}, "intel", "mock", "bar"),
+		watchedMetric(nil, "intel", "psutil", "load", "load1"),
+	}
+	namespaces := func(sm StreamedMetrics) []string {
+		var out []string
+		for _, m := range sm {
+			out = append(out, m.Namespace)
+		}
+		return out
+	}
+	Convey("Filtering the metrics of a task watch", t, func() {
+		Convey("A nil filter lets all the metrics through", func() {
+			var f *watchFilter
+			So(f.apply("", metrics), ShouldHaveLength, 3)
+		})
+		Convey("Metrics are matched by namespace globs", func() {
+			f, _ := parseWatchFilter(url.Values{"namespace": {"/intel/mock/f*", "/intel/*/load/*"}})
+			So(namespaces(f.apply("", metrics)), ShouldResemble, []string{"/intel/mock/foo", "/intel/psutil/load/load1"})
+		})
+		Convey("Metrics are matched by tags", func() {
+			f, _ := parseWatchFilter(url.Values{"tag": {"dc:eu-*"}})
+			So(namespaces(f.apply("", metrics)), ShouldResemble, []string{"/intel/mock/bar"})
+		})
+		Convey("Metrics are sampled per node and namespace", func() {
+			f, _ := parseWatchFilter(url.Values{"interval": {"1h"}})
+			So(f.apply("", metrics), ShouldHaveLength, 3)
+			So(f.apply("", metrics), ShouldBeEmpty)
+			So(f.apply("passthru:1", metrics[:1]), ShouldHaveLength, 1)
+			f.last["/intel/mock/foo"] = time.Now().Add(-2 * time.Hour)
+			So(namespaces(f.apply("", metrics)), ShouldResemble, []string{"/intel/mock/foo"})
+		})
+		Convey("Metrics not streamed during the last interval are forgotten", func() {
+			f, _ := parseWatchFilter(url.Values{"interval": {"1h"}})
+			now := time.Now()
+			So(f.sample("/intel/mock/foo", now), ShouldBeTrue)
+			So(f.sample("/intel/mock/bar", now.Add(30*time.Minute)), ShouldBeTrue)
+			So(f.last, ShouldHaveLength, 2)
+
+			So(f.sample("/intel/mock/baz", now.Add(61*time.Minute)), ShouldBeTrue)
+			So(f.last, ShouldHaveLength, 2)
+			So(f.last, ShouldNotContainKey, "/intel/mock/foo")
+			So(f.sample("/intel/mock/bar", now.Add(62*time.Minute)), ShouldBeFalse)
+		})
+	})
+}
+
+func TestTaskWatchHandler(t *testing.T) {
+	Convey("A task watch handler", t, func() {
+		f, _ := parseWatchFilter(url.Values{"namespace": {"/intel/mock/*"}})
+		tw := &TaskWatchHandler{mChan: make(chan StreamedTaskEvent, 10), filter: f}
+		Convey("skips the collections filtered out", func() {
+			tw.CatchCollection([]core.Metric{watchedMetric(nil, "intel", "psutil", "load")})
+			So(tw.mChan, ShouldBeEmpty)
+			tw.CatchCollection([]core.Metric{watchedMetric(nil, "intel", "mock", "foo")})
+			So(tw.mChan, ShouldHaveLength, 1)
+			e := <-tw.mChan
+			So(e.EventType, ShouldEqual, TaskWatchMetricEvent)
+			So(e.Event, ShouldHaveLength, 1)
+		})
+		Convey("only streams processed metrics when asked for", func() {
+			m := []core.Metric{watchedMetric(nil, "intel", "mock", "foo")}
+			tw.CatchProcessed("passthru", 1, m)
+			So(tw.mChan, ShouldBeEmpty)
+			f.processed = true
+			tw.CatchProcessed("passthru", 1, m)
+			e := <-tw.mChan
+			So(e.EventType, ShouldEqual, TaskWatchProcessEvent)
+			So(e.Node, ShouldEqual, "passthru:1")
+			So(e.Event[0].Namespace, ShouldEqual, "/intel/mock/foo")
+		})
+	})
+}
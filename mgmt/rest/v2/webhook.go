@@ -0,0 +1,52 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/intelsdi-x/snap/mgmt/webhook"
+	"github.com/julienschmidt/httprouter"
+)
+
+// ErrWebhooksUnsupported is returned when snapteld posts no webhooks
+var ErrWebhooksUnsupported = errors.New("Webhooks are not available")
+
+// WebhooksResp represents the response of listing the webhook sinks.
+//
+// swagger:response WebhooksResponse
+type WebhooksResp struct {
+	// in: body
+	Body WebhooksResponse
+}
+
+// WebhooksResponse lists the webhook sinks with their delivery status.
+type WebhooksResponse struct {
+	Webhooks []webhook.Status `json:"webhooks"`
+}
+
+func (s *apiV2) getWebhooks(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if s.webhooks == nil {
+		Write(404, FromError(ErrWebhooksUnsupported), w)
+		return
+	}
+	Write(200, WebhooksResponse{Webhooks: s.webhooks.Sinks()}, w)
+}
@@ -40,6 +40,8 @@ const (
 	defaultRestAPIPassword           string        = ""
 	defaultRestAPIPort               int           = 8181
 	defaultRestAPIInsecureSkipVerify string        = "true"
+	defaultRestClientCert            string        = ""
+	defaultRestClientKey             string        = ""
 )
 
 // holds the configuration passed in through the SNAP config file
@@ -57,6 +59,8 @@ type Config struct {
 	RestAPIPassword           string             `json:"-"yaml:"-"`
 	RestAPIPort               int                `json:"-"yaml:"-"`
 	RestAPIInsecureSkipVerify string             `json:"-"yaml:"-"`
+	RestClientCert            string             `json:"rest_client_cert"yaml:"rest_client_cert"`
+	RestClientKey             string             `json:"rest_client_key"yaml:"rest_client_key"`
 }
 
 const (
@@ -80,6 +84,12 @@ const (
 					},
 					"seed": {
 						"type" : "string"
+					},
+					"rest_client_cert": {
+						"type" : "string"
+					},
+					"rest_client_key": {
+						"type" : "string"
 					}
 				},
 				"additionalProperties": false
@@ -103,6 +113,8 @@ func GetDefaultConfig() *Config {
 		RestAPIPassword:           defaultRestAPIPassword,
 		RestAPIPort:               defaultRestAPIPort,
 		RestAPIInsecureSkipVerify: defaultRestAPIInsecureSkipVerify,
+		RestClientCert:            defaultRestClientCert,
+		RestClientKey:             defaultRestClientKey,
 	}
 }
 
@@ -140,6 +152,14 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(v, &(c.Seed)); err != nil {
 				return fmt.Errorf("%v (while parsing 'tribe::seed')", err)
 			}
+		case "rest_client_cert":
+			if err := json.Unmarshal(v, &(c.RestClientCert)); err != nil {
+				return fmt.Errorf("%v (while parsing 'tribe::rest_client_cert')", err)
+			}
+		case "rest_client_key":
+			if err := json.Unmarshal(v, &(c.RestClientKey)); err != nil {
+				return fmt.Errorf("%v (while parsing 'tribe::rest_client_key')", err)
+			}
 		default:
 			return fmt.Errorf("Unrecognized key '%v' in global config file while parsing 'tribe'", k)
 		}
@@ -81,6 +81,12 @@ func TestTribeConfigJSON(t *testing.T) {
 		Convey("Seed should be 1.1.1.1:16000", func() {
 			So(cfg.Seed, ShouldEqual, "1.1.1.1:16000")
 		})
+		Convey("RestClientCert should equal /etc/snap/tribe.pem", func() {
+			So(cfg.RestClientCert, ShouldEqual, "/etc/snap/tribe.pem")
+		})
+		Convey("RestClientKey should equal /etc/snap/tribe.key", func() {
+			So(cfg.RestClientKey, ShouldEqual, "/etc/snap/tribe.key")
+		})
 	})
 
 }
@@ -114,6 +120,12 @@ func TestTribeConfigYaml(t *testing.T) {
 		Convey("Seed should be 1.1.1.1:16000", func() {
 			So(cfg.Seed, ShouldEqual, "1.1.1.1:16000")
 		})
+		Convey("RestClientCert should equal /etc/snap/tribe.pem", func() {
+			So(cfg.RestClientCert, ShouldEqual, "/etc/snap/tribe.pem")
+		})
+		Convey("RestClientKey should equal /etc/snap/tribe.key", func() {
+			So(cfg.RestClientKey, ShouldEqual, "/etc/snap/tribe.key")
+		})
 	})
 
 }
@@ -154,5 +166,11 @@ func TestTribeDefaultConfig(t *testing.T) {
 		Convey("RestAPIInsecureSkipVerify should be true", func() {
 			So(cfg.RestAPIInsecureSkipVerify, ShouldEqual, "true")
 		})
+		Convey("RestClientCert should be empty", func() {
+			So(cfg.RestClientCert, ShouldEqual, "")
+		})
+		Convey("RestClientKey should be empty", func() {
+			So(cfg.RestClientKey, ShouldEqual, "")
+		})
 	})
 }
@@ -56,6 +56,18 @@ var (
 		EnvVar: "SNAP_TRIBE_ADDR",
 	}
 
+	flTribeRestClientCert = cli.StringFlag{
+		Name:   "tribe-rest-client-cert",
+		Usage:  "A path to the certificate tribe presents to the REST API of other members",
+		EnvVar: "SNAP_TRIBE_REST_CLIENT_CERT",
+	}
+
+	flTribeRestClientKey = cli.StringFlag{
+		Name:   "tribe-rest-client-key",
+		Usage:  "A path to the key of the certificate tribe presents to the REST API of other members",
+		EnvVar: "SNAP_TRIBE_REST_CLIENT_KEY",
+	}
+
 	// Flags consumed by snapteld
-	Flags = []cli.Flag{flTribeNodeName, flTribe, flTribeSeed, flTribeAdvertiseAddr, flTribeAdvertisePort}
+	Flags = []cli.Flag{flTribeNodeName, flTribe, flTribeSeed, flTribeAdvertiseAddr, flTribeAdvertisePort, flTribeRestClientCert, flTribeRestClientKey}
 )
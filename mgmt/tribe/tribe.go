@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -38,6 +39,7 @@ import (
 	"github.com/intelsdi-x/snap/core/tribe_event"
 	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
 	"github.com/intelsdi-x/snap/mgmt/tribe/worker"
+	"github.com/intelsdi-x/snap/pkg/audit"
 	"github.com/pborman/uuid"
 
 	"github.com/hashicorp/go-msgpack/codec"
@@ -84,6 +86,7 @@ type tribe struct {
 
 	pluginCatalog   worker.ManagesPlugins
 	taskManager     worker.ManagesTasks
+	auditLog        audit.Recorder
 	pluginWorkQueue chan worker.PluginRequest
 	taskWorkQueue   chan worker.TaskRequest
 
@@ -210,10 +213,21 @@ func (t *tribe) SetTaskManager(m worker.ManagesTasks) {
 	t.taskManager = m
 }
 
+// SetAuditLog sets the audit log recording the plugins and the tasks the
+// workers act on, it has to be set before tribe starts
+func (t *tribe) SetAuditLog(l audit.Recorder) {
+	t.auditLog = l
+}
+
 func (t *tribe) Name() string {
 	return "tribe"
 }
 
+// RegisterEventHandler registers a handler of the events emitted by tribe
+func (t *tribe) RegisterEventHandler(name string, h gomit.Handler) error {
+	return t.EventManager.RegisterHandler(name, h)
+}
+
 func (t *tribe) Start() error {
 	if t.pluginCatalog == nil {
 		return errPluginCatalogNotSet
@@ -229,7 +243,8 @@ func (t *tribe) Start() error {
 		t.workerWaitGroup,
 		t.pluginCatalog,
 		t.taskManager,
-		t)
+		t,
+		t.auditLog)
 	return nil
 }
 
@@ -460,6 +475,18 @@ func (t *tribe) GetMembers() []string {
 	return members
 }
 
+// HealthCheck returns the name of this member, the seed it joined through
+// and the members of the tribe it sees
+func (t *tribe) HealthCheck() core.TribeHealth {
+	members := t.GetMembers()
+	sort.Strings(members)
+	return core.TribeHealth{
+		Name:    t.memberlist.LocalNode().Name,
+		Seed:    t.config.Seed,
+		Members: members,
+	}
+}
+
 func (t *tribe) LeaveAgreement(agreementName, memberName string) serror.SnapError {
 	if err := t.canLeaveAgreement(agreementName, memberName); err != nil {
 		return err
@@ -1516,3 +1543,7 @@ func (t *tribe) getTimeout() time.Duration {
 func (t *tribe) GetRequestPassword() string {
 	return t.config.RestAPIPassword
 }
+
+func (t *tribe) GetRequestClientCert() (cert, key string) {
+	return t.config.RestClientCert, t.config.RestClientKey
+}
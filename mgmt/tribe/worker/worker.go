@@ -28,6 +28,8 @@ import (
 	"net"
 	"os"
 	"path"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,6 +37,7 @@ import (
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/serror"
 	"github.com/intelsdi-x/snap/mgmt/rest/client"
+	"github.com/intelsdi-x/snap/pkg/audit"
 	"github.com/intelsdi-x/snap/pkg/schedule"
 	"github.com/intelsdi-x/snap/scheduler"
 	"github.com/intelsdi-x/snap/scheduler/wmap"
@@ -120,6 +123,7 @@ type getsMembers interface {
 	GetPluginAgreementMembers() ([]Member, error)
 	GetTaskAgreementMembers() ([]Member, error)
 	GetRequestPassword() string
+	GetRequestClientCert() (cert, key string)
 }
 
 type Member interface {
@@ -138,7 +142,8 @@ func newWorker(id int,
 	wg *sync.WaitGroup,
 	pm ManagesPlugins,
 	tm ManagesTasks,
-	mm getsMembers) worker {
+	mm getsMembers,
+	al audit.Recorder) worker {
 	logger := log.WithFields(log.Fields{
 		"_module":   "worker",
 		"worker-id": id,
@@ -147,6 +152,7 @@ func newWorker(id int,
 		pluginManager: pm,
 		taskManager:   tm,
 		memberManager: mm,
+		auditLog:      al,
 		id:            id,
 		pluginWork:    pluginQueue,
 		taskWork:      taskQueue,
@@ -162,6 +168,7 @@ type worker struct {
 	pluginManager ManagesPlugins
 	memberManager getsMembers
 	taskManager   ManagesTasks
+	auditLog      audit.Recorder
 	id            int
 	pluginWork    chan PluginRequest
 	taskWork      chan TaskRequest
@@ -170,14 +177,14 @@ type worker struct {
 	logger        *log.Entry
 }
 
-func DispatchWorkers(nworkers int, pluginQueue chan PluginRequest, taskQueue chan TaskRequest, quitChan chan struct{}, workerWaitGroup *sync.WaitGroup, cp ManagesPlugins, tm ManagesTasks, mm getsMembers) {
+func DispatchWorkers(nworkers int, pluginQueue chan PluginRequest, taskQueue chan TaskRequest, quitChan chan struct{}, workerWaitGroup *sync.WaitGroup, cp ManagesPlugins, tm ManagesTasks, mm getsMembers, al audit.Recorder) {
 
 	for i := 0; i < nworkers; i++ {
 		log.WithFields(log.Fields{
 			"_module": "worker",
 			"_block":  "dispatch-workers",
 		}).Infof("dispatching tribe worker-%d", i+1)
-		worker := newWorker(i+1, pluginQueue, taskQueue, quitChan, workerWaitGroup, cp, tm, mm)
+		worker := newWorker(i+1, pluginQueue, taskQueue, quitChan, workerWaitGroup, cp, tm, mm, al)
 		worker.start()
 	}
 }
@@ -294,7 +301,9 @@ func (w worker) unloadPlugin(plugin core.Plugin) error {
 	if !w.isPluginLoaded(plugin.Name(), plugin.TypeName(), plugin.Version()) {
 		return nil
 	}
-	if _, err := w.pluginManager.Unload(plugin); err != nil {
+	_, err := w.pluginManager.Unload(plugin)
+	w.audit("unload-plugin", pluginTargets(plugin), "", err)
+	if err != nil {
 		logger.WithField("err", err).Info("failed to unload plugin")
 		return err
 	}
@@ -318,7 +327,7 @@ func (w worker) loadPlugin(plugin core.Plugin) error {
 	}
 	for _, member := range shuffle(members) {
 		url := fmt.Sprintf("%s://%s:%s/v1/plugins/%s/%s/%d?download=true", member.GetRestProto(), member.GetAddr(), member.GetRestPort(), plugin.TypeName(), plugin.Name(), plugin.Version())
-		c, err := client.New(url, "v1", member.GetRestInsecureSkipVerify(), client.Password(w.memberManager.GetRequestPassword()))
+		c, err := w.newClient(url, member)
 		if err != nil {
 			logger.WithFields(log.Fields{
 				"err": err,
@@ -338,6 +347,7 @@ func (w worker) loadPlugin(plugin core.Plugin) error {
 			return err
 		}
 		_, err = w.pluginManager.Load(rp)
+		w.audit("load-plugin", pluginTargets(plugin), c.URL, err)
 		if err != nil {
 			logger.Error(err)
 			return err
@@ -350,6 +360,15 @@ func (w worker) loadPlugin(plugin core.Plugin) error {
 	return errors.New("failed to find a member with the plugin")
 }
 
+// newClient returns a client of the REST API of a member which authenticates
+// with the password and the client certificate of this member
+func (w worker) newClient(url string, member Member) (*client.Client, error) {
+	cert, key := w.memberManager.GetRequestClientCert()
+	return client.New(url, "v1", member.GetRestInsecureSkipVerify(),
+		client.Password(w.memberManager.GetRequestPassword()),
+		client.ClientCert(cert, key))
+}
+
 func (w worker) downloadPlugin(c *client.Client, plugin core.Plugin) (*os.File, error) {
 	logger := w.logger.WithFields(log.Fields{
 		"plugin-name":    plugin.Name(),
@@ -407,7 +426,7 @@ func (w worker) createTask(taskID string, startOnCreate bool) {
 			uri := fmt.Sprintf("%s://%s:%s", member.GetRestProto(), member.GetAddr(), member.GetRestPort())
 			logger.Debugf("getting task %v from %v", taskID, uri)
 
-			c, err := client.New(uri, "v1", member.GetRestInsecureSkipVerify(), client.Password(w.memberManager.GetRequestPassword()))
+			c, err := w.newClient(uri, member)
 			if err != nil {
 				logger.Error(err)
 				continue
@@ -423,7 +442,9 @@ func (w worker) createTask(taskID string, startOnCreate bool) {
 			if startOnCreate {
 				if _, err := w.taskManager.GetTask(taskID); err == nil {
 					logger.Debug("starting task")
-					if errs := w.taskManager.StartTaskTribe(taskID); errs != nil {
+					errs := w.taskManager.StartTaskTribe(taskID)
+					w.audit("start-task", map[string]string{"id": taskID}, uri, joinErrors(errs))
+					if errs != nil {
 						fields := log.Fields{}
 						for idx, e := range errs {
 							fields[fmt.Sprintf("err-%d", idx)] = e.Error()
@@ -442,6 +463,11 @@ func (w worker) createTask(taskID string, startOnCreate bool) {
 				taskResult.Workflow,
 				startOnCreate,
 				opt)
+			var createErr error
+			if errs != nil {
+				createErr = joinErrors(errs.Errors())
+			}
+			w.audit("create-task", map[string]string{"id": taskID}, uri, createErr)
 			if errs != nil && len(errs.Errors()) > 0 {
 				fields := log.Fields{}
 				for idx, e := range errs.Errors() {
@@ -468,6 +494,7 @@ func (w worker) startTask(taskID string) error {
 	})
 	logger.Debug("starting task")
 	errs := w.taskManager.StartTaskTribe(taskID)
+	w.audit("start-task", map[string]string{"id": taskID}, "", joinErrors(errs))
 	if errs == nil || len(errs) == 0 {
 		return nil
 	}
@@ -490,6 +517,7 @@ func (w worker) stopTask(taskID string) error {
 		"_block":  "stop-task",
 	})
 	errs := w.taskManager.StopTaskTribe(taskID)
+	w.audit("stop-task", map[string]string{"id": taskID}, "", joinErrors(errs))
 	if errs == nil || len(errs) == 0 {
 		return nil
 	}
@@ -510,6 +538,7 @@ func (w worker) removeTask(taskID string) error {
 		"_block":  "remove-task",
 	})
 	err := w.taskManager.RemoveTaskTribe(taskID)
+	w.audit("remove-task", map[string]string{"id": taskID}, "", err)
 	if err == nil {
 		return nil
 	}
@@ -517,6 +546,51 @@ func (w worker) removeTask(taskID string) error {
 	return err
 }
 
+// audit records an action of the worker in the audit log, source is the
+// member the plugin or the task was retrieved from
+func (w worker) audit(action string, targets map[string]string, source string, err error) {
+	if w.auditLog == nil {
+		return
+	}
+	e := audit.Entry{
+		Identity: "tribe",
+		Source:   source,
+		Route:    "tribe/" + action,
+		Targets:  targets,
+		Outcome:  audit.OutcomeSuccess,
+	}
+	if err != nil {
+		e.Outcome = audit.OutcomeFailure
+		e.Error = err.Error()
+	}
+	if err := w.auditLog.Record(e); err != nil {
+		w.logger.WithFields(log.Fields{
+			"_block": "audit",
+			"action": action,
+		}).Error(err)
+	}
+}
+
+func pluginTargets(plugin core.Plugin) map[string]string {
+	return map[string]string{
+		"type":    plugin.TypeName(),
+		"name":    plugin.Name(),
+		"version": strconv.Itoa(plugin.Version()),
+	}
+}
+
+// joinErrors returns a single error out of the errors of a task operation
+func joinErrors(errs []serror.SnapError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
 func shuffle(m []Member) []Member {
 	result := make([]Member, len(m))
 	perm := rand.Perm(len(m))
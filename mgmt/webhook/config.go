@@ -0,0 +1,158 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/vrischmann/jsonutil"
+)
+
+// default configuration values
+const (
+	defaultMaxRetries   int           = 5
+	defaultRetryBackoff time.Duration = time.Second
+	defaultTimeout      time.Duration = 10 * time.Second
+)
+
+// holds the configuration passed in through the SNAP config file
+//   Note: if this struct is modified, then the switch statement in the
+//         UnmarshalJSON method in this same file needs to be modified to
+//         match the field mapping that is defined here
+type Config struct {
+	Sinks []*SinkConfig `json:"sinks"yaml:"sinks"`
+}
+
+// SinkConfig is the configuration of a webhook sink, the URL events are
+// posted to
+type SinkConfig struct {
+	// Name identifies the sink, it defaults to the host and path of its URL
+	Name string `json:"name"yaml:"name"`
+	URL  string `json:"url"yaml:"url"`
+	// Events are the types of the events posted, all of them when empty
+	Events []string `json:"events"yaml:"events"`
+	// Secret signs the payloads with HMAC-SHA256 when set
+	Secret string `json:"secret"yaml:"secret"`
+	// MaxRetries is the number of times a failed delivery is retried
+	MaxRetries int `json:"max_retries"yaml:"max_retries"`
+	// RetryBackoff is the time waited before the first retry, it doubles
+	// with every retry
+	RetryBackoff jsonutil.Duration `json:"retry_backoff"yaml:"retry_backoff"`
+	Timeout      jsonutil.Duration `json:"timeout"yaml:"timeout"`
+}
+
+const (
+	CONFIG_CONSTRAINTS = `
+			"webhooks": {
+				"type": ["object", "null"],
+				"properties": {
+					"sinks": {
+						"type": ["array", "null"],
+						"items": {
+							"type": "object",
+							"properties": {
+								"name": {
+									"type": "string"
+								},
+								"url": {
+									"type": "string"
+								},
+								"events": {
+									"type": ["array", "null"],
+									"items": {
+										"type": "string"
+									}
+								},
+								"secret": {
+									"type": "string"
+								},
+								"max_retries": {
+									"type": "integer",
+									"minimum": 0
+								},
+								"retry_backoff": {
+									"type": "string"
+								},
+								"timeout": {
+									"type": "string"
+								}
+							},
+							"required": ["url"],
+							"additionalProperties": false
+						}
+					}
+				},
+				"additionalProperties": false
+			}
+	`
+)
+
+// get the default snapteld configuration
+func GetDefaultConfig() *Config {
+	return &Config{}
+}
+
+// GetDefaultSinkConfig returns the configuration of a sink without URL
+func GetDefaultSinkConfig() *SinkConfig {
+	return &SinkConfig{
+		MaxRetries:   defaultMaxRetries,
+		RetryBackoff: jsonutil.Duration{defaultRetryBackoff},
+		Timeout:      jsonutil.Duration{defaultTimeout},
+	}
+}
+
+// UnmarshalJSON unmarshals valid json into a Config.  An example Config can be found
+// at github.com/intelsdi-x/snap/blob/master/examples/configs/snap-config-sample.json
+func (c *Config) UnmarshalJSON(data []byte) error {
+	// construct a map of strings to json.RawMessages (to defer the parsing of individual
+	// fields from the unmarshalled interface until later) and unmarshal the input
+	// byte array into that map
+	t := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	// loop through the individual map elements, parse each in turn, and set
+	// the appropriate field in this configuration
+	for k, v := range t {
+		switch k {
+		case "sinks":
+			if err := json.Unmarshal(v, &(c.Sinks)); err != nil {
+				return fmt.Errorf("%v (while parsing 'webhooks::sinks')", err)
+			}
+		default:
+			return fmt.Errorf("Unrecognized key '%v' in global config file while parsing 'webhooks'", k)
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON unmarshals a sink configuration, the fields missing from
+// data keep their default value
+func (c *SinkConfig) UnmarshalJSON(data []byte) error {
+	type sinkConfig SinkConfig
+	sc := sinkConfig(*GetDefaultSinkConfig())
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return err
+	}
+	*c = SinkConfig(sc)
+	return nil
+}
@@ -0,0 +1,340 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook posts the events of control, the scheduler and tribe to
+// the webhook sinks of the snapteld configuration.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/gomit"
+	"github.com/pborman/uuid"
+
+	"github.com/intelsdi-x/snap/pkg/eventstream"
+)
+
+const (
+	// queueSize is the number of events a sink holds before it drops them
+	queueSize = 100
+	// maxFailures is the number of delivery failures kept by a sink
+	maxFailures = 20
+	// maxBackoff is the longest time waited between two retries
+	maxBackoff = 5 * time.Minute
+
+	// SignatureHeader holds the HMAC-SHA256 of the payload, as sha256=<hex>,
+	// when the sink has a secret
+	SignatureHeader = "X-Snap-Signature"
+	// EventHeader holds the type of the event posted
+	EventHeader = "X-Snap-Event"
+	// DeliveryHeader holds the ID of the delivery, which is kept by retries
+	DeliveryHeader = "X-Snap-Delivery"
+)
+
+var webhookLogger = log.WithField("_module", "webhook")
+
+// Payload is the JSON body posted to the sinks
+type Payload struct {
+	// ID is the ID of the delivery
+	ID   string    `json:"id"`
+	Time time.Time `json:"time"`
+	Type string    `json:"type"`
+	// Host is the host name of snapteld
+	Host string      `json:"host"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// Failure is a delivery which failed after all its retries, or an event
+// dropped because the sink was too far behind
+type Failure struct {
+	Time       time.Time `json:"time"`
+	DeliveryID string    `json:"delivery_id"`
+	EventType  string    `json:"event_type"`
+	Attempts   int       `json:"attempts"`
+	Error      string    `json:"error"`
+}
+
+// Status is the delivery status of a sink
+type Status struct {
+	Name string `json:"name"`
+	// URL is the URL of the sink without its user info
+	URL       string   `json:"url"`
+	Events    []string `json:"events,omitempty"`
+	Signed    bool     `json:"signed"`
+	Delivered int      `json:"delivered"`
+	Failed    int      `json:"failed"`
+	Pending   int      `json:"pending"`
+	// Failures are the last failures of the sink, the latest first
+	Failures []Failure `json:"failures"`
+}
+
+// Dispatcher is a gomit handler posting the events it handles to the sinks
+type Dispatcher struct {
+	sinks []*sink
+	host  string
+	stop  chan struct{}
+	wg    sync.WaitGroup
+}
+
+type sink struct {
+	cfg    *SinkConfig
+	url    string
+	filter eventstream.Filter
+	queue  chan Payload
+	client *http.Client
+
+	mutex     sync.Mutex
+	delivered int
+	failed    int
+	failures  []Failure
+}
+
+// New returns a dispatcher posting to the sinks of cfg
+func New(cfg *Config) (*Dispatcher, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "localhost"
+	}
+	d := &Dispatcher{
+		host: host,
+		stop: make(chan struct{}),
+	}
+	names := map[string]bool{}
+	for i, sc := range cfg.Sinks {
+		u, err := url.Parse(sc.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid URL of webhook sink %d: %q", i, sc.URL)
+		}
+		if sc.Name == "" {
+			sc.Name = u.Host + u.Path
+		}
+		if names[sc.Name] {
+			return nil, fmt.Errorf("webhook sink name %s is used more than once", sc.Name)
+		}
+		names[sc.Name] = true
+		filter, err := eventstream.ParseFilter(sc.Events)
+		if err != nil {
+			return nil, fmt.Errorf("invalid events of webhook sink %s: %v", sc.Name, err)
+		}
+		u.User = nil
+		d.sinks = append(d.sinks, &sink{
+			cfg:    sc,
+			url:    u.String(),
+			filter: filter,
+			queue:  make(chan Payload, queueSize),
+			client: &http.Client{Timeout: sc.Timeout.Duration},
+		})
+	}
+	return d, nil
+}
+
+func (d *Dispatcher) Name() string {
+	return "webhook"
+}
+
+// Start starts delivering the events to the sinks
+func (d *Dispatcher) Start() error {
+	for _, s := range d.sinks {
+		d.wg.Add(1)
+		go d.run(s)
+		webhookLogger.WithFields(log.Fields{
+			"_block": "start",
+			"sink":   s.cfg.Name,
+			"url":    s.url,
+		}).Info("webhook sink started")
+	}
+	return nil
+}
+
+// Stop stops delivering the events, the pending ones are dropped
+func (d *Dispatcher) Stop() {
+	close(d.stop)
+	d.wg.Wait()
+}
+
+// HandleGomitEvent queues an event for the sinks whose filter matches it.
+// A sink whose queue is full drops the event instead of blocking the
+// emitter.
+func (d *Dispatcher) HandleGomitEvent(e gomit.Event) {
+	if e.Body == nil || !eventstream.IsType(e.Namespace()) {
+		return
+	}
+	t := e.Header.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	for _, s := range d.sinks {
+		if !s.filter.Matches(e.Namespace()) {
+			continue
+		}
+		p := Payload{
+			ID:   uuid.New(),
+			Time: t,
+			Type: e.Namespace(),
+			Host: d.host,
+			Data: eventstream.Data(e.Body),
+		}
+		select {
+		case s.queue <- p:
+		default:
+			s.fail(p, 0, fmt.Errorf("the sink is %d events behind, the event was dropped", queueSize))
+		}
+	}
+}
+
+// Sinks returns the delivery status of the sinks
+func (d *Dispatcher) Sinks() []Status {
+	statuses := make([]Status, len(d.sinks))
+	for i, s := range d.sinks {
+		s.mutex.Lock()
+		statuses[i] = Status{
+			Name:      s.cfg.Name,
+			URL:       s.url,
+			Events:    s.cfg.Events,
+			Signed:    s.cfg.Secret != "",
+			Delivered: s.delivered,
+			Failed:    s.failed,
+			Pending:   len(s.queue),
+			Failures:  make([]Failure, len(s.failures)),
+		}
+		for j := range s.failures {
+			statuses[i].Failures[j] = s.failures[len(s.failures)-1-j]
+		}
+		s.mutex.Unlock()
+	}
+	return statuses
+}
+
+func (d *Dispatcher) run(s *sink) {
+	defer d.wg.Done()
+	for {
+		select {
+		case p := <-s.queue:
+			attempts, err := d.deliver(s, p)
+			if err != nil {
+				s.fail(p, attempts, err)
+				continue
+			}
+			s.mutex.Lock()
+			s.delivered++
+			s.mutex.Unlock()
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// deliver posts a payload to a sink, retrying with an exponential backoff
+// until it succeeds or runs out of retries
+func (d *Dispatcher) deliver(s *sink, p Payload) (int, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return 0, err
+	}
+	backoff := s.cfg.RetryBackoff.Duration
+	for attempt := 1; ; attempt++ {
+		err = s.post(p, b)
+		if err == nil || attempt > s.cfg.MaxRetries {
+			return attempt, err
+		}
+		webhookLogger.WithFields(log.Fields{
+			"_block":   "deliver",
+			"sink":     s.cfg.Name,
+			"event":    p.Type,
+			"delivery": p.ID,
+			"attempt":  attempt,
+		}).Debug("webhook delivery failed, retrying in ", backoff, ": ", err)
+		select {
+		case <-time.After(backoff):
+		case <-d.stop:
+			return attempt, err
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (s *sink) post(p Payload, b []byte) error {
+	req, err := http.NewRequest("POST", s.cfg.URL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, p.Type)
+	req.Header.Set(DeliveryHeader, p.ID)
+	if s.cfg.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(s.cfg.Secret, b))
+	}
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, rsp.Body)
+	rsp.Body.Close()
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response %s", rsp.Status)
+	}
+	return nil
+}
+
+func (s *sink) fail(p Payload, attempts int, err error) {
+	webhookLogger.WithFields(log.Fields{
+		"_block":   "deliver",
+		"sink":     s.cfg.Name,
+		"event":    p.Type,
+		"delivery": p.ID,
+		"attempts": attempts,
+	}).Error("webhook delivery failed: ", err)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failed++
+	s.failures = append(s.failures, Failure{
+		Time:       time.Now(),
+		DeliveryID: p.ID,
+		EventType:  p.Type,
+		Attempts:   attempts,
+		Error:      err.Error(),
+	})
+	if len(s.failures) > maxFailures {
+		s.failures = s.failures[len(s.failures)-maxFailures:]
+	}
+}
+
+// Sign returns the signature of a payload, sha256= followed by the hex
+// encoded HMAC-SHA256 of the payload keyed by secret
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,150 @@
+// +build medium
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/gomit"
+	"github.com/intelsdi-x/snap/core/control_event"
+	"github.com/intelsdi-x/snap/core/scheduler_event"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/vrischmann/jsonutil"
+)
+
+// hook records the requests it receives and answers them with the status
+// codes of fail before answering 200
+type hook struct {
+	mutex    sync.Mutex
+	fail     []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (h *hook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, _ := ioutil.ReadAll(r.Body)
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.requests = append(h.requests, r)
+	h.bodies = append(h.bodies, b)
+	if len(h.fail) > 0 {
+		w.WriteHeader(h.fail[0])
+		h.fail = h.fail[1:]
+	}
+}
+
+func (h *hook) count() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return len(h.requests)
+}
+
+// eventually waits for cond to be true
+func eventually(cond func() bool) bool {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestDispatcher(t *testing.T) {
+	Convey("A webhook dispatcher", t, func() {
+		pager, audit := &hook{}, &hook{}
+		pagerSrv, auditSrv := httptest.NewServer(pager), httptest.NewServer(audit)
+		defer pagerSrv.Close()
+		defer auditSrv.Close()
+		sink := func(name, url, secret string, retries int, events ...string) *SinkConfig {
+			return &SinkConfig{
+				Name:         name,
+				URL:          url,
+				Events:       events,
+				Secret:       secret,
+				MaxRetries:   retries,
+				RetryBackoff: jsonutil.Duration{10 * time.Millisecond},
+				Timeout:      jsonutil.Duration{time.Second},
+			}
+		}
+		d, err := New(&Config{Sinks: []*SinkConfig{
+			sink("pager", pagerSrv.URL, "s3cr3t", 2, "Scheduler.TaskDisabled", "Control.PluginRestartsExceeded"),
+			sink("audit", auditSrv.URL, "", 1, "Control.*"),
+		}})
+		So(err, ShouldBeNil)
+		So(d.Start(), ShouldBeNil)
+		defer d.Stop()
+		emit := func(body gomit.EventBody) {
+			d.HandleGomitEvent(gomit.Event{Header: gomit.Header{Time: time.Now()}, Body: body})
+		}
+
+		Convey("posts the events matching the filter of a sink", func() {
+			emit(&scheduler_event.MetricCollectedEvent{TaskID: "1234"})
+			emit(&scheduler_event.TaskDisabledEvent{TaskID: "1234", Why: "too many failures"})
+			So(eventually(func() bool { return pager.count() == 1 }), ShouldBeTrue)
+			r, b := pager.requests[0], pager.bodies[0]
+			So(r.Method, ShouldEqual, "POST")
+			So(r.Header.Get("Content-Type"), ShouldEqual, "application/json")
+			So(r.Header.Get(EventHeader), ShouldEqual, scheduler_event.TaskDisabled)
+			So(r.Header.Get(SignatureHeader), ShouldEqual, Sign("s3cr3t", b))
+			p := Payload{}
+			So(json.Unmarshal(b, &p), ShouldBeNil)
+			So(p.ID, ShouldEqual, r.Header.Get(DeliveryHeader))
+			So(p.Type, ShouldEqual, scheduler_event.TaskDisabled)
+			So(p.Host, ShouldNotBeEmpty)
+			So(p.Data, ShouldResemble, map[string]interface{}{"TaskID": "1234", "Why": "too many failures"})
+			So(audit.count(), ShouldEqual, 0)
+			So(eventually(func() bool { return d.Sinks()[0].Delivered == 1 }), ShouldBeTrue)
+		})
+		Convey("retries a failed delivery", func() {
+			pager.fail = []int{500, 502}
+			emit(&control_event.MaxPluginRestartsExceededEvent{Name: "mock", Version: 1})
+			So(eventually(func() bool { return d.Sinks()[0].Delivered == 1 }), ShouldBeTrue)
+			So(pager.count(), ShouldEqual, 3)
+			So(pager.requests[2].Header.Get(DeliveryHeader), ShouldEqual, pager.requests[0].Header.Get(DeliveryHeader))
+			So(d.Sinks()[0].Failed, ShouldEqual, 0)
+			So(eventually(func() bool { return d.Sinks()[1].Delivered == 1 }), ShouldBeTrue)
+			So(audit.requests[0].Header.Get(SignatureHeader), ShouldBeEmpty)
+		})
+		Convey("reports the deliveries which failed all their retries", func() {
+			audit.fail = []int{503, 503, 503}
+			emit(&control_event.LoadPluginEvent{Name: "mock", Version: 1})
+			So(eventually(func() bool { return d.Sinks()[1].Failed == 1 }), ShouldBeTrue)
+			So(audit.count(), ShouldEqual, 2)
+			s := d.Sinks()[1]
+			So(s.Name, ShouldEqual, "audit")
+			So(s.Signed, ShouldBeFalse)
+			So(s.Delivered, ShouldEqual, 0)
+			So(len(s.Failures), ShouldEqual, 1)
+			So(s.Failures[0].EventType, ShouldEqual, control_event.PluginLoaded)
+			So(s.Failures[0].Attempts, ShouldEqual, 2)
+			So(s.Failures[0].Error, ShouldContainSubstring, "503")
+			So(d.Sinks()[0].Failed, ShouldEqual, 0)
+		})
+	})
+}
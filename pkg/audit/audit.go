@@ -0,0 +1,190 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the mutating management operations of snapteld in an
+// append-only log of JSON lines.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxLineSize is the size of the longest line read as an entry
+const maxLineSize = 1 << 20
+
+var errLineTooLong = errors.New("line too long")
+
+const (
+	// OutcomeSuccess is the outcome of an operation which succeeded
+	OutcomeSuccess = "success"
+	// OutcomeFailure is the outcome of an operation which failed
+	OutcomeFailure = "failure"
+)
+
+// Entry is a single operation recorded in the audit log
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Identity is the caller of the operation, ex: token:grafana, cert:ops, user:snap or tribe
+	Identity string `json:"identity"`
+	// ClientCert is the identity of the client certificate of the caller
+	ClientCert string `json:"client_cert,omitempty"`
+	// Source is the address of the caller
+	Source string `json:"source,omitempty"`
+	Method string `json:"method,omitempty"`
+	// Route is the pattern of the REST API route, or the tribe action
+	Route string `json:"route"`
+	// Targets identifies the plugins, tasks or config the operation acted on
+	Targets map[string]string `json:"targets,omitempty"`
+	Outcome string            `json:"outcome"`
+	Status  int               `json:"status,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// Recorder records audit entries
+type Recorder interface {
+	Record(e Entry) error
+}
+
+// Log is an audit log kept in a file, entries are only ever appended to it
+type Log struct {
+	path  string
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// Open opens the audit log at path, creating the file if needed
+func Open(path string) (*Log, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &Log{path: path, file: f}, nil
+}
+
+// Path returns the path to the file of the audit log
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record appends an entry to the audit log, the time of the entry defaults to now
+func (l *Log) Record(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Time = e.Time.UTC()
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	// a single write keeps lines whole when other processes append to the file
+	_, err = l.file.Write(append(b, '\n'))
+	return err
+}
+
+// Page holds entries read from the audit log
+type Page struct {
+	Entries []Entry
+	// Skipped is the number of lines read which are not a valid entry
+	Skipped int
+	// Next is the offset in the file the following entries are read from, 0
+	// when the end of the file was reached
+	Next int64
+}
+
+// Entries reads, from offset in the file, up to limit entries recorded
+// between from, included, and to, excluded.  A zero time leaves that end of
+// the range open, a zero limit reads all the entries.  The file is read line
+// by line while entries are still appended to it, the lines which are not a
+// valid entry, e.g. one being written or one too long to be an entry, are
+// skipped and counted.
+func (l *Log) Entries(from, to time.Time, offset int64, limit int) (*Page, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	p := &Page{Entries: []Entry{}}
+	r := bufio.NewReaderSize(f, maxLineSize)
+	for {
+		line, n, err := readLine(r)
+		if err != nil && err != io.EOF && err != errLineTooLong {
+			return nil, err
+		}
+		offset += int64(n)
+		if err == errLineTooLong {
+			p.Skipped++
+			continue
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			e := Entry{}
+			if json.Unmarshal(line, &e) != nil {
+				p.Skipped++
+			} else if (from.IsZero() || !e.Time.Before(from)) && (to.IsZero() || e.Time.Before(to)) {
+				p.Entries = append(p.Entries, e)
+			}
+		}
+		if err == io.EOF {
+			return p, nil
+		}
+		if limit > 0 && len(p.Entries) == limit {
+			if _, err := r.Peek(1); err == nil {
+				p.Next = offset
+			}
+			return p, nil
+		}
+	}
+}
+
+// readLine returns the next line read from r, with its line feed, and the
+// number of bytes read.  A line longer than the buffer of r is read through
+// and errLineTooLong is returned.
+func readLine(r *bufio.Reader) ([]byte, int, error) {
+	line, err := r.ReadSlice('\n')
+	if err != bufio.ErrBufferFull {
+		return line, len(line), err
+	}
+	n := len(line)
+	for err == bufio.ErrBufferFull {
+		line, err = r.ReadSlice('\n')
+		n += len(line)
+	}
+	if err != nil && err != io.EOF {
+		return nil, n, err
+	}
+	return nil, n, errLineTooLong
+}
+
+// Close closes the file of the audit log
+func (l *Log) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.file.Close()
+}
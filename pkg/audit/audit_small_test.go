@@ -0,0 +1,129 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLog(t *testing.T) {
+	Convey("An audit log", t, func() {
+		dir, err := ioutil.TempDir("", "snap-audit")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "audit.log")
+		l, err := Open(path)
+		So(err, ShouldBeNil)
+		So(l.Path(), ShouldEqual, path)
+
+		t0 := time.Date(2017, 3, 14, 10, 0, 0, 0, time.UTC)
+		So(l.Record(Entry{Time: t0, Identity: "user:snap", Method: "POST", Route: "/v2/plugins", Outcome: OutcomeSuccess}), ShouldBeNil)
+		So(l.Record(Entry{Time: t0.Add(time.Minute), Identity: "token:grafana", Method: "DELETE", Route: "/v2/tasks/:id", Targets: map[string]string{"id": "1234"}, Outcome: OutcomeFailure, Status: 403, Error: "Forbidden"}), ShouldBeNil)
+		So(l.Record(Entry{Time: t0.Add(2 * time.Minute), Identity: "tribe", Route: "tribe/start-task", Outcome: OutcomeSuccess}), ShouldBeNil)
+
+		Convey("writes an entry per line", func() {
+			b, err := ioutil.ReadFile(path)
+			So(err, ShouldBeNil)
+			lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+			So(len(lines), ShouldEqual, 3)
+			So(lines[1], ShouldContainSubstring, `"identity":"token:grafana"`)
+			So(lines[1], ShouldContainSubstring, `"targets":{"id":"1234"}`)
+		})
+		Convey("reads all the entries without time range", func() {
+			p, err := l.Entries(time.Time{}, time.Time{}, 0, 0)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 3)
+			So(p.Entries[0].Time, ShouldResemble, t0)
+			So(p.Entries[1].Status, ShouldEqual, 403)
+			So(p.Entries[1].Error, ShouldEqual, "Forbidden")
+			So(p.Next, ShouldEqual, 0)
+		})
+		Convey("reads the entries of a time range", func() {
+			p, err := l.Entries(t0.Add(time.Minute), t0.Add(2*time.Minute), 0, 0)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 1)
+			So(p.Entries[0].Identity, ShouldEqual, "token:grafana")
+			p, err = l.Entries(t0.Add(time.Minute), time.Time{}, 0, 0)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 2)
+		})
+		Convey("reads the entries a page at a time", func() {
+			p, err := l.Entries(time.Time{}, time.Time{}, 0, 2)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 2)
+			So(p.Entries[1].Identity, ShouldEqual, "token:grafana")
+			So(p.Next, ShouldBeGreaterThan, 0)
+			p, err = l.Entries(time.Time{}, time.Time{}, p.Next, 2)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 1)
+			So(p.Entries[0].Identity, ShouldEqual, "tribe")
+			So(p.Next, ShouldEqual, 0)
+		})
+		Convey("applies the limit to the entries of a time range", func() {
+			p, err := l.Entries(t0.Add(time.Minute), time.Time{}, 0, 1)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 1)
+			So(p.Entries[0].Identity, ShouldEqual, "token:grafana")
+			p, err = l.Entries(t0.Add(time.Minute), time.Time{}, p.Next, 1)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 1)
+			So(p.Entries[0].Identity, ShouldEqual, "tribe")
+			So(p.Next, ShouldEqual, 0)
+		})
+		Convey("sets the time of entries without one", func() {
+			before := time.Now()
+			So(l.Record(Entry{Identity: "user:snap", Route: "/v2/tokens", Outcome: OutcomeSuccess}), ShouldBeNil)
+			p, err := l.Entries(before.Add(-time.Second), time.Time{}, 0, 0)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 1)
+			So(p.Entries[0].Route, ShouldEqual, "/v2/tokens")
+		})
+		Convey("skips and counts the lines which are not an entry", func() {
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+			So(err, ShouldBeNil)
+			_, err = f.WriteString("not an entry\n" + strings.Repeat("x", maxLineSize+1) + "\n{\"time\":\"2017-03-14T10:05:00Z\",\"identity\":")
+			So(err, ShouldBeNil)
+			So(f.Close(), ShouldBeNil)
+			p, err := l.Entries(time.Time{}, time.Time{}, 0, 0)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 3)
+			So(p.Skipped, ShouldEqual, 3)
+		})
+		Convey("keeps the entries when it is opened again", func() {
+			So(l.Close(), ShouldBeNil)
+			l, err := Open(path)
+			So(err, ShouldBeNil)
+			defer l.Close()
+			So(l.Record(Entry{Identity: "user:snap", Route: "/v2/plugins", Outcome: OutcomeSuccess}), ShouldBeNil)
+			p, err := l.Entries(time.Time{}, time.Time{}, 0, 0)
+			So(err, ShouldBeNil)
+			So(len(p.Entries), ShouldEqual, 4)
+		})
+	})
+}
@@ -0,0 +1,161 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventstream keeps the latest gomit events emitted by snapteld and
+// hands them out to subscribers, which can resume from the ID of the last
+// event they received.
+package eventstream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/intelsdi-x/gomit"
+)
+
+// subscriberBuffer is the number of events a subscriber may fall behind
+// before it is dropped
+const subscriberBuffer = 256
+
+// Event is an event of the stream
+type Event struct {
+	// ID increases by one with every event of the stream
+	ID   uint64
+	Time time.Time
+	// Type is the namespace of the gomit event, like Control.PluginLoaded
+	Type string
+	Body gomit.EventBody
+}
+
+// Stream is a gomit handler keeping the last events it handled in a bounded
+// replay buffer
+type Stream struct {
+	mutex  sync.Mutex
+	events []Event
+	size   int
+	lastID uint64
+	subs   map[*Subscription]struct{}
+}
+
+// New returns a stream replaying up to size events
+func New(size int) *Stream {
+	return &Stream{
+		events: make([]Event, 0, size),
+		size:   size,
+		subs:   map[*Subscription]struct{}{},
+	}
+}
+
+// HandleGomitEvent adds an event to the stream and sends it to the
+// subscribers.  Only the events of Types are kept, the other events, like the
+// metrics collected by a task, are dropped.  A subscriber whose channel is
+// full is dropped instead of blocking the emitter.
+func (s *Stream) HandleGomitEvent(e gomit.Event) {
+	if e.Body == nil || !IsType(e.Namespace()) {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.lastID++
+	t := e.Header.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	ev := Event{ID: s.lastID, Time: t, Type: e.Namespace(), Body: e.Body}
+	if len(s.events) == s.size {
+		copy(s.events, s.events[1:])
+		s.events = s.events[:len(s.events)-1]
+	}
+	if s.size > 0 {
+		s.events = append(s.events, ev)
+	}
+	for sub := range s.subs {
+		select {
+		case sub.c <- ev:
+		default:
+			delete(s.subs, sub)
+			close(sub.c)
+		}
+	}
+}
+
+// LastID returns the ID of the last event of the stream
+func (s *Stream) LastID() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.lastID
+}
+
+// Subscribe returns a subscription receiving the events following the event
+// with the ID after.  The buffered events following it are replayed first,
+// after is 0 to only receive new events.
+func (s *Stream) Subscribe(after uint64) *Subscription {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	sub := &Subscription{
+		c:      make(chan Event, subscriberBuffer),
+		stream: s,
+	}
+	if after > 0 {
+		// an ID past the last one was given out before snapteld restarted
+		if after > s.lastID {
+			after = 0
+			sub.Missed = true
+		}
+		oldest := s.lastID - uint64(len(s.events)) + 1
+		if after+1 < oldest {
+			sub.Missed = true
+		}
+		for _, e := range s.events {
+			if e.ID > after {
+				sub.Replay = append(sub.Replay, e)
+			}
+		}
+	}
+	s.subs[sub] = struct{}{}
+	return sub
+}
+
+// Subscription receives the events of a stream
+type Subscription struct {
+	// Replay holds the buffered events following the ID given to Subscribe
+	Replay []Event
+	// Missed is true when events following the ID given to Subscribe are no
+	// longer buffered
+	Missed bool
+
+	c      chan Event
+	stream *Stream
+}
+
+// Events returns the channel receiving the new events, it is closed when
+// the subscriber fell too far behind or the subscription is closed
+func (sub *Subscription) Events() <-chan Event {
+	return sub.c
+}
+
+// Close stops the subscription
+func (sub *Subscription) Close() {
+	sub.stream.mutex.Lock()
+	defer sub.stream.mutex.Unlock()
+	if _, ok := sub.stream.subs[sub]; ok {
+		delete(sub.stream.subs, sub)
+		close(sub.c)
+	}
+}
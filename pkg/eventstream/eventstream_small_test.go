@@ -0,0 +1,116 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstream
+
+import (
+	"testing"
+
+	"github.com/intelsdi-x/gomit"
+	"github.com/intelsdi-x/snap/core/control_event"
+	"github.com/intelsdi-x/snap/core/scheduler_event"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func emit(s *Stream, body gomit.EventBody) {
+	s.HandleGomitEvent(gomit.Event{Body: body})
+}
+
+// undeclaredEvent is an event whose type isn't one of Types
+type undeclaredEvent struct{}
+
+func (undeclaredEvent) Namespace() string {
+	return "Control.PluginEaten"
+}
+
+func TestStream(t *testing.T) {
+	Convey("An event stream", t, func() {
+		s := New(3)
+		emit(s, &control_event.LoadPluginEvent{Name: "mock", Version: 1})
+		emit(s, &scheduler_event.MetricCollectedEvent{TaskID: "1234"})
+		emit(s, &scheduler_event.TaskStartedEvent{TaskID: "1234"})
+
+		Convey("numbers the events it keeps", func() {
+			So(s.LastID(), ShouldEqual, 2)
+			sub := s.Subscribe(0)
+			defer sub.Close()
+			So(sub.Replay, ShouldBeEmpty)
+			So(sub.Missed, ShouldBeFalse)
+			emit(s, &scheduler_event.TaskStoppedEvent{TaskID: "1234"})
+			e := <-sub.Events()
+			So(e.ID, ShouldEqual, 3)
+			So(e.Type, ShouldEqual, scheduler_event.TaskStopped)
+			So(e.Time.IsZero(), ShouldBeFalse)
+		})
+		Convey("only keeps the declared event types", func() {
+			sub := s.Subscribe(0)
+			defer sub.Close()
+			emit(s, undeclaredEvent{})
+			emit(s, scheduler_event.MetricProcessedEvent{TaskID: "1234"})
+			So(s.LastID(), ShouldEqual, 2)
+			So(sub.Events(), ShouldBeEmpty)
+		})
+		Convey("replays the events following an event ID", func() {
+			sub := s.Subscribe(1)
+			defer sub.Close()
+			So(sub.Missed, ShouldBeFalse)
+			So(len(sub.Replay), ShouldEqual, 1)
+			So(sub.Replay[0].Type, ShouldEqual, scheduler_event.TaskStarted)
+		})
+		Convey("tells when events are no longer buffered", func() {
+			for i := 0; i < 3; i++ {
+				emit(s, &scheduler_event.TaskStoppedEvent{TaskID: "1234"})
+			}
+			sub := s.Subscribe(1)
+			defer sub.Close()
+			So(sub.Missed, ShouldBeTrue)
+			So(len(sub.Replay), ShouldEqual, 3)
+			So(sub.Replay[0].ID, ShouldEqual, 3)
+			So(s.Subscribe(2).Missed, ShouldBeFalse)
+		})
+		Convey("replays all its events after an ID it never gave out", func() {
+			sub := s.Subscribe(42)
+			defer sub.Close()
+			So(sub.Missed, ShouldBeTrue)
+			So(len(sub.Replay), ShouldEqual, 2)
+		})
+		Convey("drops a subscriber which fell behind", func() {
+			sub := s.Subscribe(0)
+			for i := 0; i <= subscriberBuffer; i++ {
+				emit(s, &scheduler_event.TaskStoppedEvent{TaskID: "1234"})
+			}
+			n := 0
+			for range sub.Events() {
+				n++
+			}
+			So(n, ShouldEqual, subscriberBuffer)
+			So(s.LastID(), ShouldEqual, subscriberBuffer+3)
+		})
+		Convey("stops sending events to a closed subscription", func() {
+			sub := s.Subscribe(0)
+			sub.Close()
+			sub.Close()
+			emit(s, &scheduler_event.TaskStoppedEvent{TaskID: "1234"})
+			_, ok := <-sub.Events()
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
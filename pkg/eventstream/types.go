@@ -0,0 +1,144 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstream
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/intelsdi-x/gomit"
+	"github.com/intelsdi-x/snap/core/control_event"
+	"github.com/intelsdi-x/snap/core/scheduler_event"
+	"github.com/intelsdi-x/snap/core/tribe_event"
+)
+
+// Types are the types of the events of control, the scheduler and tribe
+// handed out by snapteld
+var Types = []string{
+	control_event.PluginLoaded,
+	control_event.PluginUnloaded,
+	control_event.PluginsSwapped,
+	control_event.PluginStarted,
+	control_event.AvailablePluginDead,
+	control_event.AvailablePluginRestarted,
+	control_event.PluginRestartsExceeded,
+	control_event.HealthCheckFailed,
+	control_event.PluginPoolScaled,
+	control_event.PluginSubscribed,
+	control_event.PluginUnsubscribed,
+	control_event.MetricAdded,
+	control_event.MetricRemoved,
+	scheduler_event.TaskCreated,
+	scheduler_event.TaskStarted,
+	scheduler_event.TaskStopped,
+	scheduler_event.TaskEnded,
+	scheduler_event.TaskDisabled,
+	scheduler_event.TaskDeleted,
+	scheduler_event.PluginsUnsubscribed,
+	scheduler_event.MetricCollectionFailed,
+	tribe_event.PluginAdded,
+}
+
+// IsType returns true if t is one of Types
+func IsType(t string) bool {
+	for _, et := range Types {
+		if t == et {
+			return true
+		}
+	}
+	return false
+}
+
+// isFilterType returns true if t is one of Types or a module with the form
+// Module.*
+func isFilterType(t string) bool {
+	for _, et := range Types {
+		if t == et || t == et[:strings.Index(et, ".")]+".*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter holds event types and modules, a nil filter matches all the events
+type Filter map[string]bool
+
+// ParseFilter parses event types, like Control.PluginLoaded, and modules,
+// like Scheduler.*, given as repeated or comma separated values
+func ParseFilter(values []string) (Filter, error) {
+	f := Filter{}
+	for _, v := range values {
+		for _, t := range strings.Split(v, ",") {
+			t = strings.TrimSpace(t)
+			if t == "" {
+				continue
+			}
+			if !isFilterType(t) {
+				return nil, fmt.Errorf("unknown event type %s", t)
+			}
+			f[t] = true
+		}
+	}
+	if len(f) == 0 {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// Matches returns true if the filter holds the event type t or its module
+func (f Filter) Matches(t string) bool {
+	if f == nil || f[t] {
+		return true
+	}
+	if i := strings.Index(t, "."); i > 0 {
+		return f[t[:i]+".*"]
+	}
+	return false
+}
+
+// Data returns the body of an event in a form which can be encoded to JSON
+func Data(body gomit.EventBody) interface{} {
+	switch v := body.(type) {
+	case *scheduler_event.MetricCollectionFailedEvent:
+		errs := make([]string, len(v.Errors))
+		for i, err := range v.Errors {
+			errs[i] = err.Error()
+		}
+		return struct {
+			TaskID string
+			Errors []string
+		}{v.TaskID, errs}
+	case *scheduler_event.PluginsUnsubscribedEvent:
+		type plugin struct {
+			Name    string
+			Type    string
+			Version int
+		}
+		plugins := make([]plugin, len(v.Plugins))
+		for i, p := range v.Plugins {
+			plugins[i] = plugin{Name: p.Name(), Type: p.TypeName(), Version: p.Version()}
+		}
+		return struct {
+			TaskID  string
+			Plugins []plugin
+		}{v.TaskID, plugins}
+	}
+	return body
+}
@@ -0,0 +1,32 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netutil
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PathSegmentEscape escapes s so it can be placed as a single segment of a
+// URL path.  Unlike url.QueryEscape, spaces become "%20" rather than "+", and
+// unlike the escaping of a whole path, "/" becomes "%2F".
+func PathSegmentEscape(s string) string {
+	return strings.Replace((&url.URL{Path: s}).EscapedPath(), "/", "%2F", -1)
+}
@@ -0,0 +1,47 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netutil
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPathSegmentEscape(t *testing.T) {
+	Convey("A path segment is escaped as a single segment", t, func() {
+		So(PathSegmentEscape("mock"), ShouldEqual, "mock")
+		So(PathSegmentEscape("my plugin"), ShouldEqual, "my%20plugin")
+		So(PathSegmentEscape("a+b"), ShouldEqual, "a+b")
+		So(PathSegmentEscape("a/b"), ShouldEqual, "a%2Fb")
+		So(PathSegmentEscape("/a/"), ShouldEqual, "%2Fa%2F")
+		So(PathSegmentEscape("a?b#c%d"), ShouldEqual, "a%3Fb%23c%25d")
+	})
+	Convey("An escaped segment unescapes to what it was", t, func() {
+		for _, s := range []string{"a/b", "my plugin", "a%2Fb", "é/ü"} {
+			u, err := url.Parse("http://localhost/x/" + PathSegmentEscape(s))
+			So(err, ShouldBeNil)
+			So(u.Path, ShouldEqual, "/x/"+s)
+		}
+	})
+}
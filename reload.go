@@ -0,0 +1,169 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/intelsdi-x/snap/control"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/rest/api"
+	"github.com/intelsdi-x/snap/pkg/cfgfile"
+)
+
+// the settings, by their config file names, that a reload applies at runtime;
+// any other changed setting only takes effect after a restart
+var reloadableSettings = map[string]bool{
+	"log_level":                    true,
+	"control.plugins":              true,
+	"control.tags":                 true,
+	"control.cache_expiration":     true,
+	"control.max_plugin_restarts":  true,
+	"control.catalog_refresh":      true,
+	"control.max_expanded_metrics": true,
+	"restapi.allowed_origins":      true,
+}
+
+// the parts of snapteld a config reload reaches into
+type configuresControl interface {
+	ApplyConfig(*control.Config) []serror.SnapError
+}
+
+type configuresRest interface {
+	SetAllowedOrigins(string) error
+}
+
+// configReloader re-reads the snapteld config file on SIGHUP or a REST request
+// and applies the settings that can change while snapteld is running.
+type configReloader struct {
+	sync.Mutex
+	cfg     *Config
+	path    string
+	ctx     runtimeFlagsContext
+	control configuresControl
+	rest    configuresRest
+}
+
+func newConfigReloader(cfg *Config, ctx runtimeFlagsContext, c configuresControl) *configReloader {
+	path := ctx.String("config")
+	if path == "" && defaultConfigFile() {
+		path = defaultConfigPath
+	}
+	return &configReloader{
+		cfg:     cfg,
+		path:    path,
+		ctx:     ctx,
+		control: c,
+	}
+}
+
+// ReloadConfig reads and validates the config file, applies the reloadable
+// settings that changed and reports those that need a restart.  Nothing is
+// applied if the config file is invalid, or if its plugin config is invalid for
+// any of the running tasks.
+func (r *configReloader) ReloadConfig() (*api.ConfigReload, []serror.SnapError) {
+	r.Lock()
+	defer r.Unlock()
+
+	cfg := getDefaultConfig()
+	if r.path != "" {
+		if serrs := cfgfile.Read(r.path, &cfg, CONFIG_CONSTRAINTS); serrs != nil {
+			return nil, serrs
+		}
+	}
+	if _, _, err := checkCfgSettings(cfg); err != nil {
+		return nil, []serror.SnapError{serror.New(err)}
+	}
+	// command line flags still take precedence over the config file
+	applyCmdLineFlags(cfg, r.ctx)
+	jb, _ := json.Marshal(cfg)
+	if serrs := cfgfile.ValidateSchema(CONFIG_CONSTRAINTS, string(jb)); serrs != nil {
+		return nil, serrs
+	}
+	// a password asked for on startup is not in the config file
+	if cfg.RestAPI.RestAuth && cfg.RestAPI.RestAuthPassword == "" {
+		cfg.RestAPI.RestAuthPassword = r.cfg.RestAPI.RestAuthPassword
+	}
+
+	// control goes first as it refuses a plugin config which is invalid for
+	// the running tasks
+	if serrs := r.control.ApplyConfig(cfg.Control); serrs != nil {
+		return nil, serrs
+	}
+
+	res := &api.ConfigReload{Applied: []string{}, RestartRequired: []string{}}
+	for _, name := range changedSettings("", r.cfg, cfg, 1) {
+		if reloadableSettings[name] {
+			res.Applied = append(res.Applied, name)
+		} else {
+			res.RestartRequired = append(res.RestartRequired, name)
+		}
+	}
+
+	if r.rest != nil && cfg.RestAPI.Corsd != r.cfg.RestAPI.Corsd {
+		if err := r.rest.SetAllowedOrigins(cfg.RestAPI.Corsd); err != nil {
+			return nil, []serror.SnapError{serror.New(err)}
+		}
+		r.cfg.RestAPI.Corsd = cfg.RestAPI.Corsd
+	}
+	if cfg.LogLevel != r.cfg.LogLevel {
+		log.SetLevel(getLevel(cfg.LogLevel))
+		r.cfg.LogLevel = cfg.LogLevel
+	}
+
+	log.WithFields(log.Fields{
+		"block":            "reload",
+		"_module":          logModule,
+		"applied":          res.Applied,
+		"restart-required": res.RestartRequired,
+	}).Info("snapteld config reloaded")
+	return res, nil
+}
+
+// changedSettings returns the config file names of the settings that differ
+// between two configs, descending depth levels into nested sections.
+func changedSettings(prefix string, prev, next interface{}, depth int) []string {
+	pv := reflect.Indirect(reflect.ValueOf(prev))
+	nv := reflect.Indirect(reflect.ValueOf(next))
+	changed := []string{}
+	for i := 0; i < pv.NumField(); i++ {
+		f := pv.Type().Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		pf, nf := pv.Field(i), nv.Field(i)
+		if depth > 0 && f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.Struct && !pf.IsNil() && !nf.IsNil() {
+			changed = append(changed, changedSettings(prefix+name+".", pf.Interface(), nf.Interface(), depth-1)...)
+			continue
+		}
+		pj, _ := json.Marshal(pf.Interface())
+		nj, _ := json.Marshal(nf.Interface())
+		if string(pj) != string(nj) {
+			changed = append(changed, prefix+name)
+		}
+	}
+	return changed
+}
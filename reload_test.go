@@ -0,0 +1,128 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2017 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/intelsdi-x/snap/control"
+	"github.com/intelsdi-x/snap/core/serror"
+)
+
+type mockControlConfig struct {
+	applied *control.Config
+	serrs   []serror.SnapError
+}
+
+func (m *mockControlConfig) ApplyConfig(cfg *control.Config) []serror.SnapError {
+	if m.serrs != nil {
+		return m.serrs
+	}
+	m.applied = cfg
+	return nil
+}
+
+type mockRestConfig struct {
+	origins string
+}
+
+func (m *mockRestConfig) SetAllowedOrigins(corsd string) error {
+	m.origins = corsd
+	return nil
+}
+
+func TestConfigReload(t *testing.T) {
+	defer log.SetLevel(log.GetLevel())
+
+	dir, err := ioutil.TempDir("", "snapteld-reload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "snapteld.yaml")
+	ctx := mockFlags{"config": path}
+
+	Convey("Given a running snapteld config", t, func() {
+		cfg := getDefaultConfig()
+		applyCmdLineFlags(cfg, mockFlags{})
+		ctl := &mockControlConfig{}
+		rst := &mockRestConfig{}
+		r := newConfigReloader(cfg, ctx, ctl)
+		r.rest = rst
+
+		Convey("reloading an unchanged config changes nothing", func() {
+			So(ioutil.WriteFile(path, []byte("log_level: 3\n"), 0600), ShouldBeNil)
+			res, serrs := r.ReloadConfig()
+			So(serrs, ShouldBeNil)
+			So(res.Applied, ShouldBeEmpty)
+			So(res.RestartRequired, ShouldBeEmpty)
+			So(ctl.applied, ShouldNotBeNil)
+		})
+
+		Convey("reloadable settings are applied and the others reported", func() {
+			So(ioutil.WriteFile(path, []byte(`
+log_level: 1
+control:
+  cache_expiration: 2s
+  tags:
+    /intel/mock:
+      dc: east
+scheduler:
+  work_manager_queue_size: 50
+restapi:
+  allowed_origins: http://example.com
+`), 0600), ShouldBeNil)
+			res, serrs := r.ReloadConfig()
+			So(serrs, ShouldBeNil)
+			So(res.Applied, ShouldResemble, []string{"log_level", "control.cache_expiration", "control.tags", "restapi.allowed_origins"})
+			So(res.RestartRequired, ShouldResemble, []string{"scheduler.work_manager_queue_size"})
+			So(log.GetLevel(), ShouldEqual, log.DebugLevel)
+			So(ctl.applied.CacheExpiration.Duration, ShouldEqual, 2*time.Second)
+			So(ctl.applied.Tags["/intel/mock"]["dc"], ShouldEqual, "east")
+			So(rst.origins, ShouldEqual, "http://example.com")
+		})
+
+		Convey("an invalid config is not applied", func() {
+			So(ioutil.WriteFile(path, []byte("log_level: 9\n"), 0600), ShouldBeNil)
+			_, serrs := r.ReloadConfig()
+			So(serrs, ShouldNotBeEmpty)
+			So(ctl.applied, ShouldBeNil)
+			So(cfg.LogLevel, ShouldEqual, defaultLogLevel)
+		})
+
+		Convey("a plugin config refused by control is not applied", func() {
+			ctl.serrs = []serror.SnapError{serror.New(control.ErrPluginConfigRejected, map[string]interface{}{"task-ids": "1234"})}
+			So(ioutil.WriteFile(path, []byte("log_level: 1\n"), 0600), ShouldBeNil)
+			_, serrs := r.ReloadConfig()
+			So(serrs, ShouldHaveLength, 1)
+			So(serrs[0].Fields()["task-ids"], ShouldEqual, "1234")
+			So(cfg.LogLevel, ShouldEqual, defaultLogLevel)
+		})
+	})
+}
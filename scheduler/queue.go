@@ -41,6 +41,8 @@ type queue struct {
 	items   []queuedJob
 	mutex   *sync.Mutex
 	status  queueStatus
+	// rejected is the number of jobs refused because the limit was reached
+	rejected uint64
 }
 
 type queueStatus int
@@ -169,9 +171,18 @@ func (q *queue) push(j queuedJob) error {
 		q.items = append(q.items, j)
 		return nil
 	}
+	q.rejected++
 	return errLimitExceeded
 }
 
+// stats returns the number of queued jobs, the limit of the queue and the
+// number of jobs it rejected
+func (q *queue) stats() (int, uint, uint64) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.length(), q.limit, q.rejected
+}
+
 func (q *queue) pop() (queuedJob, error) {
 
 	q.mutex.Lock()
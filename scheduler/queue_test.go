@@ -89,6 +89,9 @@ func TestQueue(t *testing.T) {
 		err := <-q.Err
 		So(err, ShouldNotBeNil)
 		So(err.Err, ShouldResemble, errLimitExceeded)
+		_, limit, rejected := q.stats()
+		So(limit, ShouldEqual, 3)
+		So(rejected, ShouldBeGreaterThanOrEqualTo, 1)
 		q.Stop()
 	})
 
@@ -26,6 +26,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -491,6 +492,24 @@ func (s *scheduler) GetTasks() map[string]core.Task {
 	return tasks
 }
 
+// HealthCheck returns whether the scheduler is started along with the
+// disabled tasks and the state of the work queues
+func (s *scheduler) HealthCheck() core.SchedulerHealth {
+	h := core.SchedulerHealth{
+		Started:       s.state == schedulerStarted,
+		DisabledTasks: []string{},
+		Queues:        s.workManager.health(),
+	}
+	for id, t := range s.tasks.Table() {
+		h.Tasks++
+		if t.State() == core.TaskDisabled {
+			h.DisabledTasks = append(h.DisabledTasks, id)
+		}
+	}
+	sort.Strings(h.DisabledTasks)
+	return h
+}
+
 // GetTask provided the task id a task is returned
 func (s *scheduler) GetTask(id string) (core.Task, error) {
 	t, err := s.getTask(id)
@@ -781,6 +800,17 @@ func (s *scheduler) HandleGomitEvent(e gomit.Event) {
 			"metric-count":    len(v.Metrics),
 		}).Debug("event received")
 		s.taskWatcherColl.handleMetricCollected(v.TaskID, v.Metrics)
+	case *scheduler_event.MetricProcessedEvent:
+		log.WithFields(log.Fields{
+			"_module":         "scheduler-events",
+			"_block":          "handle-events",
+			"event-namespace": e.Namespace(),
+			"task-id":         v.TaskID,
+			"plugin-name":     v.PluginName,
+			"plugin-version":  v.PluginVersion,
+			"metric-count":    len(v.Metrics),
+		}).Debug("event received")
+		s.taskWatcherColl.handleMetricProcessed(v.TaskID, v.PluginName, v.PluginVersion, v.Metrics)
 	case *scheduler_event.MetricCollectionFailedEvent:
 		log.WithFields(log.Fields{
 			"_module":         "scheduler-events",
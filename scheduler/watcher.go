@@ -133,6 +133,29 @@ func (t *taskWatcherCollection) handleMetricCollected(taskID string, m []core.Me
 	}
 }
 
+func (t *taskWatcherCollection) handleMetricProcessed(taskID string, pluginName string, pluginVersion int, m []core.Metric) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	// no taskID means no watches, early exit
+	if t.coll[taskID] == nil || len(t.coll[taskID]) == 0 {
+		return
+	}
+	// Walk all watchers for a task ID
+	for _, v := range t.coll[taskID] {
+		// Only the watchers catching processed metrics are called
+		h, ok := v.handler.(core.TaskProcessWatcherHandler)
+		if !ok {
+			continue
+		}
+		watcherLog.WithFields(log.Fields{
+			"task-id":         taskID,
+			"task-watcher-id": v.id,
+		}).Debug("calling taskwatcher processed func")
+		// Call the catcher
+		h.CatchProcessed(pluginName, pluginVersion, m)
+	}
+}
+
 func (t *taskWatcherCollection) handleTaskStarted(taskID string) {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
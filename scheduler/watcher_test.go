@@ -22,6 +22,7 @@ limitations under the License.
 package scheduler
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/intelsdi-x/snap/core"
@@ -111,3 +112,31 @@ func TestTaskWatching(t *testing.T) {
 		So(sum, ShouldEqual, 11)
 	})
 }
+
+type mockProcessCatcher struct {
+	mockCatcher
+	nodes []string
+}
+
+func (d *mockProcessCatcher) CatchProcessed(pluginName string, pluginVersion int, m []core.Metric) {
+	d.nodes = append(d.nodes, fmt.Sprintf("%s:%d", pluginName, pluginVersion))
+}
+
+func TestTaskWatchingProcessed(t *testing.T) {
+	log.SetLevel(log.FatalLevel)
+	Convey("Processed metrics are only handed to the watchers catching them", t, func() {
+		twc := newTaskWatcherCollection()
+		d1 := &mockCatcher{}
+		d2 := &mockProcessCatcher{}
+		twc.add("1", d1)
+		twc.add("1", d2)
+
+		twc.handleMetricProcessed("1", "passthru", 1, nil)
+		twc.handleMetricProcessed("2", "passthru", 1, nil)
+		twc.handleMetricProcessed("1", "movingaverage", 2, nil)
+
+		So(d1.count, ShouldEqual, 0)
+		So(d2.count, ShouldEqual, 0)
+		So(d2.nodes, ShouldResemble, []string{"passthru:1", "movingaverage:2"})
+	})
+}
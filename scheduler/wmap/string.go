@@ -43,6 +43,12 @@ func (c *CollectWorkflowMapNode) String(pad string) string {
 		out += pad + fmt.Sprintf("      Namespace: %s\n", k)
 		out += pad + fmt.Sprintf("         Version: %d\n", v.Version_)
 	}
+	if len(c.Exclude) > 0 {
+		out += pad + "Exclude:\n"
+		for _, k := range c.Exclude {
+			out += pad + fmt.Sprintf("      Namespace: %s\n", k)
+		}
+	}
 	out += "\n"
 	out += pad + "Config:\n"
 	for k, v := range c.Config {
@@ -170,10 +170,69 @@ func (w *WorkflowMap) ToYaml() ([]byte, error) {
 	return yaml.Marshal(w)
 }
 
+// Redacted returns a copy of the workflow map in which the values of
+// sensitive config keys are replaced, for use wherever a task leaves snap.
+func (w *WorkflowMap) Redacted() *WorkflowMap {
+	if w == nil || w.Collect == nil {
+		return w
+	}
+	c := *w.Collect
+	if c.Config != nil {
+		c.Config = make(map[string]map[string]interface{}, len(w.Collect.Config))
+		for ns, cfg := range w.Collect.Config {
+			c.Config[ns] = redactConfig(cfg)
+		}
+	}
+	c.Process = redactProcessNodes(c.Process)
+	c.Publish = redactPublishNodes(c.Publish)
+	return &WorkflowMap{Collect: &c}
+}
+
+func redactProcessNodes(nodes []ProcessWorkflowMapNode) []ProcessWorkflowMapNode {
+	if nodes == nil {
+		return nil
+	}
+	r := make([]ProcessWorkflowMapNode, len(nodes))
+	for i, n := range nodes {
+		n.Config = redactConfig(n.Config)
+		n.Process = redactProcessNodes(n.Process)
+		n.Publish = redactPublishNodes(n.Publish)
+		r[i] = n
+	}
+	return r
+}
+
+func redactPublishNodes(nodes []PublishWorkflowMapNode) []PublishWorkflowMapNode {
+	if nodes == nil {
+		return nil
+	}
+	r := make([]PublishWorkflowMapNode, len(nodes))
+	for i, n := range nodes {
+		n.Config = redactConfig(n.Config)
+		r[i] = n
+	}
+	return r
+}
+
+func redactConfig(cfg map[string]interface{}) map[string]interface{} {
+	if cfg == nil {
+		return nil
+	}
+	r := make(map[string]interface{}, len(cfg))
+	for k, v := range cfg {
+		if _, ok := ctypes.ParseSecret(v); !ok && ctypes.IsSensitive(k) {
+			v = ctypes.Redacted
+		}
+		r[k] = v
+	}
+	return r
+}
+
 // CollectWorkflowMapNode represents Snap workflow data model.
 type CollectWorkflowMapNode struct {
 	// required: true
 	Metrics map[string]metricInfo             `json:"metrics"yaml:"metrics"`
+	Exclude []string                          `json:"exclude,omitempty"yaml:"exclude"`
 	Config  map[string]map[string]interface{} `json:"config,omitempty"yaml:"config"`
 	Tags    map[string]map[string]string      `json:"tags,omitempty"yaml:"tags"`
 	Process []ProcessWorkflowMapNode          `json:"process,omitempty"yaml:"process"`
@@ -191,6 +250,10 @@ func (cw *CollectWorkflowMapNode) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(v, &cw.Metrics); err != nil {
 				return err
 			}
+		case "exclude":
+			if err := json.Unmarshal(v, &cw.Exclude); err != nil {
+				return fmt.Errorf("%v (while parsing 'exclude')", err)
+			}
 		case "config":
 			if err := json.Unmarshal(v, &cw.Config); err != nil {
 				return fmt.Errorf("%v (while parsing 'config')", err)
@@ -231,6 +294,18 @@ func (c *CollectWorkflowMapNode) GetMetrics() []Metric {
 	return metrics
 }
 
+// GetExcludedMetrics returns the namespaces whose matching metrics are not collected
+func (c *CollectWorkflowMapNode) GetExcludedMetrics() []Metric {
+	metrics := make([]Metric, len(c.Exclude))
+	for i, k := range c.Exclude {
+		firstChar := stringutils.GetFirstChar(k)
+		metrics[i] = Metric{
+			namespace: strings.Split(strings.Trim(k, firstChar), firstChar),
+		}
+	}
+	return metrics
+}
+
 func (c *CollectWorkflowMapNode) GetTags() map[string]map[string]string {
 	return c.Tags
 }
@@ -483,6 +558,24 @@ func configtoConfigDataNode(cmap map[string]interface{}, ns string) (*cdata.Conf
 			}
 		case bool:
 			cdn.AddItem(ck, ctypes.ConfigValueBool{Value: v})
+		case map[string]interface{}, map[interface{}]interface{}:
+			sec, ok := ctypes.ParseSecret(v)
+			if !ok {
+				return nil, fmt.Errorf("Cannot convert config object to config data node, only secret references ({\"%s\": \"<ref>\"}) are supported: %s=>%+v", ctypes.SecretKey, ns, v)
+			}
+			cdn.AddItem(ck, sec)
+		case []interface{}:
+			l := make([]string, 0, len(v))
+			for _, e := range v {
+				str, ok := e.(string)
+				if !ok {
+					return nil, fmt.Errorf("Cannot convert config list to config data node, elements must be strings: %s=>%+v", ns, v)
+				}
+				l = append(l, str)
+			}
+			cdn.AddItem(ck, ctypes.ConfigValueStrList{Value: l})
+		case []string:
+			cdn.AddItem(ck, ctypes.ConfigValueStrList{Value: v})
 		default:
 			// TODO make sure this is covered in tests!!!
 			return nil, errors.New(fmt.Sprintf("Cannot convert config value to config data node: %s=>%+v", ns, v))
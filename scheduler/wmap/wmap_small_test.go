@@ -28,6 +28,7 @@ import (
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/intelsdi-x/snap/core/ctypes"
 	"github.com/intelsdi-x/snap/scheduler/wmap/fixtures"
 )
 
@@ -128,6 +129,50 @@ func TestWfAddConfigItem(t *testing.T) {
 	})
 }
 
+func TestWfConfigStringList(t *testing.T) {
+	Convey("Config lists become string list values", t, func() {
+		wmap := NewWorkflowMap()
+		wmap.Collect.AddMetric("/foo/bar", 1)
+		wmap.Collect.AddConfigItem("/foo/bar", "hosts", []interface{}{"a", "b"})
+		pr := NewProcessNode("floor", 1)
+		pr.Config = map[string]interface{}{"hosts": []interface{}{"a", 1}}
+		wmap.Collect.Add(pr)
+
+		cdt, err := wmap.Collect.GetConfigTree()
+		So(err, ShouldBeNil)
+		table := cdt.Get([]string{"foo", "bar"}).Table()
+		So(table["hosts"], ShouldResemble, ctypes.ConfigValueStrList{Value: []string{"a", "b"}})
+
+		_, err = wmap.Collect.Process[0].GetConfigNode()
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestWfConfigSecrets(t *testing.T) {
+	Convey("Secret references become secret values and survive redaction", t, func() {
+		ctypes.MarkSensitive("wmap_test_password")
+		wmap := NewWorkflowMap()
+		wmap.Collect.AddMetric("/foo/bar", 1)
+		wmap.Collect.AddConfigItem("/foo/bar", "token", map[string]interface{}{"secret": "env:TOKEN"})
+		wmap.Collect.AddConfigItem("/foo/bar", "wmap_test_password", "hunter2")
+		pu := NewPublishNode("file", 1)
+		pu.Config = map[string]interface{}{"wmap_test_password": "hunter2"}
+		wmap.Collect.Add(pu)
+
+		cdt, err := wmap.Collect.GetConfigTree()
+		So(err, ShouldBeNil)
+		table := cdt.Get([]string{"foo", "bar"}).Table()
+		So(table["token"], ShouldResemble, ctypes.ConfigValueSecret{Ref: "env:TOKEN"})
+
+		r := wmap.Redacted()
+		So(r.Collect.Config["/foo/bar"]["token"], ShouldResemble, map[string]interface{}{"secret": "env:TOKEN"})
+		So(r.Collect.Config["/foo/bar"]["wmap_test_password"], ShouldEqual, ctypes.Redacted)
+		So(r.Collect.Publish[0].Config["wmap_test_password"], ShouldEqual, ctypes.Redacted)
+		So(wmap.Collect.Config["/foo/bar"]["wmap_test_password"], ShouldEqual, "hunter2")
+		So(wmap.Collect.Publish[0].Config["wmap_test_password"], ShouldEqual, "hunter2")
+	})
+}
+
 func TestWfPublishProcessNodes(t *testing.T) {
 	Convey("Add()/New Process/New Publish nodes", t, func() {
 		wmap := NewWorkflowMap()
@@ -19,7 +19,11 @@ limitations under the License.
 
 package scheduler
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/intelsdi-x/snap/core"
+)
 
 /*
 
@@ -215,6 +219,24 @@ func (w *workManager) Stop() {
 	close(w.kill)
 }
 
+// health returns the state of the collect, process and publish queues
+func (w *workManager) health() []core.QueueHealth {
+	queues := []struct {
+		name string
+		q    *queue
+	}{
+		{"collect", w.collectq},
+		{"process", w.processq},
+		{"publish", w.publishq},
+	}
+	qh := make([]core.QueueHealth, len(queues))
+	for i, q := range queues {
+		qh[i].Name = q.name
+		qh[i].Length, qh[i].Limit, qh[i].Rejected = q.q.stats()
+	}
+	return qh
+}
+
 // Work dispatches jobs to worker pools for processing.
 //
 // Returns a queued job to the caller, which will be
@@ -89,6 +89,12 @@ func convertCollectionNode(cnode *wmap.CollectWorkflowMapNode, wf *schedulerWork
 	for i, m := range mts {
 		wf.metrics[i] = &metric{namespace: core.NewNamespace(m.Namespace()...), version: m.Version()}
 	}
+	// excluded namespaces are requested with a leading core.ExclusionPrefix
+	// element, so that control removes their metrics from the expansion
+	for _, m := range cnode.GetExcludedMetrics() {
+		ns := append([]string{core.ExclusionPrefix}, m.Namespace()...)
+		wf.metrics = append(wf.metrics, &metric{namespace: core.NewNamespace(ns...)})
+	}
 	// get tags defined
 	wf.tags = cnode.GetTags()
 
@@ -391,6 +397,13 @@ func submitProcessJob(pj job, t *task, wg *sync.WaitGroup, pr *processNode) {
 		"process-version":  pr.Version(),
 		"parent-node-type": pj.TypeString(),
 	}).Debug("Process job completed")
+	// Send event
+	event := new(scheduler_event.MetricProcessedEvent)
+	event.TaskID = t.id
+	event.PluginName = pr.Name()
+	event.PluginVersion = pr.Version()
+	event.Metrics = j.(*processJob).metrics
+	defer t.eventEmitter.Emit(event)
 	// Iterate into any child process or publish nodes
 	workJobs(pr.ProcessNodes, pr.PublishNodes, t, j)
 }
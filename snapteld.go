@@ -35,15 +35,20 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/gomit"
 	"github.com/urfave/cli"
 	"github.com/vrischmann/jsonutil"
 	"golang.org/x/crypto/ssh/terminal"
 
 	"github.com/intelsdi-x/snap/control"
+	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/health"
 	"github.com/intelsdi-x/snap/mgmt/rest"
 	"github.com/intelsdi-x/snap/mgmt/tribe"
 	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+	"github.com/intelsdi-x/snap/mgmt/webhook"
+	"github.com/intelsdi-x/snap/pkg/audit"
 	"github.com/intelsdi-x/snap/pkg/cfgfile"
 	"github.com/intelsdi-x/snap/scheduler"
 	"google.golang.org/grpc/grpclog"
@@ -66,6 +71,11 @@ var (
 		Usage:  "Path for logs. Empty path logs to stdout.",
 		EnvVar: "SNAP_LOG_PATH",
 	}
+	flAuditLogPath = cli.StringFlag{
+		Name:   "audit-log-path",
+		Usage:  "Path to the audit log file of the mutating management operations. Empty path disables the audit log.",
+		EnvVar: "SNAP_AUDIT_LOG_PATH",
+	}
 	flLogTruncate = cli.BoolFlag{
 		Name:  "log-truncate",
 		Usage: "Log file truncating mode. Default is false => append (true => truncate).",
@@ -112,6 +122,7 @@ const (
 	defaultLogLevel    int    = 3
 	defaultGoMaxProcs  int    = 1
 	defaultLogPath     string = ""
+	defaultAuditPath   string = ""
 	defaultLogTruncate bool   = false
 	defaultLogColors   bool   = true
 	defaultConfigPath  string = "/etc/snap/snapteld.conf"
@@ -127,10 +138,13 @@ type Config struct {
 	LogPath     string            `json:"log_path,omitempty"yaml:"log_path,omitempty"`
 	LogTruncate bool              `json:"log_truncate,omitempty"yaml:"log_truncate,omitempty"`
 	LogColors   bool              `json:"log_colors,omitempty"yaml:"log_colors,omitempty"`
+	AuditPath   string            `json:"audit_log_path,omitempty"yaml:"audit_log_path,omitempty"`
 	Control     *control.Config   `json:"control,omitempty"yaml:"control,omitempty"`
 	Scheduler   *scheduler.Config `json:"scheduler,omitempty"yaml:"scheduler,omitempty"`
 	RestAPI     *rest.Config      `json:"restapi,omitempty"yaml:"restapi,omitempty"`
 	Tribe       *tribe.Config     `json:"tribe,omitempty"yaml:"tribe,omitempty"`
+	Webhooks    *webhook.Config   `json:"webhooks,omitempty"yaml:"webhooks,omitempty"`
+	Health      *health.Config    `json:"health,omitempty"yaml:"health,omitempty"`
 }
 
 const (
@@ -157,6 +171,10 @@ const (
 				"description": "log file colored output default is true",
 				"type": "boolean"
 			},
+			"audit_log_path": {
+				"description": "path to the audit log file of the mutating management operations",
+				"type": "string"
+			},
 			"gomaxprocs": {
 				"description": "value to be used for gomaxprocs",
 				"type": "integer",
@@ -165,14 +183,18 @@ const (
 			"control": { "$ref": "#/definitions/control" },
 			"scheduler": { "$ref": "#/definitions/scheduler"},
 			"restapi" : { "$ref": "#/definitions/restapi"},
-			"tribe": { "$ref": "#/definitions/tribe"}
+			"tribe": { "$ref": "#/definitions/tribe"},
+			"webhooks": { "$ref": "#/definitions/webhooks"},
+			"health": { "$ref": "#/definitions/health"}
 		},
 		"additionalProperties": false,
 		"definitions": { ` +
 		control.CONFIG_CONSTRAINTS + `,` +
 		scheduler.CONFIG_CONSTRAINTS + `,` +
 		rest.CONFIG_CONSTRAINTS + `,` +
-		tribe.CONFIG_CONSTRAINTS +
+		tribe.CONFIG_CONSTRAINTS + `,` +
+		webhook.CONFIG_CONSTRAINTS + `,` +
+		health.CONFIG_CONSTRAINTS +
 		`}` +
 		`}`
 	logModule = "snapteld"
@@ -193,6 +215,12 @@ type managesTribe interface {
 	LeaveAgreement(agreementName, memberName string) serror.SnapError
 	GetMembers() []string
 	GetMember(name string) *agreement.Member
+	AddPlugin(agreementName string, p agreement.Plugin) error
+	RemovePlugin(agreementName string, p agreement.Plugin) error
+	AddTask(agreementName string, task agreement.Task) serror.SnapError
+	RemoveTask(agreementName string, task agreement.Task) serror.SnapError
+	RegisterEventHandler(name string, h gomit.Handler) error
+	HealthCheck() core.TribeHealth
 }
 
 type runtimeFlagsContext interface {
@@ -216,6 +244,7 @@ func main() {
 	cliApp.Flags = []cli.Flag{
 		flLogLevel,
 		flLogPath,
+		flAuditLogPath,
 		flLogTruncate,
 		flLogColors,
 		flMaxProcs,
@@ -281,6 +310,19 @@ func action(ctx *cli.Context) error {
 		log.SetOutput(file)
 	}
 
+	// If the audit log path is set, the mutating calls of the REST API and
+	// the actions of tribe are recorded in it
+	var auditLog *audit.Log
+	if cfg.AuditPath != "" {
+		var err error
+		auditLog, err = audit.Open(cfg.AuditPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer auditLog.Close()
+		log.Info("Audit log is written to ", cfg.AuditPath)
+	}
+
 	// verify the temDirPath points to existing directory
 	tempDirPath := cfg.Control.TempDirPath
 	f, err := os.Stat(tempDirPath)
@@ -335,8 +377,8 @@ func action(ctx *cli.Context) error {
 	s.SetMetricManager(c)
 	coreModules = append(coreModules, s)
 
-	// Auth requested and not provided as part of config
-	if cfg.RestAPI.Enable && cfg.RestAPI.RestAuth && cfg.RestAPI.RestAuthPassword == "" {
+	// Auth requested and neither a password, API tokens nor client certificate roles provided as part of config
+	if cfg.RestAPI.Enable && cfg.RestAPI.RestAuth && cfg.RestAPI.RestAuthPassword == "" && cfg.RestAPI.RestAuthTokenFile == "" && len(cfg.RestAPI.RestClientCertRoles) == 0 {
 		fmt.Println("What password do you want to use for authentication?")
 		fmt.Print("Password:")
 		password, err := terminal.ReadPassword(0)
@@ -352,6 +394,9 @@ func action(ctx *cli.Context) error {
 	var tr managesTribe
 	if cfg.Tribe.Enable {
 		cfg.Tribe.RestAPIPort = cfg.RestAPI.Port
+		if cfg.RestAPI.HTTPS {
+			cfg.Tribe.RestAPIProto = "https"
+		}
 		if cfg.RestAPI.RestAuth {
 			cfg.Tribe.RestAPIPassword = cfg.RestAPI.RestAuthPassword
 		}
@@ -364,10 +409,31 @@ func action(ctx *cli.Context) error {
 		t.SetPluginCatalog(c)
 		s.RegisterEventHandler("tribe", t)
 		t.SetTaskManager(s)
+		if auditLog != nil {
+			t.SetAuditLog(auditLog)
+		}
 		coreModules = append(coreModules, t)
 		tr = t
 	}
 
+	wh, err := webhook.New(cfg.Webhooks)
+	if err != nil {
+		printErrorAndExit("webhook", err)
+	}
+	c.RegisterEventHandler("webhook", wh)
+	s.RegisterEventHandler("webhook", wh)
+	if tr != nil {
+		tr.RegisterEventHandler("webhook", wh)
+	}
+	coreModules = append(coreModules, wh)
+
+	hc := health.New(cfg.Health, c, s)
+	if tr != nil {
+		hc.SetTribe(tr)
+	}
+
+	reloader := newConfigReloader(cfg, ctx, c)
+
 	//Setup RESTful API if it was enabled in the configuration
 	if cfg.RestAPI.Enable {
 		r, err := rest.New(cfg.RestAPI)
@@ -377,20 +443,36 @@ func action(ctx *cli.Context) error {
 		r.BindMetricManager(c)
 		r.BindConfigManager(c.Config)
 		r.BindTaskManager(s)
+		r.BindConfigReloader(reloader)
+		r.BindWebhooks(wh)
+		r.BindHealth(hc)
+		if auditLog != nil {
+			r.BindAuditLog(auditLog)
+		}
+		reloader.rest = r
 
 		//Rest Authentication
 		if cfg.RestAPI.RestAuth {
 			log.Info("REST API authentication is enabled")
 			r.SetAPIAuth(cfg.RestAPI.RestAuth)
-			log.Info("REST API authentication password is set")
-			r.SetAPIAuthPwd(cfg.RestAPI.RestAuthPassword)
+			if cfg.RestAPI.RestAuthPassword != "" {
+				log.Info("REST API authentication password is set")
+				r.SetAPIAuthPwd(cfg.RestAPI.RestAuthPassword)
+			}
+			if cfg.RestAPI.RestAuthTokenFile != "" {
+				log.Info("REST API authentication tokens are read from ", cfg.RestAPI.RestAuthTokenFile)
+			}
 			if !cfg.RestAPI.HTTPS {
 				log.Warning("Using REST API authentication without HTTPS enabled.")
 			}
 		}
 
+		// Stream the events of control, the scheduler and tribe
+		c.RegisterEventHandler("rest", r)
+		s.RegisterEventHandler("rest", r)
 		if tr != nil {
 			r.BindTribeManager(tr)
+			tr.RegisterEventHandler("rest", r)
 		}
 		go monitorErrors(r.Err())
 		coreModules = append(coreModules, r)
@@ -399,9 +481,9 @@ func action(ctx *cli.Context) error {
 		log.Info("REST API is disabled")
 	}
 
-	// Set interrupt handling so we can either restart the app on a SIGHUP or
+	// Set interrupt handling so we can either reload the config on a SIGHUP or
 	// die gracefully when an interrupt, kill, etc. are received
-	startInterruptHandling(coreModules...)
+	startInterruptHandling(reloader, coreModules...)
 
 	// Start our modules
 	var started []coreModule
@@ -552,12 +634,15 @@ func getDefaultConfig() *Config {
 		LogLevel:    defaultLogLevel,
 		GoMaxProcs:  defaultGoMaxProcs,
 		LogPath:     defaultLogPath,
+		AuditPath:   defaultAuditPath,
 		LogTruncate: defaultLogTruncate,
 		LogColors:   defaultLogColors,
 		Control:     control.GetDefaultConfig(),
 		Scheduler:   scheduler.GetDefaultConfig(),
 		RestAPI:     rest.GetDefaultConfig(),
 		Tribe:       tribe.GetDefaultConfig(),
+		Webhooks:    webhook.GetDefaultConfig(),
+		Health:      health.GetDefaultConfig(),
 	}
 }
 
@@ -841,6 +926,7 @@ func applyCmdLineFlags(cfg *Config, ctx runtimeFlagsContext) {
 	cfg.GoMaxProcs = setIntVal(cfg.GoMaxProcs, ctx, "max-procs")
 	cfg.LogLevel = setIntVal(cfg.LogLevel, ctx, "log-level")
 	cfg.LogPath = setStringVal(cfg.LogPath, ctx, "log-path")
+	cfg.AuditPath = setStringVal(cfg.AuditPath, ctx, "audit-log-path")
 	cfg.LogTruncate = setBoolVal(cfg.LogTruncate, ctx, "log-truncate")
 	cfg.LogColors = setBoolVal(cfg.LogColors, ctx, "log-colors")
 	// next for the flags related to the control package
@@ -864,8 +950,10 @@ func applyCmdLineFlags(cfg *Config, ctx runtimeFlagsContext) {
 	cfg.RestAPI.HTTPS = setBoolVal(cfg.RestAPI.HTTPS, ctx, "rest-https")
 	cfg.RestAPI.RestCertificate = setStringVal(cfg.RestAPI.RestCertificate, ctx, "rest-cert")
 	cfg.RestAPI.RestKey = setStringVal(cfg.RestAPI.RestKey, ctx, "rest-key")
+	cfg.RestAPI.RestClientCA = setStringVal(cfg.RestAPI.RestClientCA, ctx, "rest-client-ca")
 	cfg.RestAPI.RestAuth = setBoolVal(cfg.RestAPI.RestAuth, ctx, "rest-auth")
 	cfg.RestAPI.RestAuthPassword = setStringVal(cfg.RestAPI.RestAuthPassword, ctx, "rest-auth-pwd")
+	cfg.RestAPI.RestAuthTokenFile = setStringVal(cfg.RestAPI.RestAuthTokenFile, ctx, "rest-auth-token-file")
 	cfg.RestAPI.Pprof = setBoolVal(cfg.RestAPI.Pprof, ctx, "pprof")
 	cfg.RestAPI.Corsd = setStringVal(cfg.RestAPI.Corsd, ctx, "allowed_origins")
 
@@ -878,6 +966,8 @@ func applyCmdLineFlags(cfg *Config, ctx runtimeFlagsContext) {
 	cfg.Tribe.BindAddr = setStringVal(cfg.Tribe.BindAddr, ctx, "tribe-addr")
 	cfg.Tribe.BindPort = setIntVal(cfg.Tribe.BindPort, ctx, "tribe-port")
 	cfg.Tribe.Seed = setStringVal(cfg.Tribe.Seed, ctx, "tribe-seed")
+	cfg.Tribe.RestClientCert = setStringVal(cfg.Tribe.RestClientCert, ctx, "tribe-rest-client-cert")
+	cfg.Tribe.RestClientKey = setStringVal(cfg.Tribe.RestClientKey, ctx, "tribe-rest-client-key")
 	// check to see if we have duplicate port definitions (check the various
 	// combinations of the config file and command-line parameter values that
 	// could be used to define the port and make sure we only have one)
@@ -996,6 +1086,10 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(v, &(c.LogPath)); err != nil {
 				return fmt.Errorf("%v (while parsing 'log_path')", err)
 			}
+		case "audit_log_path":
+			if err := json.Unmarshal(v, &(c.AuditPath)); err != nil {
+				return fmt.Errorf("%v (while parsing 'audit_log_path')", err)
+			}
 		case "log_truncate":
 			if err := json.Unmarshal(v, &(c.LogTruncate)); err != nil {
 				return fmt.Errorf("%v (while parsing 'log_truncate')", err)
@@ -1020,6 +1114,14 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(v, c.Tribe); err != nil {
 				return err
 			}
+		case "webhooks":
+			if err := json.Unmarshal(v, c.Webhooks); err != nil {
+				return err
+			}
+		case "health":
+			if err := json.Unmarshal(v, c.Health); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("Unrecognized key '%v' in global config file", k)
 		}
@@ -1050,13 +1152,33 @@ func printErrorAndExit(name string, err error) {
 		}).Fatal("error starting module")
 }
 
-func startInterruptHandling(modules ...coreModule) {
+func startInterruptHandling(reloader *configReloader, modules ...coreModule) {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, os.Kill, syscall.SIGTERM, syscall.SIGHUP)
 
 	//Let's block until someone tells us to quit
 	go func() {
 		sig := <-c
+		for sig == syscall.SIGHUP {
+			// reload the config file, keeping the running tasks
+			log.WithFields(
+				log.Fields{
+					"block":   "main",
+					"_module": logModule,
+					"signal":  sig.String(),
+				}).Info("reloading config")
+			if _, serrs := reloader.ReloadConfig(); serrs != nil {
+				for _, serr := range serrs {
+					log.WithFields(serr.Fields()).Error(serr.Error())
+				}
+				log.WithFields(
+					log.Fields{
+						"block":   "main",
+						"_module": logModule,
+					}).Error("config not reloaded")
+			}
+			sig = <-c
+		}
 		log.WithFields(
 			log.Fields{
 				"block":   "main",
@@ -1072,28 +1194,13 @@ func startInterruptHandling(modules ...coreModule) {
 				}).Info("stopping module")
 			m.Stop()
 		}
-		if sig == syscall.SIGHUP {
-			// log the action we're taking (restarting the app)
-			log.WithFields(
-				log.Fields{
-					"block":   "main",
-					"_module": logModule,
-					"signal":  sig.String(),
-				}).Info("restarting app")
-			// and restart the app (with the current configuration)
-			err := cliApp.Run(os.Args)
-			if err != nil {
-				os.Exit(1)
-			}
-		} else {
-			log.WithFields(
-				log.Fields{
-					"block":   "main",
-					"_module": logModule,
-					"signal":  sig.String(),
-				}).Info("exiting on signal")
-			os.Exit(0)
-		}
+		log.WithFields(
+			log.Fields{
+				"block":   "main",
+				"_module": logModule,
+				"signal":  sig.String(),
+			}).Info("exiting on signal")
+		os.Exit(0)
 	}()
 }
 
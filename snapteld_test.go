@@ -41,6 +41,7 @@ var validCmdlineFlags_input = mockFlags{
 	"max-procs":               "11",
 	"log-level":               "1",
 	"log-path":                "/no/logs/allowed",
+	"audit-log-path":          "/no/audit/log",
 	"log-truncate":            "true",
 	"log-colors":              "true",
 	"max-running-plugins":     "12",
@@ -62,8 +63,10 @@ var validCmdlineFlags_input = mockFlags{
 	"rest-https":              "true",
 	"rest-cert":               "/no/rest/cert",
 	"rest-key":                "/no/rest/key",
+	"rest-client-ca":          "/no/rest/clients",
 	"rest-auth":               "true",
 	"rest-auth-pwd":           "noway",
+	"rest-auth-token-file":    "/no/rest/tokens",
 	"allowed_origins":         "140.141.142.143",
 	"work-manager-queue-size": "70",
 	"work-manager-pool-size":  "71",
@@ -72,6 +75,8 @@ var validCmdlineFlags_input = mockFlags{
 	"tribe-addr":              "160.161.162.163",
 	"tribe-port":              "16400",
 	"tribe-seed":              "180.181.182.183",
+	"tribe-rest-client-cert":  "/no/tribe/cert",
+	"tribe-rest-client-key":   "/no/tribe/key",
 }
 
 var validCmdlineFlags_expected = &Config{
@@ -91,23 +96,27 @@ var validCmdlineFlags_expected = &Config{
 		CACertPaths:       "/no/root/certs",
 	},
 	RestAPI: &rest.Config{
-		Enable:           true,
-		Port:             12400,
-		Address:          "120.121.122.123:12400",
-		HTTPS:            true,
-		RestCertificate:  "/no/rest/cert",
-		RestKey:          "/no/rest/key",
-		RestAuth:         true,
-		RestAuthPassword: "noway",
-		Pprof:            true,
-		Corsd:            "140.141.142.143",
+		Enable:            true,
+		Port:              12400,
+		Address:           "120.121.122.123:12400",
+		HTTPS:             true,
+		RestCertificate:   "/no/rest/cert",
+		RestKey:           "/no/rest/key",
+		RestClientCA:      "/no/rest/clients",
+		RestAuth:          true,
+		RestAuthPassword:  "noway",
+		RestAuthTokenFile: "/no/rest/tokens",
+		Pprof:             true,
+		Corsd:             "140.141.142.143",
 	},
 	Tribe: &tribe.Config{
-		Name:     "bonk",
-		Enable:   true,
-		BindAddr: "160.161.162.163",
-		BindPort: 16400,
-		Seed:     "180.181.182.183",
+		Name:           "bonk",
+		Enable:         true,
+		BindAddr:       "160.161.162.163",
+		BindPort:       16400,
+		Seed:           "180.181.182.183",
+		RestClientCert: "/no/tribe/cert",
+		RestClientKey:  "/no/tribe/key",
 	},
 	Scheduler: &scheduler.Config{
 		WorkManagerQueueSize: 70,
@@ -116,6 +125,7 @@ var validCmdlineFlags_expected = &Config{
 	GoMaxProcs:  11,
 	LogLevel:    1,
 	LogPath:     "/no/logs/allowed",
+	AuditPath:   "/no/audit/log",
 	LogTruncate: true,
 	LogColors:   true,
 }